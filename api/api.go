@@ -0,0 +1,10 @@
+package api
+
+import (
+	_ "embed"
+)
+
+// OpenAPISpec is the OpenAPI document of the REST API.
+//
+//go:embed v1/openapi-spec.yml
+var OpenAPISpec []byte
@@ -0,0 +1,16 @@
+package main
+
+import (
+	"log"
+	"tariff-calculation-service/internal/database"
+)
+
+// writes the copies of the contracts for the lookups by provider and tariff, which contracts written before the
+// copies existed lack, to the DynamoDB table of the environment
+func main() {
+	count, err := database.NewContractRepo().BackfillContractCopies()
+	if err != nil {
+		log.Fatalf("failed to backfill contract copies after %d contracts: %v", count, err)
+	}
+	log.Printf("Backfilled the copies of %d contracts.", count)
+}
@@ -1,18 +1,25 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"tariff-calculation-service/internal/readmodel"
 	"tariff-calculation-service/internal/router"
 	"tariff-calculation-service/pkg"
 
+	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
 )
 
 func main() {
+	lambda.Start(newHandler())
+	fmt.Println("Started lambda handler.")
+}
+
+// returns the lambda handler serving all read model routes
+func newHandler() func(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	router := router.NewRouter()
 	readmodel.RouteReadmodelCalls(router)
 
-	lambda.Start(pkg.AdaptGinRouter(router))
-	fmt.Println("Started lambda handler.")
+	return pkg.AdaptGinRouter(router)
 }
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/repository"
+	"tariff-calculation-service/pkg"
+	"tariff-calculation-service/pkg/money"
+	"tariff-calculation-service/test"
+	"tariff-calculation-service/test/data"
+	"tariff-calculation-service/tools"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Handler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv(repository.StorageBackendEnv, repository.MemoryBackend)
+	handler := newHandler()
+	basePath := "/api/v1/partitions/"
+
+	testCases := []struct {
+		name                 string
+		request              events.APIGatewayProxyRequest
+		expectedResponseCode int
+		expectedPointer      string
+	}{
+		{
+			"Positive Test Health",
+			test.GetTestAPIGatewayProxyRequest(http.MethodGet, basePath+data.TestPartitionId+"/health", nil),
+			200,
+			"",
+		},
+		{
+			"Negative Test Get Tariff Invalid PartitionId",
+			test.GetTestAPIGatewayProxyRequest(http.MethodGet, basePath+data.TestIdInvalid+"/tariffs/"+data.TestTariffId, nil),
+			400,
+			"/partitionId",
+		},
+		{
+			"Negative Test Get Contract Invalid Id",
+			test.GetTestAPIGatewayProxyRequest(http.MethodGet, basePath+data.TestPartitionId+"/contracts/"+data.TestIdInvalid, nil),
+			400,
+			"/id",
+		},
+		{
+			"Negative Test Post Calculation Invalid Body",
+			test.GetTestAPIGatewayProxyRequest(http.MethodPost, basePath+data.TestPartitionId+"/tariffs/"+data.TestTariffId+"/calculate", []byte("{}")),
+			400,
+			"/from",
+		},
+		{
+			"Negative Test Post Bill Invalid Body",
+			test.GetTestAPIGatewayProxyRequest(http.MethodPost, basePath+data.TestPartitionId+"/contracts/"+data.TestContractId+"/bill", []byte("{}")),
+			400,
+			"/from",
+		},
+		{
+			"Negative Test Write Route Method Not Allowed",
+			test.GetTestAPIGatewayProxyRequest(http.MethodDelete, basePath+data.TestPartitionId+"/tariffs/"+data.TestTariffId, nil),
+			405,
+			"",
+		},
+		{
+			"Negative Test Route Not Found",
+			test.GetTestAPIGatewayProxyRequest(http.MethodDelete, basePath+data.TestPartitionId+"/unknown", nil),
+			404,
+			"",
+		},
+	}
+	// act
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response, err := handler(context.Background(), tc.request)
+
+			// assert
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResponseCode, response.StatusCode)
+			if tc.expectedPointer != "" {
+				var actualError models.Error
+				err := json.Unmarshal([]byte(response.Body), &actualError)
+				if err != nil {
+					t.Fail()
+				}
+				assert.Equal(t, pkg.ProblemContentType, http.Header(response.MultiValueHeaders).Get("Content-Type"))
+				assert.Contains(t, actualError.Detail, tc.expectedPointer)
+				assert.True(t, slices.ContainsFunc(actualError.Errors, func(fieldError models.FieldError) bool {
+					return fieldError.Pointer == tc.expectedPointer
+				}))
+				assert.Equal(t, test.TestRequestId, actualError.TraceId)
+				assert.Equal(t, test.TestRequestId, http.Header(response.MultiValueHeaders).Get(pkg.RequestIdHeader))
+			}
+		})
+	}
+
+	t.Run("Positive Test Memory Backend", func(t *testing.T) {
+		tariffsPath := basePath + data.TestPartitionId + "/tariffs"
+		_, err := repository.NewTariffRepo().CreateTariff(data.TestPartitionId, data.Tariff)
+		assert.Nil(t, err)
+
+		// act
+		getResponse, getErr := handler(context.Background(), test.GetTestAPIGatewayProxyRequest(http.MethodGet, tariffsPath+"/"+data.TestTariffId, nil))
+		var tariff models.Tariff
+		_ = json.Unmarshal([]byte(getResponse.Body), &tariff)
+		listResponse, listErr := handler(context.Background(), test.GetTestAPIGatewayProxyRequest(http.MethodGet, tariffsPath, nil))
+		var tariffs models.Page[models.Tariff]
+		_ = json.Unmarshal([]byte(listResponse.Body), &tariffs)
+		calculationResponse, calculationErr := handler(context.Background(), test.GetTestAPIGatewayProxyRequest(http.MethodPost,
+			tariffsPath+"/"+data.TestTariffId+"/calculate", tools.GetFirstValue(json.Marshal(data.CalculationRequest))))
+		var calculation models.Calculation
+		_ = json.Unmarshal([]byte(calculationResponse.Body), &calculation)
+
+		// assert
+		assert.Nil(t, getErr)
+		assert.Equal(t, 200, getResponse.StatusCode)
+		assert.Equal(t, data.Tariff, tariff)
+		assert.Equal(t, `"1"`, http.Header(getResponse.MultiValueHeaders).Get("ETag"))
+		assert.Nil(t, listErr)
+		assert.Equal(t, 200, listResponse.StatusCode)
+		assert.Equal(t, []models.Tariff{data.Tariff}, tariffs.Items)
+		assert.Nil(t, calculationErr)
+		assert.Equal(t, 200, calculationResponse.StatusCode)
+		assert.Equal(t, money.RequireFromString("645"), calculation.Cost)
+	})
+}
@@ -7,8 +7,14 @@ for model in cmd/*; do
     # If directory
     if [ -d "$model" ]; then
         echo ... cmd $(basename $model)
+        # The standalone server is not deployed as lambda
+        if [ "$(basename $model)" = "server" ]; then
+            echo "--- Standalone server (ignoring) ---"
+        # The backfill runs once from a workstation
+        elif [ "$(basename $model)" = "backfill" ]; then
+            echo "--- Backfill (ignoring) ---"
         # If a main.go file exists
-        if [ -f "./cmd/$(basename $model)/main.go" ]; then
+        elif [ -f "./cmd/$(basename $model)/main.go" ]; then
             GOOS=linux go build -o bootstrap ./cmd/$(basename $model)
             mkdir -p bin/$(basename $model) && zip bin/$(basename $model)/$(basename $model).zip bootstrap
 
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"tariff-calculation-service/internal/readmodel"
+	"tariff-calculation-service/internal/readmodel/httphandler"
+	"tariff-calculation-service/internal/router"
+	"tariff-calculation-service/internal/writemodel"
+	"tariff-calculation-service/pkg/constants"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultPort     = "8000"
+	shutdownTimeout = 10 * time.Second
+)
+
+func main() {
+	server := &http.Server{
+		Addr:    ":" + port(),
+		Handler: newRouter(),
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("failed to start server: %v", err)
+		}
+	}()
+	log.Printf("Started server on %s.", server.Addr)
+
+	<-ctx.Done()
+	log.Println("Shutting down server.")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Fatalf("failed to shut down server: %v", err)
+	}
+}
+
+// returns a router serving the read and write model routes and the OpenAPI spec
+func newRouter() *gin.Engine {
+	router := router.NewRouter()
+	readmodel.RouteReadmodelCalls(router)
+	writemodel.RouteWritemodelCalls(router)
+	router.GET(constants.OpenAPIPath, httphandler.NewHttpHandler().HandleGetOpenAPISpec)
+
+	return router
+}
+
+// returns the port of the env variable PORT or the default port
+func port() string {
+	if port := os.Getenv("PORT"); port != "" {
+		return port
+	}
+	return defaultPort
+}
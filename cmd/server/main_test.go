@@ -0,0 +1,290 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"tariff-calculation-service/api"
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/repository"
+	"tariff-calculation-service/pkg"
+	"tariff-calculation-service/pkg/money"
+	"tariff-calculation-service/test/data"
+	"tariff-calculation-service/tools"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var serverRouter *gin.Engine
+
+// returns the router shared by the tests, because router.NewRouter caches its engine and every route can only be
+// registered once
+func testRouter() *gin.Engine {
+	if serverRouter == nil {
+		serverRouter = newRouter()
+	}
+	return serverRouter
+}
+
+func Test_Router(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv(repository.StorageBackendEnv, repository.MemoryBackend)
+	router := testRouter()
+	basePath := "/api/v1/partitions/"
+
+	testCases := []struct {
+		name                 string
+		method               string
+		path                 string
+		body                 string
+		expectedResponseCode int
+	}{
+		{"Positive Test OpenAPI Spec", http.MethodGet, "/api/v1/openapi.yml", "", 200},
+		{"Positive Test Health", http.MethodGet, basePath + data.TestPartitionId + "/health", "", 200},
+		{"Negative Test Read Route Invalid Id", http.MethodGet, basePath + data.TestPartitionId + "/tariffs/" + data.TestIdInvalid, "", 400},
+		{"Negative Test Write Route Invalid Body", http.MethodPost, basePath + data.TestPartitionId + "/tariffs", "{}", 400},
+		{"Negative Test Method Not Allowed", http.MethodPatch, basePath + data.TestPartitionId + "/tariffs", "", 405},
+		{"Negative Test Route Not Found", http.MethodGet, basePath + data.TestPartitionId + "/unknown", "", 404},
+	}
+	// act
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body)))
+
+			// assert
+			assert.Equal(t, tc.expectedResponseCode, recorder.Code)
+			if tc.path == "/api/v1/openapi.yml" {
+				assert.Equal(t, api.OpenAPISpec, recorder.Body.Bytes())
+			}
+		})
+	}
+
+	t.Run("Positive Test Memory Backend", func(t *testing.T) {
+		tariffsPath := "/api/v1/partitions/" + data.TestPartitionId + "/tariffs"
+		serve := func(method, path string, body []byte, headers ...string) *httptest.ResponseRecorder {
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(method, path, strings.NewReader(string(body)))
+			for i := 0; i+1 < len(headers); i += 2 {
+				request.Header.Set(headers[i], headers[i+1])
+			}
+			router.ServeHTTP(recorder, request)
+			return recorder
+		}
+
+		// act
+		createResponse := serve(http.MethodPost, tariffsPath, tools.GetFirstValue(json.Marshal(data.Tariff)))
+		var createdTariff models.Tariff
+		_ = json.Unmarshal(createResponse.Body.Bytes(), &createdTariff)
+		getResponse := serve(http.MethodGet, tariffsPath+"/"+createdTariff.Id, nil)
+		var tariff models.Tariff
+		_ = json.Unmarshal(getResponse.Body.Bytes(), &tariff)
+		calculationResponse := serve(http.MethodPost, tariffsPath+"/"+createdTariff.Id+"/calculate", tools.GetFirstValue(json.Marshal(data.CalculationRequest)))
+		var calculation models.Calculation
+		_ = json.Unmarshal(calculationResponse.Body.Bytes(), &calculation)
+		notModifiedResponse := serve(http.MethodGet, tariffsPath+"/"+createdTariff.Id, nil, "If-None-Match", getResponse.Header().Get("ETag"))
+		staleDeleteResponse := serve(http.MethodDelete, tariffsPath+"/"+createdTariff.Id, nil, "If-Match", `"0"`)
+		deleteResponse := serve(http.MethodDelete, tariffsPath+"/"+createdTariff.Id, nil, "If-Match", getResponse.Header().Get("ETag"))
+		getDeletedResponse := serve(http.MethodGet, tariffsPath+"/"+createdTariff.Id, nil)
+		deleteDeletedResponse := serve(http.MethodDelete, tariffsPath+"/"+createdTariff.Id, nil, "If-Match", "*")
+		updateDeletedResponse := serve(http.MethodPut, tariffsPath+"/"+createdTariff.Id, tools.GetFirstValue(json.Marshal(createdTariff)), "If-Match", "*")
+
+		// assert
+		assert.Equal(t, 201, createResponse.Code)
+		assert.NotEqual(t, data.TestTariffId, createdTariff.Id)
+		assert.Equal(t, 200, getResponse.Code)
+		assert.Equal(t, createdTariff, tariff)
+		assert.Equal(t, `"1"`, getResponse.Header().Get("ETag"))
+		assert.Equal(t, 304, notModifiedResponse.Code)
+		assert.Equal(t, 412, staleDeleteResponse.Code)
+		assert.Equal(t, 200, calculationResponse.Code)
+		assert.Equal(t, money.RequireFromString("645"), calculation.Cost)
+		assert.Equal(t, 204, deleteResponse.Code)
+		assert.Equal(t, 404, getDeletedResponse.Code)
+		assert.Equal(t, pkg.ProblemContentType, getDeletedResponse.Header().Get("Content-Type"))
+		assert.NotEmpty(t, getDeletedResponse.Header().Get(pkg.RequestIdHeader))
+		assert.Contains(t, getDeletedResponse.Body.String(), `"traceId":"`+getDeletedResponse.Header().Get(pkg.RequestIdHeader)+`"`)
+		assert.Equal(t, 404, deleteDeletedResponse.Code)
+		assert.Equal(t, 404, updateDeletedResponse.Code)
+	})
+
+	t.Run("Positive Test Memory Backend References", func(t *testing.T) {
+		partitionPath := "/api/v1/partitions/" + data.TestPartitionId
+		serve := func(method, path string, body []byte, headers ...string) *httptest.ResponseRecorder {
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(method, path, strings.NewReader(string(body)))
+			for i := 0; i+1 < len(headers); i += 2 {
+				request.Header.Set(headers[i], headers[i+1])
+			}
+			router.ServeHTTP(recorder, request)
+			return recorder
+		}
+		var provider models.Provider
+		_ = json.Unmarshal(serve(http.MethodPost, partitionPath+"/providers", tools.GetFirstValue(json.Marshal(data.Provider))).Body.Bytes(), &provider)
+		var tariff models.Tariff
+		_ = json.Unmarshal(serve(http.MethodPost, partitionPath+"/tariffs", tools.GetFirstValue(json.Marshal(data.Tariff))).Body.Bytes(), &tariff)
+		newContract := data.Contract
+		newContract.Provider = provider.Id
+		newContract.Tariffs = []string{tariff.Id}
+
+		// act
+		missingResponse := serve(http.MethodPost, partitionPath+"/contracts", tools.GetFirstValue(json.Marshal(data.ContractWithTariff)))
+		createResponse := serve(http.MethodPost, partitionPath+"/contracts", tools.GetFirstValue(json.Marshal(newContract)))
+		var contract models.Contract
+		_ = json.Unmarshal(createResponse.Body.Bytes(), &contract)
+		var providerContracts, tariffContracts models.Page[models.Contract]
+		_ = json.Unmarshal(serve(http.MethodGet, partitionPath+"/providers/"+provider.Id+"/contracts", nil).Body.Bytes(), &providerContracts)
+		_ = json.Unmarshal(serve(http.MethodGet, partitionPath+"/tariffs/"+tariff.Id+"/contracts", nil).Body.Bytes(), &tariffContracts)
+		referencedResponse := serve(http.MethodDelete, partitionPath+"/tariffs/"+tariff.Id, nil, "If-Match", "*")
+		var referencedError models.Error
+		_ = json.Unmarshal(referencedResponse.Body.Bytes(), &referencedError)
+		cascadeTariffResponse := serve(http.MethodDelete, partitionPath+"/tariffs/"+tariff.Id+"?cascade=true", nil, "If-Match", "*")
+		var updatedContract models.Contract
+		_ = json.Unmarshal(serve(http.MethodGet, partitionPath+"/contracts/"+contract.Id, nil).Body.Bytes(), &updatedContract)
+		cascadeProviderResponse := serve(http.MethodDelete, partitionPath+"/providers/"+provider.Id+"?cascade=true", nil, "If-Match", "*")
+		getDeletedContractResponse := serve(http.MethodGet, partitionPath+"/contracts/"+contract.Id, nil)
+
+		// assert
+		assert.Equal(t, 400, missingResponse.Code)
+		assert.Equal(t, 201, createResponse.Code)
+		assert.Equal(t, []models.Contract{contract}, providerContracts.Items)
+		assert.Equal(t, []models.Contract{contract}, tariffContracts.Items)
+		assert.Equal(t, 409, referencedResponse.Code)
+		assert.Equal(t, []string{contract.Id}, referencedError.Contracts)
+		assert.Equal(t, 204, cascadeTariffResponse.Code)
+		assert.Empty(t, updatedContract.Tariffs)
+		assert.Equal(t, 204, cascadeProviderResponse.Code)
+		assert.Equal(t, 404, getDeletedContractResponse.Code)
+	})
+
+	t.Run("Positive Test Memory Backend Versions", func(t *testing.T) {
+		tariffsPath := "/api/v1/partitions/" + data.TestPartitionId + "/tariffs"
+		serve := func(method, path string, body []byte, headers ...string) *httptest.ResponseRecorder {
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(method, path, strings.NewReader(string(body)))
+			for i := 0; i+1 < len(headers); i += 2 {
+				request.Header.Set(headers[i], headers[i+1])
+			}
+			router.ServeHTTP(recorder, request)
+			return recorder
+		}
+		var tariff models.Tariff
+		_ = json.Unmarshal(serve(http.MethodPost, tariffsPath, tools.GetFirstValue(json.Marshal(data.Tariff))).Body.Bytes(), &tariff)
+		revisedTariff := data.TariffRevised
+		revisedTariff.Id = tariff.Id
+
+		// act
+		updateResponse := serve(http.MethodPut, tariffsPath+"/"+tariff.Id, tools.GetFirstValue(json.Marshal(revisedTariff)), "If-Match", `"1"`)
+		var versions []models.TariffVersion
+		_ = json.Unmarshal(serve(http.MethodGet, tariffsPath+"/"+tariff.Id+"/versions", nil).Body.Bytes(), &versions)
+		asOfResponse := serve(http.MethodGet, tariffsPath+"/"+tariff.Id+"?asOf="+versions[len(versions)-1].EffectiveFrom, nil)
+		var asOfTariff models.Tariff
+		_ = json.Unmarshal(asOfResponse.Body.Bytes(), &asOfTariff)
+		beforeCreationResponse := serve(http.MethodGet, tariffsPath+"/"+tariff.Id+"?asOf="+data.TestValidFrom, nil)
+		var calculation models.Calculation
+		_ = json.Unmarshal(serve(http.MethodPost, tariffsPath+"/"+tariff.Id+"/calculate", tools.GetFirstValue(json.Marshal(data.CalculationRequest))).Body.Bytes(), &calculation)
+
+		// assert
+		assert.Equal(t, 204, updateResponse.Code)
+		assert.Len(t, versions, 2)
+		assert.Equal(t, tariff, versions[0].Tariff)
+		assert.Equal(t, revisedTariff, versions[1].Tariff)
+		assert.Equal(t, 200, asOfResponse.Code)
+		assert.Equal(t, `"2"`, asOfResponse.Header().Get("ETag"))
+		assert.Equal(t, revisedTariff, asOfTariff)
+		assert.Equal(t, 404, beforeCreationResponse.Code)
+		assert.Equal(t, money.RequireFromString("645"), calculation.Cost)
+	})
+
+	t.Run("Positive Test Memory Backend Audit", func(t *testing.T) {
+		t.Setenv(pkg.TrustActorHeaderEnv, "true")
+		partitionPath := "/api/v1/partitions/" + uuid.NewString()
+		serve := func(method, path string, body []byte, headers ...string) *httptest.ResponseRecorder {
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(method, path, strings.NewReader(string(body)))
+			request.Header.Set(pkg.ActorHeader, data.TestActor)
+			for i := 0; i+1 < len(headers); i += 2 {
+				request.Header.Set(headers[i], headers[i+1])
+			}
+			router.ServeHTTP(recorder, request)
+			return recorder
+		}
+		auditPage := func(query string) models.Page[models.AuditRecord] {
+			var page models.Page[models.AuditRecord]
+			_ = json.Unmarshal(serve(http.MethodGet, partitionPath+"/audit?"+query, nil).Body.Bytes(), &page)
+			return page
+		}
+		serve(http.MethodPost, partitionPath+"/providers", tools.GetFirstValue(json.Marshal(data.Provider)))
+		var tariff models.Tariff
+		_ = json.Unmarshal(serve(http.MethodPost, partitionPath+"/tariffs", tools.GetFirstValue(json.Marshal(data.Tariff))).Body.Bytes(), &tariff)
+		revisedTariff := data.TariffRevised
+		revisedTariff.Id = tariff.Id
+
+		// act
+		serve(http.MethodPut, partitionPath+"/tariffs/"+tariff.Id, tools.GetFirstValue(json.Marshal(revisedTariff)), "If-Match", `"1"`)
+		serve(http.MethodDelete, partitionPath+"/tariffs/"+tariff.Id, nil, "If-Match", `"2"`)
+		allRecords := auditPage("")
+		tariffRecords := auditPage("entityType=tariff&entityId=" + tariff.Id)
+		otherActorRecords := auditPage("actor=other-actor")
+
+		// assert
+		assert.Len(t, allRecords.Items, 4)
+		assert.Len(t, tariffRecords.Items, 3)
+		assert.Empty(t, otherActorRecords.Items)
+		operations := []string{}
+		for _, record := range tariffRecords.Items {
+			operations = append(operations, record.Operation)
+			assert.Equal(t, data.TestActor, record.Actor)
+		}
+		assert.Equal(t, []string{models.AuditOperationCreate, models.AuditOperationUpdate, models.AuditOperationDelete}, operations)
+		assert.Equal(t, []models.AuditChange{{Pointer: "/fixedTariff/pricePerUnit", Before: json.RawMessage(`64.5`), After: json.RawMessage(`70.1`)}}, tariffRecords.Items[1].Changes)
+	})
+}
+
+// Test_Routes sends a request to every route through the real router and validator, so that a route whose
+// parameters are not named like the uri tags of pkg/validation fails instead of responding 400 to every request.
+func Test_Routes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv(repository.StorageBackendEnv, repository.MemoryBackend)
+	router := testRouter()
+	pathParams := map[string]string{":partitionId": uuid.NewString(), ":id": uuid.NewString()}
+
+	// act
+	for _, route := range router.Routes() {
+		t.Run(route.Method+" "+route.Path, func(t *testing.T) {
+			segments := strings.Split(route.Path, "/")
+			for idx, segment := range segments {
+				if strings.HasPrefix(segment, ":") {
+					value, ok := pathParams[segment]
+					assert.True(t, ok, "path parameter %s has no uri tag in pkg/validation", segment)
+					segments[idx] = value
+				}
+			}
+			recorder := httptest.NewRecorder()
+			// without a body, only the path parameters can fail with field errors
+			request := httptest.NewRequest(route.Method, strings.Join(segments, "/"), nil)
+			request.Header.Set("If-Match", "*")
+			router.ServeHTTP(recorder, request)
+
+			// assert
+			var problem models.Error
+			_ = json.Unmarshal(recorder.Body.Bytes(), &problem)
+			for _, fieldError := range problem.Errors {
+				assert.NotContains(t, []string{"/partitionId", "/id"}, fieldError.Pointer)
+			}
+		})
+	}
+}
+
+func Test_Port(t *testing.T) {
+	t.Setenv("PORT", "")
+	assert.Equal(t, defaultPort, port())
+
+	t.Setenv("PORT", "9000")
+	assert.Equal(t, "9000", port())
+}
@@ -1,7 +1,25 @@
 package main
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"tariff-calculation-service/internal/router"
+	"tariff-calculation-service/internal/writemodel"
+	"tariff-calculation-service/pkg"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+)
 
 func main() {
-	fmt.Println("Hello, world.")
+	lambda.Start(newHandler())
+	fmt.Println("Started lambda handler.")
+}
+
+// returns the lambda handler serving all write model routes
+func newHandler() func(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	router := router.NewRouter()
+	writemodel.RouteWritemodelCalls(router)
+
+	return pkg.AdaptGinRouter(router)
 }
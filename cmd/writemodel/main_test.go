@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/repository"
+	"tariff-calculation-service/pkg"
+	"tariff-calculation-service/test"
+	"tariff-calculation-service/test/data"
+	"tariff-calculation-service/tools"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Handler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv(repository.StorageBackendEnv, repository.MemoryBackend)
+	handler := newHandler()
+	basePath := "/api/v1/partitions/"
+
+	testCases := []struct {
+		name                 string
+		request              events.APIGatewayProxyRequest
+		expectedResponseCode int
+		expectedPointer      string
+	}{
+		{
+			"Negative Test Post Tariff Invalid PartitionId",
+			test.GetTestAPIGatewayProxyRequest(http.MethodPost, basePath+data.TestIdInvalid+"/tariffs", []byte("{}")),
+			400,
+			"/partitionId",
+		},
+		{
+			"Negative Test Post Tariff Invalid Body",
+			test.GetTestAPIGatewayProxyRequest(http.MethodPost, basePath+data.TestPartitionId+"/tariffs", []byte("{}")),
+			400,
+			"/name",
+		},
+		{
+			"Negative Test Put Contract Invalid Id",
+			test.GetTestAPIGatewayProxyRequest(http.MethodPut, basePath+data.TestPartitionId+"/contracts/"+data.TestIdInvalid, []byte("{}")),
+			400,
+			"/id",
+		},
+		{
+			"Negative Test Delete Provider Invalid Id",
+			test.GetTestAPIGatewayProxyRequest(http.MethodDelete, basePath+data.TestPartitionId+"/providers/"+data.TestIdInvalid, nil),
+			400,
+			"/id",
+		},
+		{
+			"Negative Test Delete Tax Rule Invalid Id",
+			test.GetTestAPIGatewayProxyRequest(http.MethodDelete, basePath+data.TestPartitionId+"/tax-rules/"+data.TestIdInvalid, nil),
+			400,
+			"/id",
+		},
+		{
+			"Negative Test Read Route Method Not Allowed",
+			test.GetTestAPIGatewayProxyRequest(http.MethodGet, basePath+data.TestPartitionId+"/tariffs", nil),
+			405,
+			"",
+		},
+		{
+			"Negative Test Route Not Found",
+			test.GetTestAPIGatewayProxyRequest(http.MethodGet, basePath+data.TestPartitionId+"/unknown", nil),
+			404,
+			"",
+		},
+	}
+	// act
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response, err := handler(context.Background(), tc.request)
+
+			// assert
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResponseCode, response.StatusCode)
+			if tc.expectedPointer != "" {
+				var actualError models.Error
+				err := json.Unmarshal([]byte(response.Body), &actualError)
+				if err != nil {
+					t.Fail()
+				}
+				assert.Equal(t, pkg.ProblemContentType, http.Header(response.MultiValueHeaders).Get("Content-Type"))
+				assert.Contains(t, actualError.Detail, tc.expectedPointer)
+				assert.True(t, slices.ContainsFunc(actualError.Errors, func(fieldError models.FieldError) bool {
+					return fieldError.Pointer == tc.expectedPointer
+				}))
+				assert.Equal(t, test.TestRequestId, actualError.TraceId)
+				assert.Equal(t, test.TestRequestId, http.Header(response.MultiValueHeaders).Get(pkg.RequestIdHeader))
+			}
+		})
+	}
+
+	t.Run("Positive Test Memory Backend", func(t *testing.T) {
+		tariffsPath := basePath + data.TestPartitionId + "/tariffs"
+
+		// act
+		createResponse, createErr := handler(context.Background(),
+			test.GetTestAPIGatewayProxyRequest(http.MethodPost, tariffsPath, tools.GetFirstValue(json.Marshal(data.Tariff))))
+		var createdTariff models.Tariff
+		_ = json.Unmarshal([]byte(createResponse.Body), &createdTariff)
+		updatedTariff := createdTariff
+		updatedTariff.Name = "Updated Tariff"
+		updateRequest := test.GetTestAPIGatewayProxyRequest(http.MethodPut, tariffsPath+"/"+createdTariff.Id, tools.GetFirstValue(json.Marshal(updatedTariff)))
+		updateRequest.Headers["If-Match"] = http.Header(createResponse.MultiValueHeaders).Get("ETag")
+		updateResponse, updateErr := handler(context.Background(), updateRequest)
+		staleUpdateResponse, staleUpdateErr := handler(context.Background(), updateRequest)
+
+		// assert
+		assert.Nil(t, createErr)
+		assert.Equal(t, 201, createResponse.StatusCode)
+		assert.NotEqual(t, data.TestTariffId, createdTariff.Id)
+		assert.Equal(t, data.Tariff.Name, createdTariff.Name)
+		assert.Equal(t, tariffsPath+"/"+createdTariff.Id, http.Header(createResponse.MultiValueHeaders).Get("Location"))
+		assert.Equal(t, `"1"`, http.Header(createResponse.MultiValueHeaders).Get("ETag"))
+		assert.Nil(t, updateErr)
+		assert.Equal(t, 204, updateResponse.StatusCode)
+		assert.Equal(t, `"2"`, http.Header(updateResponse.MultiValueHeaders).Get("ETag"))
+		assert.Nil(t, staleUpdateErr)
+		assert.Equal(t, 412, staleUpdateResponse.StatusCode)
+	})
+}
@@ -0,0 +1,170 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"tariff-calculation-service/internal/models"
+)
+
+// TimestampLayout is the layout of the timestamps of audit records: RFC 3339 in UTC with nanoseconds and a
+// fixed width, so that the timestamps sort by time as strings.
+const TimestampLayout = "2006-01-02T15:04:05.000000000Z"
+
+// absent stands for a value that does not exist on one side of a change.
+var absent = &struct{}{}
+
+// Timestamp returns the timestamp of an audit record written at the instant.
+func Timestamp(at time.Time) string {
+	return at.UTC().Format(TimestampLayout)
+}
+
+// FilterTimestamp returns a timestamp of a filter, which may have any offset, in TimestampLayout.
+func FilterTimestamp(value string) (string, error) {
+	at, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return "", err
+	}
+	return Timestamp(at), nil
+}
+
+// Diff returns the changes between the JSON documents of an entity before and after a write, ordered by
+// pointer. Objects and arrays are compared by member and by element; a nil entity does not exist, so a create
+// or delete is a single change of the whole entity.
+func Diff(before, after any) ([]models.AuditChange, error) {
+	beforeValue, err := jsonValue(before)
+	if err != nil {
+		return nil, err
+	}
+	afterValue, err := jsonValue(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []models.AuditChange{}
+	if err := diff(&changes, "", beforeValue, afterValue); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// Matches reports whether the audit record matches all criteria of the filter.
+func Matches(record models.AuditRecord, filter models.AuditFilter) bool {
+	if filter.EntityType != "" && record.EntityType != filter.EntityType {
+		return false
+	}
+	if filter.EntityId != "" && record.EntityId != filter.EntityId {
+		return false
+	}
+	if filter.Actor != "" && record.Actor != filter.Actor {
+		return false
+	}
+	if filter.From != "" {
+		if from, err := FilterTimestamp(filter.From); err != nil || record.Timestamp < from {
+			return false
+		}
+	}
+	if filter.To != "" {
+		if to, err := FilterTimestamp(filter.To); err != nil || record.Timestamp >= to {
+			return false
+		}
+	}
+	return true
+}
+
+// jsonValue returns the entity as decoded JSON, or absent for nil. Numbers are decoded as json.Number, so that
+// decimals keep their precision.
+func jsonValue(entity any) (any, error) {
+	if entity == nil {
+		return absent, nil
+	}
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func diff(changes *[]models.AuditChange, pointer string, before, after any) error {
+	if reflect.DeepEqual(before, after) {
+		return nil
+	}
+
+	beforeObject, beforeIsObject := before.(map[string]any)
+	afterObject, afterIsObject := after.(map[string]any)
+	if beforeIsObject && afterIsObject {
+		keys := []string{}
+		for key := range beforeObject {
+			keys = append(keys, key)
+		}
+		for key := range afterObject {
+			if _, ok := beforeObject[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if err := diff(changes, pointer+"/"+escapePointer(key), member(beforeObject, key), member(afterObject, key)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	beforeArray, beforeIsArray := before.([]any)
+	afterArray, afterIsArray := after.([]any)
+	if beforeIsArray && afterIsArray {
+		for idx := 0; idx < max(len(beforeArray), len(afterArray)); idx++ {
+			if err := diff(changes, pointer+"/"+strconv.Itoa(idx), element(beforeArray, idx), element(afterArray, idx)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	change := models.AuditChange{Pointer: pointer}
+	var err error
+	if before != absent {
+		if change.Before, err = json.Marshal(before); err != nil {
+			return err
+		}
+	}
+	if after != absent {
+		if change.After, err = json.Marshal(after); err != nil {
+			return err
+		}
+	}
+	*changes = append(*changes, change)
+	return nil
+}
+
+func member(object map[string]any, key string) any {
+	if value, ok := object[key]; ok {
+		return value
+	}
+	return absent
+}
+
+func element(array []any, idx int) any {
+	if idx < len(array) {
+		return array[idx]
+	}
+	return absent
+}
+
+// escapePointer escapes a member name as a reference token of a JSON pointer (RFC 6901).
+func escapePointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,81 @@
+package audit
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/test/data"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Diff(t *testing.T) {
+	// arrange
+	renamed := data.Provider
+	renamed.Name = "Renamed/Provider"
+	moreHourlyTariffs := data.Tariff
+	moreHourlyTariffs.DynamicTariff.HourlyTariffs = append(moreHourlyTariffs.DynamicTariff.HourlyTariffs, data.Tariff.DynamicTariff.HourlyTariffs[0])
+
+	testcases := []struct {
+		name     string
+		before   any
+		after    any
+		expected []models.AuditChange
+	}{
+		{"Positive Test Create", nil, map[string]any{"name": "Test"}, []models.AuditChange{{Pointer: "", After: json.RawMessage(`{"name":"Test"}`)}}},
+		{"Positive Test Delete", map[string]any{"name": "Test"}, nil, []models.AuditChange{{Pointer: "", Before: json.RawMessage(`{"name":"Test"}`)}}},
+		{"Positive Test Update", data.Provider, renamed, []models.AuditChange{{Pointer: "/name", Before: json.RawMessage(`"` + data.Provider.Name + `"`), After: json.RawMessage(`"Renamed/Provider"`)}}},
+		{"Positive Test Price", data.Tariff, data.TariffRevised, []models.AuditChange{{Pointer: "/fixedTariff/pricePerUnit", Before: json.RawMessage(`64.5`), After: json.RawMessage(`70.1`)}}},
+		{"Positive Test Added Element", data.Tariff, moreHourlyTariffs, []models.AuditChange{{Pointer: "/dynamicTariff/hourlyTariffs/1", After: json.RawMessage(`{"pricePerUnit":54.2,"startTime":"` + data.TestValidFrom + `","validDays":"AAEC"}`)}}},
+		{"Positive Test Escaped Member", map[string]any{"a/b~c": 1}, map[string]any{"a/b~c": 2}, []models.AuditChange{{Pointer: "/a~1b~0c", Before: json.RawMessage(`1`), After: json.RawMessage(`2`)}}},
+		{"Positive Test Unchanged", data.Tariff, data.Tariff, []models.AuditChange{}},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := Diff(tc.before, tc.after)
+
+			// assert
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func Test_Matches(t *testing.T) {
+	// arrange
+	record := models.AuditRecord{
+		Actor:      "alice",
+		Timestamp:  Timestamp(time.Date(2021, 3, 24, 12, 4, 18, 0, time.UTC)),
+		EntityType: models.AuditEntityTariff,
+		EntityId:   data.TestTariffId,
+		Operation:  models.AuditOperationUpdate,
+	}
+
+	testcases := []struct {
+		name     string
+		filter   models.AuditFilter
+		expected bool
+	}{
+		{"Positive Test No Filter", models.AuditFilter{}, true},
+		{"Positive Test Entity", models.AuditFilter{EntityType: models.AuditEntityTariff, EntityId: data.TestTariffId}, true},
+		{"Positive Test Actor", models.AuditFilter{Actor: "alice"}, true},
+		{"Positive Test Range With Offset", models.AuditFilter{From: "2021-03-24T13:04:18+01:00", To: "2021-03-24T12:04:19Z"}, true},
+		{"Negative Test Entity Type", models.AuditFilter{EntityType: models.AuditEntityContract}, false},
+		{"Negative Test Entity Id", models.AuditFilter{EntityId: data.TestContractId}, false},
+		{"Negative Test Actor", models.AuditFilter{Actor: "bob"}, false},
+		{"Negative Test Before Range", models.AuditFilter{From: "2021-03-24T12:04:19Z"}, false},
+		{"Negative Test Range End", models.AuditFilter{To: "2021-03-24T12:04:18Z"}, false},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := Matches(record, tc.filter)
+
+			// assert
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
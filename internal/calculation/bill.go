@@ -0,0 +1,200 @@
+package calculation
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/enums"
+	"tariff-calculation-service/pkg/money"
+)
+
+var ErrOutsideContract = errors.New("billing period is outside of the contract period")
+
+// billSegment is a part of the billing period that is billed with a single tariff.
+type billSegment struct {
+	tariff *models.Tariff
+	from   time.Time
+	to     time.Time
+}
+
+// CalculateBill bills the consumption of every tariff type of a contract. The billing period is split
+// wherever a tariff of that type starts or ends, and the consumption is prorated over the resulting
+// periods by their duration, the last period getting the remainder. The tiers of a tariff apply to its consumption
+// over the whole billing period, across its versions. The fixed charges of every tariff are prorated over its period
+// and listed separately from the consumption lines.
+func CalculateBill(contract models.Contract, tariffs []models.Tariff, request models.BillRequest) (*models.Bill, error) {
+	from, err := time.Parse(time.RFC3339, request.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := time.Parse(time.RFC3339, request.To)
+	if err != nil {
+		return nil, err
+	}
+	if !to.After(from) {
+		return nil, ErrInvalidPeriod
+	}
+	if err := checkContractPeriod(contract, from, to); err != nil {
+		return nil, err
+	}
+
+	bill := &models.Bill{
+		ContractId:       contract.Id,
+		From:             from.Format(time.RFC3339),
+		To:               to.Format(time.RFC3339),
+		Lines:            []models.BillLine{},
+		Charges:          []models.ChargeCost{},
+		TariffTypeTotals: []models.BillTotal{},
+		Totals:           []models.BillTotal{},
+	}
+	for _, consumption := range request.Consumptions {
+		lines, charges, err := billTariffType(tariffs, consumption, from, to)
+		if err != nil {
+			return nil, err
+		}
+		bill.Lines = append(bill.Lines, lines...)
+		bill.Charges = append(bill.Charges, charges...)
+	}
+	sumBillTotals(bill)
+
+	return bill, nil
+}
+
+func billTariffType(tariffs []models.Tariff, consumption models.BillConsumption, from, to time.Time) ([]models.BillLine, []models.ChargeCost, error) {
+	lines := []models.BillLine{}
+	charges := []models.ChargeCost{}
+	duration := to.Sub(from)
+	periods, err := splitByTariffValidity(tariffs, consumption.TariffType, from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	segments := []billSegment{}
+	for _, period := range periods {
+		tariff := FindValidTariff(tariffs, &consumption.TariffType, period[0], period[1])
+		if tariff == nil {
+			return nil, nil, fmt.Errorf("%w: %s %s - %s", ErrNoValidTariff, consumption.TariffType,
+				period[0].Format(time.RFC3339), period[1].Format(time.RFC3339))
+		}
+		if last := len(segments) - 1; last >= 0 && segments[last].tariff == tariff {
+			segments[last].to = period[1]
+			continue
+		}
+		segments = append(segments, billSegment{tariff: tariff, from: period[0], to: period[1]})
+	}
+
+	consumedBefore := map[string]money.Decimal{}
+	remainder := consumption.Quantity
+	for idx, segment := range segments {
+		quantity := remainder
+		if idx < len(segments)-1 {
+			quantity = prorate(consumption.Quantity, segment.to.Sub(segment.from), duration)
+		}
+		remainder = remainder.Sub(quantity)
+		cost, err := CalculateCost(*segment.tariff, Consumption{
+			Quantity:       quantity,
+			From:           segment.from,
+			To:             segment.to,
+			ConsumedBefore: consumedBefore[segment.tariff.Id],
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		consumedBefore[segment.tariff.Id] = consumedBefore[segment.tariff.Id].Add(quantity)
+
+		lines = append(lines, models.BillLine{
+			TariffType:   consumption.TariffType,
+			TariffId:     cost.TariffId,
+			From:         cost.From,
+			To:           cost.To,
+			Currency:     cost.Currency,
+			Quantity:     cost.Quantity,
+			PricePerUnit: cost.PricePerUnit,
+			Cost:         cost.ConsumptionCost,
+		})
+		charges = append(charges, cost.Charges...)
+	}
+
+	return lines, charges, nil
+}
+
+// splitByTariffValidity splits [from, to) at every start and end of a tariff of the given type.
+func splitByTariffValidity(tariffs []models.Tariff, tariffType enums.TariffType, from, to time.Time) ([][2]time.Time, error) {
+	boundaries := []time.Time{from, to}
+	for _, tariff := range tariffs {
+		if tariff.TariffType != tariffType {
+			continue
+		}
+		for _, value := range []string{tariff.ValidFrom, tariff.ValidTo} {
+			boundary, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, err
+			}
+			if boundary.After(from) && boundary.Before(to) {
+				boundaries = append(boundaries, boundary)
+			}
+		}
+	}
+	sort.Slice(boundaries, func(i, j int) bool { return boundaries[i].Before(boundaries[j]) })
+
+	periods := [][2]time.Time{}
+	for idx := 1; idx < len(boundaries); idx++ {
+		if boundaries[idx].After(boundaries[idx-1]) {
+			periods = append(periods, [2]time.Time{boundaries[idx-1], boundaries[idx]})
+		}
+	}
+
+	return periods, nil
+}
+
+func checkContractPeriod(contract models.Contract, from, to time.Time) error {
+	startDate, err := time.Parse(time.RFC3339, contract.StartDate)
+	if err != nil {
+		return err
+	}
+	endDate, err := time.Parse(time.RFC3339, contract.EndDate)
+	if err != nil {
+		return err
+	}
+	if from.Before(startDate) || to.After(endDate) {
+		return ErrOutsideContract
+	}
+
+	return nil
+}
+
+// sumBillTotals sums the lines and charges of the bill up per tariff type and currency and per currency. A charge
+// counts for the tariff type of the lines of its tariff.
+func sumBillTotals(bill *models.Bill) {
+	tariffTypes := map[string]enums.TariffType{}
+	bill.TariffTypeTotals = []models.BillTotal{}
+	bill.Totals = []models.BillTotal{}
+	for _, line := range bill.Lines {
+		tariffType := line.TariffType
+		tariffTypes[line.TariffId] = tariffType
+		bill.TariffTypeTotals = addToBillTotal(bill.TariffTypeTotals, models.BillTotal{TariffType: &tariffType, Currency: line.Currency, Quantity: line.Quantity, Cost: line.Cost})
+		bill.Totals = addToBillTotal(bill.Totals, models.BillTotal{Currency: line.Currency, Quantity: line.Quantity, Cost: line.Cost})
+	}
+	for _, charge := range bill.Charges {
+		tariffType := tariffTypes[charge.TariffId]
+		bill.TariffTypeTotals = addToBillTotal(bill.TariffTypeTotals, models.BillTotal{TariffType: &tariffType, Currency: charge.Currency, Cost: charge.Cost})
+		bill.Totals = addToBillTotal(bill.Totals, models.BillTotal{Currency: charge.Currency, Cost: charge.Cost})
+	}
+}
+
+func addToBillTotal(totals []models.BillTotal, amount models.BillTotal) []models.BillTotal {
+	for idx := range totals {
+		sameTariffType := totals[idx].TariffType == nil && amount.TariffType == nil ||
+			totals[idx].TariffType != nil && amount.TariffType != nil && *totals[idx].TariffType == *amount.TariffType
+		if sameTariffType && totals[idx].Currency == amount.Currency {
+			totals[idx].Quantity = totals[idx].Quantity.Add(amount.Quantity)
+			totals[idx].Cost = totals[idx].Cost.Add(amount.Cost)
+			return totals
+		}
+	}
+
+	return append(totals, amount)
+}
@@ -0,0 +1,99 @@
+package calculation
+
+import (
+	"errors"
+	"testing"
+
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/enums"
+	"tariff-calculation-service/pkg/money"
+	"tariff-calculation-service/test/data"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var billTariffs = []models.Tariff{data.TariffElectricityJanuary, data.TariffElectricityFebruary, data.TariffGas}
+
+func Test_CalculateBill(t *testing.T) {
+	// act
+	bill, err := CalculateBill(data.Contract, billTariffs, data.BillRequest)
+
+	// assert
+	assert.Nil(t, err)
+	assert.Len(t, bill.Lines, 3)
+
+	assert.Equal(t, data.TariffElectricityJanuary.Id, bill.Lines[0].TariffId)
+	assert.Equal(t, "2021-01-01T00:00:00Z", bill.Lines[0].From)
+	assert.Equal(t, "2021-01-16T00:00:00Z", bill.Lines[0].To)
+	assert.Equal(t, money.RequireFromString("150"), bill.Lines[0].Quantity)
+	assert.Equal(t, money.RequireFromString("45"), bill.Lines[0].Cost)
+
+	assert.Equal(t, data.TariffElectricityFebruary.Id, bill.Lines[1].TariffId)
+	assert.Equal(t, "2021-01-16T00:00:00Z", bill.Lines[1].From)
+	assert.Equal(t, "2021-02-01T00:00:00Z", bill.Lines[1].To)
+	assert.Equal(t, money.RequireFromString("160"), bill.Lines[1].Quantity)
+	assert.Equal(t, money.RequireFromString("64"), bill.Lines[1].Cost)
+
+	assert.Equal(t, data.TariffGas.Id, bill.Lines[2].TariffId)
+	assert.Equal(t, money.RequireFromString("10"), bill.Lines[2].Cost)
+
+	assert.Len(t, bill.TariffTypeTotals, 2)
+	assert.Equal(t, enums.Electricity, *bill.TariffTypeTotals[0].TariffType)
+	assert.Equal(t, money.RequireFromString("109"), bill.TariffTypeTotals[0].Cost)
+	assert.Len(t, bill.Totals, 1)
+	assert.Equal(t, money.RequireFromString("119"), bill.Totals[0].Cost)
+}
+
+func Test_CalculateBill_Negative(t *testing.T) {
+	// arrange
+	outsideContract := data.BillRequest
+	outsideContract.To = "2023-01-01T00:00:00Z"
+
+	uncovered := data.BillRequest
+	uncovered.To = "2021-04-01T00:00:00Z"
+
+	testcases := []struct {
+		name          string
+		request       models.BillRequest
+		expectedError error
+	}{
+		{"Negative Test Outside Contract", outsideContract, ErrOutsideContract},
+		{"Negative Test Period Not Covered By Tariffs", uncovered, ErrNoValidTariff},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			bill, err := CalculateBill(data.Contract, billTariffs, tc.request)
+
+			// assert
+			assert.True(t, errors.Is(err, tc.expectedError))
+			assert.Nil(t, bill)
+		})
+	}
+}
+
+func Test_CalculateBill_TieredAcrossVersions(t *testing.T) {
+	// arrange
+	january := data.TariffTiered
+	january.ValidFrom = "2021-01-01T00:00:00Z"
+	january.ValidTo = "2021-01-16T00:00:00Z"
+	february := data.TariffTiered
+	february.ValidFrom = "2021-01-16T00:00:00Z"
+	february.ValidTo = "2021-02-01T00:00:00Z"
+	request := models.BillRequest{
+		From:         "2021-01-01T00:00:00Z",
+		To:           "2021-02-01T00:00:00Z",
+		Consumptions: []models.BillConsumption{{TariffType: enums.Water, Quantity: money.RequireFromString("310")}},
+	}
+
+	// act
+	bill, err := CalculateBill(data.Contract, []models.Tariff{january, february}, request)
+
+	// assert
+	assert.Nil(t, err)
+	assert.Len(t, bill.Lines, 2)
+	assert.Equal(t, money.RequireFromString("150"), bill.Lines[0].Quantity)
+	assert.Equal(t, money.RequireFromString("350"), bill.Lines[0].Cost)
+	assert.Equal(t, money.RequireFromString("160"), bill.Lines[1].Quantity)
+	assert.Equal(t, money.RequireFromString("480"), bill.Lines[1].Cost)
+}
@@ -0,0 +1,171 @@
+package calculation
+
+import (
+	"errors"
+	"time"
+
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/enums"
+	"tariff-calculation-service/pkg/money"
+)
+
+var (
+	ErrInvalidPeriod   = errors.New("consumption period must end after it starts")
+	ErrOutsideValidity = errors.New("consumption period is outside of the tariff validity")
+	ErrNoUnitPrice     = errors.New("tiered tariffs have no single price per unit")
+)
+
+// Consumption is a consumed quantity over the half-open period [From, To).
+// ConsumedBefore is the quantity consumed earlier in the same billing period, which tiered tariffs price against.
+type Consumption struct {
+	Quantity       money.Decimal
+	ConsumedBefore money.Decimal
+	From           time.Time
+	To             time.Time
+}
+
+func ParseConsumption(request models.CalculationRequest) (Consumption, error) {
+	from, err := time.Parse(time.RFC3339, request.From)
+	if err != nil {
+		return Consumption{}, err
+	}
+	to, err := time.Parse(time.RFC3339, request.To)
+	if err != nil {
+		return Consumption{}, err
+	}
+	if !to.After(from) {
+		return Consumption{}, ErrInvalidPeriod
+	}
+
+	return Consumption{Quantity: request.Quantity, From: from, To: to}, nil
+}
+
+// CalculateCost returns the cost of the consumption. Dynamic tariffs are priced per hourly tariff slot,
+// tiered tariffs per consumption band and all other tariffs with their fixed price. The fixed charges
+// of the tariff are prorated over the period and added to the cost.
+func CalculateCost(tariff models.Tariff, consumption Consumption) (*models.Calculation, error) {
+	if err := CheckValidity(tariff, consumption.From, consumption.To); err != nil {
+		return nil, err
+	}
+
+	result := &models.Calculation{
+		TariffId: tariff.Id,
+		Currency: tariff.Currency,
+		From:     consumption.From.Format(time.RFC3339),
+		To:       consumption.To.Format(time.RFC3339),
+		Quantity: consumption.Quantity,
+	}
+
+	switch tariff.PricingModel {
+	case enums.Dynamic:
+		slots, err := SplitConsumption(tariff.DynamicTariff, consumption)
+		if err != nil {
+			return nil, err
+		}
+		for _, slot := range slots {
+			result.ConsumptionCost = result.ConsumptionCost.Add(slot.Cost)
+		}
+		result.Slots = slots
+		result.PricePerUnit = averagePrice(result.ConsumptionCost, consumption.Quantity)
+	case enums.Tiered:
+		tiers, err := SplitIntoTiers(tariff.TieredTariff, consumption)
+		if err != nil {
+			return nil, err
+		}
+		for _, tier := range tiers {
+			result.ConsumptionCost = result.ConsumptionCost.Add(tier.Cost)
+		}
+		result.Tiers = tiers
+		result.PricePerUnit = averagePrice(result.ConsumptionCost, consumption.Quantity)
+	default:
+		result.PricePerUnit = tariff.FixedTariff.PricePerUnit
+		result.ConsumptionCost = tariff.FixedTariff.PricePerUnit.Mul(consumption.Quantity)
+	}
+
+	result.Cost = result.ConsumptionCost
+	result.Charges = CalculateCharges(tariff, consumption.From, consumption.To)
+	for _, charge := range result.Charges {
+		result.Cost = result.Cost.Add(charge.Cost)
+	}
+
+	return result, nil
+}
+
+func averagePrice(cost money.Decimal, quantity money.Decimal) money.Decimal {
+	if quantity.Cmp(money.Zero) > 0 {
+		return cost.Div(quantity)
+	}
+	return money.Zero
+}
+
+// PriceAt returns the price per unit of the tariff at the given instant.
+func PriceAt(tariff models.Tariff, at time.Time) (*models.Price, error) {
+	if err := CheckValidAt(tariff, at); err != nil {
+		return nil, err
+	}
+
+	price := &models.Price{
+		TariffId: tariff.Id,
+		Currency: tariff.Currency,
+		At:       at.Format(time.RFC3339),
+	}
+	if tariff.PricingModel == enums.Tiered {
+		return nil, ErrNoUnitPrice
+	}
+	if !IsDynamic(tariff) {
+		price.PricePerUnit = tariff.FixedTariff.PricePerUnit
+		return price, nil
+	}
+
+	slot, err := FindHourlyTariff(tariff.DynamicTariff, at)
+	if err != nil {
+		return nil, err
+	}
+	price.StartTime = slot.StartTime
+	price.PricePerUnit = slot.PricePerUnit
+
+	return price, nil
+}
+
+// IsDynamic reports whether the tariff is priced by hourly tariff slots.
+func IsDynamic(tariff models.Tariff) bool {
+	return tariff.PricingModel == enums.Dynamic
+}
+
+// CheckValidity returns ErrOutsideValidity if [from, to) is not fully covered by the validity of the tariff.
+func CheckValidity(tariff models.Tariff, from, to time.Time) error {
+	validFrom, validTo, err := validity(tariff)
+	if err != nil {
+		return err
+	}
+	if from.Before(validFrom) || to.After(validTo) {
+		return ErrOutsideValidity
+	}
+
+	return nil
+}
+
+// CheckValidAt returns ErrOutsideValidity if the instant is not within [validFrom, validTo) of the tariff.
+func CheckValidAt(tariff models.Tariff, at time.Time) error {
+	validFrom, validTo, err := validity(tariff)
+	if err != nil {
+		return err
+	}
+	if at.Before(validFrom) || !at.Before(validTo) {
+		return ErrOutsideValidity
+	}
+
+	return nil
+}
+
+func validity(tariff models.Tariff) (time.Time, time.Time, error) {
+	validFrom, err := time.Parse(time.RFC3339, tariff.ValidFrom)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	validTo, err := time.Parse(time.RFC3339, tariff.ValidTo)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return validFrom, validTo, nil
+}
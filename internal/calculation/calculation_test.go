@@ -0,0 +1,58 @@
+package calculation
+
+import (
+	"testing"
+
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/test/data"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testcaseCalculation struct {
+	name             string
+	request          models.CalculationRequest
+	expectedResponse *models.Calculation
+	expectedError    error
+}
+
+func Test_CalculateCost(t *testing.T) {
+	// arrange
+	testcases := []testcaseCalculation{
+		{
+			name:             "Positive Test",
+			request:          data.CalculationRequest,
+			expectedResponse: &data.Calculation,
+		},
+		{
+			name:          "Negative Test Outside Validity",
+			request:       data.CalculationRequestOutsideValidity,
+			expectedError: ErrOutsideValidity,
+		},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			consumption, err := ParseConsumption(tc.request)
+			assert.Nil(t, err)
+
+			actualCalculation, err := CalculateCost(data.Tariff, consumption)
+
+			// assert
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedResponse, actualCalculation)
+		})
+	}
+}
+
+func Test_ParseConsumption_Negative(t *testing.T) {
+	// arrange
+	request := data.CalculationRequest
+	request.From, request.To = request.To, request.From
+
+	// act
+	_, err := ParseConsumption(request)
+
+	// assert
+	assert.Equal(t, ErrInvalidPeriod, err)
+}
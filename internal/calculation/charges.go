@@ -0,0 +1,90 @@
+package calculation
+
+import (
+	"time"
+
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/enums"
+	"tariff-calculation-service/pkg/money"
+)
+
+const day = 24 * time.Hour
+
+// CalculateCharges prorates the fixed charges of the tariff over the period [from, to).
+func CalculateCharges(tariff models.Tariff, from, to time.Time) []models.ChargeCost {
+	if len(tariff.FixedCharges) == 0 {
+		return nil
+	}
+
+	charges := make([]models.ChargeCost, 0, len(tariff.FixedCharges))
+	for _, charge := range tariff.FixedCharges {
+		charges = append(charges, models.ChargeCost{
+			TariffId:  tariff.Id,
+			Name:      charge.Name,
+			Frequency: charge.Frequency,
+			Currency:  tariff.Currency,
+			Amount:    charge.Amount,
+			From:      from.Format(time.RFC3339),
+			To:        to.Format(time.RFC3339),
+			Days:      float64(to.Sub(from)) / float64(day),
+			Cost:      ProrateCharge(charge, from, to),
+		})
+	}
+
+	return charges
+}
+
+// ProrateCharge returns the share of the charge that is due for the period [from, to). Daily charges are
+// multiplied by the number of days, monthly and yearly charges are divided by the number of days of the
+// calendar month or year the period falls into.
+func ProrateCharge(charge models.FixedCharge, from, to time.Time) money.Decimal {
+	if !to.After(from) {
+		return money.Zero
+	}
+
+	if charge.Frequency != enums.Monthly && charge.Frequency != enums.Yearly {
+		return prorate(charge.Amount, to.Sub(from), day)
+	}
+
+	cost := money.Zero
+	for start := from; start.Before(to); {
+		periodStart, periodEnd := calendarPeriod(charge.Frequency, start)
+		end := periodEnd
+		if to.Before(end) {
+			end = to
+		}
+		cost = cost.Add(prorate(charge.Amount, end.Sub(start), periodEnd.Sub(periodStart)))
+		start = end
+	}
+
+	return cost
+}
+
+// prorate returns the share of the amount that falls on part of the whole duration.
+func prorate(amount money.Decimal, part, whole time.Duration) money.Decimal {
+	return amount.Mul(money.NewFromInt(int64(part))).Div(money.NewFromInt(int64(whole)))
+}
+
+// calendarPeriod returns the calendar month or year that contains t.
+func calendarPeriod(frequency enums.ChargeFrequency, t time.Time) (time.Time, time.Time) {
+	if frequency == enums.Yearly {
+		start := time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location())
+		return start, start.AddDate(1, 0, 0)
+	}
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	return start, start.AddDate(0, 1, 0)
+}
+
+func addCharge(charges []models.ChargeCost, index map[string]int, charge models.ChargeCost) []models.ChargeCost {
+	key := charge.TariffId + "#" + charge.Name
+	idx, found := index[key]
+	if !found {
+		index[key] = len(charges)
+		return append(charges, charge)
+	}
+	charges[idx].To = charge.To
+	charges[idx].Days += charge.Days
+	charges[idx].Cost = charges[idx].Cost.Add(charge.Cost)
+
+	return charges
+}
@@ -0,0 +1,113 @@
+package calculation
+
+import (
+	"testing"
+	"time"
+
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/enums"
+	"tariff-calculation-service/pkg/money"
+	"tariff-calculation-service/test/data"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ProrateCharge(t *testing.T) {
+	// arrange
+	testcases := []struct {
+		name         string
+		charge       models.FixedCharge
+		from         string
+		to           string
+		expectedCost money.Decimal
+	}{
+		{"Positive Test Daily", models.FixedCharge{Amount: money.RequireFromString("0.5"), Frequency: enums.Daily}, "2021-01-01T00:00:00Z", "2021-01-11T00:00:00Z", money.RequireFromString("5")},
+		{"Positive Test Daily Part Of Day", models.FixedCharge{Amount: money.RequireFromString("2"), Frequency: enums.Daily}, "2021-01-01T00:00:00Z", "2021-01-01T06:00:00Z", money.RequireFromString("0.5")},
+		{"Positive Test Monthly Full Month", models.FixedCharge{Amount: money.RequireFromString("31"), Frequency: enums.Monthly}, "2021-01-01T00:00:00Z", "2021-02-01T00:00:00Z", money.RequireFromString("31")},
+		{"Positive Test Monthly Across Months", models.FixedCharge{Amount: money.RequireFromString("31"), Frequency: enums.Monthly}, "2021-01-16T00:00:00Z", "2021-02-15T00:00:00Z", money.RequireFromString("31.5")},
+		{"Positive Test Yearly", models.FixedCharge{Amount: money.RequireFromString("365"), Frequency: enums.Yearly}, "2021-01-01T00:00:00Z", "2021-02-01T00:00:00Z", money.RequireFromString("31")},
+		{"Positive Test Yearly Leap Year", models.FixedCharge{Amount: money.RequireFromString("366"), Frequency: enums.Yearly}, "2020-02-01T00:00:00Z", "2020-03-01T00:00:00Z", money.RequireFromString("29")},
+		{"Positive Test Empty Period", models.FixedCharge{Amount: money.RequireFromString("31"), Frequency: enums.Monthly}, "2021-01-01T00:00:00Z", "2021-01-01T00:00:00Z", money.RequireFromString("0")},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			from, _ := time.Parse(time.RFC3339, tc.from)
+			to, _ := time.Parse(time.RFC3339, tc.to)
+			cost := ProrateCharge(tc.charge, from, to)
+
+			// assert
+			assert.Equal(t, tc.expectedCost, cost)
+		})
+	}
+}
+
+func Test_CalculateCost_Charges(t *testing.T) {
+	// arrange
+	from, _ := time.Parse(time.RFC3339, "2021-01-01T00:00:00Z")
+	to, _ := time.Parse(time.RFC3339, "2021-02-01T00:00:00Z")
+
+	// act
+	result, err := CalculateCost(data.TariffWithCharges, Consumption{Quantity: money.RequireFromString("100"), From: from, To: to})
+
+	// assert
+	assert.Nil(t, err)
+	assert.Equal(t, money.RequireFromString("0.25"), result.PricePerUnit)
+	assert.Equal(t, money.RequireFromString("25"), result.ConsumptionCost)
+	assert.Len(t, result.Charges, 3)
+	assert.Equal(t, "Standing Charge", result.Charges[0].Name)
+	assert.Equal(t, 31.0, result.Charges[0].Days)
+	assert.Equal(t, money.RequireFromString("15.5"), result.Charges[0].Cost)
+	assert.Equal(t, money.RequireFromString("31"), result.Charges[1].Cost)
+	assert.Equal(t, money.RequireFromString("31"), result.Charges[2].Cost)
+	assert.Equal(t, money.RequireFromString("102.5"), result.Cost)
+}
+
+func Test_CalculateIntervals_Charges(t *testing.T) {
+	// arrange
+	request := models.IntervalCalculationRequest{
+		Resolution: 720,
+		Readings: []models.Reading{
+			{Timestamp: "2021-01-01T00:00:00Z", Quantity: money.RequireFromString("4")},
+			{Timestamp: "2021-01-01T12:00:00Z", Quantity: money.RequireFromString("4")},
+		},
+	}
+
+	// act
+	result, err := CalculateIntervals([]models.Tariff{data.TariffWithCharges}, request)
+
+	// assert
+	assert.Nil(t, err)
+	assert.Equal(t, money.RequireFromString("1"), result.Intervals[0].Cost)
+	assert.Equal(t, money.RequireFromString("1"), result.Intervals[1].Cost)
+	assert.Len(t, result.Charges, 3)
+	assert.Equal(t, "2021-01-01T00:00:00Z", result.Charges[0].From)
+	assert.Equal(t, "2021-01-02T00:00:00Z", result.Charges[0].To)
+	assert.Equal(t, 1.0, result.Charges[0].Days)
+	assert.Equal(t, money.RequireFromString("0.5"), result.Charges[0].Cost)
+	assert.Equal(t, money.RequireFromString("4.5"), result.TariffTotals[0].Cost)
+	assert.Equal(t, money.RequireFromString("4.5"), result.Totals[0].Cost)
+}
+
+func Test_CalculateBill_Charges(t *testing.T) {
+	// arrange
+	request := models.BillRequest{
+		From:         "2021-01-01T00:00:00Z",
+		To:           "2021-02-01T00:00:00Z",
+		Consumptions: []models.BillConsumption{{TariffType: enums.Electricity, Quantity: money.RequireFromString("100")}},
+	}
+
+	// act
+	bill, err := CalculateBill(data.Contract, []models.Tariff{data.TariffWithCharges}, request)
+
+	// assert
+	assert.Nil(t, err)
+	assert.Len(t, bill.Lines, 1)
+	assert.Equal(t, money.RequireFromString("25"), bill.Lines[0].Cost)
+	assert.Len(t, bill.Charges, 3)
+	assert.Equal(t, data.TariffWithCharges.Id, bill.Charges[1].TariffId)
+	assert.Equal(t, money.RequireFromString("31"), bill.Charges[1].Cost)
+	assert.Equal(t, money.RequireFromString("102.5"), bill.TariffTypeTotals[0].Cost)
+	assert.Equal(t, money.RequireFromString("100"), bill.TariffTypeTotals[0].Quantity)
+	assert.Equal(t, money.RequireFromString("102.5"), bill.Totals[0].Cost)
+}
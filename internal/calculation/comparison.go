@@ -0,0 +1,131 @@
+package calculation
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/enums"
+)
+
+// CompareTariffs costs a consumption profile against every tariff that matches the tariff type and currency
+// of the request and ranks them by cost, the cheapest first. Without a requested currency the currency of the
+// current contract is used. Tariffs that cannot price the whole profile, e.g. because they are not valid for
+// every interval, are left out. The costs include the fixed charges but no taxes and are the sums of the intervals
+// and charges rounded with the given mode. The versions of a tariff, as of
+// TariffVersions, are compared as one tariff with the name, type and currency of its last version.
+func CompareTariffs(current []models.Tariff, tariffs []models.Tariff, request models.ComparisonRequest, mode enums.RoundingMode) (*models.Comparison, error) {
+	for _, reading := range request.Readings {
+		if _, err := time.Parse(time.RFC3339, reading.Timestamp); err != nil {
+			return nil, err
+		}
+	}
+
+	comparison := &models.Comparison{Tariffs: []models.TariffComparison{}}
+	currentCost, err := profileCost(current, request, mode)
+	if err != nil {
+		return nil, err
+	}
+	comparison.Current = currentCost
+
+	currency := request.Currency
+	if currency == "" && currentCost != nil {
+		currency = currentCost.Currency
+	}
+	currentIds := map[string]bool{}
+	for _, tariff := range current {
+		currentIds[tariff.Id] = true
+	}
+
+	for _, versions := range groupVersions(tariffs) {
+		tariff := versions[len(versions)-1]
+		if request.TariffType != nil && tariff.TariffType != *request.TariffType {
+			continue
+		}
+		if currency != "" && tariff.Currency != currency {
+			continue
+		}
+		cost, err := profileCost(versions, request, mode)
+		if err != nil {
+			return nil, err
+		}
+		if cost == nil {
+			continue
+		}
+
+		tariffComparison := models.TariffComparison{
+			TariffId:   tariff.Id,
+			Name:       tariff.Name,
+			TariffType: tariff.TariffType,
+			Current:    currentIds[tariff.Id],
+			Currency:   cost.Currency,
+			Quantity:   cost.Quantity,
+			Cost:       cost.Cost,
+		}
+		if currentCost != nil && currentCost.Currency == cost.Currency {
+			savings := currentCost.Cost.Sub(cost.Cost)
+			tariffComparison.Savings = &savings
+		}
+		comparison.Tariffs = append(comparison.Tariffs, tariffComparison)
+	}
+	rankTariffs(comparison.Tariffs)
+
+	return comparison, nil
+}
+
+// profileCost returns the cost of the profile with the given tariffs, or nil if the tariffs cannot price
+// every interval or the cost is in more than one currency.
+func profileCost(tariffs []models.Tariff, request models.ComparisonRequest, mode enums.RoundingMode) (*models.ComparisonCost, error) {
+	result, err := CalculateIntervals(tariffs, models.IntervalCalculationRequest{
+		TariffType: request.TariffType,
+		Resolution: request.Resolution,
+		Readings:   request.Readings,
+	})
+	if errors.Is(err, ErrNoValidTariff) || errors.Is(err, ErrNoHourlyTariff) || errors.Is(err, ErrInvalidTiers) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	RoundIntervalCalculation(result, mode)
+	if len(result.Totals) != 1 {
+		return nil, nil
+	}
+
+	return &models.ComparisonCost{
+		Currency: result.Totals[0].Currency,
+		Quantity: result.Totals[0].Quantity,
+		Cost:     result.Totals[0].Cost,
+	}, nil
+}
+
+// groupVersions groups the tariffs by id. The versions of a tariff follow each other.
+func groupVersions(tariffs []models.Tariff) [][]models.Tariff {
+	groups := [][]models.Tariff{}
+	for idx, tariff := range tariffs {
+		if last := len(groups) - 1; idx > 0 && tariffs[idx-1].Id == tariff.Id {
+			groups[last] = append(groups[last], tariff)
+			continue
+		}
+		groups = append(groups, []models.Tariff{tariff})
+	}
+
+	return groups
+}
+
+// rankTariffs sorts the tariffs by currency and cost and ranks them per currency.
+func rankTariffs(tariffs []models.TariffComparison) {
+	sort.SliceStable(tariffs, func(i, j int) bool {
+		if tariffs[i].Currency != tariffs[j].Currency {
+			return tariffs[i].Currency < tariffs[j].Currency
+		}
+		return tariffs[i].Cost.Cmp(tariffs[j].Cost) < 0
+	})
+	for idx := range tariffs {
+		tariffs[idx].Rank = 1
+		if idx > 0 && tariffs[idx-1].Currency == tariffs[idx].Currency {
+			tariffs[idx].Rank = tariffs[idx-1].Rank + 1
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package calculation
+
+import (
+	"testing"
+
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/enums"
+	"tariff-calculation-service/pkg/money"
+	"tariff-calculation-service/test/data"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CompareTariffs(t *testing.T) {
+	// arrange
+	tariffEuro := data.Tariff
+	tariffEuro.Id = "4f0f7c5e-3c1d-4b0e-9a7e-0c9f1f6e2a05"
+	tariffEuro.Currency = "EUR"
+	tariffs := []models.Tariff{data.Tariff, data.TariffGas, data.TariffElectricityJanuary, data.TariffElectricityFebruary, tariffEuro}
+	electricity := enums.Electricity
+
+	savings := func(value string) *money.Decimal {
+		savings := money.RequireFromString(value)
+		return &savings
+	}
+	current := &models.ComparisonCost{Currency: data.TestCurrency, Quantity: money.RequireFromString("2"), Cost: money.RequireFromString("129")}
+
+	testcases := []struct {
+		name               string
+		tariffType         *enums.TariffType
+		currency           string
+		expectedComparison *models.Comparison
+	}{
+		{
+			"Positive Test Currency Of Contract",
+			nil,
+			"",
+			&models.Comparison{
+				Current: current,
+				Tariffs: []models.TariffComparison{
+					{Rank: 1, TariffId: data.TariffGas.Id, Name: data.TariffGas.Name, TariffType: enums.Gas, Currency: data.TestCurrency, Quantity: money.RequireFromString("2"), Cost: money.RequireFromString("0.2"), Savings: savings("128.8")},
+					{Rank: 2, TariffId: data.TariffElectricityJanuary.Id, Name: data.TariffElectricityJanuary.Name, TariffType: enums.Electricity, Currency: data.TestCurrency, Quantity: money.RequireFromString("2"), Cost: money.RequireFromString("0.6"), Savings: savings("128.4")},
+					{Rank: 3, TariffId: data.TestTariffId, Name: data.TestTariffName, TariffType: data.TestTariffType, Current: true, Currency: data.TestCurrency, Quantity: money.RequireFromString("2"), Cost: money.RequireFromString("129"), Savings: savings("0")},
+				},
+			},
+		},
+		{
+			"Positive Test Tariff Type Without Current Cost",
+			&electricity,
+			"",
+			&models.Comparison{
+				Tariffs: []models.TariffComparison{
+					{Rank: 1, TariffId: data.TariffElectricityJanuary.Id, Name: data.TariffElectricityJanuary.Name, TariffType: enums.Electricity, Currency: data.TestCurrency, Quantity: money.RequireFromString("2"), Cost: money.RequireFromString("0.6")},
+				},
+			},
+		},
+		{
+			"Positive Test Other Currency",
+			nil,
+			"EUR",
+			&models.Comparison{
+				Current: current,
+				Tariffs: []models.TariffComparison{
+					{Rank: 1, TariffId: tariffEuro.Id, Name: data.TestTariffName, TariffType: data.TestTariffType, Currency: "EUR", Quantity: money.RequireFromString("2"), Cost: money.RequireFromString("129")},
+				},
+			},
+		},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := models.ComparisonRequest{
+				TariffType: tc.tariffType,
+				Currency:   tc.currency,
+				Resolution: data.IntervalCalculationRequest.Resolution,
+				Readings:   data.IntervalCalculationRequest.Readings,
+			}
+			comparison, err := CompareTariffs([]models.Tariff{data.Tariff}, tariffs, request, enums.HalfUp)
+
+			// assert
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedComparison, comparison)
+		})
+	}
+}
+
+func Test_CompareTariffs_InvalidTimestamp(t *testing.T) {
+	// arrange
+	request := models.ComparisonRequest{Resolution: 15, Readings: []models.Reading{{Timestamp: "2021-01-01", Quantity: money.RequireFromString("1")}}}
+
+	// act
+	comparison, err := CompareTariffs(data.Tariffs, data.Tariffs, request, enums.HalfUp)
+
+	// assert
+	assert.NotNil(t, err)
+	assert.Nil(t, comparison)
+}
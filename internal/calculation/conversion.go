@@ -0,0 +1,166 @@
+package calculation
+
+import (
+	"time"
+
+	"tariff-calculation-service/internal/fxrate"
+	"tariff-calculation-service/internal/interfaces"
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/enums"
+	"tariff-calculation-service/pkg/money"
+)
+
+// Converter converts amounts to a target currency with the rates of a provider. Rates are looked up once
+// per currency and day. Every converted amount is rounded to the minor units of the target currency.
+type Converter struct {
+	provider    interfaces.FxRateProvider
+	partitionId string
+	currency    string
+	mode        enums.RoundingMode
+	rates       map[string]models.FxRate
+}
+
+func NewConverter(provider interfaces.FxRateProvider, partitionId, currency string, mode enums.RoundingMode) *Converter {
+	return &Converter{
+		provider:    provider,
+		partitionId: partitionId,
+		currency:    currency,
+		mode:        mode,
+		rates:       map[string]models.FxRate{},
+	}
+}
+
+// ConvertCalculation converts the cost and taxes of a calculation with the rate valid at its start.
+func ConvertCalculation(result *models.Calculation, converter *Converter) error {
+	from, err := time.Parse(time.RFC3339, result.From)
+	if err != nil {
+		return err
+	}
+	conversion := converter.newConversion()
+	if err := converter.add(conversion, result.Currency, result.Cost, result.Tax, from); err != nil {
+		return err
+	}
+	result.Conversion = conversion
+
+	return nil
+}
+
+// ConvertIntervals converts every interval and charge with the rate valid at its start. The taxes of a
+// currency are converted with the rate valid at the start of its first interval.
+func ConvertIntervals(result *models.IntervalCalculation, converter *Converter) error {
+	conversion := converter.newConversion()
+	for _, interval := range result.Intervals {
+		from, err := time.Parse(time.RFC3339, interval.From)
+		if err != nil {
+			return err
+		}
+		if err := converter.add(conversion, interval.Currency, interval.Cost, nil, from); err != nil {
+			return err
+		}
+	}
+	if err := converter.addCharges(conversion, result.Charges); err != nil {
+		return err
+	}
+	for _, total := range result.Totals {
+		from, _, err := intervalPeriod(result.Intervals, total.Currency)
+		if err != nil {
+			return err
+		}
+		if err := converter.add(conversion, total.Currency, money.Zero, total.Tax, from); err != nil {
+			return err
+		}
+	}
+	result.Conversion = conversion
+
+	return nil
+}
+
+// ConvertBill converts every line and charge with the rate valid at its start. The taxes are converted
+// with the rate valid at the start of the billing period.
+func ConvertBill(bill *models.Bill, converter *Converter) error {
+	from, err := time.Parse(time.RFC3339, bill.From)
+	if err != nil {
+		return err
+	}
+
+	conversion := converter.newConversion()
+	for _, line := range bill.Lines {
+		lineFrom, err := time.Parse(time.RFC3339, line.From)
+		if err != nil {
+			return err
+		}
+		if err := converter.add(conversion, line.Currency, line.Cost, nil, lineFrom); err != nil {
+			return err
+		}
+	}
+	if err := converter.addCharges(conversion, bill.Charges); err != nil {
+		return err
+	}
+	for _, total := range bill.Totals {
+		if err := converter.add(conversion, total.Currency, money.Zero, total.Tax, from); err != nil {
+			return err
+		}
+	}
+	bill.Conversion = conversion
+
+	return nil
+}
+
+func (converter *Converter) newConversion() *models.Conversion {
+	return &models.Conversion{Currency: converter.currency, Rates: []models.FxRate{}}
+}
+
+func (converter *Converter) addCharges(conversion *models.Conversion, charges []models.ChargeCost) error {
+	for _, charge := range charges {
+		from, err := time.Parse(time.RFC3339, charge.From)
+		if err != nil {
+			return err
+		}
+		if err := converter.add(conversion, charge.Currency, charge.Cost, nil, from); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// add converts the cost and the taxes of the taxation to the conversion.
+func (converter *Converter) add(conversion *models.Conversion, currency string, cost money.Decimal, tax *models.Taxation, date time.Time) error {
+	rate, err := converter.rate(conversion, currency, date)
+	if err != nil {
+		return err
+	}
+	conversion.Cost = conversion.Cost.Add(cost.Mul(rate).RoundToCurrency(converter.currency, converter.mode))
+	if tax != nil {
+		conversion.TaxCost = conversion.TaxCost.Add(tax.TaxCost.Mul(rate).RoundToCurrency(converter.currency, converter.mode))
+	}
+	conversion.GrossCost = conversion.Cost.Add(conversion.TaxCost)
+
+	return nil
+}
+
+// rate returns the rate of the currency to the target currency and lists it in the conversion.
+func (converter *Converter) rate(conversion *models.Conversion, currency string, date time.Time) (money.Decimal, error) {
+	if currency == converter.currency {
+		return money.NewFromInt(1), nil
+	}
+
+	key := currency + "#" + date.UTC().Format(fxrate.DateLayout)
+	rate, found := converter.rates[key]
+	if !found {
+		fxRate, err := converter.provider.GetFxRate(converter.partitionId, currency, converter.currency, date)
+		if err != nil {
+			return money.Zero, err
+		}
+		rate = *fxRate
+		converter.rates[key] = rate
+	}
+	for _, listed := range conversion.Rates {
+		if listed.BaseCurrency == rate.BaseCurrency && listed.QuoteCurrency == rate.QuoteCurrency && listed.Date == rate.Date {
+			return rate.Rate, nil
+		}
+	}
+	conversion.Rates = append(conversion.Rates, rate)
+
+	return rate.Rate, nil
+}
@@ -0,0 +1,131 @@
+package calculation
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"tariff-calculation-service/internal/fxrate"
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/enums"
+	"tariff-calculation-service/pkg/money"
+	"tariff-calculation-service/test/data"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testFxRates []models.FxRate
+
+func (rates testFxRates) GetFxRate(partitionId, baseCurrency, quoteCurrency string, date time.Time) (*models.FxRate, error) {
+	return fxrate.FindRate(rates, baseCurrency, quoteCurrency, date)
+}
+
+func Test_ConvertCalculation(t *testing.T) {
+	// arrange
+	testcases := []struct {
+		name               string
+		currency           string
+		rates              testFxRates
+		expectedConversion *models.Conversion
+		expectedError      error
+	}{
+		{
+			"Positive Test",
+			"EUR",
+			data.FxRates,
+			&models.Conversion{
+				Currency:  "EUR",
+				Cost:      money.RequireFromString("725.63"),
+				TaxCost:   money.RequireFromString("145.13"),
+				GrossCost: money.RequireFromString("870.76"),
+				Rates:     []models.FxRate{data.FxRate},
+			},
+			nil,
+		},
+		{
+			"Positive Test Same Currency",
+			data.TestCurrency,
+			nil,
+			&models.Conversion{
+				Currency:  data.TestCurrency,
+				Cost:      money.RequireFromString("645"),
+				TaxCost:   money.RequireFromString("129"),
+				GrossCost: money.RequireFromString("774"),
+				Rates:     []models.FxRate{},
+			},
+			nil,
+		},
+		{"Negative Test No Rate", "CHF", data.FxRates, nil, fxrate.ErrNoFxRate},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := data.Calculation
+			result.Tax = CalculateTaxes(&data.TaxRuleDefault, result.Cost, result.Quantity, time.Time{}, time.Time{}, result.Currency, enums.HalfUp)
+			err := ConvertCalculation(&result, NewConverter(tc.rates, data.TestPartitionId, tc.currency, enums.HalfUp))
+
+			// assert
+			assert.True(t, errors.Is(err, tc.expectedError))
+			assert.Equal(t, tc.expectedConversion, result.Conversion)
+			assert.Equal(t, data.Calculation.Cost, result.Cost)
+		})
+	}
+}
+
+func Test_ConvertIntervals(t *testing.T) {
+	// arrange
+	result, err := CalculateIntervals([]models.Tariff{data.Tariff}, data.IntervalCalculationRequest)
+	assert.Nil(t, err)
+	result.Intervals = append(result.Intervals, models.IntervalCost{
+		From:     "2021-01-02T00:00:00Z",
+		To:       "2021-01-02T00:15:00Z",
+		Currency: "EUR",
+		Quantity: money.RequireFromString("1"),
+		Cost:     money.RequireFromString("10"),
+	})
+	result.Totals = append(result.Totals, models.IntervalTotal{Currency: "EUR", Quantity: money.RequireFromString("1"), Cost: money.RequireFromString("10")})
+
+	// act
+	err = ConvertIntervals(result, NewConverter(testFxRates(data.FxRates), data.TestPartitionId, "EUR", enums.HalfUp))
+
+	// assert
+	assert.Nil(t, err)
+	assert.Equal(t, &models.Conversion{
+		Currency:  "EUR",
+		Cost:      money.RequireFromString("155.12"),
+		TaxCost:   money.Zero,
+		GrossCost: money.RequireFromString("155.12"),
+		Rates:     []models.FxRate{data.FxRate},
+	}, result.Conversion)
+	assert.Equal(t, money.RequireFromString("129"), result.Totals[0].Cost)
+}
+
+func Test_ConvertBill(t *testing.T) {
+	// arrange
+	bill, err := CalculateBill(data.Contract, billTariffs, data.BillRequest)
+	assert.Nil(t, err)
+	RoundBill(bill, enums.HalfUp)
+	err = ApplyBillTaxes(bill, &data.TaxRuleDefault, enums.HalfUp)
+	assert.Nil(t, err)
+	converted := func(amount money.Decimal) money.Decimal {
+		return amount.Mul(data.FxRate.Rate).RoundToCurrency("EUR", enums.HalfUp)
+	}
+	expectedCost := money.Zero
+	for _, line := range bill.Lines {
+		expectedCost = expectedCost.Add(converted(line.Cost))
+	}
+	for _, charge := range bill.Charges {
+		expectedCost = expectedCost.Add(converted(charge.Cost))
+	}
+
+	// act
+	err = ConvertBill(bill, NewConverter(testFxRates{data.FxRate}, data.TestPartitionId, "EUR", enums.HalfUp))
+
+	// assert
+	assert.Nil(t, err)
+	assert.Equal(t, "EUR", bill.Conversion.Currency)
+	assert.Equal(t, expectedCost, bill.Conversion.Cost)
+	assert.Equal(t, converted(bill.Totals[0].Tax.TaxCost), bill.Conversion.TaxCost)
+	assert.Equal(t, expectedCost.Add(converted(bill.Totals[0].Tax.TaxCost)), bill.Conversion.GrossCost)
+	assert.Equal(t, []models.FxRate{data.FxRate}, bill.Conversion.Rates)
+}
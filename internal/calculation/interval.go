@@ -0,0 +1,113 @@
+package calculation
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/enums"
+	"tariff-calculation-service/pkg/money"
+)
+
+var ErrNoValidTariff = errors.New("no tariff of the contract is valid for the interval")
+
+// CalculateIntervals costs every reading of a load profile against the first of the given tariffs
+// that is valid for the whole interval. Each reading covers the interval [Timestamp, Timestamp+Resolution).
+// The readings form one billing period, so tiered tariffs price every reading against the cumulative
+// consumption of the preceding readings of the same tariff. The fixed charges are summed up per tariff
+// over all intervals and included in the totals.
+func CalculateIntervals(tariffs []models.Tariff, request models.IntervalCalculationRequest) (*models.IntervalCalculation, error) {
+	resolution := time.Duration(request.Resolution) * time.Minute
+	result := &models.IntervalCalculation{
+		Resolution:   request.Resolution,
+		Intervals:    []models.IntervalCost{},
+		Charges:      []models.ChargeCost{},
+		TariffTotals: []models.IntervalTotal{},
+		Totals:       []models.IntervalTotal{},
+	}
+
+	consumedBefore := map[string]money.Decimal{}
+	charges := map[string]int{}
+	for _, reading := range request.Readings {
+		from, err := time.Parse(time.RFC3339, reading.Timestamp)
+		if err != nil {
+			return nil, err
+		}
+		consumption := Consumption{Quantity: reading.Quantity, From: from, To: from.Add(resolution)}
+
+		tariff := FindValidTariff(tariffs, request.TariffType, consumption.From, consumption.To)
+		if tariff == nil {
+			return nil, fmt.Errorf("%w: %s", ErrNoValidTariff, reading.Timestamp)
+		}
+		consumption.ConsumedBefore = consumedBefore[tariff.Id]
+		cost, err := CalculateCost(*tariff, consumption)
+		if err != nil {
+			return nil, err
+		}
+		consumedBefore[tariff.Id] = consumedBefore[tariff.Id].Add(consumption.Quantity)
+
+		result.Intervals = append(result.Intervals, models.IntervalCost{
+			From:         cost.From,
+			To:           cost.To,
+			TariffId:     cost.TariffId,
+			Currency:     cost.Currency,
+			Quantity:     cost.Quantity,
+			PricePerUnit: cost.PricePerUnit,
+			Cost:         cost.ConsumptionCost,
+		})
+		for _, charge := range cost.Charges {
+			result.Charges = addCharge(result.Charges, charges, charge)
+		}
+	}
+	sumIntervalTotals(result)
+
+	return result, nil
+}
+
+// FindValidTariff returns the first tariff of the given tariff type that is valid for the whole period [from, to).
+// All tariff types are considered if tariffType is nil. The versions of a tariff, as of TariffVersions, count as
+// valid for a period that starts in one version and ends in a later one.
+func FindValidTariff(tariffs []models.Tariff, tariffType *enums.TariffType, from, to time.Time) *models.Tariff {
+	for idx := range tariffs {
+		if tariffType != nil && tariffs[idx].TariffType != *tariffType {
+			continue
+		}
+		if CheckValidity(tariffs[idx], from, to) == nil {
+			return &tariffs[idx]
+		}
+		if tariff := continuedTariff(tariffs, idx, from, to); tariff != nil {
+			return tariff
+		}
+	}
+
+	return nil
+}
+
+// sumIntervalTotals sums the intervals and charges up per tariff and per currency.
+func sumIntervalTotals(result *models.IntervalCalculation) {
+	tariffTotals := map[string]int{}
+	totals := map[string]int{}
+	result.TariffTotals = []models.IntervalTotal{}
+	result.Totals = []models.IntervalTotal{}
+	for _, interval := range result.Intervals {
+		result.TariffTotals = addToTotal(result.TariffTotals, tariffTotals, interval.TariffId, models.IntervalTotal{TariffId: interval.TariffId, Currency: interval.Currency, Quantity: interval.Quantity, Cost: interval.Cost})
+		result.Totals = addToTotal(result.Totals, totals, interval.Currency, models.IntervalTotal{Currency: interval.Currency, Quantity: interval.Quantity, Cost: interval.Cost})
+	}
+	for _, charge := range result.Charges {
+		result.TariffTotals = addToTotal(result.TariffTotals, tariffTotals, charge.TariffId, models.IntervalTotal{TariffId: charge.TariffId, Currency: charge.Currency, Cost: charge.Cost})
+		result.Totals = addToTotal(result.Totals, totals, charge.Currency, models.IntervalTotal{Currency: charge.Currency, Cost: charge.Cost})
+	}
+}
+
+func addToTotal(totals []models.IntervalTotal, index map[string]int, key string, amount models.IntervalTotal) []models.IntervalTotal {
+	idx, found := index[key]
+	if !found {
+		index[key] = len(totals)
+		return append(totals, amount)
+	}
+	totals[idx].Quantity = totals[idx].Quantity.Add(amount.Quantity)
+	totals[idx].Cost = totals[idx].Cost.Add(amount.Cost)
+
+	return totals
+}
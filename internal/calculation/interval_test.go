@@ -0,0 +1,55 @@
+package calculation
+
+import (
+	"errors"
+	"testing"
+
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/enums"
+	"tariff-calculation-service/pkg/money"
+	"tariff-calculation-service/test/data"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CalculateIntervals(t *testing.T) {
+	// arrange
+	expected := data.IntervalCalculation
+	expected.ContractId = ""
+
+	// act
+	result, err := CalculateIntervals([]models.Tariff{data.Tariff}, data.IntervalCalculationRequest)
+
+	// assert
+	assert.Nil(t, err)
+	assert.Equal(t, &expected, result)
+}
+
+func Test_CalculateIntervals_Negative(t *testing.T) {
+	// arrange
+	gas := enums.Gas
+	outsideValidity := models.IntervalCalculationRequest{
+		Resolution: 60,
+		Readings:   []models.Reading{{Timestamp: data.TestValidTo, Quantity: money.RequireFromString("1")}},
+	}
+	otherTariffType := data.IntervalCalculationRequest
+	otherTariffType.TariffType = &gas
+
+	testcases := []struct {
+		name    string
+		request models.IntervalCalculationRequest
+	}{
+		{"Negative Test Outside Validity", outsideValidity},
+		{"Negative Test Other Tariff Type", otherTariffType},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := CalculateIntervals([]models.Tariff{data.Tariff}, tc.request)
+
+			// assert
+			assert.True(t, errors.Is(err, ErrNoValidTariff))
+			assert.Nil(t, result)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package calculation
+
+import (
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/enums"
+	"tariff-calculation-service/pkg/money"
+)
+
+// RoundCalculation rounds the slots, tiers and charges of a calculation to the minor units of its currency and
+// derives the consumption cost and the cost from the rounded amounts. Prices per unit keep their full precision.
+// Taxes and conversions are calculated from the rounded cost.
+func RoundCalculation(result *models.Calculation, mode enums.RoundingMode) {
+	switch {
+	case len(result.Slots) > 0:
+		result.ConsumptionCost = money.Zero
+		for idx := range result.Slots {
+			result.Slots[idx].Cost = result.Slots[idx].Cost.RoundToCurrency(result.Currency, mode)
+			result.ConsumptionCost = result.ConsumptionCost.Add(result.Slots[idx].Cost)
+		}
+	case len(result.Tiers) > 0:
+		result.ConsumptionCost = money.Zero
+		for idx := range result.Tiers {
+			result.Tiers[idx].Cost = result.Tiers[idx].Cost.RoundToCurrency(result.Currency, mode)
+			result.ConsumptionCost = result.ConsumptionCost.Add(result.Tiers[idx].Cost)
+		}
+	default:
+		result.ConsumptionCost = result.ConsumptionCost.RoundToCurrency(result.Currency, mode)
+	}
+	roundCharges(result.Charges, mode)
+	result.Cost = result.ConsumptionCost
+	for _, charge := range result.Charges {
+		result.Cost = result.Cost.Add(charge.Cost)
+	}
+}
+
+// RoundIntervalCalculation rounds the costs of the intervals and charges to the minor units of their currency
+// and sums the totals up again from the rounded costs.
+func RoundIntervalCalculation(result *models.IntervalCalculation, mode enums.RoundingMode) {
+	for idx := range result.Intervals {
+		interval := &result.Intervals[idx]
+		interval.Cost = interval.Cost.RoundToCurrency(interval.Currency, mode)
+	}
+	roundCharges(result.Charges, mode)
+	sumIntervalTotals(result)
+}
+
+// RoundBill rounds the costs of the lines and charges of a bill to the minor units of their currency and sums
+// the totals up again from the rounded costs.
+func RoundBill(bill *models.Bill, mode enums.RoundingMode) {
+	for idx := range bill.Lines {
+		line := &bill.Lines[idx]
+		line.Cost = line.Cost.RoundToCurrency(line.Currency, mode)
+	}
+	roundCharges(bill.Charges, mode)
+	sumBillTotals(bill)
+}
+
+func roundCharges(charges []models.ChargeCost, mode enums.RoundingMode) {
+	for idx := range charges {
+		charges[idx].Cost = charges[idx].Cost.RoundToCurrency(charges[idx].Currency, mode)
+	}
+}
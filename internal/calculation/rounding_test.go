@@ -0,0 +1,97 @@
+package calculation
+
+import (
+	"testing"
+
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/enums"
+	"tariff-calculation-service/pkg/money"
+	"tariff-calculation-service/test/data"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RoundCalculation(t *testing.T) {
+	// arrange
+	testcases := []struct {
+		name         string
+		currency     string
+		mode         enums.RoundingMode
+		cost         string
+		expectedCost string
+	}{
+		{"Positive Test Half Up", "EUR", enums.HalfUp, "0.125", "0.13"},
+		{"Positive Test Half Even", "EUR", enums.HalfEven, "0.125", "0.12"},
+		{"Positive Test Half Even Odd", "EUR", enums.HalfEven, "0.135", "0.14"},
+		{"Positive Test No Minor Units", "JPY", enums.HalfUp, "100.5", "101"},
+		{"Positive Test No Minor Units Half Even", "JPY", enums.HalfEven, "100.5", "100"},
+		{"Positive Test Three Minor Units", "KWD", enums.HalfUp, "1.2345", "1.235"},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cost := money.RequireFromString(tc.cost)
+			result := models.Calculation{
+				Currency:        tc.currency,
+				PricePerUnit:    money.RequireFromString("0.0125"),
+				ConsumptionCost: cost,
+				Cost:            cost,
+			}
+			RoundCalculation(&result, tc.mode)
+
+			// assert
+			expectedCost := money.RequireFromString(tc.expectedCost)
+			assert.Equal(t, money.RequireFromString("0.0125"), result.PricePerUnit)
+			assert.Equal(t, expectedCost, result.ConsumptionCost)
+			assert.Equal(t, expectedCost, result.Cost)
+		})
+	}
+}
+
+func Test_RoundCalculation_LineItems(t *testing.T) {
+	// arrange
+	result := models.Calculation{
+		Currency: "EUR",
+		Slots: []models.SlotCost{
+			{Cost: money.RequireFromString("0.125")},
+			{Cost: money.RequireFromString("0.125")},
+		},
+		ConsumptionCost: money.RequireFromString("0.25"),
+		Charges:         []models.ChargeCost{{Currency: "EUR", Cost: money.RequireFromString("0.125")}},
+		Cost:            money.RequireFromString("0.375"),
+	}
+
+	// act
+	RoundCalculation(&result, enums.HalfUp)
+
+	// assert
+	assert.Equal(t, money.RequireFromString("0.13"), result.Slots[0].Cost)
+	assert.Equal(t, money.RequireFromString("0.13"), result.Slots[1].Cost)
+	assert.Equal(t, money.RequireFromString("0.26"), result.ConsumptionCost)
+	assert.Equal(t, money.RequireFromString("0.13"), result.Charges[0].Cost)
+	assert.Equal(t, money.RequireFromString("0.39"), result.Cost)
+}
+
+func Test_RoundBill(t *testing.T) {
+	// arrange
+	request := data.BillRequest
+	request.To = "2021-01-20T07:00:00Z"
+	bill, err := CalculateBill(data.Contract, billTariffs, request)
+	assert.Nil(t, err)
+
+	// act
+	RoundBill(bill, enums.HalfUp)
+
+	// assert
+	expectedCost := money.Zero
+	for _, line := range bill.Lines {
+		assert.Equal(t, line.Cost, line.Cost.Round(2, enums.HalfUp))
+		expectedCost = expectedCost.Add(line.Cost)
+	}
+	for _, charge := range bill.Charges {
+		assert.Equal(t, charge.Cost, charge.Cost.Round(2, enums.HalfUp))
+		expectedCost = expectedCost.Add(charge.Cost)
+	}
+	assert.Len(t, bill.Totals, 1)
+	assert.Equal(t, expectedCost, bill.Totals[0].Cost)
+}
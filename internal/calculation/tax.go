@@ -0,0 +1,136 @@
+package calculation
+
+import (
+	"time"
+
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/enums"
+	"tariff-calculation-service/pkg/money"
+)
+
+// FindTaxRule returns the tax rule of the given country, or the partition default if there is none.
+func FindTaxRule(rules []models.TaxRule, countryCode string) *models.TaxRule {
+	var defaultRule *models.TaxRule
+	for idx := range rules {
+		switch rules[idx].CountryCode {
+		case "":
+			if defaultRule == nil {
+				defaultRule = &rules[idx]
+			}
+		case countryCode:
+			if countryCode != "" {
+				return &rules[idx]
+			}
+		}
+	}
+
+	return defaultRule
+}
+
+// CalculateTaxes returns the taxes on a net cost for the given quantity and period [from, to). The energy tax is
+// charged per unit, the levies are prorated over the period and the VAT is charged on the net cost including
+// the energy tax and levies. Every tax is rounded to the minor units of the currency and the tax cost is their
+// sum. Without a tax rule the gross cost equals the net cost.
+func CalculateTaxes(rule *models.TaxRule, netCost money.Decimal, quantity money.Decimal, from, to time.Time, currency string, mode enums.RoundingMode) *models.Taxation {
+	taxation := &models.Taxation{NetCost: netCost, Taxes: []models.TaxCost{}, GrossCost: netCost}
+	if rule == nil {
+		return taxation
+	}
+	taxation.TaxRuleId = rule.Id
+	taxation.CountryCode = rule.CountryCode
+
+	if rule.EnergyTaxPerUnit.Cmp(money.Zero) > 0 {
+		taxation.Taxes = append(taxation.Taxes, models.TaxCost{
+			Name:    enums.EnergyTax.String(),
+			TaxType: enums.EnergyTax,
+			Rate:    rule.EnergyTaxPerUnit,
+			Base:    quantity,
+			Cost:    rule.EnergyTaxPerUnit.Mul(quantity).RoundToCurrency(currency, mode),
+		})
+	}
+	for _, levy := range rule.Levies {
+		taxation.Taxes = append(taxation.Taxes, models.TaxCost{
+			Name:    levy.Name,
+			TaxType: enums.Levy,
+			Cost:    ProrateCharge(levy, from, to).RoundToCurrency(currency, mode),
+		})
+	}
+	for _, tax := range taxation.Taxes {
+		taxation.TaxCost = taxation.TaxCost.Add(tax.Cost)
+	}
+
+	if rule.VatRate.Cmp(money.Zero) > 0 {
+		base := netCost.Add(taxation.TaxCost)
+		vat := base.Mul(rule.VatRate).Div(money.NewFromInt(100)).RoundToCurrency(currency, mode)
+		taxation.Taxes = append(taxation.Taxes, models.TaxCost{
+			Name:    enums.Vat.String(),
+			TaxType: enums.Vat,
+			Rate:    rule.VatRate,
+			Base:    base,
+			Cost:    vat,
+		})
+		taxation.TaxCost = taxation.TaxCost.Add(vat)
+	}
+	taxation.GrossCost = netCost.Add(taxation.TaxCost)
+
+	return taxation
+}
+
+// ApplyIntervalTaxes adds the taxes to the totals per currency. Levies are prorated from the start
+// of the first to the end of the last interval of the currency.
+func ApplyIntervalTaxes(result *models.IntervalCalculation, rule *models.TaxRule, mode enums.RoundingMode) error {
+	for idx := range result.Totals {
+		total := &result.Totals[idx]
+		from, to, err := intervalPeriod(result.Intervals, total.Currency)
+		if err != nil {
+			return err
+		}
+		total.Tax = CalculateTaxes(rule, total.Cost, total.Quantity, from, to, total.Currency, mode)
+	}
+
+	return nil
+}
+
+// intervalPeriod returns the start of the first and the end of the last interval of the currency.
+func intervalPeriod(intervals []models.IntervalCost, currency string) (time.Time, time.Time, error) {
+	var from, to time.Time
+	for _, interval := range intervals {
+		if interval.Currency != currency {
+			continue
+		}
+		intervalFrom, err := time.Parse(time.RFC3339, interval.From)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		intervalTo, err := time.Parse(time.RFC3339, interval.To)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		if from.IsZero() || intervalFrom.Before(from) {
+			from = intervalFrom
+		}
+		if intervalTo.After(to) {
+			to = intervalTo
+		}
+	}
+
+	return from, to, nil
+}
+
+// ApplyBillTaxes adds the taxes to the totals per currency of the bill.
+func ApplyBillTaxes(bill *models.Bill, rule *models.TaxRule, mode enums.RoundingMode) error {
+	from, err := time.Parse(time.RFC3339, bill.From)
+	if err != nil {
+		return err
+	}
+	to, err := time.Parse(time.RFC3339, bill.To)
+	if err != nil {
+		return err
+	}
+	for idx := range bill.Totals {
+		total := &bill.Totals[idx]
+		total.Tax = CalculateTaxes(rule, total.Cost, total.Quantity, from, to, total.Currency, mode)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package calculation
+
+import (
+	"testing"
+	"time"
+
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/enums"
+	"tariff-calculation-service/pkg/money"
+	"tariff-calculation-service/test/data"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FindTaxRule(t *testing.T) {
+	// arrange
+	testcases := []struct {
+		name         string
+		rules        []models.TaxRule
+		countryCode  string
+		expectedRule *models.TaxRule
+	}{
+		{"Positive Test Country", data.TaxRules, "JPN", &data.TaxRule},
+		{"Positive Test Partition Default", data.TaxRules, "DEU", &data.TaxRuleDefault},
+		{"Positive Test No Country", data.TaxRules, "", &data.TaxRuleDefault},
+		{"Positive Test No Default", []models.TaxRule{data.TaxRule}, "DEU", nil},
+		{"Positive Test No Tax Rules", nil, "JPN", nil},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule := FindTaxRule(tc.rules, tc.countryCode)
+
+			// assert
+			assert.Equal(t, tc.expectedRule, rule)
+		})
+	}
+}
+
+func Test_CalculateTaxes(t *testing.T) {
+	// arrange
+	from, _ := time.Parse(time.RFC3339, "2021-01-01T00:00:00Z")
+	to, _ := time.Parse(time.RFC3339, "2021-02-01T00:00:00Z")
+
+	// act
+	taxation := CalculateTaxes(&data.TaxRule, money.NewFromInt(100), money.NewFromInt(10), from, to, data.TestCurrency, enums.HalfUp)
+
+	// assert
+	assert.Equal(t, data.TestTaxRuleId, taxation.TaxRuleId)
+	assert.Equal(t, "JPN", taxation.CountryCode)
+	assert.Equal(t, money.NewFromInt(100), taxation.NetCost)
+	assert.Len(t, taxation.Taxes, 3)
+	assert.Equal(t, enums.EnergyTax, taxation.Taxes[0].TaxType)
+	assert.Equal(t, money.RequireFromString("5"), taxation.Taxes[0].Cost)
+	assert.Equal(t, "Renewable Energy Levy", taxation.Taxes[1].Name)
+	assert.Equal(t, money.RequireFromString("31"), taxation.Taxes[1].Cost)
+	assert.Equal(t, enums.Vat, taxation.Taxes[2].TaxType)
+	assert.Equal(t, money.RequireFromString("136"), taxation.Taxes[2].Base)
+	assert.Equal(t, money.RequireFromString("13.6"), taxation.Taxes[2].Cost)
+	assert.Equal(t, money.RequireFromString("49.6"), taxation.TaxCost)
+	assert.Equal(t, money.RequireFromString("149.6"), taxation.GrossCost)
+}
+
+func Test_CalculateTaxes_NoTaxRule(t *testing.T) {
+	// act
+	taxation := CalculateTaxes(nil, money.NewFromInt(100), money.NewFromInt(10), time.Time{}, time.Time{}, data.TestCurrency, enums.HalfUp)
+
+	// assert
+	assert.Equal(t, &models.Taxation{NetCost: money.RequireFromString("100"), Taxes: []models.TaxCost{}, GrossCost: money.RequireFromString("100")}, taxation)
+}
+
+func Test_ApplyBillTaxes(t *testing.T) {
+	// arrange
+	bill, err := CalculateBill(data.Contract, billTariffs, data.BillRequest)
+	assert.Nil(t, err)
+
+	// act
+	err = ApplyBillTaxes(bill, &data.TaxRuleDefault, enums.HalfUp)
+
+	// assert
+	assert.Nil(t, err)
+	assert.Nil(t, bill.TariffTypeTotals[0].Tax)
+	assert.Equal(t, money.RequireFromString("119"), bill.Totals[0].Tax.NetCost)
+	assert.Equal(t, money.RequireFromString("23.8"), bill.Totals[0].Tax.TaxCost)
+	assert.Equal(t, money.RequireFromString("142.8"), bill.Totals[0].Tax.GrossCost)
+}
+
+func Test_ApplyIntervalTaxes(t *testing.T) {
+	// arrange
+	result, err := CalculateIntervals(data.Tariffs, data.IntervalCalculationRequest)
+	assert.Nil(t, err)
+
+	// act
+	err = ApplyIntervalTaxes(result, &data.TaxRule, enums.HalfUp)
+
+	// assert
+	assert.Nil(t, err)
+	taxation := result.Totals[0].Tax
+	assert.Equal(t, money.RequireFromString("1"), taxation.Taxes[0].Cost)
+	// the levy of half an hour, 31 / 744 * 0.5, and the VAT of 130.02 are rounded before they are summed up
+	assert.Equal(t, money.RequireFromString("0.02"), taxation.Taxes[1].Cost)
+	assert.Equal(t, money.RequireFromString("13"), taxation.Taxes[2].Cost)
+	assert.Equal(t, money.RequireFromString("14.02"), taxation.TaxCost)
+	assert.Equal(t, money.RequireFromString("143.02"), taxation.GrossCost)
+}
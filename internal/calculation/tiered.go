@@ -0,0 +1,68 @@
+package calculation
+
+import (
+	"errors"
+
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/money"
+)
+
+var ErrInvalidTiers = errors.New("tiered tariff needs at least one tier and tiers ordered by ascending upper bound")
+
+// SplitIntoTiers splits the consumption into the consumption bands of the tariff. The bands apply to the
+// cumulative consumption of the billing period, so consumption.ConsumedBefore is skipped first.
+func SplitIntoTiers(tariff models.TieredTariff, consumption Consumption) ([]models.TierCost, error) {
+	if err := checkTiers(tariff); err != nil {
+		return nil, err
+	}
+
+	tierCosts := []models.TierCost{}
+	start := consumption.ConsumedBefore
+	end := consumption.ConsumedBefore.Add(consumption.Quantity)
+	lower := money.Zero
+	for idx, tier := range tariff.Tiers {
+		last := idx == len(tariff.Tiers)-1
+		from, to := lower, end
+		if from.Cmp(start) < 0 {
+			from = start
+		}
+		if !last && tier.UpTo.Cmp(to) < 0 {
+			to = tier.UpTo
+		}
+		if to.Cmp(from) > 0 {
+			quantity := to.Sub(from)
+			tierCost := models.TierCost{
+				From:         lower,
+				Quantity:     quantity,
+				PricePerUnit: tier.PricePerUnit,
+				Cost:         tier.PricePerUnit.Mul(quantity),
+			}
+			if !last {
+				upTo := tier.UpTo
+				tierCost.UpTo = &upTo
+			}
+			tierCosts = append(tierCosts, tierCost)
+		}
+		lower = tier.UpTo
+	}
+
+	return tierCosts, nil
+}
+
+// checkTiers reports tiers whose upper bounds do not ascend from zero. The last tier may leave its upper bound
+// zero, because it has none.
+func checkTiers(tariff models.TieredTariff) error {
+	if len(tariff.Tiers) == 0 {
+		return ErrInvalidTiers
+	}
+	lower := money.Zero
+	for idx, tier := range tariff.Tiers {
+		unbounded := idx == len(tariff.Tiers)-1 && tier.UpTo.IsZero()
+		if !unbounded && tier.UpTo.Cmp(lower) <= 0 {
+			return ErrInvalidTiers
+		}
+		lower = tier.UpTo
+	}
+
+	return nil
+}
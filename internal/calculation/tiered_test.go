@@ -0,0 +1,105 @@
+package calculation
+
+import (
+	"testing"
+
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/money"
+	"tariff-calculation-service/test/data"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SplitIntoTiers(t *testing.T) {
+	// arrange
+	testcases := []struct {
+		name          string
+		consumption   Consumption
+		expectedTiers []models.TierCost
+	}{
+		{
+			"Positive Test First Tier",
+			Consumption{Quantity: money.RequireFromString("40")},
+			[]models.TierCost{{From: money.RequireFromString("0"), UpTo: decimalPtr("100"), Quantity: money.RequireFromString("40"), PricePerUnit: money.RequireFromString("2"), Cost: money.RequireFromString("80")}},
+		},
+		{
+			"Positive Test Across Tiers",
+			Consumption{Quantity: money.RequireFromString("150")},
+			[]models.TierCost{
+				{From: money.RequireFromString("0"), UpTo: decimalPtr("100"), Quantity: money.RequireFromString("100"), PricePerUnit: money.RequireFromString("2"), Cost: money.RequireFromString("200")},
+				{From: money.RequireFromString("100"), Quantity: money.RequireFromString("50"), PricePerUnit: money.RequireFromString("3"), Cost: money.RequireFromString("150")},
+			},
+		},
+		{
+			"Positive Test Cumulative Consumption",
+			Consumption{Quantity: money.RequireFromString("50"), ConsumedBefore: money.RequireFromString("80")},
+			[]models.TierCost{
+				{From: money.RequireFromString("0"), UpTo: decimalPtr("100"), Quantity: money.RequireFromString("20"), PricePerUnit: money.RequireFromString("2"), Cost: money.RequireFromString("40")},
+				{From: money.RequireFromString("100"), Quantity: money.RequireFromString("30"), PricePerUnit: money.RequireFromString("3"), Cost: money.RequireFromString("90")},
+			},
+		},
+		{
+			"Positive Test Last Tier Only",
+			Consumption{Quantity: money.RequireFromString("10"), ConsumedBefore: money.RequireFromString("120")},
+			[]models.TierCost{{From: money.RequireFromString("100"), Quantity: money.RequireFromString("10"), PricePerUnit: money.RequireFromString("3"), Cost: money.RequireFromString("30")}},
+		},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tiers, err := SplitIntoTiers(data.TariffTiered.TieredTariff, tc.consumption)
+
+			// assert
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedTiers, tiers)
+		})
+	}
+}
+
+func Test_SplitIntoTiers_Negative(t *testing.T) {
+	// arrange
+	testcases := []struct {
+		name   string
+		tariff models.TieredTariff
+	}{
+		{"Negative Test No Tiers", models.TieredTariff{}},
+		{"Negative Test Unbounded First Tier", models.TieredTariff{Tiers: []models.Tier{{PricePerUnit: money.RequireFromString("1")}, {PricePerUnit: money.RequireFromString("2")}}}},
+		{"Negative Test Descending Tiers", models.TieredTariff{Tiers: []models.Tier{{UpTo: money.RequireFromString("100")}, {UpTo: money.RequireFromString("50")}, {}}}},
+		{"Negative Test Descending Last Tier", models.TieredTariff{Tiers: []models.Tier{{UpTo: money.RequireFromString("100")}, {UpTo: money.RequireFromString("50")}}}},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tiers, err := SplitIntoTiers(tc.tariff, Consumption{Quantity: money.RequireFromString("1")})
+
+			// assert
+			assert.Equal(t, ErrInvalidTiers, err)
+			assert.Nil(t, tiers)
+		})
+	}
+}
+
+func Test_CalculateIntervals_Tiered(t *testing.T) {
+	// arrange
+	request := models.IntervalCalculationRequest{
+		Resolution: 60,
+		Readings: []models.Reading{
+			{Timestamp: "2021-01-01T00:00:00Z", Quantity: money.RequireFromString("80")},
+			{Timestamp: "2021-01-01T01:00:00Z", Quantity: money.RequireFromString("50")},
+		},
+	}
+
+	// act
+	result, err := CalculateIntervals([]models.Tariff{data.TariffTiered}, request)
+
+	// assert
+	assert.Nil(t, err)
+	assert.Equal(t, money.RequireFromString("160"), result.Intervals[0].Cost)
+	assert.Equal(t, money.RequireFromString("130"), result.Intervals[1].Cost)
+	assert.Equal(t, money.RequireFromString("290"), result.Totals[0].Cost)
+}
+
+func decimalPtr(value string) *money.Decimal {
+	decimal := money.RequireFromString(value)
+	return &decimal
+}
@@ -0,0 +1,148 @@
+package calculation
+
+import (
+	"errors"
+	"time"
+
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/enums"
+)
+
+var ErrNoHourlyTariff = errors.New("dynamic tariff has no hourly tariff for the given time")
+
+const daysPerWeek = 7
+
+// FindHourlyTariff returns the hourly tariff slot that is active at the given instant.
+// A slot stays active from its start time until the next slot of the week starts.
+func FindHourlyTariff(tariff models.DynamicTariff, at time.Time) (*models.HourlyTariff, error) {
+	var active *models.HourlyTariff
+	var activeSince time.Time
+	for idx := range tariff.HourlyTariffs {
+		slot := &tariff.HourlyTariffs[idx]
+		start, found, err := lastSlotStart(*slot, at)
+		if err != nil {
+			return nil, err
+		}
+		if found && (active == nil || start.After(activeSince)) {
+			active, activeSince = slot, start
+		}
+	}
+	if active == nil {
+		return nil, ErrNoHourlyTariff
+	}
+
+	return active, nil
+}
+
+// SplitConsumption distributes the consumption evenly over time and splits it at every slot boundary. The last
+// slot gets the remainder, so that the quantities of the slots add up to the consumption.
+func SplitConsumption(tariff models.DynamicTariff, consumption Consumption) ([]models.SlotCost, error) {
+	slotCosts := []models.SlotCost{}
+	duration := consumption.To.Sub(consumption.From)
+	remainder := consumption.Quantity
+	for from := consumption.From; from.Before(consumption.To); {
+		slot, err := FindHourlyTariff(tariff, from)
+		if err != nil {
+			return nil, err
+		}
+		to, err := nextSlotBoundary(tariff, from)
+		if err != nil {
+			return nil, err
+		}
+		if to.IsZero() || to.After(consumption.To) {
+			to = consumption.To
+		}
+
+		share := remainder
+		if to.Before(consumption.To) {
+			share = prorate(consumption.Quantity, to.Sub(from), duration)
+		}
+		remainder = remainder.Sub(share)
+		slotCosts = append(slotCosts, models.SlotCost{
+			From:         from.Format(time.RFC3339),
+			To:           to.Format(time.RFC3339),
+			StartTime:    slot.StartTime,
+			Quantity:     share,
+			PricePerUnit: slot.PricePerUnit,
+			Cost:         share.Mul(slot.PricePerUnit),
+		})
+		from = to
+	}
+
+	return slotCosts, nil
+}
+
+func nextSlotBoundary(tariff models.DynamicTariff, after time.Time) (time.Time, error) {
+	var next time.Time
+	for _, slot := range tariff.HourlyTariffs {
+		start, found, err := nextSlotStart(slot, after)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if found && (next.IsZero() || start.Before(next)) {
+			next = start
+		}
+	}
+
+	return next, nil
+}
+
+// lastSlotStart returns the latest start of the slot at or before the given instant.
+func lastSlotStart(slot models.HourlyTariff, at time.Time) (time.Time, bool, error) {
+	for day := 0; day <= daysPerWeek; day++ {
+		start, valid, err := slotStartOnDay(slot, at, -day)
+		if err != nil {
+			return time.Time{}, false, err
+		}
+		if valid && !start.After(at) {
+			return start, true, nil
+		}
+	}
+
+	return time.Time{}, false, nil
+}
+
+// nextSlotStart returns the earliest start of the slot strictly after the given instant.
+func nextSlotStart(slot models.HourlyTariff, after time.Time) (time.Time, bool, error) {
+	for day := 0; day <= daysPerWeek; day++ {
+		start, valid, err := slotStartOnDay(slot, after, day)
+		if err != nil {
+			return time.Time{}, false, err
+		}
+		if valid && start.After(after) {
+			return start, true, nil
+		}
+	}
+
+	return time.Time{}, false, nil
+}
+
+// slotStartOnDay returns the start of the slot on the day that is dayOffset days away from the given
+// instant, evaluated in the time zone of the slot, and whether the slot is valid on that weekday.
+func slotStartOnDay(slot models.HourlyTariff, at time.Time, dayOffset int) (time.Time, bool, error) {
+	startTime, err := time.Parse(time.RFC3339, slot.StartTime)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	local := at.In(startTime.Location())
+	start := time.Date(local.Year(), local.Month(), local.Day()+dayOffset,
+		startTime.Hour(), startTime.Minute(), startTime.Second(), 0, startTime.Location())
+
+	return start, isValidOn(slot, start.Weekday()), nil
+}
+
+func isValidOn(slot models.HourlyTariff, weekday time.Weekday) bool {
+	day := uint8(ToWeekDay(weekday))
+	for _, validDay := range slot.ValidDays {
+		if validDay == day {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ToWeekDay converts a time.Weekday, which starts on Sunday, to enums.WeekDays, which starts on Monday.
+func ToWeekDay(weekday time.Weekday) enums.WeekDays {
+	return enums.WeekDays((int(weekday) + 6) % daysPerWeek)
+}
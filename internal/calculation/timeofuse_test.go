@@ -0,0 +1,124 @@
+package calculation
+
+import (
+	"testing"
+	"time"
+
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/money"
+	"tariff-calculation-service/test/data"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FindHourlyTariff(t *testing.T) {
+	// arrange
+	testcases := []struct {
+		name              string
+		at                string
+		expectedStartTime string
+	}{
+		{"Positive Test Weekday Peak", "2021-01-11T08:30:00Z", "2021-01-04T08:00:00+01:00"},
+		{"Positive Test Weekday Before Peak", "2021-01-11T06:59:59Z", "2021-01-04T00:00:00+01:00"},
+		{"Positive Test Weekday Evening", "2021-01-11T21:00:00+01:00", "2021-01-04T20:00:00+01:00"},
+		{"Positive Test Weekend", "2021-01-16T10:00:00+01:00", "2021-01-04T00:00:00+01:00"},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			at, _ := time.Parse(time.RFC3339, tc.at)
+			slot, err := FindHourlyTariff(data.TariffDynamic.DynamicTariff, at)
+
+			// assert
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedStartTime, slot.StartTime)
+		})
+	}
+}
+
+func Test_FindHourlyTariff_Weekdays(t *testing.T) {
+	// arrange
+	testcases := []struct {
+		name              string
+		at                string
+		expectedStartTime string
+	}{
+		{"Positive Test Friday Evening", "2021-01-15T20:00:00+01:00", "2021-01-04T08:00:00+01:00"},
+		{"Positive Test Weekend", "2021-01-17T10:00:00+01:00", "2021-01-04T08:00:00+01:00"},
+		{"Positive Test Monday", "2021-01-18T00:00:00+01:00", "2021-01-04T00:00:00+01:00"},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			at, _ := time.Parse(time.RFC3339, tc.at)
+			slot, err := FindHourlyTariff(data.TariffDynamicWeekdays.DynamicTariff, at)
+
+			// assert
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedStartTime, slot.StartTime)
+		})
+	}
+}
+
+func Test_FindHourlyTariff_Negative(t *testing.T) {
+	// act
+	slot, err := FindHourlyTariff(models.DynamicTariff{}, time.Now())
+
+	// assert
+	assert.Equal(t, ErrNoHourlyTariff, err)
+	assert.Nil(t, slot)
+}
+
+func Test_SplitConsumption(t *testing.T) {
+	// arrange
+	consumption, _ := ParseConsumption(models.CalculationRequest{
+		Quantity: money.RequireFromString("3"),
+		From:     "2021-01-11T07:00:00+01:00",
+		To:       "2021-01-11T10:00:00+01:00",
+	})
+
+	// act
+	slots, err := SplitConsumption(data.TariffDynamic.DynamicTariff, consumption)
+
+	// assert
+	assert.Nil(t, err)
+	assert.Equal(t, []models.SlotCost{
+		{From: "2021-01-11T07:00:00+01:00", To: "2021-01-11T08:00:00+01:00", StartTime: "2021-01-04T00:00:00+01:00", Quantity: money.RequireFromString("1"), PricePerUnit: money.RequireFromString("0.2"), Cost: money.RequireFromString("0.2")},
+		{From: "2021-01-11T08:00:00+01:00", To: "2021-01-11T10:00:00+01:00", StartTime: "2021-01-04T08:00:00+01:00", Quantity: money.RequireFromString("2"), PricePerUnit: money.RequireFromString("0.4"), Cost: money.RequireFromString("0.8")},
+	}, slots)
+}
+
+func Test_SplitConsumption_Remainder(t *testing.T) {
+	// arrange
+	consumption, _ := ParseConsumption(models.CalculationRequest{
+		Quantity: money.RequireFromString("1"),
+		From:     "2021-01-11T07:00:00+01:00",
+		To:       "2021-01-11T10:00:00+01:00",
+	})
+
+	// act
+	slots, err := SplitConsumption(data.TariffDynamic.DynamicTariff, consumption)
+
+	// assert
+	assert.Nil(t, err)
+	assert.Equal(t, money.RequireFromString("0.3333333333333333"), slots[0].Quantity)
+	assert.Equal(t, money.RequireFromString("0.6666666666666667"), slots[1].Quantity)
+	assert.Equal(t, consumption.Quantity, slots[0].Quantity.Add(slots[1].Quantity))
+}
+
+func Test_CalculateCost_Dynamic(t *testing.T) {
+	// arrange
+	consumption, _ := ParseConsumption(models.CalculationRequest{
+		Quantity: money.RequireFromString("24"),
+		From:     "2021-01-11T00:00:00+01:00",
+		To:       "2021-01-12T00:00:00+01:00",
+	})
+
+	// act
+	result, err := CalculateCost(data.TariffDynamic, consumption)
+
+	// assert
+	assert.Nil(t, err)
+	assert.Len(t, result.Slots, 3)
+	assert.InDelta(t, 8*0.2+12*0.4+4*0.2, result.Cost.Float64(), 1e-9)
+}
@@ -0,0 +1,184 @@
+package calculation
+
+import (
+	"errors"
+	"time"
+
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/tariffquery"
+)
+
+var ErrMixedCurrencies = errors.New("tariff versions of the consumption period have different currencies")
+
+// TariffVersions returns a copy of every version of a tariff with the validity cut to the time the version was
+// effective, so that consumption is priced against the version that was effective when it was consumed. The first
+// version also prices consumption before it was written, and the last one consumption after. The copies follow
+// each other in the order of the versions.
+func TariffVersions(versions []models.TariffVersion) ([]models.Tariff, error) {
+	tariffs := []models.Tariff{}
+	for idx, version := range versions {
+		tariff := version.Tariff
+		validFrom, err := time.Parse(time.RFC3339, tariff.ValidFrom)
+		if err != nil {
+			return nil, err
+		}
+		validTo, err := time.Parse(time.RFC3339, tariff.ValidTo)
+		if err != nil {
+			return nil, err
+		}
+		if idx > 0 && version.EffectiveFrom != "" {
+			effectiveFrom, err := time.Parse(time.RFC3339, version.EffectiveFrom)
+			if err != nil {
+				return nil, err
+			}
+			if effectiveFrom.After(validFrom) {
+				validFrom = effectiveFrom
+				tariff.ValidFrom = version.EffectiveFrom
+			}
+		}
+		if idx < len(versions)-1 && versions[idx+1].EffectiveFrom != "" {
+			effectiveTo, err := time.Parse(time.RFC3339, versions[idx+1].EffectiveFrom)
+			if err != nil {
+				return nil, err
+			}
+			if effectiveTo.Before(validTo) {
+				validTo = effectiveTo
+				tariff.ValidTo = versions[idx+1].EffectiveFrom
+			}
+		}
+		if validFrom.Before(validTo) {
+			tariffs = append(tariffs, tariff)
+		}
+	}
+
+	return tariffs, nil
+}
+
+// CalculateVersionedCost returns the cost of the consumption like CalculateCost does, with the period split wherever
+// a version of the tariff became effective. Each part is priced with its version, and the consumption is prorated
+// over the parts by their duration, the last part getting the remainder. The tiers of a tiered tariff apply to the
+// consumption of the whole period, across its versions, and the price per unit is the average of the period.
+func CalculateVersionedCost(versions []models.TariffVersion, consumption Consumption) (*models.Calculation, error) {
+	tariffs, err := TariffVersions(versions)
+	if err != nil {
+		return nil, err
+	}
+	segments := []billSegment{}
+	for idx := range tariffs {
+		validFrom, validTo, err := validity(tariffs[idx])
+		if err != nil {
+			return nil, err
+		}
+		from, to := laterOf(validFrom, consumption.From), earlierOf(validTo, consumption.To)
+		if from.Before(to) {
+			segments = append(segments, billSegment{tariff: &tariffs[idx], from: from, to: to})
+		}
+	}
+	switch len(segments) {
+	case 0:
+		return CalculateCost(TariffAt(versions, consumption.From), consumption)
+	case 1:
+		return CalculateCost(*segments[0].tariff, consumption)
+	}
+	if !segments[0].from.Equal(consumption.From) || !segments[len(segments)-1].to.Equal(consumption.To) {
+		return nil, ErrOutsideValidity
+	}
+
+	result := &models.Calculation{
+		TariffId: segments[0].tariff.Id,
+		Currency: segments[0].tariff.Currency,
+		From:     consumption.From.Format(time.RFC3339),
+		To:       consumption.To.Format(time.RFC3339),
+		Quantity: consumption.Quantity,
+	}
+	duration := consumption.To.Sub(consumption.From)
+	remainder := consumption.Quantity
+	for idx, segment := range segments {
+		if segment.tariff.Currency != result.Currency {
+			return nil, ErrMixedCurrencies
+		}
+		if idx > 0 && !segment.from.Equal(segments[idx-1].to) {
+			return nil, ErrOutsideValidity
+		}
+		quantity := remainder
+		if idx < len(segments)-1 {
+			quantity = prorate(consumption.Quantity, segment.to.Sub(segment.from), duration)
+		}
+		cost, err := CalculateCost(*segment.tariff, Consumption{
+			Quantity:       quantity,
+			ConsumedBefore: consumption.ConsumedBefore.Add(consumption.Quantity.Sub(remainder)),
+			From:           segment.from,
+			To:             segment.to,
+		})
+		if err != nil {
+			return nil, err
+		}
+		remainder = remainder.Sub(quantity)
+
+		result.ConsumptionCost = result.ConsumptionCost.Add(cost.ConsumptionCost)
+		result.Cost = result.Cost.Add(cost.Cost)
+		result.Slots = append(result.Slots, cost.Slots...)
+		result.Tiers = append(result.Tiers, cost.Tiers...)
+		result.Charges = append(result.Charges, cost.Charges...)
+	}
+	result.PricePerUnit = averagePrice(result.ConsumptionCost, consumption.Quantity)
+
+	return result, nil
+}
+
+func laterOf(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earlierOf(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// TariffAt returns the version of a tariff that was effective at the instant, or the first version if the instant
+// is before it was written.
+func TariffAt(versions []models.TariffVersion, at time.Time) models.Tariff {
+	if version, found := tariffquery.VersionAt(versions, at); found {
+		return version.Tariff
+	}
+	if len(versions) == 0 {
+		return models.Tariff{}
+	}
+
+	return versions[0].Tariff
+}
+
+// continuedTariff returns the version of a tariff at idx if it is effective at the start of [from, to) and the
+// versions of the tariff that follow it cover the rest of the period. The period is priced with the version that
+// was effective at its start, as a whole. The returned copy is valid until the last of these versions.
+func continuedTariff(tariffs []models.Tariff, idx int, from, to time.Time) *models.Tariff {
+	validFrom, err := time.Parse(time.RFC3339, tariffs[idx].ValidFrom)
+	if err != nil || from.Before(validFrom) {
+		return nil
+	}
+	validTo, err := time.Parse(time.RFC3339, tariffs[idx].ValidTo)
+	if err != nil || !from.Before(validTo) {
+		return nil
+	}
+	for next := idx + 1; next < len(tariffs) && tariffs[next].Id == tariffs[idx].Id && validTo.Before(to); next++ {
+		nextFrom, err := time.Parse(time.RFC3339, tariffs[next].ValidFrom)
+		if err != nil || !nextFrom.Equal(validTo) {
+			return nil
+		}
+		if validTo, err = time.Parse(time.RFC3339, tariffs[next].ValidTo); err != nil {
+			return nil
+		}
+	}
+	if validTo.Before(to) {
+		return nil
+	}
+
+	tariff := tariffs[idx]
+	tariff.ValidTo = validTo.Format(time.RFC3339)
+	return &tariff
+}
@@ -0,0 +1,161 @@
+package calculation
+
+import (
+	"testing"
+	"time"
+
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/money"
+	"tariff-calculation-service/test/data"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TariffVersions(t *testing.T) {
+	// arrange
+	first, revised := data.Tariff, data.TariffRevised
+	first.ValidTo = "2021-06-01T00:00:00Z"
+	revised.ValidFrom = "2021-06-01T00:00:00Z"
+	superseded := []models.TariffVersion{
+		data.TariffVersions[0],
+		{Version: 2, EffectiveFrom: "2021-06-01T00:00:00Z", Tariff: data.Tariff},
+		{Version: 3, EffectiveFrom: "2021-06-01T00:00:00Z", Tariff: data.TariffRevised},
+	}
+	backfilled := []models.TariffVersion{{Version: 1, Tariff: data.Tariff}}
+
+	testcases := []struct {
+		name     string
+		versions []models.TariffVersion
+		expected []models.Tariff
+	}{
+		{"Positive Test Versions", data.TariffVersions, []models.Tariff{first, revised}},
+		{"Positive Test Superseded Version", superseded, []models.Tariff{first, revised}},
+		{"Positive Test Without Effective From", backfilled, []models.Tariff{data.Tariff}},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := TariffVersions(tc.versions)
+
+			// assert
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func Test_TariffVersions_Negative(t *testing.T) {
+	// arrange
+	versions := []models.TariffVersion{data.TariffVersions[0], {Version: 2, EffectiveFrom: "2021-06-01", Tariff: data.TariffRevised}}
+
+	// act
+	actual, err := TariffVersions(versions)
+
+	// assert
+	assert.NotNil(t, err)
+	assert.Nil(t, actual)
+}
+
+func Test_TariffAt(t *testing.T) {
+	testcases := []struct {
+		name     string
+		at       string
+		expected models.Tariff
+	}{
+		{"Positive Test Before First Version", "2020-03-24T12:04:18Z", data.Tariff},
+		{"Positive Test First Version", "2021-05-31T23:59:59Z", data.Tariff},
+		{"Positive Test Revised Version", "2021-06-01T00:00:00Z", data.TariffRevised},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			at, _ := time.Parse(time.RFC3339, tc.at)
+			actual := TariffAt(data.TariffVersions, at)
+
+			// assert
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func Test_CalculateIntervals_Versions(t *testing.T) {
+	// arrange
+	tariffs, _ := TariffVersions(data.TariffVersions)
+
+	testcases := []struct {
+		name     string
+		request  models.IntervalCalculationRequest
+		expected []string
+	}{
+		{
+			"Positive Test Interval Per Version",
+			models.IntervalCalculationRequest{Resolution: 15, Readings: []models.Reading{
+				{Timestamp: "2021-05-31T23:45:00Z", Quantity: money.RequireFromString("1")},
+				{Timestamp: "2021-06-01T00:00:00Z", Quantity: money.RequireFromString("1")},
+			}},
+			[]string{"64.5", "70.1"},
+		},
+		{
+			"Positive Test Interval Across Versions",
+			models.IntervalCalculationRequest{Resolution: 60, Readings: []models.Reading{
+				{Timestamp: "2021-05-31T23:30:00Z", Quantity: money.RequireFromString("1")},
+			}},
+			[]string{"64.5"},
+		},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := CalculateIntervals(tariffs, tc.request)
+
+			// assert
+			assert.Nil(t, err)
+			assert.Len(t, result.Intervals, len(tc.expected))
+			for idx, price := range tc.expected {
+				assert.Equal(t, data.TestTariffId, result.Intervals[idx].TariffId)
+				assert.True(t, money.RequireFromString(price).Equal(result.Intervals[idx].PricePerUnit))
+			}
+			assert.Len(t, result.TariffTotals, 1)
+		})
+	}
+}
+
+func Test_CalculateVersionedCost(t *testing.T) {
+	// arrange
+	otherCurrency := data.TariffRevised
+	otherCurrency.Currency = "USD"
+	mixedCurrencies := []models.TariffVersion{data.TariffVersions[0], {Version: 2, EffectiveFrom: "2021-06-01T00:00:00Z", Tariff: otherCurrency}}
+
+	testcases := []struct {
+		name                    string
+		versions                []models.TariffVersion
+		from                    string
+		to                      string
+		expectedConsumptionCost string
+		expectedPricePerUnit    string
+		expectedErr             error
+	}{
+		{"Positive Test Before Revision", data.TariffVersions, "2021-05-30T00:00:00Z", "2021-06-01T00:00:00Z", "129", "64.5", nil},
+		{"Positive Test After Revision", data.TariffVersions, "2021-06-01T00:00:00Z", "2021-06-03T00:00:00Z", "140.2", "70.1", nil},
+		{"Positive Test Across Revision", data.TariffVersions, "2021-05-31T00:00:00Z", "2021-06-02T00:00:00Z", "134.6", "67.3", nil},
+		{"Negative Test Outside Validity", data.TariffVersions, "2019-01-01T00:00:00Z", "2021-06-02T00:00:00Z", "", "", ErrOutsideValidity},
+		{"Negative Test Mixed Currencies", mixedCurrencies, "2021-05-31T00:00:00Z", "2021-06-02T00:00:00Z", "", "", ErrMixedCurrencies},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			from, _ := time.Parse(time.RFC3339, tc.from)
+			to, _ := time.Parse(time.RFC3339, tc.to)
+			result, err := CalculateVersionedCost(tc.versions, Consumption{Quantity: money.RequireFromString("2"), From: from, To: to})
+
+			// assert
+			assert.ErrorIs(t, err, tc.expectedErr)
+			if tc.expectedErr != nil {
+				return
+			}
+			assert.Equal(t, data.TestTariffId, result.TariffId)
+			assert.True(t, money.RequireFromString(tc.expectedConsumptionCost).Equal(result.ConsumptionCost))
+			assert.True(t, money.RequireFromString(tc.expectedPricePerUnit).Equal(result.PricePerUnit))
+		})
+	}
+}
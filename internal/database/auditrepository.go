@@ -0,0 +1,122 @@
+package database
+
+import (
+	"fmt"
+	"tariff-calculation-service/internal/audit"
+	"tariff-calculation-service/internal/models"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type AuditRepo struct {
+	DBClient
+}
+
+func NewAuditRepo() AuditRepo {
+	return AuditRepo{
+		DBClient: NewDBClient(),
+	}
+}
+
+// AppendAuditRecord puts the record under audit#<timestamp>#<id>, so that the records sort by time. The put
+// never replaces an item.
+func (ar AuditRepo) AppendAuditRecord(partitionId string, record models.AuditRecord) error {
+	return CreateEntity(ar.DBClient, auditEntity(partitionId, record))
+}
+
+// appendItems returns the puts of the audit records like AppendAuditRecord does them, to be written in the
+// transaction of the write they record.
+func (ar AuditRepo) appendItems(partitionId string, records []models.AuditRecord) ([]types.TransactWriteItem, error) {
+	expr, err := expression.NewBuilder().WithCondition(ar.notExistsCondition()).Build()
+	if err != nil {
+		return nil, err
+	}
+	items := []types.TransactWriteItem{}
+	for _, record := range records {
+		item, err := attributevalue.MarshalMap(auditEntity(partitionId, record))
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, types.TransactWriteItem{Put: &types.Put{
+			TableName:                &ar.TableName,
+			Item:                     item,
+			ExpressionAttributeNames: expr.Names(),
+			ConditionExpression:      expr.Condition(),
+		}})
+	}
+
+	return items, nil
+}
+
+func auditEntity(partitionId string, record models.AuditRecord) DBEntity[models.AuditRecord] {
+	return DBEntity[models.AuditRecord]{
+		PartitionKey: partitionId,
+		SortKey:      AuditSortKeyPrefix + record.Timestamp + "#" + record.Id,
+		Data:         record,
+	}
+}
+
+// GetAuditRecordsPage returns one page of the audit records of the partition that match the filter, ordered
+// by time. The time range is a range of sort keys; the other criteria are filters.
+func (ar AuditRepo) GetAuditRecordsPage(partitionId string, filter models.AuditFilter, pageRequest models.PageRequest) (*models.Page[models.AuditRecord], error) {
+	sortKeyCondition, err := ar.auditSortKeyCondition(filter)
+	if err != nil {
+		return nil, err
+	}
+	recordEntities, nextCursor, err := QueryEntitiesRangePage[models.AuditRecord](ar.DBClient, partitionId, AuditSortKeyPrefix, sortKeyCondition, pageRequest, auditFilterConditions(filter)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit records: %w", err)
+	}
+	page := models.Page[models.AuditRecord]{Items: []models.AuditRecord{}, NextCursor: nextCursor}
+	for _, record := range recordEntities {
+		page.Items = append(page.Items, record.Data)
+	}
+
+	return &page, nil
+}
+
+// auditSortKeyCondition selects the sort keys of the records written in [From, To). A record written at To
+// has a sort key after audit#<To>, as the id follows the timestamp.
+func (ar AuditRepo) auditSortKeyCondition(filter models.AuditFilter) (expression.KeyConditionBuilder, error) {
+	sortKey := expression.Key(ar.SortKey)
+	from, to := "", ""
+	var err error
+	if filter.From != "" {
+		if from, err = audit.FilterTimestamp(filter.From); err != nil {
+			return expression.KeyConditionBuilder{}, err
+		}
+	}
+	if filter.To != "" {
+		if to, err = audit.FilterTimestamp(filter.To); err != nil {
+			return expression.KeyConditionBuilder{}, err
+		}
+	}
+
+	switch {
+	case from != "" && to != "":
+		return expression.KeyBetween(sortKey, expression.Value(AuditSortKeyPrefix+from), expression.Value(AuditSortKeyPrefix+to)), nil
+	case from != "":
+		return expression.KeyBetween(sortKey, expression.Value(AuditSortKeyPrefix+from), expression.Value(AuditSortKeyPrefix+"~")), nil
+	case to != "":
+		return expression.KeyBetween(sortKey, expression.Value(AuditSortKeyPrefix), expression.Value(AuditSortKeyPrefix+to)), nil
+	default:
+		return expression.KeyBeginsWith(sortKey, AuditSortKeyPrefix), nil
+	}
+}
+
+func auditFilterConditions(filter models.AuditFilter) []expression.ConditionBuilder {
+	conditions := []expression.ConditionBuilder{}
+	if filter.EntityType != "" {
+		conditions = append(conditions, expression.Name("Data.EntityType").Equal(expression.Value(filter.EntityType)))
+	}
+	if filter.EntityId != "" {
+		conditions = append(conditions, expression.Name("Data.EntityId").Equal(expression.Value(filter.EntityId)))
+	}
+	if filter.Actor != "" {
+		conditions = append(conditions, expression.Name("Data.Actor").Equal(expression.Value(filter.Actor)))
+	}
+
+	return conditions
+}
@@ -0,0 +1,156 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	dbtesting "tariff-calculation-service/internal/database/testing"
+	"tariff-calculation-service/internal/dberrors"
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/constants"
+	"tariff-calculation-service/test/data"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_AppendAuditRecord(t *testing.T) {
+	// arrange
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockDBManager := dbtesting.NewMockDynamoDBManager(mockController)
+
+	auditRepo := AuditRepo{
+		DBClient: DBClient{
+			DynamoDBClient: mockDBManager,
+			TableName:      "TestTableName",
+			PartitionKey:   "TestPartitionKey",
+			SortKey:        "TestSortKey",
+		},
+	}
+	expectPut := func(err error) {
+		mockDBManager.EXPECT().PutItem(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, input *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+			assert.Equal(t, &types.AttributeValueMemberS{Value: "audit#" + data.AuditRecord.Timestamp + "#" + data.TestAuditId}, input.Item["Sort_Key"])
+			assert.Contains(t, *input.ConditionExpression, "attribute_not_exists")
+			return &dynamodb.PutItemOutput{}, err
+		})
+	}
+
+	testcases := []struct {
+		name          string
+		mock          func()
+		expectedError error
+	}{
+		{"Positive Test", func() { expectPut(nil) }, nil},
+		{"Negative Test Existing Record", func() { expectPut(&types.ConditionalCheckFailedException{}) }, dberrors.ErrConflict},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			err := auditRepo.AppendAuditRecord(data.TestPartitionId, data.AuditRecord)
+
+			// assert
+			if tc.expectedError == nil {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func Test_GetAuditRecordsPage(t *testing.T) {
+	// arrange
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockDBManager := dbtesting.NewMockDynamoDBManager(mockController)
+
+	auditRepo := AuditRepo{
+		DBClient: DBClient{
+			DynamoDBClient: mockDBManager,
+			TableName:      "TestTableName",
+			PartitionKey:   "TestPartitionKey",
+			SortKey:        "TestSortKey",
+		},
+	}
+	item, err := attributevalue.MarshalMap(DBEntity[models.AuditRecord]{
+		PartitionKey: data.TestPartitionId,
+		SortKey:      "audit#" + data.AuditRecord.Timestamp + "#" + data.TestAuditId,
+		Data:         data.AuditRecord,
+	})
+	assert.Nil(t, err)
+	queryOutput := &dynamodb.QueryOutput{Items: []map[string]types.AttributeValue{item}}
+	expectQuery := func(keyCondition string, sortKeyValues []string, criteria int) {
+		mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, input *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+			assert.Contains(t, *input.KeyConditionExpression, keyCondition)
+			values := []string{}
+			for _, value := range input.ExpressionAttributeValues {
+				if value, ok := value.(*types.AttributeValueMemberS); ok {
+					values = append(values, value.Value)
+				}
+			}
+			assert.Subset(t, values, sortKeyValues)
+			assert.Len(t, input.ExpressionAttributeValues, 1+len(sortKeyValues)+criteria)
+			return queryOutput, nil
+		})
+	}
+
+	testcases := []struct {
+		name             string
+		filter           models.AuditFilter
+		mock             func()
+		expectedResponse *models.Page[models.AuditRecord]
+		expectedError    error
+	}{
+		{
+			"Positive Test",
+			models.AuditFilter{},
+			func() { expectQuery("begins_with", []string{"audit#"}, 0) },
+			&models.Page[models.AuditRecord]{Items: []models.AuditRecord{data.AuditRecord}},
+			nil,
+		},
+		{
+			"Positive Test Filter",
+			data.AuditFilter,
+			func() {
+				expectQuery("BETWEEN", []string{"audit#2021-03-24T00:00:00.000000000Z", "audit#2021-03-24T23:00:00.000000000Z"}, 3)
+			},
+			&models.Page[models.AuditRecord]{Items: []models.AuditRecord{data.AuditRecord}},
+			nil,
+		},
+		{
+			"Positive Test From",
+			models.AuditFilter{From: "2021-03-24T00:00:00Z"},
+			func() { expectQuery("BETWEEN", []string{"audit#2021-03-24T00:00:00.000000000Z", "audit#~"}, 0) },
+			&models.Page[models.AuditRecord]{Items: []models.AuditRecord{data.AuditRecord}},
+			nil,
+		},
+		{
+			"Negative Test",
+			models.AuditFilter{},
+			func() {
+				mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).Return(nil, errors.New(constants.InternalServerError))
+			},
+			nil,
+			fmt.Errorf("failed to query audit records: %w", errors.New(constants.InternalServerError)),
+		},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			actual, err := auditRepo.GetAuditRecordsPage(data.TestPartitionId, tc.filter, models.PageRequest{})
+
+			// assert
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedResponse, actual)
+		})
+	}
+}
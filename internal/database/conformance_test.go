@@ -0,0 +1,40 @@
+package database
+
+import (
+	"context"
+	"os"
+	"tariff-calculation-service/test/conformance"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+// Test_Conformance runs against the table in DYNAMODB_TEST_TABLE, e.g. of DynamoDB Local with
+// AWS_ENDPOINT_URL_DYNAMODB=http://localhost:8001
+func Test_Conformance(t *testing.T) {
+	tableName := os.Getenv("DYNAMODB_TEST_TABLE")
+	if tableName == "" {
+		t.Skip("DYNAMODB_TEST_TABLE is not set")
+	}
+	cfg, err := config.LoadDefaultConfig(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := DBClient{
+		DynamoDBClient: dynamodb.NewFromConfig(cfg),
+		TableName:      tableName,
+		PartitionKey:   "Partition_Id",
+		SortKey:        "Sort_Key",
+	}
+
+	conformance.RunRepositoryTests(t, conformance.Repositories{
+		Tariffs:   TariffRepo{DBClient: client},
+		Contracts: ContractRepo{DBClient: client},
+		Providers: ProviderRepo{DBClient: client},
+		TaxRules:  TaxRuleRepo{DBClient: client},
+		Settings:  SettingsRepo{DBClient: client},
+		FxRates:   FxRateRepo{DBClient: client},
+		Audit:     AuditRepo{DBClient: client},
+	})
+}
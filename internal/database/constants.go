@@ -4,4 +4,21 @@ const (
 	ContractSortKeyPrefix = "contract#"
 	ProviderSortKeyPrefix = "provider#"
 	TariffSortKeyPrefix   = "tariff#"
+	TaxRuleSortKeyPrefix  = "taxrule#"
+	FxRateSortKeyPrefix   = "fxrate#"
+	AuditSortKeyPrefix    = "audit#"
+	SettingsSortKey       = "settings"
+
+	// The contracts of a provider and of a tariff are kept as copies under the sort keys
+	// providercontract#<providerId>#<contractId> and tariffcontract#<tariffId>#<contractId>, so that they are
+	// queried by the prefix of the provider or tariff.
+	ProviderContractSortKeyPrefix = "providercontract#"
+	TariffContractSortKeyPrefix   = "tariffcontract#"
+
+	// The versions of a tariff are kept under tariff#<tariffId>#version#<incarnation>#<version>, with the version
+	// zero-padded so that the sort keys sort by version. The incarnation is the creation time of the tariff, so that
+	// the versions of a tariff created again with the same id never replace those kept of the deleted one. Tariffs
+	// created before the incarnation was kept have none, and their versions are under
+	// tariff#<tariffId>#version#<version>.
+	TariffVersionSortKeyInfix = "#version#"
 )
@@ -1,14 +1,33 @@
 package database
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strconv"
+	"tariff-calculation-service/internal/dberrors"
 	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/versioning"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 )
 
+// referenceVersionAttribute counts the contract writes that added a reference to a provider or a tariff. The
+// copies of the contracts written after a deletion read the contracts of the entity have keys that the deletion
+// does not know, so it is conditional on the count instead.
+const referenceVersionAttribute = "Reference_Version"
+
+// referencedEntity is the part of the item of a provider or a tariff that its deletion is conditional on.
+type referencedEntity struct {
+	Version          int `dynamodbav:"Version"`
+	ReferenceVersion int `dynamodbav:"Reference_Version"`
+}
+
 type ContractRepo struct {
 	DBClient
 }
@@ -20,16 +39,20 @@ func NewContractRepo() ContractRepo {
 }
 
 func (cr ContractRepo) GetKey(partitionId, contractId string) map[string]types.AttributeValue {
+	return cr.getSortKey(partitionId, ContractSortKeyPrefix+contractId)
+}
+
+func (cr ContractRepo) getSortKey(partitionId, sortKey string) map[string]types.AttributeValue {
 	return map[string]types.AttributeValue{
 		cr.PartitionKey: &types.AttributeValueMemberS{Value: partitionId},
-		cr.SortKey:      &types.AttributeValueMemberS{Value: ContractSortKeyPrefix + contractId},
+		cr.SortKey:      &types.AttributeValueMemberS{Value: sortKey},
 	}
 }
 
 func (cr ContractRepo) GetContracts(partitionId string) (*[]models.Contract, error) {
 	contractEntities, err := QueryEntities[models.Contract](cr.DBClient, partitionId, ContractSortKeyPrefix)
 	if err != nil {
-		return nil, errors.New("failed to query contracts")
+		return nil, fmt.Errorf("failed to query contracts: %w", err)
 	}
 	contracts := []models.Contract{}
 
@@ -40,6 +63,34 @@ func (cr ContractRepo) GetContracts(partitionId string) (*[]models.Contract, err
 	return &contracts, nil
 }
 
+// GetContractsPage returns one page of the contracts of the partition.
+func (cr ContractRepo) GetContractsPage(partitionId string, pageRequest models.PageRequest) (*models.Page[models.Contract], error) {
+	return cr.queryContractsPage(partitionId, ContractSortKeyPrefix, pageRequest)
+}
+
+// GetContractsByProvider returns one page of the contracts of the provider.
+func (cr ContractRepo) GetContractsByProvider(partitionId, providerId string, pageRequest models.PageRequest) (*models.Page[models.Contract], error) {
+	return cr.queryContractsPage(partitionId, ProviderContractSortKeyPrefix+providerId+"#", pageRequest)
+}
+
+// GetContractsByTariff returns one page of the contracts of the tariff.
+func (cr ContractRepo) GetContractsByTariff(partitionId, tariffId string, pageRequest models.PageRequest) (*models.Page[models.Contract], error) {
+	return cr.queryContractsPage(partitionId, TariffContractSortKeyPrefix+tariffId+"#", pageRequest)
+}
+
+func (cr ContractRepo) queryContractsPage(partitionId, sortKeyPrefix string, pageRequest models.PageRequest) (*models.Page[models.Contract], error) {
+	contractEntities, nextCursor, err := QueryEntitiesPage[models.Contract](cr.DBClient, partitionId, sortKeyPrefix, pageRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query contracts: %w", err)
+	}
+	page := models.Page[models.Contract]{Items: []models.Contract{}, NextCursor: nextCursor}
+	for _, contract := range contractEntities {
+		page.Items = append(page.Items, contract.Data)
+	}
+
+	return &page, nil
+}
+
 func (cr ContractRepo) GetContract(partitionId, contractId string) (*models.Contract, error) {
 	contract, err := GetEntity[models.Contract](cr.DBClient, cr.GetKey(partitionId, contractId))
 	if err != nil || contract == nil {
@@ -48,33 +99,459 @@ func (cr ContractRepo) GetContract(partitionId, contractId string) (*models.Cont
 	return contract, nil
 }
 
-func (cr ContractRepo) CreateContract(partitionId string, contract models.Contract) (*models.Contract, error) {
-	contractDB := DBEntity[models.Contract]{
-		PartitionKey: partitionId,
-		SortKey:      ContractSortKeyPrefix + contract.Id,
-		Data:         contract,
+// GetContractWithVersion returns the contract and its version.
+func (cr ContractRepo) GetContractWithVersion(partitionId, contractId string) (*models.Contract, int, error) {
+	contract, err := GetVersionedEntity[models.Contract](cr.DBClient, cr.GetKey(partitionId, contractId))
+	if err != nil {
+		return &models.Contract{}, 0, err
+	}
+
+	return &contract.Data, contract.Version, nil
+}
+
+// CreateContract puts the contract together with its copies for the lookups by provider and tariff and its audit
+// records. It returns dberrors.ErrConflict if the contract exists or if the provider or a tariff it refers to does
+// not exist.
+func (cr ContractRepo) CreateContract(partitionId string, contract models.Contract, records ...models.AuditRecord) (*models.Contract, error) {
+	items := []types.TransactWriteItem{}
+	for _, sortKey := range append([]string{ContractSortKeyPrefix + contract.Id}, referenceSortKeys(contract)...) {
+		item, err := cr.putItem(partitionId, sortKey, contract)
+		if err != nil {
+			return &models.Contract{}, err
+		}
+		items = append(items, item)
+	}
+	expr, err := expression.NewBuilder().WithCondition(cr.notExistsCondition()).Build()
+	if err != nil {
+		return &models.Contract{}, err
 	}
-	err := PutEntity[DBEntity[models.Contract]](cr.DBClient, contractDB)
+	items[0].Put.Item["Version"] = &types.AttributeValueMemberN{Value: strconv.Itoa(versioning.InitialVersion)}
+	items[0].Put.ExpressionAttributeNames = expr.Names()
+	items[0].Put.ConditionExpression = expr.Condition()
+	references, err := cr.referenceItems(partitionId, nil, contract)
 	if err != nil {
 		return &models.Contract{}, err
 	}
+	auditItems, err := AuditRepo{DBClient: cr.DBClient}.appendItems(partitionId, records)
+	if err != nil {
+		return &models.Contract{}, err
+	}
+	if err := CreateTransaction(cr.DBClient, append(append(items, references...), auditItems...)); err != nil {
+		return &models.Contract{}, err
+	}
 
 	return &contract, nil
 }
 
-func (cr ContractRepo) UpdateContract(partitionId string, contract models.Contract) error {
-	dbUpdate := expression.Set(expression.Name("Data"), expression.Value(contract))
-	expr, err := expression.NewBuilder().WithUpdate(dbUpdate).Build()
+// UpdateContract replaces the contract and its copies if it has the expected version, deletes the copies of the
+// providers and tariffs it no longer refers to, and returns the new version. The contract is read first to find
+// its copies, and the write is conditional on the contract as it was read, so it returns dberrors.ErrConflict if
+// the contract changed in the meantime, even if any version is expected. It returns dberrors.ErrConflict as well if
+// the provider or a tariff it refers to does not exist. The audit records are put in the same transaction.
+func (cr ContractRepo) UpdateContract(partitionId string, contract models.Contract, version int, records ...models.AuditRecord) (int, error) {
+	storedContract, err := GetVersionedEntity[models.Contract](cr.DBClient, cr.GetKey(partitionId, contract.Id))
 	if err != nil {
-		return fmt.Errorf("error building expression %v", err)
+		return 0, err
+	}
+	if !versioning.Matches(storedContract.Version, version) {
+		return 0, versioning.ErrVersionMismatch
 	}
-	err = UpdateEntity(cr.DBClient, cr.GetKey(partitionId, contract.Id), expr)
 
-	return err
+	items, err := cr.updateItems(partitionId, storedContract.Data, contract, cr.readCondition(storedContract))
+	if err != nil {
+		return 0, err
+	}
+	// the old item tells a changed contract from a missing one
+	items[0].Update.ReturnValuesOnConditionCheckFailure = types.ReturnValuesOnConditionCheckFailureAllOld
+	references, err := cr.referenceItems(partitionId, &storedContract.Data, contract)
+	if err != nil {
+		return 0, err
+	}
+	auditItems, err := AuditRepo{DBClient: cr.DBClient}.appendItems(partitionId, records)
+	if err != nil {
+		return 0, err
+	}
+	if err := changedError(WriteChangeTransaction(cr.DBClient, items, append(references, auditItems...))); err != nil {
+		return 0, err
+	}
+
+	return storedContract.Version + 1, nil
+}
+
+// DeleteContract deletes the contract together with its copies if it has the expected version, and puts the audit
+// records in the same transaction. Like UpdateContract, it returns dberrors.ErrConflict if the contract changed
+// after it was read.
+func (cr ContractRepo) DeleteContract(partitionId, contractId string, version int, records ...models.AuditRecord) error {
+	storedContract, err := GetVersionedEntity[models.Contract](cr.DBClient, cr.GetKey(partitionId, contractId))
+	if err != nil {
+		return err
+	}
+	if !versioning.Matches(storedContract.Version, version) {
+		return versioning.ErrVersionMismatch
+	}
+
+	items, err := cr.deleteItems(partitionId, storedContract.Data, cr.readCondition(storedContract))
+	if err != nil {
+		return err
+	}
+	// the old item tells a changed contract from a missing one
+	items[0].Delete.ReturnValuesOnConditionCheckFailure = types.ReturnValuesOnConditionCheckFailureAllOld
+	auditItems, err := AuditRepo{DBClient: cr.DBClient}.appendItems(partitionId, records)
+	if err != nil {
+		return err
+	}
+
+	return changedError(WriteChangeTransaction(cr.DBClient, items, auditItems))
+}
+
+// readCondition requires the contract to be as it was read, with its version and its data, as its copies are
+// written from the data that was read.
+func (cr ContractRepo) readCondition(storedContract *DBEntity[models.Contract]) expression.ConditionBuilder {
+	return cr.versionCondition(storedContract.Version).And(expression.Name("Data").Equal(expression.Value(storedContract.Data)))
 }
 
-func (cr ContractRepo) DeleteContract(partitionId, contractId string) error {
-	err := DeleteEntity(cr.DBClient, cr.GetKey(partitionId, contractId))
+// changedError maps the failed condition of a contract that exists to dberrors.ErrConflict, as the contract
+// changed after it was read.
+func changedError(err error) error {
+	if errors.Is(err, versioning.ErrVersionMismatch) {
+		return dberrors.ErrConflict
+	}
 
 	return err
 }
+
+// changeItems returns the writes of the changes of the contracts. The write of each contract is conditional on
+// its data as it was read, while its copies are written unconditionally. The changes of a deletion only remove
+// references, so the providers and tariffs of the contracts are not checked.
+func (cr ContractRepo) changeItems(partitionId string, changes []models.ContractChange) ([]types.TransactWriteItem, error) {
+	items := []types.TransactWriteItem{}
+	for _, change := range changes {
+		condition := expression.Name("Data").Equal(expression.Value(change.Before))
+		var changeItems []types.TransactWriteItem
+		var err error
+		if change.After == nil {
+			changeItems, err = cr.deleteItems(partitionId, change.Before, condition)
+		} else {
+			changeItems, err = cr.updateItems(partitionId, change.Before, *change.After, condition)
+		}
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, changeItems...)
+	}
+
+	return items, nil
+}
+
+// updateItems returns the update of the stored contract under the condition, which increments its version, the puts of
+// its copies and the deletes of the copies of the providers and tariffs it no longer refers to.
+func (cr ContractRepo) updateItems(partitionId string, storedContract, contract models.Contract, condition expression.ConditionBuilder) ([]types.TransactWriteItem, error) {
+	expr, err := expression.NewBuilder().
+		WithUpdate(expression.Set(expression.Name("Data"), expression.Value(contract)).
+			Set(expression.Name("Version"), expression.Plus(expression.IfNotExists(expression.Name("Version"), expression.Value(0)), expression.Value(1)))).
+		WithCondition(condition).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	items := []types.TransactWriteItem{{Update: &types.Update{
+		TableName:                 &cr.TableName,
+		Key:                       cr.GetKey(partitionId, contract.Id),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+	}}}
+	sortKeys := referenceSortKeys(contract)
+	for _, sortKey := range sortKeys {
+		item, err := cr.putItem(partitionId, sortKey, contract)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	for _, sortKey := range referenceSortKeys(storedContract) {
+		if !slices.Contains(sortKeys, sortKey) {
+			items = append(items, types.TransactWriteItem{Delete: &types.Delete{TableName: &cr.TableName, Key: cr.getSortKey(partitionId, sortKey)}})
+		}
+	}
+
+	return items, nil
+}
+
+// deleteItems returns the delete of the stored contract under the condition and the deletes of its copies.
+func (cr ContractRepo) deleteItems(partitionId string, storedContract models.Contract, condition expression.ConditionBuilder) ([]types.TransactWriteItem, error) {
+	expr, err := expression.NewBuilder().WithCondition(condition).Build()
+	if err != nil {
+		return nil, err
+	}
+	items := []types.TransactWriteItem{{Delete: &types.Delete{
+		TableName:                 &cr.TableName,
+		Key:                       cr.GetKey(partitionId, storedContract.Id),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ConditionExpression:       expr.Condition(),
+	}}}
+	for _, sortKey := range referenceSortKeys(storedContract) {
+		items = append(items, types.TransactWriteItem{Delete: &types.Delete{TableName: &cr.TableName, Key: cr.getSortKey(partitionId, sortKey)}})
+	}
+
+	return items, nil
+}
+
+func (cr ContractRepo) putItem(partitionId, sortKey string, contract models.Contract) (types.TransactWriteItem, error) {
+	item, err := attributevalue.MarshalMap(DBEntity[models.Contract]{
+		PartitionKey: partitionId,
+		SortKey:      sortKey,
+		Data:         contract,
+	})
+	if err != nil {
+		return types.TransactWriteItem{}, err
+	}
+
+	return types.TransactWriteItem{Put: &types.Put{TableName: &cr.TableName, Item: item}}, nil
+}
+
+// BackfillContractCopies writes the copies for the lookups by provider and tariff of the contracts of all
+// partitions, which contracts written before the copies existed lack, and returns the number of contracts it wrote
+// copies for. It scans the whole table, so it is run once after the deployment that introduced the copies, and it
+// can be rerun after a failure.
+func (cr ContractRepo) BackfillContractCopies() (int, error) {
+	expr, err := expression.NewBuilder().WithFilter(expression.Name(cr.SortKey).BeginsWith(ContractSortKeyPrefix)).Build()
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	var exclusiveStartKey map[string]types.AttributeValue
+	for {
+		response, err := cr.DynamoDBClient.Scan(context.TODO(), &dynamodb.ScanInput{
+			TableName:                 &cr.TableName,
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+			FilterExpression:          expr.Filter(),
+			ExclusiveStartKey:         exclusiveStartKey,
+			ConsistentRead:            aws.Bool(true),
+		})
+		if err != nil {
+			return count, dbError(err)
+		}
+		contracts := []DBEntity[models.Contract]{}
+		if err := attributevalue.UnmarshalListOfMaps(response.Items, &contracts); err != nil {
+			return count, err
+		}
+		for idx := range contracts {
+			written, err := cr.backfillContract(&contracts[idx])
+			if err != nil {
+				return count, fmt.Errorf("failed to backfill contract %s: %w", contracts[idx].SortKey, err)
+			}
+			if written {
+				count++
+			}
+		}
+		if len(response.LastEvaluatedKey) == 0 {
+			return count, nil
+		}
+		exclusiveStartKey = response.LastEvaluatedKey
+	}
+}
+
+// backfillContract puts the copies of the stored contract on the condition that the contract is still as it was
+// read. It reports false if the contract has no references, or if it changed or was deleted since, as its writes
+// have written or deleted its copies then.
+func (cr ContractRepo) backfillContract(storedContract *DBEntity[models.Contract]) (bool, error) {
+	sortKeys := referenceSortKeys(storedContract.Data)
+	if len(sortKeys) == 0 {
+		return false, nil
+	}
+	expr, err := expression.NewBuilder().WithCondition(cr.readCondition(storedContract)).Build()
+	if err != nil {
+		return false, err
+	}
+	items := []types.TransactWriteItem{{ConditionCheck: &types.ConditionCheck{
+		TableName:                 &cr.TableName,
+		Key:                       cr.getSortKey(storedContract.PartitionKey, storedContract.SortKey),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ConditionExpression:       expr.Condition(),
+	}}}
+	for _, sortKey := range sortKeys {
+		item, err := cr.putItem(storedContract.PartitionKey, sortKey, storedContract.Data)
+		if err != nil {
+			return false, err
+		}
+		items = append(items, item)
+	}
+
+	err = WriteTransaction(cr.DBClient, items)
+	if errors.Is(err, dberrors.ErrNotFound) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// referenceSortKeys returns the sort keys of the copies of the contract for its provider and its tariffs. A
+// transaction holds up to 100 items, and a contract is written with a copy and a check per reference, so a write of
+// a contract with more than 48 tariffs returns dberrors.ErrTooLarge.
+func referenceSortKeys(contract models.Contract) []string {
+	sortKeys := []string{}
+	if contract.Provider != "" {
+		sortKeys = append(sortKeys, ProviderContractSortKeyPrefix+contract.Provider+"#"+contract.Id)
+	}
+	for _, tariffId := range contract.Tariffs {
+		sortKey := TariffContractSortKeyPrefix + tariffId + "#" + contract.Id
+		if !slices.Contains(sortKeys, sortKey) {
+			sortKeys = append(sortKeys, sortKey)
+		}
+	}
+
+	return sortKeys
+}
+
+// referencedSortKeys returns the sort keys of the provider and the tariffs of the contract.
+func referencedSortKeys(contract models.Contract) []string {
+	sortKeys := []string{}
+	if contract.Provider != "" {
+		sortKeys = append(sortKeys, ProviderSortKeyPrefix+contract.Provider)
+	}
+	for _, tariffId := range contract.Tariffs {
+		if sortKey := TariffSortKeyPrefix + tariffId; !slices.Contains(sortKeys, sortKey) {
+			sortKeys = append(sortKeys, sortKey)
+		}
+	}
+
+	return sortKeys
+}
+
+// referenceItems returns the checks that the provider and the tariffs of the contract exist. A reference that the
+// stored contract did not have increments the reference version of the entity, so that a deletion of it that read
+// the contracts before fails.
+func (cr ContractRepo) referenceItems(partitionId string, storedContract *models.Contract, contract models.Contract) ([]types.TransactWriteItem, error) {
+	checkExpr, err := expression.NewBuilder().WithCondition(cr.existsCondition()).Build()
+	if err != nil {
+		return nil, err
+	}
+	referenceVersion := expression.Name(referenceVersionAttribute)
+	addExpr, err := expression.NewBuilder().
+		WithUpdate(expression.Set(referenceVersion, expression.Plus(expression.IfNotExists(referenceVersion, expression.Value(0)), expression.Value(1)))).
+		WithCondition(cr.existsCondition()).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	storedSortKeys := []string{}
+	if storedContract != nil {
+		storedSortKeys = referencedSortKeys(*storedContract)
+	}
+
+	items := []types.TransactWriteItem{}
+	for _, sortKey := range referencedSortKeys(contract) {
+		if slices.Contains(storedSortKeys, sortKey) {
+			items = append(items, types.TransactWriteItem{ConditionCheck: &types.ConditionCheck{
+				TableName:                &cr.TableName,
+				Key:                      cr.getSortKey(partitionId, sortKey),
+				ExpressionAttributeNames: checkExpr.Names(),
+				ConditionExpression:      checkExpr.Condition(),
+			}})
+			continue
+		}
+		items = append(items, types.TransactWriteItem{Update: &types.Update{
+			TableName:                 &cr.TableName,
+			Key:                       cr.getSortKey(partitionId, sortKey),
+			ExpressionAttributeNames:  addExpr.Names(),
+			ExpressionAttributeValues: addExpr.Values(),
+			UpdateExpression:          addExpr.Update(),
+			ConditionExpression:       addExpr.Condition(),
+		}})
+	}
+
+	return items, nil
+}
+
+// deleteReferenced deletes the provider or tariff with the key if it has the expected version, and writes the changes
+// of the contracts and puts the audit records in the same transaction. The contracts that refer to it are the
+// contracts of the partition that match refersTo. They are read from the contracts themselves rather than from their
+// copies, which contracts stored before the copies existed lack until the backfill. It returns dberrors.ErrConflict if
+// one of them is not changed, or if a contract referred to the entity after they were read.
+func (cr ContractRepo) deleteReferenced(partitionId string, key map[string]types.AttributeValue, refersTo expression.ConditionBuilder, version int, contracts []models.ContractChange, records []models.AuditRecord) error {
+	stored, err := cr.getReferencedEntity(key)
+	if err != nil {
+		return err
+	}
+	if !versioning.Matches(stored.Version, version) {
+		return versioning.ErrVersionMismatch
+	}
+	referringContracts, err := QueryEntitiesConsistent[models.Contract](cr.DBClient, partitionId, ContractSortKeyPrefix, refersTo)
+	if err != nil {
+		return fmt.Errorf("failed to query contracts: %w", err)
+	}
+	for _, contract := range referringContracts {
+		if !slices.ContainsFunc(contracts, func(change models.ContractChange) bool { return change.Before.Id == contract.Data.Id }) {
+			return dberrors.ErrConflict
+		}
+	}
+
+	changes, err := cr.changeItems(partitionId, contracts)
+	if err != nil {
+		return err
+	}
+	auditItems, err := AuditRepo{DBClient: cr.DBClient}.appendItems(partitionId, records)
+	if err != nil {
+		return err
+	}
+	referenceVersion := expression.Name(referenceVersionAttribute)
+	referenceCondition := referenceVersion.Equal(expression.Value(stored.ReferenceVersion))
+	if stored.ReferenceVersion == 0 {
+		referenceCondition = expression.AttributeNotExists(referenceVersion).Or(referenceCondition)
+	}
+	expr, err := expression.NewBuilder().WithCondition(cr.versionCondition(stored.Version).And(referenceCondition)).Build()
+	if err != nil {
+		return err
+	}
+	items := []types.TransactWriteItem{{Delete: &types.Delete{
+		TableName:                 &cr.TableName,
+		Key:                       key,
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ConditionExpression:       expr.Condition(),
+		// the old item tells a changed entity from a missing one
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+	}}}
+	err = WriteChangeTransaction(cr.DBClient, items, append(changes, auditItems...))
+	if !errors.Is(err, versioning.ErrVersionMismatch) {
+		return err
+	}
+
+	// the version is unchanged if only the reference version failed the condition
+	current, err := cr.getReferencedEntity(key)
+	if err != nil {
+		return err
+	}
+	if current.Version == stored.Version {
+		return dberrors.ErrConflict
+	}
+
+	return versioning.ErrVersionMismatch
+}
+
+// getReferencedEntity reads the versions of a provider or a tariff with a strongly consistent read.
+func (cr ContractRepo) getReferencedEntity(key map[string]types.AttributeValue) (*referencedEntity, error) {
+	result, err := cr.DynamoDBClient.GetItem(context.TODO(), &dynamodb.GetItemInput{
+		TableName:      aws.String(cr.TableName),
+		Key:            key,
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, dbError(err)
+	}
+	if len(result.Item) == 0 {
+		return nil, dberrors.ErrNotFound
+	}
+	entity := referencedEntity{}
+	if err := attributevalue.UnmarshalMap(result.Item, &entity); err != nil {
+		return nil, err
+	}
+
+	return &entity, nil
+}
@@ -1,14 +1,21 @@
 package database
 
 import (
+	"context"
 	"errors"
+	"strings"
 	dbtesting "tariff-calculation-service/internal/database/testing"
+	"tariff-calculation-service/internal/dberrors"
 	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/versioning"
 	"tariff-calculation-service/pkg/constants"
 	"tariff-calculation-service/test/data"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -72,7 +79,7 @@ func Test_GetContracts(t *testing.T) {
 			actualContracts, err := contractRepo.GetContracts(tc.PartitionId)
 			// assert
 			if err != nil {
-				assert.Contains(t, "failed to query contracts", err.Error())
+				assert.Contains(t, err.Error(), "failed to query contracts")
 				assert.Nil(t, actualContracts)
 			} else {
 				assert.NotNil(t, actualContracts)
@@ -168,10 +175,29 @@ func Test_CreateContract(t *testing.T) {
 			ContractId:  data.TestContractId,
 			Mock: []func(){
 				func() {
-					mockDBManager.EXPECT().PutItem(gomock.Any(), gomock.Any()).Return(data.TestPutItemOutputContract, nil)
+					expectTransaction(t, mockDBManager, []string{
+						"Put " + ContractSortKeyPrefix + data.TestContractId,
+						"Put " + ProviderContractSortKeyPrefix + data.TestProviderId + "#" + data.TestContractId,
+						"Put " + TariffContractSortKeyPrefix + data.TestTariffId + "#" + data.TestContractId,
+						"Update " + ProviderSortKeyPrefix + data.TestProviderId,
+						"Update " + TariffSortKeyPrefix + data.TestTariffId,
+					}, nil)
 				},
 			},
-			expectedResponse: &data.Contract,
+			expectedResponse: &data.ContractWithTariff,
+		},
+		{
+			Name:        "Negative Test Reference Missing",
+			PartitionId: data.TestPartitionId,
+			ContractId:  data.TestContractId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any()).Return(nil, &types.TransactionCanceledException{
+						CancellationReasons: []types.CancellationReason{{Code: aws.String("None")}, {Code: aws.String("None")}, {Code: aws.String("None")}, {Code: aws.String("ConditionalCheckFailed")}},
+					})
+				},
+			},
+			expectedResponse: &models.Contract{},
 		},
 		{
 			Name:        "Negative Test",
@@ -179,7 +205,7 @@ func Test_CreateContract(t *testing.T) {
 			ContractId:  data.TestContractId,
 			Mock: []func(){
 				func() {
-					mockDBManager.EXPECT().PutItem(gomock.Any(), gomock.Any()).Return(&dynamodb.PutItemOutput{}, errors.New(constants.InternalServerError))
+					mockDBManager.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any()).Return(nil, errors.New(constants.InternalServerError))
 				},
 			},
 			expectedResponse: &models.Contract{},
@@ -191,9 +217,11 @@ func Test_CreateContract(t *testing.T) {
 			tc.Mock[idx]()
 		}
 		t.Run(tc.Name, func(t *testing.T) {
-			actualContract, err := contractRepo.CreateContract(tc.PartitionId, data.Contract)
+			actualContract, err := contractRepo.CreateContract(tc.PartitionId, data.ContractWithTariff)
 			// assert
-			if err != nil {
+			if tc.Name == "Negative Test Reference Missing" {
+				assert.ErrorIs(t, err, dberrors.ErrConflict)
+			} else if err != nil {
 				assert.Contains(t, constants.InternalServerError, err.Error())
 			} else {
 				assert.NotNil(t, actualContract)
@@ -228,21 +256,74 @@ func Test_UpdateContract(t *testing.T) {
 			ContractId:  data.TestContractId,
 			Mock: []func(){
 				func() {
-					mockDBManager.EXPECT().UpdateItem(gomock.Any(), gomock.Any()).Return(data.TestUpdateItemOutputContract, nil)
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(data.TestGetItemOutputContract, nil)
+					expectTransaction(t, mockDBManager, []string{
+						"Update " + ContractSortKeyPrefix + data.TestContractId,
+						"Put " + ProviderContractSortKeyPrefix + data.TestProviderId + "#" + data.TestContractId,
+						"Put " + TariffContractSortKeyPrefix + data.TestTariffId + "#" + data.TestContractId,
+						"Delete " + TariffContractSortKeyPrefix + "7c433cd3-f3b0-463b-82c2-24177dd7bfe8#" + data.TestContractId,
+						"Delete " + TariffContractSortKeyPrefix + "8c433cd3-f3b0-463b-82c2-24177dd7bfe8#" + data.TestContractId,
+						"Delete " + TariffContractSortKeyPrefix + "9c433cd3-f3b0-463b-82c2-24177dd7bfe8#" + data.TestContractId,
+						"Check " + ProviderSortKeyPrefix + data.TestProviderId,
+						"Update " + TariffSortKeyPrefix + data.TestTariffId,
+					}, nil)
 				},
 			},
 			expectedResponse: nil,
 		},
 		{
-			Name:        "Negative Test",
+			Name:        "Negative Test Reference Missing",
 			PartitionId: data.TestPartitionId,
 			ContractId:  data.TestContractId,
 			Mock: []func(){
 				func() {
-					mockDBManager.EXPECT().UpdateItem(gomock.Any(), gomock.Any()).Return(&dynamodb.UpdateItemOutput{}, errors.New(constants.ResourceNotFound))
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(data.TestGetItemOutputContract, nil)
+					mockDBManager.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any()).Return(nil, &types.TransactionCanceledException{
+						CancellationReasons: []types.CancellationReason{{Code: aws.String("None")}, {Code: aws.String("None")}, {Code: aws.String("None")}, {Code: aws.String("None")},
+							{Code: aws.String("None")}, {Code: aws.String("None")}, {Code: aws.String("None")}, {Code: aws.String("ConditionalCheckFailed")}},
+					})
 				},
 			},
-			expectedResponse: errors.New(constants.ResourceNotFound),
+			expectedResponse: dberrors.ErrConflict,
+		},
+		{
+			Name:        "Negative Test Not Found",
+			PartitionId: data.TestPartitionId,
+			ContractId:  data.TestContractId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(&dynamodb.GetItemOutput{}, nil)
+				},
+			},
+			expectedResponse: dberrors.ErrNotFound,
+		},
+		{
+			Name:        "Negative Test Deleted Concurrently",
+			PartitionId: data.TestPartitionId,
+			ContractId:  data.TestContractId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(data.TestGetItemOutputContract, nil)
+					mockDBManager.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any()).Return(nil, &types.TransactionCanceledException{
+						CancellationReasons: []types.CancellationReason{{Code: aws.String("ConditionalCheckFailed")}, {Code: aws.String("None")}},
+					})
+				},
+			},
+			expectedResponse: dberrors.ErrNotFound,
+		},
+		{
+			Name:        "Negative Test Updated Concurrently",
+			PartitionId: data.TestPartitionId,
+			ContractId:  data.TestContractId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(data.TestGetItemOutputContract, nil)
+					mockDBManager.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any()).Return(nil, &types.TransactionCanceledException{
+						CancellationReasons: []types.CancellationReason{{Code: aws.String("ConditionalCheckFailed"), Item: data.TestAttributeValuesContract}, {Code: aws.String("None")}},
+					})
+				},
+			},
+			expectedResponse: dberrors.ErrConflict,
 		},
 	}
 	// act
@@ -251,9 +332,10 @@ func Test_UpdateContract(t *testing.T) {
 			tc.Mock[idx]()
 		}
 		t.Run(tc.Name, func(t *testing.T) {
-			err := contractRepo.UpdateContract(tc.PartitionId, data.Contract)
+			_, err := contractRepo.UpdateContract(tc.PartitionId, data.ContractWithTariff, versioning.AnyVersion)
 			// assert
-			assert.Equal(t, tc.expectedResponse, err)
+			expectedErr, _ := tc.expectedResponse.(error)
+			assert.ErrorIs(t, err, expectedErr)
 		})
 	}
 }
@@ -282,7 +364,11 @@ func Test_DeleteContract(t *testing.T) {
 			ContractId:  data.TestContractId,
 			Mock: []func(){
 				func() {
-					mockDBManager.EXPECT().DeleteItem(gomock.Any(), gomock.Any()).Return(&dynamodb.DeleteItemOutput{}, nil)
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(data.TestGetItemOutputContractWithoutTariffs, nil)
+					expectTransaction(t, mockDBManager, []string{
+						"Delete " + ContractSortKeyPrefix + data.TestContractId,
+						"Delete " + ProviderContractSortKeyPrefix + data.TestProviderId + "#" + data.TestContractId,
+					}, nil)
 				},
 			},
 			expectedResponse: nil,
@@ -293,11 +379,30 @@ func Test_DeleteContract(t *testing.T) {
 			ContractId:  data.TestContractId,
 			Mock: []func(){
 				func() {
-					mockDBManager.EXPECT().DeleteItem(gomock.Any(), gomock.Any()).Return(&dynamodb.DeleteItemOutput{}, errors.New(constants.ResourceNotFound))
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(&dynamodb.GetItemOutput{}, errors.New(constants.ResourceNotFound))
 				},
 			},
 			expectedResponse: errors.New(constants.ResourceNotFound),
 		},
+		{
+			Name:        "Negative Test Changed Concurrently",
+			PartitionId: data.TestPartitionId,
+			ContractId:  data.TestContractId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(data.TestGetItemOutputContractWithoutTariffs, nil)
+					mockDBManager.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, input *dynamodb.TransactWriteItemsInput, _ ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+							// the contract is deleted only if its data is as it was read
+							assert.Equal(t, "Data", input.TransactItems[0].Delete.ExpressionAttributeNames["#2"])
+							return nil, &types.TransactionCanceledException{
+								CancellationReasons: []types.CancellationReason{{Code: aws.String("ConditionalCheckFailed"), Item: data.TestAttributeValuesContractWithoutTariffs}, {Code: aws.String("None")}},
+							}
+						})
+				},
+			},
+			expectedResponse: dberrors.ErrConflict,
+		},
 	}
 	// act
 	for _, tc := range testcases {
@@ -305,8 +410,12 @@ func Test_DeleteContract(t *testing.T) {
 			tc.Mock[idx]()
 		}
 		t.Run(tc.Name, func(t *testing.T) {
-			err := contractRepo.DeleteContract(tc.PartitionId, tc.ContractId)
+			err := contractRepo.DeleteContract(tc.PartitionId, tc.ContractId, versioning.AnyVersion)
 			// assert
+			if tc.expectedResponse == dberrors.ErrConflict {
+				assert.ErrorIs(t, err, dberrors.ErrConflict)
+				return
+			}
 			if err != nil {
 				assert.Contains(t, constants.ResourceNotFound, err.Error())
 			}
@@ -314,3 +423,135 @@ func Test_DeleteContract(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetContractsByTariff(t *testing.T) {
+	// arrange
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockDBManager := dbtesting.NewMockDynamoDBManager(mockController)
+	contractRepo := ContractRepo{
+		DBClient: DBClient{
+			DynamoDBClient: mockDBManager,
+			TableName:      "TestTableName",
+			PartitionKey:   "TestPartitionKey",
+			SortKey:        "TestSortKey",
+		},
+	}
+	mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, input *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+			assert.Contains(t, input.ExpressionAttributeValues, ":1")
+			assert.Equal(t, &types.AttributeValueMemberS{Value: TariffContractSortKeyPrefix + data.TestTariffId + "#"}, input.ExpressionAttributeValues[":1"])
+			return data.TestContractQueryOutputWithoutTariffs, nil
+		})
+
+	// act
+	page, err := contractRepo.GetContractsByTariff(data.TestPartitionId, data.TestTariffId, models.PageRequest{})
+
+	// assert
+	assert.Nil(t, err)
+	assert.Equal(t, &models.Page[models.Contract]{Items: data.Contracts}, page)
+}
+
+func Test_BackfillContractCopies(t *testing.T) {
+	// arrange
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockDBManager := dbtesting.NewMockDynamoDBManager(mockController)
+	contractRepo := ContractRepo{
+		DBClient: DBClient{
+			DynamoDBClient: mockDBManager,
+			TableName:      "TestTableName",
+			PartitionKey:   "TestPartitionKey",
+			SortKey:        "TestSortKey",
+		},
+	}
+	contractItem := func(contract models.Contract, version int) map[string]types.AttributeValue {
+		item, err := attributevalue.MarshalMap(DBEntity[models.Contract]{
+			PartitionKey: data.TestPartitionId,
+			SortKey:      ContractSortKeyPrefix + contract.Id,
+			Data:         contract,
+			Version:      version,
+		})
+		assert.Nil(t, err)
+		return item
+	}
+	changedContract := data.Contract
+	changedContract.Id = "changed"
+	unreferencingContract := models.Contract{Id: "unreferencing", Tariffs: []string{}}
+	lastEvaluatedKey := map[string]types.AttributeValue{"TestSortKey": &types.AttributeValueMemberS{Value: ContractSortKeyPrefix + data.TestContractId}}
+	gomock.InOrder(
+		mockDBManager.EXPECT().Scan(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, input *dynamodb.ScanInput, _ ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+				assert.Nil(t, input.ExclusiveStartKey)
+				assert.True(t, aws.ToBool(input.ConsistentRead))
+				assert.Equal(t, &types.AttributeValueMemberS{Value: ContractSortKeyPrefix}, input.ExpressionAttributeValues[":0"])
+				// a contract written before the copies existed has no version either
+				return &dynamodb.ScanOutput{Items: []map[string]types.AttributeValue{contractItem(data.ContractWithTariff, 0)}, LastEvaluatedKey: lastEvaluatedKey}, nil
+			}),
+		mockDBManager.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, input *dynamodb.TransactWriteItemsInput, _ ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+				assert.Equal(t, ContractSortKeyPrefix+data.TestContractId, input.TransactItems[0].ConditionCheck.Key["TestSortKey"].(*types.AttributeValueMemberS).Value)
+				assert.Equal(t, ProviderContractSortKeyPrefix+data.TestProviderId+"#"+data.TestContractId, input.TransactItems[1].Put.Item["Sort_Key"].(*types.AttributeValueMemberS).Value)
+				assert.Equal(t, TariffContractSortKeyPrefix+data.TestTariffId+"#"+data.TestContractId, input.TransactItems[2].Put.Item["Sort_Key"].(*types.AttributeValueMemberS).Value)
+				assert.Len(t, input.TransactItems, 3)
+				return &dynamodb.TransactWriteItemsOutput{}, nil
+			}),
+		mockDBManager.EXPECT().Scan(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, input *dynamodb.ScanInput, _ ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+				assert.Equal(t, lastEvaluatedKey, input.ExclusiveStartKey)
+				return &dynamodb.ScanOutput{Items: []map[string]types.AttributeValue{contractItem(changedContract, 1), contractItem(unreferencingContract, 1)}}, nil
+			}),
+		mockDBManager.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any()).Return(nil, &types.TransactionCanceledException{
+			CancellationReasons: []types.CancellationReason{{Code: aws.String("ConditionalCheckFailed")}, {Code: aws.String("None")}},
+		}),
+	)
+
+	// act
+	count, err := contractRepo.BackfillContractCopies()
+
+	// assert
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+}
+
+// expectReferringContractsQuery expects the query of the contracts themselves that refer to the entity by the
+// attribute, so that contracts without copies are found as well.
+func expectReferringContractsQuery(t *testing.T, input *dynamodb.QueryInput, attribute, entityId string) {
+	values := []types.AttributeValue{}
+	for _, value := range input.ExpressionAttributeValues {
+		values = append(values, value)
+	}
+	names := []string{}
+	for _, name := range input.ExpressionAttributeNames {
+		names = append(names, name)
+	}
+	assert.Contains(t, values, &types.AttributeValueMemberS{Value: ContractSortKeyPrefix})
+	assert.Contains(t, values, &types.AttributeValueMemberS{Value: entityId})
+	assert.Contains(t, names, strings.Split(attribute, ".")[1])
+	assert.NotNil(t, input.FilterExpression)
+}
+
+// expectTransaction expects a transaction of the actions, each an operation and the sort key of its item.
+func expectTransaction(t *testing.T, mockDBManager *dbtesting.MockDynamoDBManager, expectedActions []string, err error) {
+	mockDBManager.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, input *dynamodb.TransactWriteItemsInput, _ ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+			actions := []string{}
+			for _, item := range input.TransactItems {
+				switch {
+				case item.Put != nil:
+					actions = append(actions, "Put "+item.Put.Item["Sort_Key"].(*types.AttributeValueMemberS).Value)
+				case item.Update != nil:
+					actions = append(actions, "Update "+item.Update.Key["TestSortKey"].(*types.AttributeValueMemberS).Value)
+					assert.NotNil(t, item.Update.ConditionExpression)
+				case item.Delete != nil:
+					actions = append(actions, "Delete "+item.Delete.Key["TestSortKey"].(*types.AttributeValueMemberS).Value)
+				case item.ConditionCheck != nil:
+					actions = append(actions, "Check "+item.ConditionCheck.Key["TestSortKey"].(*types.AttributeValueMemberS).Value)
+				}
+			}
+			assert.Equal(t, expectedActions, actions)
+			return &dynamodb.TransactWriteItemsOutput{}, err
+		})
+}
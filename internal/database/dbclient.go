@@ -4,8 +4,14 @@ package database
 
 import (
 	"context"
+	"net"
 	"os"
-	"tariff-calculation-service/pkg/constants"
+	"slices"
+	"strings"
+	"tariff-calculation-service/internal/dberrors"
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/pagination"
+	"tariff-calculation-service/internal/versioning"
 
 	"errors"
 
@@ -15,6 +21,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
 )
 
 type DynamoDBManager interface {
@@ -23,7 +30,9 @@ type DynamoDBManager interface {
 	UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error)
 	DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error)
 	Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
+	Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error)
 	BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error)
+	TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error)
 }
 
 type DBClient struct {
@@ -49,6 +58,22 @@ func NewDBClient() DBClient {
 }
 
 func GetEntity[T any](dbClient DBClient, key map[string]types.AttributeValue) (*T, error) {
+	dbEntity, err := GetVersionedEntity[T](dbClient, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dbEntity.Data, nil
+}
+
+// GetVersionedEntity returns the stored entity with the key, including its version.
+func GetVersionedEntity[T any](dbClient DBClient, key map[string]types.AttributeValue) (*DBEntity[T], error) {
+	return getItem[DBEntity[T]](dbClient, key)
+}
+
+// getItem returns the item with the key, unmarshaled into an item type such as DBEntity. It returns
+// dberrors.ErrNotFound if the item does not exist.
+func getItem[E any](dbClient DBClient, key map[string]types.AttributeValue) (*E, error) {
 	input := &dynamodb.GetItemInput{
 		TableName: aws.String(dbClient.TableName),
 		Key:       key,
@@ -56,20 +81,20 @@ func GetEntity[T any](dbClient DBClient, key map[string]types.AttributeValue) (*
 
 	result, err := dbClient.DynamoDBClient.GetItem(context.TODO(), input)
 	if err != nil {
-		return nil, err
+		return nil, dbError(err)
 	}
 
 	if result.Item == nil || len(result.Item) == 0 {
-		return nil, errors.New(constants.ResourceNotFound) // todo create proper error
+		return nil, dberrors.ErrNotFound
 	}
 
-	dbEntity := DBEntity[T]{}
-	err = attributevalue.UnmarshalMap(result.Item, &dbEntity)
+	var item E
+	err = attributevalue.UnmarshalMap(result.Item, &item)
 	if err != nil {
 		return nil, err
 	}
 
-	return &dbEntity.Data, nil
+	return &item, nil
 }
 
 func PutEntity[T any](dbClient DBClient, entity T) error {
@@ -84,46 +109,230 @@ func PutEntity[T any](dbClient DBClient, entity T) error {
 	}
 
 	_, err = dbClient.DynamoDBClient.PutItem(context.TODO(), input)
-	return err
+	return dbError(err)
 }
 
-func UpdateEntity(dbClient DBClient, key map[string]types.AttributeValue, expr expression.Expression) error {
-	_, err := dbClient.DynamoDBClient.UpdateItem(context.TODO(), &dynamodb.UpdateItemInput{
+// CreateEntity puts the entity unless an item with its key exists. It returns dberrors.ErrConflict if one does,
+// so that an entity is never replaced.
+func CreateEntity[T any](dbClient DBClient, entity T) error {
+	value, err := attributevalue.MarshalMap(entity)
+	if err != nil {
+		return err
+	}
+	expr, err := expression.NewBuilder().WithCondition(dbClient.notExistsCondition()).Build()
+	if err != nil {
+		return err
+	}
+
+	_, err = dbClient.DynamoDBClient.PutItem(context.TODO(), &dynamodb.PutItemInput{
+		Item:                     value,
+		TableName:                &dbClient.TableName,
+		ExpressionAttributeNames: expr.Names(),
+		ConditionExpression:      expr.Condition(),
+	})
+	return dbError(err)
+}
+
+// UpdateEntity applies the update to the entity with the key. It returns dberrors.ErrNotFound if the
+// entity does not exist, instead of creating it.
+func UpdateEntity(dbClient DBClient, key map[string]types.AttributeValue, update expression.UpdateBuilder) error {
+	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(dbClient.existsCondition()).Build()
+	if err != nil {
+		return err
+	}
+
+	_, err = dbClient.DynamoDBClient.UpdateItem(context.TODO(), &dynamodb.UpdateItemInput{
 		TableName:                 &dbClient.TableName,
 		Key:                       key,
 		ExpressionAttributeNames:  expr.Names(),
 		ExpressionAttributeValues: expr.Values(),
 		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
 		ReturnValues:              types.ReturnValueNone,
 	})
-	if err != nil {
-		// todo properly log this
-		//log.Fatalf("failed to update entity")
+
+	return notFoundError(err)
+}
+
+// versionCondition requires the entity to exist with the version. Entities written before versioning have
+// no version attribute and match version 0.
+func (dbClient DBClient) versionCondition(version int) expression.ConditionBuilder {
+	exists := dbClient.existsCondition()
+	switch version {
+	case versioning.AnyVersion:
+		return exists
+	case 0:
+		return exists.And(expression.AttributeNotExists(expression.Name("Version")).Or(expression.Name("Version").Equal(expression.Value(0))))
+	default:
+		return exists.And(expression.Name("Version").Equal(expression.Value(version)))
+	}
+}
+
+// existsCondition requires the entity to exist, so that writes to a missing key do not create an item.
+func (dbClient DBClient) existsCondition() expression.ConditionBuilder {
+	return expression.AttributeExists(expression.Name(dbClient.PartitionKey))
+}
+
+// notExistsCondition requires the entity not to exist, so that a create never replaces an item.
+func (dbClient DBClient) notExistsCondition() expression.ConditionBuilder {
+	return expression.AttributeNotExists(expression.Name(dbClient.PartitionKey))
+}
+
+// notFoundError maps a failed existence condition to dberrors.ErrNotFound.
+func notFoundError(err error) error {
+	var conditionalCheckFailed *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionalCheckFailed) {
+		return dberrors.ErrNotFound
+	}
+	return dbError(err)
+}
+
+// dbError maps the errors of DynamoDB and of the connection to the kinds of dberrors.
+func dbError(err error) error {
+	var apiErr smithy.APIError
+	var netErr net.Error
+	switch {
+	case errors.As(err, &apiErr) && apiErrorKind(apiErr) != nil:
+		return dberrors.Wrap(apiErrorKind(apiErr), err)
+	case errors.As(err, &netErr):
+		return dberrors.Wrap(dberrors.ErrUnavailable, err)
+	default:
 		return err
 	}
+}
 
-	return nil
+// apiErrorKind returns the kind of a DynamoDB error by its code, or nil for other codes. See
+// https://docs.aws.amazon.com/amazondynamodb/latest/developerguide/Programming.Errors.html
+func apiErrorKind(err smithy.APIError) error {
+	switch err.ErrorCode() {
+	case "ConditionalCheckFailedException", "TransactionConflictException":
+		return dberrors.ErrConflict
+	case "ProvisionedThroughputExceededException", "RequestLimitExceeded", "ThrottlingException":
+		return dberrors.ErrThrottled
+	case "ValidationException", "ItemCollectionSizeLimitExceededException":
+		return dberrors.ErrValidation
+	case "InternalServerError", "ServiceUnavailable":
+		return dberrors.ErrUnavailable
+	default:
+		return nil
+	}
 }
 
-func DeleteEntity(dbClient DBClient, key map[string]types.AttributeValue) error {
-	input := &dynamodb.DeleteItemInput{
-		TableName: &dbClient.TableName,
-		Key:       key,
+// maxTransactionItems is the most items a DynamoDB transaction holds.
+const maxTransactionItems = 100
+
+// WriteTransaction writes the items all at once or not at all. It returns dberrors.ErrNotFound if the
+// condition of an item failed, as the conditions of the writes require the entity to exist, and
+// versioning.ErrVersionMismatch if the failed item returns the old item, as versioned writes do.
+func WriteTransaction(dbClient DBClient, items []types.TransactWriteItem) error {
+	return transactionError(transactWriteItems(dbClient, items))
+}
+
+// transactWriteItems writes the items in one transaction. It returns dberrors.ErrTooLarge without writing if a
+// transaction cannot hold them, which DynamoDB would reject as invalid.
+func transactWriteItems(dbClient DBClient, items []types.TransactWriteItem) error {
+	if len(items) > maxTransactionItems {
+		return dberrors.ErrTooLarge
+	}
+	_, err := dbClient.DynamoDBClient.TransactWriteItems(context.TODO(), &dynamodb.TransactWriteItemsInput{
+		TransactItems: items,
+	})
+
+	return err
+}
+
+// WriteChangeTransaction writes the items like WriteTransaction does, together with the changes of other entities,
+// whose conditions require them to be as they were read, or not to exist for the puts of audit records. It returns
+// dberrors.ErrConflict if the condition of a change canceled the transaction.
+func WriteChangeTransaction(dbClient DBClient, items, changes []types.TransactWriteItem) error {
+	err := transactWriteItems(dbClient, append(slices.Clone(items), changes...))
+	if changeCanceled(err, len(items)) {
+		return dberrors.Wrap(dberrors.ErrConflict, err)
+	}
+
+	return transactionError(err)
+}
+
+// changeCanceled tells whether the first item that canceled the transaction is a change, which follows the
+// items, whose condition failed.
+func changeCanceled(err error, itemCount int) bool {
+	var canceled *types.TransactionCanceledException
+	if !errors.As(err, &canceled) {
+		return false
+	}
+	for idx, reason := range canceled.CancellationReasons {
+		if code := aws.ToString(reason.Code); code != "" && code != "None" {
+			return idx >= itemCount && code == "ConditionalCheckFailed"
+		}
 	}
 
-	_, err := dbClient.DynamoDBClient.DeleteItem(context.TODO(), input)
+	return false
+}
+
+// CreateTransaction writes the items of a create all at once or not at all. The conditions of a create require its
+// items not to exist, so it returns dberrors.ErrConflict if a condition failed.
+func CreateTransaction(dbClient DBClient, items []types.TransactWriteItem) error {
+	err := WriteTransaction(dbClient, items)
+	if errors.Is(err, dberrors.ErrNotFound) || errors.Is(err, versioning.ErrVersionMismatch) {
+		return dberrors.ErrConflict
+	}
+
+	return err
+}
+
+// transactionError maps a canceled transaction to the kind of dberrors of the first item that canceled it.
+// See https://docs.aws.amazon.com/amazondynamodb/latest/APIReference/API_TransactWriteItems.html
+func transactionError(err error) error {
+	var canceled *types.TransactionCanceledException
+	if !errors.As(err, &canceled) {
+		return dbError(err)
+	}
+	for _, reason := range canceled.CancellationReasons {
+		switch aws.ToString(reason.Code) {
+		case "ConditionalCheckFailed":
+			if len(reason.Item) > 0 {
+				return versioning.ErrVersionMismatch
+			}
+			return dberrors.ErrNotFound
+		case "TransactionConflict":
+			return dberrors.Wrap(dberrors.ErrConflict, err)
+		case "ProvisionedThroughputExceeded", "ThrottlingError", "RequestLimitExceeded":
+			return dberrors.Wrap(dberrors.ErrThrottled, err)
+		case "ValidationError", "ItemCollectionSizeLimitExceeded":
+			return dberrors.Wrap(dberrors.ErrValidation, err)
+		}
+	}
 
 	return err
 }
 
-func QueryEntities[T any](dbClient DBClient, partitionKey, sortKey string) ([]DBEntity[T], error) {
-	keyEx := expression.Key(dbClient.PartitionKey).Equal(expression.Value(partitionKey)).And(expression.KeyBeginsWith(expression.Key(dbClient.SortKey), sortKey))
-	expr, err := expression.NewBuilder().WithKeyCondition(keyEx).Build()
+// DeleteEntity deletes the entity with the key. It returns dberrors.ErrNotFound if the entity does not
+// exist.
+func DeleteEntity(dbClient DBClient, key map[string]types.AttributeValue) error {
+	expr, err := expression.NewBuilder().WithCondition(dbClient.existsCondition()).Build()
+	if err != nil {
+		return err
+	}
+
+	_, err = dbClient.DynamoDBClient.DeleteItem(context.TODO(), &dynamodb.DeleteItemInput{
+		TableName:                 &dbClient.TableName,
+		Key:                       key,
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ConditionExpression:       expr.Condition(),
+	})
+
+	return notFoundError(err)
+}
+
+// QueryEntities returns the entities whose sort key begins with the prefix and that match all filters.
+func QueryEntities[T any](dbClient DBClient, partitionKey, sortKey string, filters ...expression.ConditionBuilder) ([]DBEntity[T], error) {
+	expr, err := dbClient.queryExpression(partitionKey, sortKey, filters)
 	if err != nil {
 		return nil, err
 	}
 
-	dbEntity, err := query[DBEntity[T]](dbClient, expr)
+	dbEntity, err := query[DBEntity[T]](dbClient, expr, 0, false)
 	if err != nil {
 		return nil, err
 	}
@@ -131,9 +340,174 @@ func QueryEntities[T any](dbClient DBClient, partitionKey, sortKey string) ([]DB
 	return dbEntity, nil
 }
 
-func query[T any](dbClient DBClient, expr expression.Expression) (queryResponse []T, err error) {
+// QueryEntitiesConsistent returns the entities like QueryEntities does, with strongly consistent reads, so that
+// the entities written before the query are not missed.
+func QueryEntitiesConsistent[T any](dbClient DBClient, partitionKey, sortKey string, filters ...expression.ConditionBuilder) ([]DBEntity[T], error) {
+	expr, err := dbClient.queryExpression(partitionKey, sortKey, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return query[DBEntity[T]](dbClient, expr, 0, true)
+}
+
+// QueryEntitiesUpTo returns the entities like QueryEntities does, but stops reading once more than maxItems
+// entities matched. It returns at most maxItems entities and reports whether more entities matched.
+func QueryEntitiesUpTo[T any](dbClient DBClient, partitionKey, sortKey string, maxItems int, filters ...expression.ConditionBuilder) ([]DBEntity[T], bool, error) {
+	expr, err := dbClient.queryExpression(partitionKey, sortKey, filters)
+	if err != nil {
+		return nil, false, err
+	}
+
+	dbEntity, err := query[DBEntity[T]](dbClient, expr, maxItems, false)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(dbEntity) > maxItems {
+		return dbEntity[:maxItems], true, nil
+	}
+
+	return dbEntity, false, nil
+}
+
+// QueryLatestEntity returns the entity with the greatest sort key that begins with the prefix and is at most
+// the upper bound, or nil if there is none. It reads a single item, since the query reads the sort keys backwards.
+func QueryLatestEntity[T any](dbClient DBClient, partitionKey, sortKeyPrefix, upTo string) (*DBEntity[T], error) {
+	sortKeyCondition := expression.Key(dbClient.SortKey).Between(expression.Value(sortKeyPrefix), expression.Value(upTo))
+	expr, err := dbClient.keyQueryExpression(partitionKey, sortKeyCondition, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := dbClient.DynamoDBClient.Query(context.TODO(), &dynamodb.QueryInput{
+		TableName:                 &dbClient.TableName,
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ScanIndexForward:          aws.Bool(false),
+		Limit:                     aws.Int32(1),
+	})
+	if err != nil {
+		return nil, dbError(err)
+	}
+	if len(response.Items) == 0 {
+		return nil, nil
+	}
+	var dbEntity DBEntity[T]
+	if err := attributevalue.UnmarshalMap(response.Items[0], &dbEntity); err != nil {
+		return nil, err
+	}
+
+	return &dbEntity, nil
+}
+
+// QueryEntitiesPage returns one page of the entities whose sort key begins with the prefix and that match all
+// filters, and the cursor of the next page. The cursor is the LastEvaluatedKey of the query. DynamoDB applies
+// the filters after reading a page, so a page may hold fewer entities than the limit.
+func QueryEntitiesPage[T any](dbClient DBClient, partitionKey, sortKey string, pageRequest models.PageRequest, filters ...expression.ConditionBuilder) ([]DBEntity[T], string, error) {
+	return QueryEntitiesRangePage[T](dbClient, partitionKey, sortKey, expression.KeyBeginsWith(expression.Key(dbClient.SortKey), sortKey), pageRequest, filters...)
+}
+
+// QueryEntitiesRangePage returns one page of the entities whose sort key matches the sort key condition and
+// that match all filters, like QueryEntitiesPage does. The sort keys of the condition must begin with the
+// prefix, which cursors are checked against.
+func QueryEntitiesRangePage[T any](dbClient DBClient, partitionKey, sortKeyPrefix string, sortKeyCondition expression.KeyConditionBuilder, pageRequest models.PageRequest, filters ...expression.ConditionBuilder) ([]DBEntity[T], string, error) {
+	exclusiveStartKey, err := dbClient.decodeCursor(partitionKey, sortKeyPrefix, pageRequest.Cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	expr, err := dbClient.keyQueryExpression(partitionKey, sortKeyCondition, filters)
+	if err != nil {
+		return nil, "", err
+	}
+
+	response, err := dbClient.DynamoDBClient.Query(context.TODO(), &dynamodb.QueryInput{
+		TableName:                 &dbClient.TableName,
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		FilterExpression:          expr.Filter(),
+		ExclusiveStartKey:         exclusiveStartKey,
+		Limit:                     aws.Int32(int32(pagination.Limit(pageRequest.Limit))),
+	})
+	if err != nil {
+		return nil, "", dbError(err)
+	}
+	dbEntities := []DBEntity[T]{}
+	if err := attributevalue.UnmarshalListOfMaps(response.Items, &dbEntities); err != nil {
+		return nil, "", err
+	}
+
+	return dbEntities, dbClient.encodeCursor(response.LastEvaluatedKey), nil
+}
+
+func (dbClient DBClient) queryExpression(partitionKey, sortKey string, filters []expression.ConditionBuilder) (expression.Expression, error) {
+	return dbClient.keyQueryExpression(partitionKey, expression.KeyBeginsWith(expression.Key(dbClient.SortKey), sortKey), filters)
+}
+
+func (dbClient DBClient) keyQueryExpression(partitionKey string, sortKeyCondition expression.KeyConditionBuilder, filters []expression.ConditionBuilder) (expression.Expression, error) {
+	keyEx := expression.Key(dbClient.PartitionKey).Equal(expression.Value(partitionKey)).And(sortKeyCondition)
+	builder := expression.NewBuilder().WithKeyCondition(keyEx)
+	if len(filters) > 0 {
+		filter := filters[0]
+		for _, condition := range filters[1:] {
+			filter = filter.And(condition)
+		}
+		builder = builder.WithFilter(filter)
+	}
+
+	return builder.Build()
+}
+
+func (dbClient DBClient) encodeCursor(lastEvaluatedKey map[string]types.AttributeValue) string {
+	if len(lastEvaluatedKey) == 0 {
+		return ""
+	}
+	key := map[string]string{}
+	for name, value := range lastEvaluatedKey {
+		if value, ok := value.(*types.AttributeValueMemberS); ok {
+			key[name] = value.Value
+		}
+	}
+	return pagination.EncodeCursor(key)
+}
+
+// decodeCursor returns the ExclusiveStartKey of a cursor. Cursors of other partitions or entity types are
+// rejected.
+func (dbClient DBClient) decodeCursor(partitionKey, sortKey, cursor string) (map[string]types.AttributeValue, error) {
+	key, err := pagination.DecodeCursor(cursor)
+	if err != nil || key == nil {
+		return nil, err
+	}
+	if len(key) != 2 || key[dbClient.PartitionKey] != partitionKey || !strings.HasPrefix(key[dbClient.SortKey], sortKey) {
+		return nil, pagination.ErrInvalidCursor
+	}
+
+	return map[string]types.AttributeValue{
+		dbClient.PartitionKey: &types.AttributeValueMemberS{Value: partitionKey},
+		dbClient.SortKey:      &types.AttributeValueMemberS{Value: key[dbClient.SortKey]},
+	}, nil
+}
+
+// query reads the pages of the query until more than maxItems items matched, or all pages if maxItems is 0.
+func query[T any](dbClient DBClient, expr expression.Expression, maxItems int, consistentRead bool) ([]T, error) {
+	items, err := queryItems(dbClient, expr, maxItems, consistentRead)
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+	var queryResponse []T
+	if err := attributevalue.UnmarshalListOfMaps(items, &queryResponse); err != nil {
+		return nil, err
+	}
+
+	return queryResponse, nil
+}
+
+// queryItems returns the items of the query like query does, without unmarshaling them, for queries whose items
+// have different types.
+func queryItems(dbClient DBClient, expr expression.Expression, maxItems int, consistentRead bool) (items []map[string]types.AttributeValue, err error) {
 	var response *dynamodb.QueryOutput
-	for response == nil || response.LastEvaluatedKey != nil {
+	for response == nil || response.LastEvaluatedKey != nil && (maxItems == 0 || len(items) <= maxItems) {
 		lastEvaluatedKey := map[string]types.AttributeValue{}
 		if response == nil {
 			lastEvaluatedKey = nil
@@ -145,19 +519,15 @@ func query[T any](dbClient DBClient, expr expression.Expression) (queryResponse
 			ExpressionAttributeNames:  expr.Names(),
 			ExpressionAttributeValues: expr.Values(),
 			KeyConditionExpression:    expr.KeyCondition(),
+			FilterExpression:          expr.Filter(),
 			ExclusiveStartKey:         lastEvaluatedKey,
+			ConsistentRead:            aws.Bool(consistentRead),
 		})
 		if err != nil {
-			return nil, err
+			return nil, dbError(err)
 		}
-		var queryResponsePage []T
-
-		err = attributevalue.UnmarshalListOfMaps(response.Items, &queryResponsePage)
-		if err != nil {
-			return nil, err
-		}
-		queryResponse = append(queryResponse, queryResponsePage...)
+		items = append(items, response.Items...)
 	}
 
-	return queryResponse, nil
+	return items, nil
 }
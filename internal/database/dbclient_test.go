@@ -1,16 +1,22 @@
 package database
 
 import (
+	"context"
 	"errors"
 	dbtesting "tariff-calculation-service/internal/database/testing"
+	"tariff-calculation-service/internal/dberrors"
 	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/pagination"
+	"tariff-calculation-service/internal/versioning"
 	"tariff-calculation-service/pkg/constants"
 	"tariff-calculation-service/test/data"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -281,7 +287,11 @@ func TestUnit_DeleteEntity(t *testing.T) {
 			Type: POSITIVE,
 			Mock: []func(){
 				func() {
-					mockDBManager.EXPECT().DeleteItem(gomock.Any(), gomock.Any()).Return(&dynamodb.DeleteItemOutput{}, nil)
+					mockDBManager.EXPECT().DeleteItem(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, input *dynamodb.DeleteItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+							assert.NotNil(t, input.ConditionExpression)
+							return &dynamodb.DeleteItemOutput{}, nil
+						})
 				},
 			},
 		},
@@ -294,6 +304,15 @@ func TestUnit_DeleteEntity(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "Negative Test Not Existing",
+			Type: NEGATIVE,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().DeleteItem(gomock.Any(), gomock.Any()).Return(nil, &types.ConditionalCheckFailedException{})
+				},
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -324,7 +343,6 @@ func TestUnit_UpdateEntity(t *testing.T) {
 	mockDBManager := dbtesting.NewMockDynamoDBManager(mockController)
 
 	update := expression.Set(expression.Name("TestPath"), expression.Value("TestData"))
-	expr, _ := expression.NewBuilder().WithUpdate(update).Build()
 
 	testDBClient := DBClient{
 		DynamoDBClient: mockDBManager,
@@ -339,7 +357,11 @@ func TestUnit_UpdateEntity(t *testing.T) {
 			Type: POSITIVE,
 			Mock: []func(){
 				func() {
-					mockDBManager.EXPECT().UpdateItem(gomock.Any(), gomock.Any()).Return(&dynamodb.UpdateItemOutput{}, nil)
+					mockDBManager.EXPECT().UpdateItem(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, input *dynamodb.UpdateItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+							assert.NotNil(t, input.ConditionExpression)
+							return &dynamodb.UpdateItemOutput{}, nil
+						})
 				},
 			},
 		},
@@ -352,6 +374,15 @@ func TestUnit_UpdateEntity(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "Negative Test Not Existing",
+			Type: NEGATIVE,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().UpdateItem(gomock.Any(), gomock.Any()).Return(nil, &types.ConditionalCheckFailedException{})
+				},
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -361,12 +392,12 @@ func TestUnit_UpdateEntity(t *testing.T) {
 			}
 			switch tc.Type {
 			case POSITIVE:
-				err := UpdateEntity(testDBClient, testKey, expr)
+				err := UpdateEntity(testDBClient, testKey, update)
 
 				//assert
 				assert.Nil(t, err)
 			case NEGATIVE:
-				err := UpdateEntity(testDBClient, testKey, expr)
+				err := UpdateEntity(testDBClient, testKey, update)
 
 				//assert
 				assert.NotNil(t, err)
@@ -530,3 +561,152 @@ func Test_QueryEntities_Negative(t *testing.T) {
 		})
 	}
 }
+
+func Test_QueryEntitiesPage(t *testing.T) {
+	//arrange
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+	mockDBManager := dbtesting.NewMockDynamoDBManager(mockController)
+
+	testDBClient := DBClient{
+		DynamoDBClient: mockDBManager,
+		TableName:      "TestTableName",
+		PartitionKey:   "partitionKey",
+		SortKey:        "sortKey",
+	}
+	nextCursor := pagination.EncodeCursor(map[string]string{"partitionKey": data.TestPartitionId, "sortKey": data.TestSortKey})
+
+	testcases := []struct {
+		name               string
+		pageRequest        models.PageRequest
+		mock               func()
+		expectedCount      int
+		expectedNextCursor string
+		expectedErr        error
+	}{
+		{
+			name:        "Positive Test First Page",
+			pageRequest: models.PageRequest{Limit: 1},
+			mock: func() {
+				mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, input *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+						assert.Equal(t, int32(1), *input.Limit)
+						assert.Nil(t, input.ExclusiveStartKey)
+						return data.TestContractQueryOutputPagination, nil
+					})
+			},
+			expectedCount:      1,
+			expectedNextCursor: nextCursor,
+		},
+		{
+			name:        "Positive Test Last Page",
+			pageRequest: models.PageRequest{Cursor: nextCursor},
+			mock: func() {
+				mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, input *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+						assert.Equal(t, int32(pagination.DefaultLimit), *input.Limit)
+						assert.Equal(t, data.TestContractQueryOutputPagination.LastEvaluatedKey, input.ExclusiveStartKey)
+						return data.TestContractQueryOutput, nil
+					})
+			},
+			expectedCount: 1,
+		},
+		{
+			name:        "Negative Test Cursor Of Other Partition",
+			pageRequest: models.PageRequest{Cursor: pagination.EncodeCursor(map[string]string{"partitionKey": data.TestIdInvalid, "sortKey": data.TestSortKey})},
+			mock:        func() {},
+			expectedErr: pagination.ErrInvalidCursor,
+		},
+		{
+			name:        "Negative Test Cursor Of Other Entity Type",
+			pageRequest: models.PageRequest{Cursor: pagination.EncodeCursor(map[string]string{"partitionKey": data.TestPartitionId, "sortKey": "tariff#"})},
+			mock:        func() {},
+			expectedErr: pagination.ErrInvalidCursor,
+		},
+		{
+			name:        "Negative Test Database Error",
+			pageRequest: models.PageRequest{},
+			mock: func() {
+				mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).Return(&dynamodb.QueryOutput{}, errors.New("DatabaseError"))
+			},
+			expectedErr: errors.New("DatabaseError"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+
+			//act
+			contracts, cursor, err := QueryEntitiesPage[models.Contract](testDBClient, data.TestPartitionId, data.TestSortKey, tc.pageRequest)
+
+			//assert
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Len(t, contracts, tc.expectedCount)
+			assert.Equal(t, tc.expectedNextCursor, cursor)
+		})
+	}
+}
+
+func Test_DbError(t *testing.T) {
+	// arrange
+	otherErr := errors.New("other")
+	testcases := []struct {
+		name         string
+		err          error
+		expectedKind error
+	}{
+		{"Positive Test Throughput Exceeded", &types.ProvisionedThroughputExceededException{}, dberrors.ErrThrottled},
+		{"Positive Test Throttling", &smithy.GenericAPIError{Code: "ThrottlingException"}, dberrors.ErrThrottled},
+		{"Positive Test Transaction Conflict", &types.TransactionConflictException{}, dberrors.ErrConflict},
+		{"Positive Test Validation", &smithy.GenericAPIError{Code: "ValidationException"}, dberrors.ErrValidation},
+		{"Positive Test Internal Server Error", &types.InternalServerError{}, dberrors.ErrUnavailable},
+		{"Positive Test Other Error", otherErr, otherErr},
+		{"Positive Test No Error", nil, nil},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := dbError(tc.err)
+
+			// assert
+			assert.ErrorIs(t, err, tc.expectedKind)
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func Test_TransactionError(t *testing.T) {
+	// arrange
+	canceled := func(codes ...string) error {
+		reasons := []types.CancellationReason{}
+		for _, code := range codes {
+			reasons = append(reasons, types.CancellationReason{Code: aws.String(code)})
+		}
+		return &types.TransactionCanceledException{CancellationReasons: reasons}
+	}
+	testcases := []struct {
+		name         string
+		err          error
+		expectedKind error
+	}{
+		{"Positive Test Condition Failed", canceled("None", "ConditionalCheckFailed"), dberrors.ErrNotFound},
+		{"Positive Test Version Condition Failed", &types.TransactionCanceledException{CancellationReasons: []types.CancellationReason{
+			{Code: aws.String("ConditionalCheckFailed"), Item: data.TestConditionalCheckFailedTariffVersion.Item},
+		}}, versioning.ErrVersionMismatch},
+		{"Positive Test Transaction Conflict", canceled("TransactionConflict", "None"), dberrors.ErrConflict},
+		{"Positive Test Throttled", canceled("None", "ThrottlingError"), dberrors.ErrThrottled},
+		{"Positive Test Validation", canceled("ValidationError"), dberrors.ErrValidation},
+		{"Positive Test Not Canceled", &types.InternalServerError{}, dberrors.ErrUnavailable},
+		{"Positive Test No Error", nil, nil},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := transactionError(tc.err)
+
+			// assert
+			assert.ErrorIs(t, err, tc.expectedKind)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"tariff-calculation-service/internal/fxrate"
+	"tariff-calculation-service/internal/models"
+)
+
+type FxRateRepo struct {
+	DBClient
+}
+
+func NewFxRateRepo() FxRateRepo {
+	return FxRateRepo{
+		DBClient: NewDBClient(),
+	}
+}
+
+func fxRatePairPrefix(baseCurrency, quoteCurrency string) string {
+	return FxRateSortKeyPrefix + baseCurrency + "#" + quoteCurrency + "#"
+}
+
+func (frr FxRateRepo) GetFxRates(partitionId string) (*[]models.FxRate, error) {
+	fxRates, err := frr.queryFxRates(partitionId, FxRateSortKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fxRates, nil
+}
+
+// GetFxRate returns the rate of the currency pair that is valid on the given date. The latest rate on or before
+// the date of the pair and of the reversed pair are read, the latter to fall back to the inverse rate.
+func (frr FxRateRepo) GetFxRate(partitionId, baseCurrency, quoteCurrency string, date time.Time) (*models.FxRate, error) {
+	day := date.UTC().Format(fxrate.DateLayout)
+	fxRates := []models.FxRate{}
+	for _, pairPrefix := range []string{fxRatePairPrefix(baseCurrency, quoteCurrency), fxRatePairPrefix(quoteCurrency, baseCurrency)} {
+		fxRateEntity, err := QueryLatestEntity[models.FxRate](frr.DBClient, partitionId, pairPrefix, pairPrefix+day)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query fx rates: %w", err)
+		}
+		if fxRateEntity != nil {
+			fxRates = append(fxRates, fxRateEntity.Data)
+		}
+	}
+
+	return fxrate.FindRate(fxRates, baseCurrency, quoteCurrency, date)
+}
+
+// PutFxRate creates the rate of the currency pair and date, or replaces it if it exists.
+func (frr FxRateRepo) PutFxRate(partitionId string, fxRate models.FxRate) (*models.FxRate, error) {
+	fxRateDB := DBEntity[models.FxRate]{
+		PartitionKey: partitionId,
+		SortKey:      fxRatePairPrefix(fxRate.BaseCurrency, fxRate.QuoteCurrency) + fxRate.Date,
+		Data:         fxRate,
+	}
+	err := PutEntity[DBEntity[models.FxRate]](frr.DBClient, fxRateDB)
+	if err != nil {
+		return &models.FxRate{}, err
+	}
+	return &fxRate, nil
+}
+
+func (frr FxRateRepo) queryFxRates(partitionId, sortKeyPrefix string) ([]models.FxRate, error) {
+	fxRateEntities, err := QueryEntities[models.FxRate](frr.DBClient, partitionId, sortKeyPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query fx rates: %w", err)
+	}
+	fxRates := []models.FxRate{}
+	for _, fxRate := range fxRateEntities {
+		fxRates = append(fxRates, fxRate.Data)
+	}
+
+	return fxRates, nil
+}
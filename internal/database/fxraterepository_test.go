@@ -0,0 +1,228 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	dbtesting "tariff-calculation-service/internal/database/testing"
+	"tariff-calculation-service/internal/fxrate"
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/constants"
+	"tariff-calculation-service/test/data"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type testcaseFxRateRepo struct {
+	Name             string
+	PartitionId      string
+	Mock             []func()
+	expectedResponse any
+	expectedError    error
+}
+
+func Test_GetFxRates(t *testing.T) {
+	// arrange
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockDBManager := dbtesting.NewMockDynamoDBManager(mockController)
+
+	testDBClient := DBClient{
+		DynamoDBClient: mockDBManager,
+		TableName:      "TestTableName",
+		PartitionKey:   "TestPartitionKey",
+		SortKey:        "TestSortKey",
+	}
+
+	fxRateRepo := FxRateRepo{
+		DBClient: testDBClient,
+	}
+
+	testcases := []testcaseFxRateRepo{
+		{
+			Name:        "Positive Test",
+			PartitionId: data.TestPartitionId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).Return(data.TestFxRateQueryOutput, nil)
+				},
+			},
+			expectedResponse: &[]models.FxRate{data.FxRate},
+		},
+		{
+			Name:        "Negative Test",
+			PartitionId: data.TestPartitionId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).Return(&dynamodb.QueryOutput{}, errors.New(constants.InternalServerError))
+				},
+			},
+			expectedResponse: (*[]models.FxRate)(nil),
+			expectedError:    fmt.Errorf("failed to query fx rates: %w", errors.New(constants.InternalServerError)),
+		},
+	}
+	// act
+	for _, tc := range testcases {
+		for idx := range tc.Mock {
+			tc.Mock[idx]()
+		}
+		t.Run(tc.Name, func(t *testing.T) {
+			actualFxRates, err := fxRateRepo.GetFxRates(tc.PartitionId)
+			// assert
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedResponse, actualFxRates)
+		})
+	}
+}
+
+func Test_GetFxRate(t *testing.T) {
+	// arrange
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockDBManager := dbtesting.NewMockDynamoDBManager(mockController)
+
+	testDBClient := DBClient{
+		DynamoDBClient: mockDBManager,
+		TableName:      "TestTableName",
+		PartitionKey:   "TestPartitionKey",
+		SortKey:        "TestSortKey",
+	}
+
+	fxRateRepo := FxRateRepo{
+		DBClient: testDBClient,
+	}
+
+	testcases := []testcaseFxRateRepo{
+		{
+			Name:        "Positive Test",
+			PartitionId: data.TestPartitionId,
+			Mock: []func(){
+				func() {
+					gomock.InOrder(
+						mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).DoAndReturn(latestFxRateQuery(t, "fxrate#GBP#EUR#", data.TestFxRateQueryOutput)),
+						mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).DoAndReturn(latestFxRateQuery(t, "fxrate#EUR#GBP#", &dynamodb.QueryOutput{})),
+					)
+				},
+			},
+			expectedResponse: &data.FxRate,
+		},
+		{
+			Name:        "Negative Test No Rate",
+			PartitionId: data.TestPartitionId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).Return(&dynamodb.QueryOutput{}, nil).Times(2)
+				},
+			},
+			expectedResponse: (*models.FxRate)(nil),
+			expectedError:    fxrate.ErrNoFxRate,
+		},
+		{
+			Name:        "Negative Test",
+			PartitionId: data.TestPartitionId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).Return(&dynamodb.QueryOutput{}, errors.New(constants.InternalServerError))
+				},
+			},
+			expectedResponse: (*models.FxRate)(nil),
+			expectedError:    fmt.Errorf("failed to query fx rates: %w", errors.New(constants.InternalServerError)),
+		},
+	}
+	// act
+	for _, tc := range testcases {
+		for idx := range tc.Mock {
+			tc.Mock[idx]()
+		}
+		t.Run(tc.Name, func(t *testing.T) {
+			date, _ := time.Parse(time.RFC3339, "2021-01-01T00:00:00Z")
+			actualFxRate, err := fxRateRepo.GetFxRate(tc.PartitionId, "GBP", "EUR", date)
+			// assert
+			if errors.Is(tc.expectedError, fxrate.ErrNoFxRate) {
+				assert.ErrorIs(t, err, fxrate.ErrNoFxRate)
+			} else {
+				assert.Equal(t, tc.expectedError, err)
+			}
+			assert.Equal(t, tc.expectedResponse, actualFxRate)
+		})
+	}
+}
+
+// latestFxRateQuery returns a mock of Query that asserts that the query reads the single latest rate of the pair
+// on or before 2021-01-01.
+func latestFxRateQuery(t *testing.T, pairPrefix string, output *dynamodb.QueryOutput) func(context.Context, *dynamodb.QueryInput, ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	return func(_ context.Context, input *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+		assert.Equal(t, int32(1), aws.ToInt32(input.Limit))
+		assert.False(t, aws.ToBool(input.ScanIndexForward))
+		assert.Contains(t, aws.ToString(input.KeyConditionExpression), "BETWEEN")
+		values := []string{}
+		for _, value := range input.ExpressionAttributeValues {
+			values = append(values, value.(*types.AttributeValueMemberS).Value)
+		}
+		assert.ElementsMatch(t, []string{data.TestPartitionId, pairPrefix, pairPrefix + "2021-01-01"}, values)
+		return output, nil
+	}
+}
+
+func Test_PutFxRate(t *testing.T) {
+	// arrange
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockDBManager := dbtesting.NewMockDynamoDBManager(mockController)
+
+	testDBClient := DBClient{
+		DynamoDBClient: mockDBManager,
+		TableName:      "TestTableName",
+		PartitionKey:   "TestPartitionKey",
+		SortKey:        "TestSortKey",
+	}
+
+	fxRateRepo := FxRateRepo{
+		DBClient: testDBClient,
+	}
+
+	testcases := []testcaseFxRateRepo{
+		{
+			Name:        "Positive Test",
+			PartitionId: data.TestPartitionId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().PutItem(gomock.Any(), gomock.Any()).Return(data.TestPutItemOutputFxRate, nil)
+				},
+			},
+			expectedResponse: &data.FxRate,
+		},
+		{
+			Name:        "Negative Test",
+			PartitionId: data.TestPartitionId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().PutItem(gomock.Any(), gomock.Any()).Return(&dynamodb.PutItemOutput{}, errors.New(constants.InternalServerError))
+				},
+			},
+			expectedResponse: &models.FxRate{},
+			expectedError:    errors.New(constants.InternalServerError),
+		},
+	}
+	// act
+	for _, tc := range testcases {
+		for idx := range tc.Mock {
+			tc.Mock[idx]()
+		}
+		t.Run(tc.Name, func(t *testing.T) {
+			actualFxRate, err := fxRateRepo.PutFxRate(tc.PartitionId, data.FxRate)
+			// assert
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedResponse, actualFxRate)
+		})
+	}
+}
@@ -1,10 +1,11 @@
 package database
 
 import (
-	"errors"
 	"fmt"
 	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/versioning"
 
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 )
@@ -29,7 +30,7 @@ func (pr ProviderRepo) GetKey(partitionId, providerId string) map[string]types.A
 func (pr ProviderRepo) GetProviders(partitionId string) (*[]models.Provider, error) {
 	providerEntities, err := QueryEntities[models.Provider](pr.DBClient, partitionId, ProviderSortKeyPrefix)
 	if err != nil {
-		return nil, errors.New("failed to query providers")
+		return nil, fmt.Errorf("failed to query providers: %w", err)
 	}
 	providers := []models.Provider{}
 
@@ -40,6 +41,20 @@ func (pr ProviderRepo) GetProviders(partitionId string) (*[]models.Provider, err
 	return &providers, nil
 }
 
+// GetProvidersPage returns one page of the providers of the partition.
+func (pr ProviderRepo) GetProvidersPage(partitionId string, pageRequest models.PageRequest) (*models.Page[models.Provider], error) {
+	providerEntities, nextCursor, err := QueryEntitiesPage[models.Provider](pr.DBClient, partitionId, ProviderSortKeyPrefix, pageRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query providers: %w", err)
+	}
+	page := models.Page[models.Provider]{Items: []models.Provider{}, NextCursor: nextCursor}
+	for _, provider := range providerEntities {
+		page.Items = append(page.Items, provider.Data)
+	}
+
+	return &page, nil
+}
+
 func (pr ProviderRepo) GetProvider(partitionId, providerId string) (*models.Provider, error) {
 	provider, err := GetEntity[models.Provider](pr.DBClient, pr.GetKey(partitionId, providerId))
 	if err != nil || provider == nil {
@@ -48,32 +63,94 @@ func (pr ProviderRepo) GetProvider(partitionId, providerId string) (*models.Prov
 	return provider, nil
 }
 
-func (pr ProviderRepo) CreateProvider(partitionId string, provider models.Provider) (*models.Provider, error) {
-	providerDB := DBEntity[models.Provider]{
+// GetProviderWithVersion returns the provider and its version.
+func (pr ProviderRepo) GetProviderWithVersion(partitionId, providerId string) (*models.Provider, int, error) {
+	provider, err := GetVersionedEntity[models.Provider](pr.DBClient, pr.GetKey(partitionId, providerId))
+	if err != nil {
+		return &models.Provider{}, 0, err
+	}
+
+	return &provider.Data, provider.Version, nil
+}
+
+// CreateProvider puts the provider together with its audit records. It returns dberrors.ErrConflict if the provider
+// exists.
+func (pr ProviderRepo) CreateProvider(partitionId string, provider models.Provider, records ...models.AuditRecord) (*models.Provider, error) {
+	providerItem, err := attributevalue.MarshalMap(DBEntity[models.Provider]{
 		PartitionKey: partitionId,
 		SortKey:      ProviderSortKeyPrefix + provider.Id,
 		Data:         provider,
+		Version:      versioning.InitialVersion,
+	})
+	if err != nil {
+		return &models.Provider{}, err
 	}
-	err := PutEntity[DBEntity[models.Provider]](pr.DBClient, providerDB)
+	expr, err := expression.NewBuilder().WithCondition(pr.notExistsCondition()).Build()
 	if err != nil {
 		return &models.Provider{}, err
 	}
+	auditItems, err := AuditRepo{DBClient: pr.DBClient}.appendItems(partitionId, records)
+	if err != nil {
+		return &models.Provider{}, err
+	}
+	items := []types.TransactWriteItem{{Put: &types.Put{
+		TableName:                &pr.TableName,
+		Item:                     providerItem,
+		ExpressionAttributeNames: expr.Names(),
+		ConditionExpression:      expr.Condition(),
+	}}}
+	if err := CreateTransaction(pr.DBClient, append(items, auditItems...)); err != nil {
+		return &models.Provider{}, err
+	}
+
 	return &provider, nil
 }
 
-func (pr ProviderRepo) UpdateProvider(partitionId string, provider models.Provider) error {
-	dbUpdate := expression.Set(expression.Name("Data"), expression.Value(pr))
-	expr, err := expression.NewBuilder().WithUpdate(dbUpdate).Build()
+// UpdateProvider replaces the provider if it has the expected version, puts its audit records in the same
+// transaction and returns the new version. The provider is read first to number the new version, so a concurrent
+// update fails with versioning.ErrVersionMismatch even if any version is expected.
+func (pr ProviderRepo) UpdateProvider(partitionId string, provider models.Provider, version int, records ...models.AuditRecord) (int, error) {
+	storedProvider, err := GetVersionedEntity[models.Provider](pr.DBClient, pr.GetKey(partitionId, provider.Id))
 	if err != nil {
-		return fmt.Errorf("error building expression %v", err)
+		return 0, err
+	}
+	if !versioning.Matches(storedProvider.Version, version) {
+		return 0, versioning.ErrVersionMismatch
 	}
-	err = UpdateEntity(pr.DBClient, pr.GetKey(partitionId, provider.Id), expr)
 
-	return err
-}
+	updatedVersion := storedProvider.Version + 1
+	update := expression.Set(expression.Name("Data"), expression.Value(provider)).
+		Set(expression.Name("Version"), expression.Value(updatedVersion))
+	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(pr.versionCondition(storedProvider.Version)).Build()
+	if err != nil {
+		return 0, err
+	}
+	auditItems, err := AuditRepo{DBClient: pr.DBClient}.appendItems(partitionId, records)
+	if err != nil {
+		return 0, err
+	}
+	items := []types.TransactWriteItem{{Update: &types.Update{
+		TableName:                 &pr.TableName,
+		Key:                       pr.GetKey(partitionId, provider.Id),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		// the old item tells a version mismatch from a missing entity
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+	}}}
+	if err := WriteChangeTransaction(pr.DBClient, items, auditItems); err != nil {
+		return 0, err
+	}
 
-func (pr ProviderRepo) DeleteProvider(partitionId, providerId string) error {
-	err := DeleteEntity(pr.DBClient, pr.GetKey(partitionId, providerId))
+	return updatedVersion, nil
+}
 
-	return err
+// DeleteProvider deletes the provider if it has the expected version, and writes the changes of the contracts that
+// referred to it and puts the audit records in the same transaction. It returns dberrors.ErrConflict if a contract
+// still refers to it after the changes. A transaction holds up to 100 items, so it returns dberrors.ErrTooLarge if the
+// changes and the records do not fit into one.
+func (pr ProviderRepo) DeleteProvider(partitionId, providerId string, version int, contracts []models.ContractChange, records ...models.AuditRecord) error {
+	return ContractRepo{DBClient: pr.DBClient}.deleteReferenced(partitionId, pr.GetKey(partitionId, providerId),
+		expression.Name("Data.Provider").Equal(expression.Value(providerId)), version, contracts, records)
 }
@@ -1,14 +1,22 @@
 package database
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"slices"
 	dbtesting "tariff-calculation-service/internal/database/testing"
+	"tariff-calculation-service/internal/dberrors"
 	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/versioning"
 	"tariff-calculation-service/pkg/constants"
 	"tariff-calculation-service/test/data"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -72,7 +80,7 @@ func Test_GetProviders(t *testing.T) {
 			actualProviders, err := providerRepo.GetProviders(tc.PartitionId)
 			// assert
 			if err != nil {
-				assert.Contains(t, "failed to query providers", err.Error())
+				assert.Contains(t, err.Error(), "failed to query providers")
 				assert.Nil(t, actualProviders)
 			} else {
 				assert.NotNil(t, actualProviders)
@@ -168,7 +176,10 @@ func Test_CreateProvider(t *testing.T) {
 			ProviderId:  data.TestProviderId,
 			Mock: []func(){
 				func() {
-					mockDBManager.EXPECT().PutItem(gomock.Any(), gomock.Any()).Return(data.TestPutItemOutputProvider, nil)
+					expectTransaction(t, mockDBManager, []string{
+						"Put provider#" + data.TestProviderId,
+						"Put " + auditEntity(data.TestPartitionId, data.AuditRecord).SortKey,
+					}, nil)
 				},
 			},
 			expectedResponse: &data.Provider,
@@ -179,7 +190,7 @@ func Test_CreateProvider(t *testing.T) {
 			ProviderId:  data.TestProviderId,
 			Mock: []func(){
 				func() {
-					mockDBManager.EXPECT().PutItem(gomock.Any(), gomock.Any()).Return(&dynamodb.PutItemOutput{}, errors.New(constants.InternalServerError))
+					mockDBManager.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any()).Return(nil, errors.New(constants.InternalServerError))
 				},
 			},
 			expectedResponse: &models.Provider{},
@@ -191,10 +202,10 @@ func Test_CreateProvider(t *testing.T) {
 			tc.Mock[idx]()
 		}
 		t.Run(tc.Name, func(t *testing.T) {
-			actualProvider, err := providerRepo.CreateProvider(tc.PartitionId, data.Provider)
+			actualProvider, err := providerRepo.CreateProvider(tc.PartitionId, data.Provider, data.AuditRecord)
 			// assert
 			if err != nil {
-				assert.Contains(t, constants.InternalServerError, err.Error())
+				assert.Contains(t, err.Error(), constants.InternalServerError)
 			} else {
 				assert.NotNil(t, actualProvider)
 			}
@@ -228,21 +239,42 @@ func Test_UpdateProvider(t *testing.T) {
 			ProviderId:  data.TestProviderId,
 			Mock: []func(){
 				func() {
-					mockDBManager.EXPECT().UpdateItem(gomock.Any(), gomock.Any()).Return(data.TestUpdateItemOutputProvider, nil)
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(data.TestGetItemOutputProviderInitialVersion, nil)
+					expectTransaction(t, mockDBManager, []string{
+						"Update provider#" + data.TestProviderId,
+						"Put " + auditEntity(data.TestPartitionId, data.AuditRecord).SortKey,
+					}, nil)
 				},
 			},
 			expectedResponse: nil,
 		},
 		{
-			Name:        "Negative Test",
+			Name:        "Negative Test Not Found",
+			PartitionId: data.TestPartitionId,
+			ProviderId:  data.TestProviderId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(&dynamodb.GetItemOutput{}, nil)
+				},
+			},
+			expectedResponse: dberrors.ErrNotFound,
+		},
+		{
+			Name:        "Negative Test Concurrent Update",
 			PartitionId: data.TestPartitionId,
 			ProviderId:  data.TestProviderId,
 			Mock: []func(){
 				func() {
-					mockDBManager.EXPECT().UpdateItem(gomock.Any(), gomock.Any()).Return(&dynamodb.UpdateItemOutput{}, errors.New(constants.ResourceNotFound))
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(data.TestGetItemOutputProviderInitialVersion, nil)
+					mockDBManager.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any()).Return(nil, &types.TransactionCanceledException{
+						CancellationReasons: []types.CancellationReason{
+							{Code: aws.String("ConditionalCheckFailed"), Item: data.TestGetItemOutputProvider.Item},
+							{Code: aws.String("None")},
+						},
+					})
 				},
 			},
-			expectedResponse: errors.New(constants.ResourceNotFound),
+			expectedResponse: versioning.ErrVersionMismatch,
 		},
 	}
 	// act
@@ -251,9 +283,13 @@ func Test_UpdateProvider(t *testing.T) {
 			tc.Mock[idx]()
 		}
 		t.Run(tc.Name, func(t *testing.T) {
-			err := providerRepo.UpdateProvider(tc.PartitionId, data.Provider)
+			_, err := providerRepo.UpdateProvider(tc.PartitionId, data.Provider, versioning.InitialVersion, data.AuditRecord)
 			// assert
-			assert.Equal(t, tc.expectedResponse, err)
+			if tc.expectedResponse == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.expectedResponse.(error))
+			}
 		})
 	}
 }
@@ -275,6 +311,7 @@ func Test_DeleteProvider(t *testing.T) {
 		DBClient: testDBClient,
 	}
 
+	errGetItem := errors.New(constants.ResourceNotFound)
 	testcases := []testcaseProviderRepo{
 		{
 			Name:        "Positive Test",
@@ -282,21 +319,169 @@ func Test_DeleteProvider(t *testing.T) {
 			ProviderId:  data.TestProviderId,
 			Mock: []func(){
 				func() {
-					mockDBManager.EXPECT().DeleteItem(gomock.Any(), gomock.Any()).Return(&dynamodb.DeleteItemOutput{}, nil)
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, input *dynamodb.GetItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+							assert.True(t, aws.ToBool(input.ConsistentRead))
+							return data.TestGetItemOutputProviderInitialVersion, nil
+						})
+					mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, input *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+							assert.True(t, aws.ToBool(input.ConsistentRead))
+							expectReferringContractsQuery(t, input, "Data.Provider", data.TestProviderId)
+							return &dynamodb.QueryOutput{}, nil
+						})
+					mockDBManager.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, input *dynamodb.TransactWriteItemsInput, _ ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+							assert.Len(t, input.TransactItems, 1)
+							assert.Equal(t, "provider#"+data.TestProviderId, input.TransactItems[0].Delete.Key["TestSortKey"].(*types.AttributeValueMemberS).Value)
+							assert.Equal(t, referenceVersionAttribute, input.TransactItems[0].Delete.ExpressionAttributeNames["#2"])
+							return &dynamodb.TransactWriteItemsOutput{}, nil
+						})
 				},
 			},
 			expectedResponse: nil,
 		},
+		{
+			Name:        "Negative Test Referenced",
+			PartitionId: data.TestPartitionId,
+			ProviderId:  data.TestProviderId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(data.TestGetItemOutputProviderInitialVersion, nil)
+					mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).Return(data.TestContractQueryOutput, nil)
+				},
+			},
+			expectedResponse: dberrors.ErrConflict,
+		},
+		{
+			Name:        "Negative Test Referenced Concurrently",
+			PartitionId: data.TestPartitionId,
+			ProviderId:  data.TestProviderId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(data.TestGetItemOutputProviderInitialVersion, nil).Times(2)
+					mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).Return(&dynamodb.QueryOutput{}, nil)
+					mockDBManager.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any()).Return(nil, &types.TransactionCanceledException{
+						CancellationReasons: []types.CancellationReason{{Code: aws.String("ConditionalCheckFailed"), Item: data.TestGetItemOutputProviderInitialVersion.Item}},
+					})
+				},
+			},
+			expectedResponse: dberrors.ErrConflict,
+		},
+		{
+			Name:        "Negative Test Version Mismatch",
+			PartitionId: data.TestPartitionId,
+			ProviderId:  data.TestProviderId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(data.TestGetItemOutputProvider, nil)
+				},
+			},
+			expectedResponse: versioning.ErrVersionMismatch,
+		},
+		{
+			Name:        "Negative Test Not Found",
+			PartitionId: data.TestPartitionId,
+			ProviderId:  data.TestProviderId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(&dynamodb.GetItemOutput{}, nil)
+				},
+			},
+			expectedResponse: dberrors.ErrNotFound,
+		},
 		{
 			Name:        "Negative Test",
 			PartitionId: data.TestPartitionId,
 			ProviderId:  data.TestProviderId,
 			Mock: []func(){
 				func() {
-					mockDBManager.EXPECT().DeleteItem(gomock.Any(), gomock.Any()).Return(&dynamodb.DeleteItemOutput{}, errors.New(constants.ResourceNotFound))
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(nil, errGetItem)
+				},
+			},
+			expectedResponse: errGetItem,
+		},
+	}
+	// act
+	for _, tc := range testcases {
+		for idx := range tc.Mock {
+			tc.Mock[idx]()
+		}
+		t.Run(tc.Name, func(t *testing.T) {
+			err := providerRepo.DeleteProvider(tc.PartitionId, tc.ProviderId, versioning.InitialVersion, nil)
+			// assert
+			expectedErr, _ := tc.expectedResponse.(error)
+			assert.ErrorIs(t, err, expectedErr)
+		})
+	}
+}
+
+func Test_DeleteProviderCascade(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockDBManager := dbtesting.NewMockDynamoDBManager(mockController)
+
+	providerRepo := ProviderRepo{
+		DBClient: DBClient{
+			DynamoDBClient: mockDBManager,
+			TableName:      "TestTableName",
+			PartitionKey:   "TestPartitionKey",
+			SortKey:        "TestSortKey",
+		},
+	}
+
+	testcases := []testcaseProviderRepo{
+		{
+			Name:        "Positive Test",
+			PartitionId: data.TestPartitionId,
+			ProviderId:  data.TestProviderId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(data.TestGetItemOutputProviderInitialVersion, nil)
+					mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).Return(data.TestContractQueryOutput, nil)
+					mockDBManager.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, input *dynamodb.TransactWriteItemsInput, _ ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+							// the provider, the contract and its copies for the provider and the tariff
+							assert.Len(t, input.TransactItems, 4)
+							assert.Equal(t, "provider#"+data.TestProviderId, input.TransactItems[0].Delete.Key["TestSortKey"].(*types.AttributeValueMemberS).Value)
+							assert.Equal(t, "contract#"+data.TestContractId, input.TransactItems[1].Delete.Key["TestSortKey"].(*types.AttributeValueMemberS).Value)
+							assert.NotNil(t, input.TransactItems[1].Delete.ConditionExpression)
+							return &dynamodb.TransactWriteItemsOutput{}, nil
+						})
+				},
+			},
+			expectedResponse: nil,
+		},
+		{
+			Name:        "Negative Test Contract Changed",
+			PartitionId: data.TestPartitionId,
+			ProviderId:  data.TestProviderId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(data.TestGetItemOutputProviderInitialVersion, nil)
+					mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).Return(data.TestContractQueryOutput, nil)
+					mockDBManager.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any()).Return(nil, &types.TransactionCanceledException{
+						CancellationReasons: []types.CancellationReason{{Code: aws.String("None")}, {Code: aws.String("ConditionalCheckFailed")}},
+					})
 				},
 			},
-			expectedResponse: errors.New(constants.ResourceNotFound),
+			expectedResponse: dberrors.ErrConflict,
+		},
+		{
+			Name:        "Negative Test Not Found",
+			PartitionId: data.TestPartitionId,
+			ProviderId:  data.TestProviderId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(data.TestGetItemOutputProviderInitialVersion, nil)
+					mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).Return(data.TestContractQueryOutput, nil)
+					mockDBManager.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any()).Return(nil, &types.TransactionCanceledException{
+						CancellationReasons: []types.CancellationReason{{Code: aws.String("ConditionalCheckFailed")}, {Code: aws.String("ConditionalCheckFailed")}},
+					})
+				},
+			},
+			expectedResponse: dberrors.ErrNotFound,
 		},
 	}
 	// act
@@ -305,9 +490,70 @@ func Test_DeleteProvider(t *testing.T) {
 			tc.Mock[idx]()
 		}
 		t.Run(tc.Name, func(t *testing.T) {
-			err := providerRepo.DeleteProvider(tc.PartitionId, tc.ProviderId)
+			err := providerRepo.DeleteProvider(tc.PartitionId, tc.ProviderId, versioning.InitialVersion, []models.ContractChange{{Before: data.ContractWithTariff}})
+			// assert
+			expectedErr, _ := tc.expectedResponse.(error)
+			assert.ErrorIs(t, err, expectedErr)
+		})
+	}
+}
+
+func Test_DeleteProviderCascadeLimit(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockDBManager := dbtesting.NewMockDynamoDBManager(mockController)
+
+	providerRepo := ProviderRepo{
+		DBClient: DBClient{
+			DynamoDBClient: mockDBManager,
+			TableName:      "TestTableName",
+			PartitionKey:   "TestPartitionKey",
+			SortKey:        "TestSortKey",
+		},
+	}
+	// the provider and each contract with its copy and its audit record fill a transaction
+	contractsOutput := &dynamodb.QueryOutput{}
+	changes := []models.ContractChange{}
+	records := []models.AuditRecord{}
+	for idx := 0; idx < 33; idx++ {
+		contract := data.Contract
+		contract.Id = fmt.Sprintf("contract-%d", idx)
+		item, err := attributevalue.MarshalMap(DBEntity[models.Contract]{PartitionKey: data.TestPartitionId, SortKey: ContractSortKeyPrefix + contract.Id, Data: contract})
+		assert.Nil(t, err)
+		contractsOutput.Items = append(contractsOutput.Items, item)
+		changes = append(changes, models.ContractChange{Before: contract})
+		record := data.AuditRecord
+		record.Id = contract.Id
+		records = append(records, record)
+	}
+
+	testcases := []struct {
+		name          string
+		records       []models.AuditRecord
+		expectedErr   error
+		expectedWrite bool
+	}{
+		{"Positive Test At The Limit", records, nil, true},
+		{"Negative Test Too Large", append(slices.Clone(records), data.AuditRecord), dberrors.ErrTooLarge, false},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(data.TestGetItemOutputProviderInitialVersion, nil)
+			mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).Return(contractsOutput, nil)
+			if tc.expectedWrite {
+				mockDBManager.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, input *dynamodb.TransactWriteItemsInput, _ ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+						assert.Len(t, input.TransactItems, maxTransactionItems)
+						return &dynamodb.TransactWriteItemsOutput{}, nil
+					})
+			}
+
+			// act
+			err := providerRepo.DeleteProvider(data.TestPartitionId, data.TestProviderId, versioning.InitialVersion, changes, tc.records...)
+
 			// assert
-			assert.Equal(t, tc.expectedResponse, err)
+			assert.ErrorIs(t, err, tc.expectedErr)
 		})
 	}
 }
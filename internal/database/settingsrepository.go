@@ -0,0 +1,49 @@
+package database
+
+import (
+	"errors"
+	"tariff-calculation-service/internal/dberrors"
+	"tariff-calculation-service/internal/models"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type SettingsRepo struct {
+	DBClient
+}
+
+func NewSettingsRepo() SettingsRepo {
+	return SettingsRepo{
+		DBClient: NewDBClient(),
+	}
+}
+
+func (sr SettingsRepo) GetKey(partitionId string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		sr.PartitionKey: &types.AttributeValueMemberS{Value: partitionId},
+		sr.SortKey:      &types.AttributeValueMemberS{Value: SettingsSortKey},
+	}
+}
+
+// GetSettings returns the settings of the partition, or the default settings if none are stored.
+func (sr SettingsRepo) GetSettings(partitionId string) (*models.Settings, error) {
+	settings, err := GetEntity[models.Settings](sr.DBClient, sr.GetKey(partitionId))
+	if errors.Is(err, dberrors.ErrNotFound) {
+		return &models.Settings{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+func (sr SettingsRepo) PutSettings(partitionId string, settings models.Settings) error {
+	settingsDB := DBEntity[models.Settings]{
+		PartitionKey: partitionId,
+		SortKey:      SettingsSortKey,
+		Data:         settings,
+	}
+
+	return PutEntity[DBEntity[models.Settings]](sr.DBClient, settingsDB)
+}
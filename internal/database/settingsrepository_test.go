@@ -0,0 +1,139 @@
+package database
+
+import (
+	"errors"
+	dbtesting "tariff-calculation-service/internal/database/testing"
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/constants"
+	"tariff-calculation-service/test/data"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type testcaseSettingsRepo struct {
+	Name             string
+	PartitionId      string
+	Mock             []func()
+	expectedResponse any
+	expectedError    error
+}
+
+func Test_GetSettings(t *testing.T) {
+	// arrange
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockDBManager := dbtesting.NewMockDynamoDBManager(mockController)
+
+	testDBClient := DBClient{
+		DynamoDBClient: mockDBManager,
+		TableName:      "TestTableName",
+		PartitionKey:   "TestPartitionKey",
+		SortKey:        "TestSortKey",
+	}
+
+	settingsRepo := SettingsRepo{
+		DBClient: testDBClient,
+	}
+
+	testcases := []testcaseSettingsRepo{
+		{
+			Name:        "Positive Test",
+			PartitionId: data.TestPartitionId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(data.TestGetItemOutputSettings, nil)
+				},
+			},
+			expectedResponse: &data.Settings,
+		},
+		{
+			Name:        "Positive Test Default Settings",
+			PartitionId: data.TestPartitionId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(&dynamodb.GetItemOutput{}, nil)
+				},
+			},
+			expectedResponse: &models.Settings{},
+		},
+		{
+			Name:        "Negative Test",
+			PartitionId: data.TestPartitionId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(&dynamodb.GetItemOutput{}, errors.New(constants.InternalServerError))
+				},
+			},
+			expectedResponse: (*models.Settings)(nil),
+			expectedError:    errors.New(constants.InternalServerError),
+		},
+	}
+	// act
+	for _, tc := range testcases {
+		for idx := range tc.Mock {
+			tc.Mock[idx]()
+		}
+		t.Run(tc.Name, func(t *testing.T) {
+			actualSettings, err := settingsRepo.GetSettings(tc.PartitionId)
+			// assert
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedResponse, actualSettings)
+		})
+	}
+}
+
+func Test_PutSettings(t *testing.T) {
+	// arrange
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockDBManager := dbtesting.NewMockDynamoDBManager(mockController)
+
+	testDBClient := DBClient{
+		DynamoDBClient: mockDBManager,
+		TableName:      "TestTableName",
+		PartitionKey:   "TestPartitionKey",
+		SortKey:        "TestSortKey",
+	}
+
+	settingsRepo := SettingsRepo{
+		DBClient: testDBClient,
+	}
+
+	testcases := []testcaseSettingsRepo{
+		{
+			Name:        "Positive Test",
+			PartitionId: data.TestPartitionId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().PutItem(gomock.Any(), gomock.Any()).Return(data.TestPutItemOutputSettings, nil)
+				},
+			},
+		},
+		{
+			Name:        "Negative Test",
+			PartitionId: data.TestPartitionId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().PutItem(gomock.Any(), gomock.Any()).Return(&dynamodb.PutItemOutput{}, errors.New(constants.InternalServerError))
+				},
+			},
+			expectedError: errors.New(constants.InternalServerError),
+		},
+	}
+	// act
+	for _, tc := range testcases {
+		for idx := range tc.Mock {
+			tc.Mock[idx]()
+		}
+		t.Run(tc.Name, func(t *testing.T) {
+			err := settingsRepo.PutSettings(tc.PartitionId, data.Settings)
+			// assert
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -1,10 +1,15 @@
 package database
 
 import (
-	"errors"
 	"fmt"
+	"strings"
 	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/pagination"
+	"tariff-calculation-service/internal/tariffquery"
+	"tariff-calculation-service/internal/versioning"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 )
@@ -13,6 +18,34 @@ type TariffRepo struct {
 	DBClient
 }
 
+const (
+	validFromAttribute = "Valid_From"
+	validToAttribute   = "Valid_To"
+)
+
+// tariffEntity is the item of a tariff. It keeps the validity of the tariff in UTC besides its data, so that filter
+// expressions compare the validity as strings, and the incarnation that keys its versions.
+type tariffEntity struct {
+	DBEntity[models.Tariff]
+	ValidFrom   string `dynamodbav:"Valid_From"`
+	ValidTo     string `dynamodbav:"Valid_To"`
+	Incarnation string `dynamodbav:"Incarnation,omitempty"`
+}
+
+func newTariffEntity(partitionId string, tariff models.Tariff, version int, incarnation string) tariffEntity {
+	return tariffEntity{
+		DBEntity: DBEntity[models.Tariff]{
+			PartitionKey: partitionId,
+			SortKey:      TariffSortKeyPrefix + tariff.Id,
+			Data:         tariff,
+			Version:      version,
+		},
+		ValidFrom:   tariffquery.UTC(tariff.ValidFrom),
+		ValidTo:     tariffquery.UTC(tariff.ValidTo),
+		Incarnation: incarnation,
+	}
+}
+
 func NewTariffRepo() TariffRepo {
 	return TariffRepo{
 		DBClient: NewDBClient(),
@@ -20,16 +53,20 @@ func NewTariffRepo() TariffRepo {
 }
 
 func (tr TariffRepo) GetKey(partitionId, tariffId string) map[string]types.AttributeValue {
+	return tr.getSortKey(partitionId, TariffSortKeyPrefix+tariffId)
+}
+
+func (tr TariffRepo) getSortKey(partitionId, sortKey string) map[string]types.AttributeValue {
 	return map[string]types.AttributeValue{
 		tr.PartitionKey: &types.AttributeValueMemberS{Value: partitionId},
-		tr.SortKey:      &types.AttributeValueMemberS{Value: TariffSortKeyPrefix + tariffId},
+		tr.SortKey:      &types.AttributeValueMemberS{Value: sortKey},
 	}
 }
 
 func (tr TariffRepo) GetTariffs(partitionId string) (*[]models.Tariff, error) {
-	tariffEntities, err := QueryEntities[models.Tariff](tr.DBClient, partitionId, TariffSortKeyPrefix)
+	tariffEntities, err := QueryEntities[models.Tariff](tr.DBClient, partitionId, TariffSortKeyPrefix, isTariff())
 	if err != nil {
-		return nil, errors.New("failed to query tariffs")
+		return nil, fmt.Errorf("failed to query tariffs: %w", err)
 	}
 	tariffs := []models.Tariff{}
 	for _, tariff := range tariffEntities {
@@ -39,6 +76,112 @@ func (tr TariffRepo) GetTariffs(partitionId string) (*[]models.Tariff, error) {
 	return &tariffs, nil
 }
 
+// GetTariffsPage returns one page of the tariffs of the partition that match the filter. Sorted tariffs are
+// read and sorted in memory, as DynamoDB only sorts by the sort key, so at most tariffquery.MaxSorted tariffs are
+// sorted. DynamoDB filters the items after it limited the query, and the versions of the tariffs share their sort
+// key prefix, so unsorted pages are read until they hold the limit of tariffs or the tariffs end.
+func (tr TariffRepo) GetTariffsPage(partitionId string, filter models.TariffFilter, pageRequest models.PageRequest) (*models.Page[models.Tariff], error) {
+	if filter.Sort != "" {
+		return tr.getSortedTariffsPage(partitionId, filter, pageRequest)
+	}
+	limit := pagination.Limit(pageRequest.Limit)
+	page := models.Page[models.Tariff]{Items: []models.Tariff{}}
+	queryRequest := models.PageRequest{Limit: limit, Cursor: pageRequest.Cursor}
+	for {
+		tariffEntities, nextCursor, err := QueryEntitiesPage[models.Tariff](tr.DBClient, partitionId, TariffSortKeyPrefix, queryRequest, tariffFilterConditions(filter)...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query tariffs: %w", err)
+		}
+		for idx, tariff := range tariffEntities {
+			if !tariffquery.MatchesValidity(tariff.Data, filter) {
+				continue
+			}
+			page.Items = append(page.Items, tariff.Data)
+			if len(page.Items) == limit {
+				// the next page starts after the last tariff of this one, which may be before the end of the query
+				if idx < len(tariffEntities)-1 || nextCursor != "" {
+					page.NextCursor = tr.encodeCursor(tr.getSortKey(partitionId, tariff.SortKey))
+				}
+				return &page, nil
+			}
+		}
+		if nextCursor == "" {
+			return &page, nil
+		}
+		queryRequest.Cursor = nextCursor
+	}
+}
+
+// getSortedTariffsPage returns one page of the sorted tariffs. It returns tariffquery.ErrTooManyToSort if more
+// than tariffquery.MaxSorted tariffs match the filter.
+func (tr TariffRepo) getSortedTariffsPage(partitionId string, filter models.TariffFilter, pageRequest models.PageRequest) (*models.Page[models.Tariff], error) {
+	tariffEntities, more, err := QueryEntitiesUpTo[models.Tariff](tr.DBClient, partitionId, TariffSortKeyPrefix, tariffquery.MaxSorted, tariffFilterConditions(filter)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tariffs: %w", err)
+	}
+	if more {
+		return nil, tariffquery.ErrTooManyToSort
+	}
+	tariffs := []models.Tariff{}
+	for _, tariff := range tariffEntities {
+		if tariffquery.MatchesValidity(tariff.Data, filter) {
+			tariffs = append(tariffs, tariff.Data)
+		}
+	}
+	tariffquery.Sort(tariffs, filter.Sort)
+	items, nextCursor, err := pagination.OffsetPage(tariffs, pageRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Page[models.Tariff]{Items: items, NextCursor: nextCursor}, nil
+}
+
+// tariffFilterConditions returns the criteria of the filter, which DynamoDB evaluates. The validity is compared in
+// UTC; tariffs written before their validity was kept in UTC pass it and are checked after the query.
+func tariffFilterConditions(filter models.TariffFilter) []expression.ConditionBuilder {
+	conditions := []expression.ConditionBuilder{isTariff()}
+	if filter.TariffType != nil {
+		conditions = append(conditions, expression.Name("Data.TariffType").Equal(expression.Value(*filter.TariffType)))
+	}
+	if filter.Currency != "" {
+		conditions = append(conditions, expression.Name("Data.Currency").Equal(expression.Value(filter.Currency)))
+	}
+	if filter.Name != "" {
+		conditions = append(conditions, expression.Name("Data.Name").BeginsWith(filter.Name))
+	}
+	if filter.ValidAt != "" || filter.ValidFrom != "" || filter.ValidTo != "" {
+		conditions = append(conditions, validityCondition(filter))
+	}
+
+	return conditions
+}
+
+// validityCondition selects the tariffs whose validity, which includes ValidFrom and excludes ValidTo, matches the
+// filter like tariffquery.MatchesValidity does.
+func validityCondition(filter models.TariffFilter) expression.ConditionBuilder {
+	validFrom, validTo := expression.Name(validFromAttribute), expression.Name(validToAttribute)
+	condition := expression.AttributeExists(validFrom)
+	if filter.ValidAt != "" {
+		at := tariffquery.UTC(filter.ValidAt)
+		condition = condition.And(validFrom.LessThanEqual(expression.Value(at)), validTo.GreaterThan(expression.Value(at)))
+	}
+	if filter.ValidFrom != "" {
+		condition = condition.And(validTo.GreaterThan(expression.Value(tariffquery.UTC(filter.ValidFrom))))
+	}
+	if filter.ValidTo != "" {
+		condition = condition.And(validFrom.LessThan(expression.Value(tariffquery.UTC(filter.ValidTo))))
+	}
+
+	return condition.Or(expression.AttributeNotExists(validFrom))
+}
+
+// isTariff excludes the versions of the tariffs, which share the sort key prefix of the tariffs. Only versions have
+// an EffectiveFrom.
+func isTariff() expression.ConditionBuilder {
+	return expression.AttributeNotExists(expression.Name("Data.EffectiveFrom"))
+}
+
 func (tr TariffRepo) GetTariff(partitionId, tariffId string) (*models.Tariff, error) {
 	tariff, err := GetEntity[models.Tariff](tr.DBClient, tr.GetKey(partitionId, tariffId))
 	if err != nil || tariff == nil {
@@ -48,32 +191,222 @@ func (tr TariffRepo) GetTariff(partitionId, tariffId string) (*models.Tariff, er
 	return tariff, nil
 }
 
-func (tr TariffRepo) CreateTariff(partitionId string, tariff models.Tariff) (*models.Tariff, error) {
-	tariffDB := DBEntity[models.Tariff]{
-		PartitionKey: partitionId,
-		SortKey:      TariffSortKeyPrefix + tariff.Id,
-		Data:         tariff,
+// GetTariffWithVersion returns the tariff and its version.
+func (tr TariffRepo) GetTariffWithVersion(partitionId, tariffId string) (*models.Tariff, int, error) {
+	tariff, err := GetVersionedEntity[models.Tariff](tr.DBClient, tr.GetKey(partitionId, tariffId))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return &tariff.Data, tariff.Version, nil
+}
+
+// GetTariffVersions returns the versions of the tariff, ordered by version. Only the versions of its incarnation are
+// queried, so the versions kept of a deleted tariff with the same id are not part of its history.
+func (tr TariffRepo) GetTariffVersions(partitionId, tariffId string) (*[]models.TariffVersion, error) {
+	tariff, err := tr.getTariffEntity(partitionId, tariffId)
+	if err != nil {
+		return nil, err
+	}
+	versionEntities, err := QueryEntities[models.TariffVersion](tr.DBClient, partitionId, versionSortKeyPrefix(tariffId, tariff.Incarnation))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tariff versions: %w", err)
+	}
+	versions := tariffHistory(*tariff, versionEntities)
+
+	return &versions, nil
+}
+
+// GetAllTariffVersions returns the versions of every tariff of the partition like GetTariffVersions does, ordered by
+// the id of the tariff. The tariffs and their versions share the sort key prefix, so they are read in one query.
+func (tr TariffRepo) GetAllTariffVersions(partitionId string) (*[][]models.TariffVersion, error) {
+	expr, err := tr.queryExpression(partitionId, TariffSortKeyPrefix, nil)
+	if err != nil {
+		return nil, err
 	}
-	err := PutEntity[DBEntity[models.Tariff]](tr.DBClient, tariffDB)
+	items, err := queryItems(tr.DBClient, expr, 0, false)
 	if err != nil {
+		return nil, fmt.Errorf("failed to query tariff versions: %w", err)
+	}
+	tariffs := []tariffEntity{}
+	versionEntities := map[string][]DBEntity[models.TariffVersion]{}
+	for _, item := range items {
+		entity := DBEntity[models.TariffVersion]{}
+		if err := attributevalue.UnmarshalMap(item, &entity); err != nil {
+			return nil, err
+		}
+		tariffId, _, isVersion := strings.Cut(strings.TrimPrefix(entity.SortKey, TariffSortKeyPrefix), TariffVersionSortKeyInfix)
+		if isVersion {
+			versionEntities[tariffId] = append(versionEntities[tariffId], entity)
+			continue
+		}
+		tariff := tariffEntity{}
+		if err := attributevalue.UnmarshalMap(item, &tariff); err != nil {
+			return nil, err
+		}
+		tariffs = append(tariffs, tariff)
+	}
+	allVersions := [][]models.TariffVersion{}
+	for _, tariff := range tariffs {
+		allVersions = append(allVersions, tariffHistory(tariff, versionEntities[tariff.Data.Id]))
+	}
+
+	return &allVersions, nil
+}
+
+// tariffHistory returns the versions of the tariff among the version items of its id, ordered by version. The
+// versions of other incarnations were kept of a deleted tariff with the same id and are skipped. Of a tariff created
+// before the incarnation was kept, the versions after its current one were left behind by such a tariff and are
+// skipped as well. A tariff written before its versions were kept has its current version only.
+func tariffHistory(tariff tariffEntity, versionEntities []DBEntity[models.TariffVersion]) []models.TariffVersion {
+	prefix := versionSortKeyPrefix(tariff.Data.Id, tariff.Incarnation)
+	versions := []models.TariffVersion{}
+	for _, version := range versionEntities {
+		// without an incarnation, the prefix also matches the versions of later incarnations
+		isIncarnation := strings.HasPrefix(version.SortKey, prefix) && !strings.Contains(strings.TrimPrefix(version.SortKey, prefix), "#")
+		if isIncarnation && (tariff.Incarnation != "" || version.Version <= tariff.Version) {
+			versions = append(versions, version.Data)
+		}
+	}
+	if len(versions) == 0 {
+		return []models.TariffVersion{{Version: tariff.Version, Tariff: tariff.Data}}
+	}
+
+	return versions
+}
+
+// CreateTariff puts the tariff in a new incarnation together with its first version and its audit records. It returns
+// dberrors.ErrConflict if the tariff exists.
+func (tr TariffRepo) CreateTariff(partitionId string, tariff models.Tariff, records ...models.AuditRecord) (*models.Tariff, error) {
+	incarnation := versioning.NewIncarnation()
+	tariffItem, err := attributevalue.MarshalMap(newTariffEntity(partitionId, tariff, versioning.InitialVersion, incarnation))
+	if err != nil {
+		return &models.Tariff{}, err
+	}
+	versionItem, err := tr.putVersion(partitionId, tariff, incarnation, versioning.InitialVersion)
+	if err != nil {
+		return &models.Tariff{}, err
+	}
+	expr, err := expression.NewBuilder().WithCondition(tr.notExistsCondition()).Build()
+	if err != nil {
+		return &models.Tariff{}, err
+	}
+	auditItems, err := AuditRepo{DBClient: tr.DBClient}.appendItems(partitionId, records)
+	if err != nil {
+		return &models.Tariff{}, err
+	}
+	items := []types.TransactWriteItem{{Put: &types.Put{
+		TableName:                &tr.TableName,
+		Item:                     tariffItem,
+		ExpressionAttributeNames: expr.Names(),
+		ConditionExpression:      expr.Condition(),
+	}}, versionItem}
+	if err := CreateTransaction(tr.DBClient, append(items, auditItems...)); err != nil {
 		return &models.Tariff{}, err
 	}
+
 	return &tariff, nil
 }
 
-func (tr TariffRepo) UpdateTariff(partitionId string, tariff models.Tariff) error {
-	dbUpdate := expression.Set(expression.Name("Data"), expression.Value(tariff))
-	expr, err := expression.NewBuilder().WithUpdate(dbUpdate).Build()
+// UpdateTariff replaces the tariff if it has the expected version and adds the new version, which it returns, with the
+// audit records in the same transaction. The tariff is read first to number the new version, so a concurrent update
+// fails with versioning.ErrVersionMismatch even if any version is expected. It returns dberrors.ErrConflict if the
+// new version exists, which only a tariff created before the incarnation was kept finds, if a deleted tariff with the
+// same id left the version behind.
+func (tr TariffRepo) UpdateTariff(partitionId string, tariff models.Tariff, version int, records ...models.AuditRecord) (int, error) {
+	storedTariff, err := tr.getTariffEntity(partitionId, tariff.Id)
 	if err != nil {
-		return fmt.Errorf("error building expression %v", err)
+		return 0, err
 	}
-	err = UpdateEntity(tr.DBClient, tr.GetKey(partitionId, tariff.Id), expr)
+	if !versioning.Matches(storedTariff.Version, version) {
+		return 0, versioning.ErrVersionMismatch
+	}
+
+	updatedVersion := storedTariff.Version + 1
+	update := expression.Set(expression.Name("Data"), expression.Value(tariff)).
+		Set(expression.Name("Version"), expression.Value(updatedVersion)).
+		Set(expression.Name(validFromAttribute), expression.Value(tariffquery.UTC(tariff.ValidFrom))).
+		Set(expression.Name(validToAttribute), expression.Value(tariffquery.UTC(tariff.ValidTo)))
+	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(tr.versionCondition(storedTariff.Version)).Build()
+	if err != nil {
+		return 0, err
+	}
+	versionItem, err := tr.putVersion(partitionId, tariff, storedTariff.Incarnation, updatedVersion)
+	if err != nil {
+		return 0, err
+	}
+	auditItems, err := AuditRepo{DBClient: tr.DBClient}.appendItems(partitionId, records)
+	if err != nil {
+		return 0, err
+	}
+	items := []types.TransactWriteItem{{Update: &types.Update{
+		TableName:                 &tr.TableName,
+		Key:                       tr.GetKey(partitionId, tariff.Id),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		// the old item tells a version mismatch from a missing entity
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+	}}}
+	changes := append([]types.TransactWriteItem{versionItem}, auditItems...)
+	if err := WriteChangeTransaction(tr.DBClient, items, changes); err != nil {
+		return 0, err
+	}
+
+	return updatedVersion, nil
+}
 
-	return err
+// DeleteTariff deletes the tariff if it has the expected version. Its versions are kept. The changes of the contracts
+// that referred to it and the audit records are written in the same transaction as the tariff. It returns
+// dberrors.ErrConflict if a contract still refers to the tariff after the changes, and dberrors.ErrTooLarge if the
+// changes and the records do not fit into a transaction, which holds up to 100 items.
+func (tr TariffRepo) DeleteTariff(partitionId, tariffId string, version int, contracts []models.ContractChange, records ...models.AuditRecord) error {
+	return ContractRepo{DBClient: tr.DBClient}.deleteReferenced(partitionId, tr.GetKey(partitionId, tariffId),
+		expression.Name("Data.Tariffs").Contains(tariffId), version, contracts, records)
 }
 
-func (tr TariffRepo) DeleteTariff(partitionId, tariffId string) error {
-	err := DeleteEntity(tr.DBClient, tr.GetKey(partitionId, tariffId))
+// putVersion returns the put of a version of the tariff in its incarnation, effective from now. Versions are
+// immutable, so the put requires the version not to exist.
+func (tr TariffRepo) putVersion(partitionId string, tariff models.Tariff, incarnation string, version int) (types.TransactWriteItem, error) {
+	item, err := attributevalue.MarshalMap(DBEntity[models.TariffVersion]{
+		PartitionKey: partitionId,
+		SortKey:      fmt.Sprintf("%s%010d", versionSortKeyPrefix(tariff.Id, incarnation), version),
+		Data: models.TariffVersion{
+			Version:       version,
+			EffectiveFrom: time.Now().UTC().Format(time.RFC3339),
+			Tariff:        tariff,
+		},
+		Version: version,
+	})
+	if err != nil {
+		return types.TransactWriteItem{}, err
+	}
+
+	expr, err := expression.NewBuilder().WithCondition(tr.notExistsCondition()).Build()
+	if err != nil {
+		return types.TransactWriteItem{}, err
+	}
+
+	return types.TransactWriteItem{Put: &types.Put{
+		TableName:                &tr.TableName,
+		Item:                     item,
+		ExpressionAttributeNames: expr.Names(),
+		ConditionExpression:      expr.Condition(),
+	}}, nil
+}
+
+// getTariffEntity returns the item of the tariff, including its version and its incarnation.
+func (tr TariffRepo) getTariffEntity(partitionId, tariffId string) (*tariffEntity, error) {
+	return getItem[tariffEntity](tr.DBClient, tr.GetKey(partitionId, tariffId))
+}
+
+// versionSortKeyPrefix returns the prefix of the sort keys of the versions of the tariff in the incarnation, which is
+// empty for tariffs created before the incarnation was kept.
+func versionSortKeyPrefix(tariffId, incarnation string) string {
+	if incarnation == "" {
+		return TariffSortKeyPrefix + tariffId + TariffVersionSortKeyInfix
+	}
 
-	return err
+	return TariffSortKeyPrefix + tariffId + TariffVersionSortKeyInfix + incarnation + "#"
 }
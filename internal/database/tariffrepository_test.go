@@ -1,14 +1,22 @@
 package database
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	dbtesting "tariff-calculation-service/internal/database/testing"
+	"tariff-calculation-service/internal/dberrors"
 	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/tariffquery"
+	"tariff-calculation-service/internal/versioning"
 	"tariff-calculation-service/pkg/constants"
 	"tariff-calculation-service/test/data"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -72,7 +80,7 @@ func Test_GetTariffs(t *testing.T) {
 			actualTariffs, err := tariffRepo.GetTariffs(tc.PartitionId)
 			// assert
 			if err != nil {
-				assert.Contains(t, "failed to query tariffs", err.Error())
+				assert.Contains(t, err.Error(), "failed to query tariffs")
 				assert.Nil(t, actualTariffs)
 			} else {
 				assert.NotNil(t, actualTariffs)
@@ -83,6 +91,138 @@ func Test_GetTariffs(t *testing.T) {
 	}
 }
 
+func Test_GetTariffsPage(t *testing.T) {
+	// arrange
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockDBManager := dbtesting.NewMockDynamoDBManager(mockController)
+
+	tariffRepo := TariffRepo{
+		DBClient: DBClient{
+			DynamoDBClient: mockDBManager,
+			TableName:      "TestTableName",
+			PartitionKey:   "TestPartitionKey",
+			SortKey:        "TestSortKey",
+		},
+	}
+	// the filter expression always excludes the tariff versions and compares the values of the criteria, besides
+	// the values of the key condition
+	expectQuery := func(criteria int) {
+		mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, input *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+				assert.Contains(t, *input.FilterExpression, "attribute_not_exists")
+				assert.Len(t, input.ExpressionAttributeValues, 2+criteria)
+				return data.TestGetQueryOutputTariff, nil
+			})
+	}
+	// the validity is compared with the values of the filter in UTC
+	expectValidityQuery := func(values ...string) {
+		mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, input *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+				names := []string{}
+				for _, name := range input.ExpressionAttributeNames {
+					names = append(names, name)
+				}
+				assert.Subset(t, names, []string{validFromAttribute, validToAttribute})
+				comparedValues := []string{}
+				for _, value := range input.ExpressionAttributeValues {
+					if value, ok := value.(*types.AttributeValueMemberS); ok {
+						comparedValues = append(comparedValues, value.Value)
+					}
+				}
+				assert.Subset(t, comparedValues, values)
+				return data.TestGetQueryOutputTariff, nil
+			})
+	}
+
+	testcases := []struct {
+		name          string
+		filter        models.TariffFilter
+		mock          func()
+		expectedItems []models.Tariff
+		expectedErr   error
+	}{
+		{
+			name:          "Positive Test Filter Expression",
+			filter:        models.TariffFilter{TariffType: data.TariffFilter.TariffType, Currency: data.TestCurrency, Name: "Test"},
+			mock:          func() { expectQuery(3) },
+			expectedItems: data.Tariffs,
+		},
+		{
+			name:          "Positive Test Validity Filter Expression",
+			filter:        models.TariffFilter{ValidAt: "2021-01-01T01:00:00+01:00", ValidTo: "2021-06-01T00:00:00Z"},
+			mock:          func() { expectValidityQuery("2021-01-01T00:00:00Z", "2021-06-01T00:00:00Z") },
+			expectedItems: data.Tariffs,
+		},
+		{
+			name:          "Positive Test Validity Checked After Query",
+			filter:        models.TariffFilter{ValidAt: data.TestValidTo},
+			mock:          func() { expectValidityQuery(data.TestValidTo) },
+			expectedItems: []models.Tariff{},
+		},
+		{
+			name:          "Positive Test Sorted",
+			filter:        models.TariffFilter{Sort: "name"},
+			mock:          func() { expectQuery(0) },
+			expectedItems: data.Tariffs,
+		},
+		{
+			name:   "Positive Test Page Of Versions",
+			filter: models.TariffFilter{},
+			mock: func() {
+				// the first query only evaluated versions, so the page is read on from where it stopped
+				mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).Return(&dynamodb.QueryOutput{
+					Items:            []map[string]types.AttributeValue{},
+					LastEvaluatedKey: tariffRepo.getSortKey(data.TestPartitionId, versionSortKeyPrefix(data.TestTariffId, data.TestTariffIncarnation)+"0000000001"),
+				}, nil)
+				mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, input *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+						assert.NotNil(t, input.ExclusiveStartKey)
+						return data.TestGetQueryOutputTariff, nil
+					})
+			},
+			expectedItems: data.Tariffs,
+		},
+		{
+			name:   "Negative Test Too Many To Sort",
+			filter: models.TariffFilter{Sort: "name"},
+			mock: func() {
+				items := []map[string]types.AttributeValue{}
+				for len(items) <= tariffquery.MaxSorted {
+					items = append(items, data.TestAttributeValuesTariff)
+				}
+				mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).Return(&dynamodb.QueryOutput{
+					Items:            items,
+					LastEvaluatedKey: tariffRepo.GetKey(data.TestPartitionId, data.TestTariffId),
+				}, nil)
+			},
+			expectedErr: tariffquery.ErrTooManyToSort,
+		},
+		{
+			name:   "Negative Test Throttled",
+			filter: models.TariffFilter{},
+			mock: func() {
+				mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).Return(nil, &types.ProvisionedThroughputExceededException{})
+			},
+			expectedErr: dberrors.ErrThrottled,
+		},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			page, err := tariffRepo.GetTariffsPage(data.TestPartitionId, tc.filter, models.PageRequest{})
+
+			// assert
+			assert.ErrorIs(t, err, tc.expectedErr)
+			if tc.expectedErr == nil {
+				assert.Equal(t, tc.expectedItems, page.Items)
+			}
+		})
+	}
+}
+
 func Test_GetTariff(t *testing.T) {
 	// arrange
 	mockController := gomock.NewController(t)
@@ -113,6 +253,17 @@ func Test_GetTariff(t *testing.T) {
 			},
 			expectedResponse: &data.Tariff,
 		},
+		{
+			Name:        "Positive Test Tiered Tariff",
+			PartitionId: data.TestPartitionId,
+			TariffId:    data.TestTariffId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(data.TestGetItemOutputTariffTiered, nil)
+				},
+			},
+			expectedResponse: &data.TariffTiered,
+		},
 		{
 			Name:        "Negative Test",
 			PartitionId: data.TestPartitionId,
@@ -167,7 +318,17 @@ func Test_CreateTariff(t *testing.T) {
 			TariffId:    data.TestTariffId,
 			Mock: []func(){
 				func() {
-					mockDBManager.EXPECT().PutItem(gomock.Any(), gomock.Any()).Return(data.TestPutItemOutputTariff, nil)
+					mockDBManager.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, input *dynamodb.TransactWriteItemsInput, _ ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+							// the version is put under the incarnation of the tariff and never replaces a version
+							assert.Len(t, input.TransactItems, 2)
+							incarnation := input.TransactItems[0].Put.Item["Incarnation"].(*types.AttributeValueMemberS).Value
+							assert.Equal(t, "tariff#"+data.TestTariffId, input.TransactItems[0].Put.Item["Sort_Key"].(*types.AttributeValueMemberS).Value)
+							assert.Equal(t, "tariff#"+data.TestTariffId+"#version#"+incarnation+"#0000000001",
+								input.TransactItems[1].Put.Item["Sort_Key"].(*types.AttributeValueMemberS).Value)
+							assert.Equal(t, "attribute_not_exists (#0)", *input.TransactItems[1].Put.ConditionExpression)
+							return &dynamodb.TransactWriteItemsOutput{}, nil
+						})
 				},
 			},
 			expectedResponse: &data.Tariff,
@@ -178,7 +339,7 @@ func Test_CreateTariff(t *testing.T) {
 			TariffId:    data.TestTariffId,
 			Mock: []func(){
 				func() {
-					mockDBManager.EXPECT().PutItem(gomock.Any(), gomock.Any()).Return(&dynamodb.PutItemOutput{}, errors.New(constants.InternalServerError))
+					mockDBManager.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any()).Return(nil, errors.New(constants.InternalServerError))
 				},
 			},
 			expectedResponse: &models.Tariff{},
@@ -226,18 +387,94 @@ func Test_UpdateTariff(t *testing.T) {
 			TariffId:    data.TestTariffId,
 			Mock: []func(){
 				func() {
-					mockDBManager.EXPECT().UpdateItem(gomock.Any(), gomock.Any()).Return(data.TestUpdateItemOutputTariff, nil)
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(data.TestGetItemOutputTariffInitialVersion, nil)
+					expectTransaction(t, mockDBManager, []string{
+						"Update tariff#" + data.TestTariffId,
+						"Put tariff#" + data.TestTariffId + "#version#" + data.TestTariffIncarnation + "#0000000002",
+					}, nil)
 				},
 			},
 			expectedResponse: nil,
 		},
+		{
+			Name:        "Positive Test Without Incarnation",
+			PartitionId: data.TestPartitionId,
+			TariffId:    data.TestTariffId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(data.TestGetItemOutputTariffWithoutIncarnation, nil)
+					expectTransaction(t, mockDBManager, []string{
+						"Update tariff#" + data.TestTariffId,
+						"Put tariff#" + data.TestTariffId + "#version#0000000002",
+					}, nil)
+				},
+			},
+			expectedResponse: nil,
+		},
+		{
+			Name:        "Negative Test Version Exists",
+			PartitionId: data.TestPartitionId,
+			TariffId:    data.TestTariffId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(data.TestGetItemOutputTariffWithoutIncarnation, nil)
+					mockDBManager.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any()).Return(nil, &types.TransactionCanceledException{
+						CancellationReasons: []types.CancellationReason{
+							{Code: aws.String("None")},
+							{Code: aws.String("ConditionalCheckFailed")},
+						},
+					})
+				},
+			},
+			expectedResponse: dberrors.ErrConflict,
+		},
+		{
+			Name:        "Negative Test Version Mismatch",
+			PartitionId: data.TestPartitionId,
+			TariffId:    data.TestTariffId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(data.TestGetItemOutputTariff, nil)
+				},
+			},
+			expectedResponse: versioning.ErrVersionMismatch,
+		},
+		{
+			Name:        "Negative Test Concurrent Update",
+			PartitionId: data.TestPartitionId,
+			TariffId:    data.TestTariffId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(data.TestGetItemOutputTariffInitialVersion, nil)
+					mockDBManager.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any()).Return(nil, &types.TransactionCanceledException{
+						CancellationReasons: []types.CancellationReason{
+							{Code: aws.String("ConditionalCheckFailed"), Item: data.TestConditionalCheckFailedTariffVersion.Item},
+							{Code: aws.String("None")},
+						},
+					})
+				},
+			},
+			expectedResponse: versioning.ErrVersionMismatch,
+		},
+		{
+			Name:        "Negative Test Not Found",
+			PartitionId: data.TestPartitionId,
+			TariffId:    data.TestTariffId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(&dynamodb.GetItemOutput{}, nil)
+				},
+			},
+			expectedResponse: dberrors.ErrNotFound,
+		},
 		{
 			Name:        "Negative Test",
 			PartitionId: data.TestPartitionId,
 			TariffId:    data.TestTariffId,
 			Mock: []func(){
 				func() {
-					mockDBManager.EXPECT().UpdateItem(gomock.Any(), gomock.Any()).Return(&dynamodb.UpdateItemOutput{}, errors.New(constants.ResourceNotFound))
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(data.TestGetItemOutputTariffInitialVersion, nil)
+					mockDBManager.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any()).Return(nil, errors.New(constants.ResourceNotFound))
 				},
 			},
 			expectedResponse: errors.New(constants.ResourceNotFound),
@@ -249,9 +486,14 @@ func Test_UpdateTariff(t *testing.T) {
 			tc.Mock[idx]()
 		}
 		t.Run(tc.Name, func(t *testing.T) {
-			err := tariffRepo.UpdateTariff(tc.PartitionId, data.Tariff)
+			version, err := tariffRepo.UpdateTariff(tc.PartitionId, data.Tariff, versioning.InitialVersion)
 			// assert
-			assert.Equal(t, tc.expectedResponse, err)
+			if expectedErr, _ := tc.expectedResponse.(error); !errors.Is(err, expectedErr) {
+				assert.Equal(t, expectedErr, err)
+			}
+			if err == nil {
+				assert.Equal(t, versioning.InitialVersion+1, version)
+			}
 		})
 	}
 }
@@ -280,18 +522,58 @@ func Test_DeleteTariff(t *testing.T) {
 			TariffId:    data.TestTariffId,
 			Mock: []func(){
 				func() {
-					mockDBManager.EXPECT().DeleteItem(gomock.Any(), gomock.Any()).Return(&dynamodb.DeleteItemOutput{}, nil)
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(data.TestGetItemOutputTariffInitialVersion, nil)
+					mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, input *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+							expectReferringContractsQuery(t, input, "Data.Tariffs", data.TestTariffId)
+							return &dynamodb.QueryOutput{}, nil
+						})
+					mockDBManager.EXPECT().TransactWriteItems(gomock.Any(), gomock.Any()).Return(&dynamodb.TransactWriteItemsOutput{}, nil)
 				},
 			},
 			expectedResponse: nil,
 		},
+		{
+			Name:        "Negative Test Version Mismatch",
+			PartitionId: data.TestPartitionId,
+			TariffId:    data.TestTariffId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(data.TestGetItemOutputTariff, nil)
+				},
+			},
+			expectedResponse: versioning.ErrVersionMismatch,
+		},
+		{
+			Name:        "Negative Test Referenced",
+			PartitionId: data.TestPartitionId,
+			TariffId:    data.TestTariffId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(data.TestGetItemOutputTariffInitialVersion, nil)
+					mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).Return(data.TestContractQueryOutput, nil)
+				},
+			},
+			expectedResponse: dberrors.ErrConflict,
+		},
+		{
+			Name:        "Negative Test Not Found",
+			PartitionId: data.TestPartitionId,
+			TariffId:    data.TestTariffId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(&dynamodb.GetItemOutput{}, nil)
+				},
+			},
+			expectedResponse: dberrors.ErrNotFound,
+		},
 		{
 			Name:        "Negative Test",
 			PartitionId: data.TestPartitionId,
 			TariffId:    data.TestTariffId,
 			Mock: []func(){
 				func() {
-					mockDBManager.EXPECT().DeleteItem(gomock.Any(), gomock.Any()).Return(&dynamodb.DeleteItemOutput{}, errors.New(constants.ResourceNotFound))
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(nil, errors.New(constants.ResourceNotFound))
 				},
 			},
 			expectedResponse: errors.New(constants.ResourceNotFound),
@@ -303,12 +585,189 @@ func Test_DeleteTariff(t *testing.T) {
 			tc.Mock[idx]()
 		}
 		t.Run(tc.Name, func(t *testing.T) {
-			err := tariffRepo.DeleteTariff(tc.PartitionId, tc.TariffId)
+			err := tariffRepo.DeleteTariff(tc.PartitionId, tc.TariffId, versioning.InitialVersion, nil)
 			// assert
-			if err != nil {
-				assert.Contains(t, constants.ResourceNotFound, err.Error())
-			}
 			assert.Equal(t, tc.expectedResponse, err)
 		})
 	}
 }
+
+func Test_GetTariffVersions(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockDBManager := dbtesting.NewMockDynamoDBManager(mockController)
+
+	tariffRepo := TariffRepo{
+		DBClient: DBClient{
+			DynamoDBClient: mockDBManager,
+			TableName:      "TestTableName",
+			PartitionKey:   "TestPartitionKey",
+			SortKey:        "TestSortKey",
+		},
+	}
+
+	testcases := []testcaseTariffRepo{
+		{
+			Name:        "Positive Test",
+			PartitionId: data.TestPartitionId,
+			TariffId:    data.TestTariffId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(data.TestGetItemOutputTariffInitialVersion, nil)
+					mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, input *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+							// only the versions of the incarnation of the tariff are queried
+							assert.Contains(t, input.ExpressionAttributeValues, ":1")
+							assert.Equal(t, &types.AttributeValueMemberS{Value: "tariff#" + data.TestTariffId + "#version#" + data.TestTariffIncarnation + "#"},
+								input.ExpressionAttributeValues[":1"])
+							return testTariffVersionsQueryOutput(t, data.TestTariffIncarnation, versioning.InitialVersion), nil
+						})
+				},
+			},
+			expectedResponse: &[]models.TariffVersion{{Version: versioning.InitialVersion, EffectiveFrom: data.TestValidFrom, Tariff: data.Tariff}},
+		},
+		{
+			Name:        "Positive Test Without Incarnation",
+			PartitionId: data.TestPartitionId,
+			TariffId:    data.TestTariffId,
+			Mock: []func(){
+				func() {
+					// a deleted tariff with the same id left the second version behind, and a later incarnation its own
+					output := testTariffVersionsQueryOutput(t, "", versioning.InitialVersion+1)
+					output.Items = append(output.Items, testTariffVersionsQueryOutput(t, data.TestTariffIncarnation, versioning.InitialVersion).Items...)
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(data.TestGetItemOutputTariffWithoutIncarnation, nil)
+					mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).Return(output, nil)
+				},
+			},
+			expectedResponse: &[]models.TariffVersion{{Version: versioning.InitialVersion, EffectiveFrom: data.TestValidFrom, Tariff: data.Tariff}},
+		},
+		{
+			Name:        "Positive Test Tariff Without Versions",
+			PartitionId: data.TestPartitionId,
+			TariffId:    data.TestTariffId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(data.TestGetItemOutputTariff, nil)
+					mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).Return(&dynamodb.QueryOutput{}, nil)
+				},
+			},
+			expectedResponse: &[]models.TariffVersion{{Version: 0, Tariff: data.Tariff}},
+		},
+		{
+			Name:        "Negative Test Not Found",
+			PartitionId: data.TestPartitionId,
+			TariffId:    data.TestTariffId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(&dynamodb.GetItemOutput{}, nil)
+				},
+			},
+			expectedResponse: dberrors.ErrNotFound,
+		},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			for idx := range tc.Mock {
+				tc.Mock[idx]()
+			}
+			versions, err := tariffRepo.GetTariffVersions(tc.PartitionId, tc.TariffId)
+			// assert
+			if expectedErr, ok := tc.expectedResponse.(error); ok {
+				assert.ErrorIs(t, err, expectedErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResponse, versions)
+		})
+	}
+}
+
+func Test_GetAllTariffVersions(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockDBManager := dbtesting.NewMockDynamoDBManager(mockController)
+
+	tariffRepo := TariffRepo{
+		DBClient: DBClient{
+			DynamoDBClient: mockDBManager,
+			TableName:      "TestTableName",
+			PartitionKey:   "TestPartitionKey",
+			SortKey:        "TestSortKey",
+		},
+	}
+	// the tariff has a version of its own and one kept of a deleted tariff with the same id, the gas tariff was
+	// written before its versions were kept
+	tariffItem, err := attributevalue.MarshalMap(newTariffEntity(data.TestPartitionId, data.Tariff, versioning.InitialVersion, data.TestTariffIncarnation))
+	assert.Nil(t, err)
+	gasTariffItem, err := attributevalue.MarshalMap(newTariffEntity(data.TestPartitionId, data.TariffGas, 0, ""))
+	assert.Nil(t, err)
+	keptVersions := testTariffVersionsQueryOutput(t, "2019-01-01T00:00:00.000000000Z", versioning.InitialVersion).Items
+	ownVersions := testTariffVersionsQueryOutput(t, data.TestTariffIncarnation, versioning.InitialVersion).Items
+	items := append(append(append([]map[string]types.AttributeValue{tariffItem}, keptVersions...), ownVersions...), gasTariffItem)
+
+	testcases := []struct {
+		name             string
+		mock             func()
+		expectedResponse *[][]models.TariffVersion
+		expectedErr      error
+	}{
+		{
+			name: "Positive Test",
+			mock: func() {
+				mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, input *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+						// the tariffs and their versions are read in one query
+						assert.Nil(t, input.FilterExpression)
+						assert.Equal(t, &types.AttributeValueMemberS{Value: "tariff#"}, input.ExpressionAttributeValues[":1"])
+						return &dynamodb.QueryOutput{Items: items}, nil
+					})
+			},
+			expectedResponse: &[][]models.TariffVersion{
+				{{Version: versioning.InitialVersion, EffectiveFrom: data.TestValidFrom, Tariff: data.Tariff}},
+				{{Version: 0, Tariff: data.TariffGas}},
+			},
+		},
+		{
+			name: "Negative Test",
+			mock: func() {
+				mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).Return(nil, errors.New(constants.InternalServerError))
+			},
+			expectedErr: errors.New(constants.InternalServerError),
+		},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			allVersions, err := tariffRepo.GetAllTariffVersions(data.TestPartitionId)
+			// assert
+			if tc.expectedErr != nil {
+				assert.ErrorContains(t, err, tc.expectedErr.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedResponse, allVersions)
+		})
+	}
+}
+
+// testTariffVersionsQueryOutput returns the versions of the test tariff in the incarnation from the first up to the
+// last.
+func testTariffVersionsQueryOutput(t *testing.T, incarnation string, last int) *dynamodb.QueryOutput {
+	output := &dynamodb.QueryOutput{}
+	for version := versioning.InitialVersion; version <= last; version++ {
+		item, err := attributevalue.MarshalMap(DBEntity[models.TariffVersion]{
+			PartitionKey: data.TestPartitionId,
+			SortKey:      fmt.Sprintf("%s%010d", versionSortKeyPrefix(data.TestTariffId, incarnation), version),
+			Data:         models.TariffVersion{Version: version, EffectiveFrom: data.TestValidFrom, Tariff: data.Tariff},
+			Version:      version,
+		})
+		assert.Nil(t, err)
+		output.Items = append(output.Items, item)
+	}
+
+	return output
+}
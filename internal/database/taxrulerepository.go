@@ -0,0 +1,73 @@
+package database
+
+import (
+	"fmt"
+	"tariff-calculation-service/internal/models"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type TaxRuleRepo struct {
+	DBClient
+}
+
+func NewTaxRuleRepo() TaxRuleRepo {
+	return TaxRuleRepo{
+		DBClient: NewDBClient(),
+	}
+}
+
+func (trr TaxRuleRepo) GetKey(partitionId, taxRuleId string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		trr.PartitionKey: &types.AttributeValueMemberS{Value: partitionId},
+		trr.SortKey:      &types.AttributeValueMemberS{Value: TaxRuleSortKeyPrefix + taxRuleId},
+	}
+}
+
+func (trr TaxRuleRepo) GetTaxRules(partitionId string) (*[]models.TaxRule, error) {
+	taxRuleEntities, err := QueryEntities[models.TaxRule](trr.DBClient, partitionId, TaxRuleSortKeyPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tax rules: %w", err)
+	}
+	taxRules := []models.TaxRule{}
+	for _, taxRule := range taxRuleEntities {
+		taxRules = append(taxRules, taxRule.Data)
+	}
+
+	return &taxRules, nil
+}
+
+func (trr TaxRuleRepo) GetTaxRule(partitionId, taxRuleId string) (*models.TaxRule, error) {
+	taxRule, err := GetEntity[models.TaxRule](trr.DBClient, trr.GetKey(partitionId, taxRuleId))
+	if err != nil || taxRule == nil {
+		return &models.TaxRule{}, err
+	}
+
+	return taxRule, nil
+}
+
+func (trr TaxRuleRepo) CreateTaxRule(partitionId string, taxRule models.TaxRule) (*models.TaxRule, error) {
+	taxRuleDB := DBEntity[models.TaxRule]{
+		PartitionKey: partitionId,
+		SortKey:      TaxRuleSortKeyPrefix + taxRule.Id,
+		Data:         taxRule,
+	}
+	err := CreateEntity[DBEntity[models.TaxRule]](trr.DBClient, taxRuleDB)
+	if err != nil {
+		return &models.TaxRule{}, err
+	}
+	return &taxRule, nil
+}
+
+func (trr TaxRuleRepo) UpdateTaxRule(partitionId string, taxRule models.TaxRule) error {
+	dbUpdate := expression.Set(expression.Name("Data"), expression.Value(taxRule))
+
+	return UpdateEntity(trr.DBClient, trr.GetKey(partitionId, taxRule.Id), dbUpdate)
+}
+
+func (trr TaxRuleRepo) DeleteTaxRule(partitionId, taxRuleId string) error {
+	err := DeleteEntity(trr.DBClient, trr.GetKey(partitionId, taxRuleId))
+
+	return err
+}
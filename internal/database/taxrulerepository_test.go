@@ -0,0 +1,314 @@
+package database
+
+import (
+	"errors"
+	dbtesting "tariff-calculation-service/internal/database/testing"
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/constants"
+	"tariff-calculation-service/test/data"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type testcaseTaxRuleRepo struct {
+	Name             string
+	PartitionId      string
+	TaxRuleId        string
+	Mock             []func()
+	expectedResponse any
+}
+
+func Test_GetTaxRules(t *testing.T) {
+	// arrange
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockDBManager := dbtesting.NewMockDynamoDBManager(mockController)
+
+	testDBClient := DBClient{
+		DynamoDBClient: mockDBManager,
+		TableName:      "TestTableName",
+		PartitionKey:   "TestPartitionKey",
+		SortKey:        "TestSortKey",
+	}
+
+	taxRuleRepo := TaxRuleRepo{
+		DBClient: testDBClient,
+	}
+
+	testcases := []testcaseTaxRuleRepo{
+		{
+			Name:        "Positive Test",
+			PartitionId: data.TestPartitionId,
+			TaxRuleId:   data.TestTaxRuleId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).Return(data.TestTaxRuleQueryOutput, nil)
+				},
+			},
+			expectedResponse: &[]models.TaxRule{data.TaxRule},
+		},
+		{
+			Name:        "Negative Test",
+			PartitionId: data.TestPartitionId,
+			TaxRuleId:   data.TestTaxRuleId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().Query(gomock.Any(), gomock.Any()).Return(&dynamodb.QueryOutput{}, errors.New(constants.ResourceNotFound))
+				},
+			},
+			expectedResponse: &models.TaxRule{},
+		},
+	}
+	// act
+	for _, tc := range testcases {
+		for idx := range tc.Mock {
+			tc.Mock[idx]()
+		}
+		t.Run(tc.Name, func(t *testing.T) {
+			actualTaxRules, err := taxRuleRepo.GetTaxRules(tc.PartitionId)
+			// assert
+			if err != nil {
+				assert.Contains(t, err.Error(), "failed to query tax rules")
+				assert.Nil(t, actualTaxRules)
+			} else {
+				assert.NotNil(t, actualTaxRules)
+				assert.GreaterOrEqual(t, 1, len(*actualTaxRules))
+				assert.Equal(t, tc.expectedResponse, actualTaxRules)
+			}
+		})
+	}
+}
+
+func Test_GetTaxRule(t *testing.T) {
+	// arrange
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockDBManager := dbtesting.NewMockDynamoDBManager(mockController)
+
+	testDBClient := DBClient{
+		DynamoDBClient: mockDBManager,
+		TableName:      "TestTableName",
+		PartitionKey:   "TestPartitionKey",
+		SortKey:        "TestSortKey",
+	}
+
+	taxRuleRepo := TaxRuleRepo{
+		DBClient: testDBClient,
+	}
+
+	testcases := []testcaseTaxRuleRepo{
+		{
+			Name:        "Positive Test",
+			PartitionId: data.TestPartitionId,
+			TaxRuleId:   data.TestTaxRuleId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(data.TestGetItemOutputTaxRule, nil)
+				},
+			},
+			expectedResponse: &data.TaxRule,
+		},
+		{
+			Name:        "Negative Test",
+			PartitionId: data.TestPartitionId,
+			TaxRuleId:   data.TestTaxRuleId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(&dynamodb.GetItemOutput{}, errors.New(constants.ResourceNotFound))
+				},
+			},
+			expectedResponse: &models.TaxRule{},
+		},
+	}
+	// act
+	for _, tc := range testcases {
+		for idx := range tc.Mock {
+			tc.Mock[idx]()
+		}
+		t.Run(tc.Name, func(t *testing.T) {
+			actualTaxRule, err := taxRuleRepo.GetTaxRule(tc.PartitionId, tc.TaxRuleId)
+			// assert
+			if err != nil {
+				assert.Contains(t, constants.ResourceNotFound, err.Error())
+			} else {
+				assert.NotNil(t, actualTaxRule)
+			}
+			assert.Equal(t, tc.expectedResponse, actualTaxRule)
+		})
+	}
+}
+
+func Test_CreateTaxRule(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockDBManager := dbtesting.NewMockDynamoDBManager(mockController)
+
+	testDBClient := DBClient{
+		DynamoDBClient: mockDBManager,
+		TableName:      "TestTableName",
+		PartitionKey:   "TestPartitionKey",
+		SortKey:        "TestSortKey",
+	}
+
+	taxRuleRepo := TaxRuleRepo{
+		DBClient: testDBClient,
+	}
+
+	testcases := []testcaseTaxRuleRepo{
+		{
+			Name:        "Positive Test",
+			PartitionId: data.TestPartitionId,
+			TaxRuleId:   data.TestTaxRuleId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().PutItem(gomock.Any(), gomock.Any()).Return(data.TestPutItemOutputTaxRule, nil)
+				},
+			},
+			expectedResponse: &data.TaxRule,
+		},
+		{
+			Name:        "Negative Test",
+			PartitionId: data.TestPartitionId,
+			TaxRuleId:   data.TestTaxRuleId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().PutItem(gomock.Any(), gomock.Any()).Return(&dynamodb.PutItemOutput{}, errors.New(constants.InternalServerError))
+				},
+			},
+			expectedResponse: &models.TaxRule{},
+		},
+	}
+	// act
+	for _, tc := range testcases {
+		for idx := range tc.Mock {
+			tc.Mock[idx]()
+		}
+		t.Run(tc.Name, func(t *testing.T) {
+			actualTaxRulePtr, err := taxRuleRepo.CreateTaxRule(tc.PartitionId, data.TaxRule)
+			// assert
+			if err != nil {
+				assert.Contains(t, constants.InternalServerError, err.Error())
+			} else {
+				assert.NotNil(t, actualTaxRulePtr)
+			}
+			assert.Equal(t, tc.expectedResponse, actualTaxRulePtr)
+		})
+	}
+}
+
+func Test_UpdateTaxRule(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockDBManager := dbtesting.NewMockDynamoDBManager(mockController)
+
+	testDBClient := DBClient{
+		DynamoDBClient: mockDBManager,
+		TableName:      "TestTableName",
+		PartitionKey:   "TestPartitionKey",
+		SortKey:        "TestSortKey",
+	}
+
+	taxRuleRepo := TaxRuleRepo{
+		DBClient: testDBClient,
+	}
+
+	testcases := []testcaseTaxRuleRepo{
+		{
+			Name:        "Positive Test",
+			PartitionId: data.TestPartitionId,
+			TaxRuleId:   data.TestTaxRuleId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().UpdateItem(gomock.Any(), gomock.Any()).Return(data.TestUpdateItemOutputTaxRule, nil)
+				},
+			},
+			expectedResponse: nil,
+		},
+		{
+			Name:        "Negative Test",
+			PartitionId: data.TestPartitionId,
+			TaxRuleId:   data.TestTaxRuleId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().UpdateItem(gomock.Any(), gomock.Any()).Return(&dynamodb.UpdateItemOutput{}, errors.New(constants.ResourceNotFound))
+				},
+			},
+			expectedResponse: errors.New(constants.ResourceNotFound),
+		},
+	}
+	// act
+	for _, tc := range testcases {
+		for idx := range tc.Mock {
+			tc.Mock[idx]()
+		}
+		t.Run(tc.Name, func(t *testing.T) {
+			err := taxRuleRepo.UpdateTaxRule(tc.PartitionId, data.TaxRule)
+			// assert
+			assert.Equal(t, tc.expectedResponse, err)
+		})
+	}
+}
+
+func Test_DeleteTaxRule(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockDBManager := dbtesting.NewMockDynamoDBManager(mockController)
+
+	testDBClient := DBClient{
+		DynamoDBClient: mockDBManager,
+		TableName:      "TestTableName",
+		PartitionKey:   "TestPartitionKey",
+		SortKey:        "TestSortKey",
+	}
+
+	taxRuleRepo := TaxRuleRepo{
+		DBClient: testDBClient,
+	}
+
+	testcases := []testcaseTaxRuleRepo{
+		{
+			Name:        "Positive Test",
+			PartitionId: data.TestPartitionId,
+			TaxRuleId:   data.TestTaxRuleId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().DeleteItem(gomock.Any(), gomock.Any()).Return(&dynamodb.DeleteItemOutput{}, nil)
+				},
+			},
+			expectedResponse: nil,
+		},
+		{
+			Name:        "Negative Test",
+			PartitionId: data.TestPartitionId,
+			TaxRuleId:   data.TestTaxRuleId,
+			Mock: []func(){
+				func() {
+					mockDBManager.EXPECT().DeleteItem(gomock.Any(), gomock.Any()).Return(&dynamodb.DeleteItemOutput{}, errors.New(constants.ResourceNotFound))
+				},
+			},
+			expectedResponse: errors.New(constants.ResourceNotFound),
+		},
+	}
+	// act
+	for _, tc := range testcases {
+		for idx := range tc.Mock {
+			tc.Mock[idx]()
+		}
+		t.Run(tc.Name, func(t *testing.T) {
+			err := taxRuleRepo.DeleteTaxRule(tc.PartitionId, tc.TaxRuleId)
+			// assert
+			if err != nil {
+				assert.Contains(t, constants.ResourceNotFound, err.Error())
+			}
+			assert.Equal(t, tc.expectedResponse, err)
+		})
+	}
+}
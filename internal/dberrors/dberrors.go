@@ -0,0 +1,46 @@
+package dberrors
+
+import (
+	"errors"
+
+	"tariff-calculation-service/pkg/constants"
+)
+
+// The kinds of failures of the storage backends. Errors of the repositories match one of them with errors.Is,
+// so that the handlers never depend on the errors of a backend. ErrInvalidRequest is a query the backend cannot
+// serve as requested, e.g. with an invalid cursor; ErrValidation is data the backend rejected, which the
+// handlers should have rejected before. ErrTooLarge is a write of more items than the backend writes at once, e.g.
+// a cascade over too many contracts.
+var (
+	ErrNotFound           = errors.New(constants.ResourceNotFound)
+	ErrConflict           = errors.New(constants.Conflict)
+	ErrPreconditionFailed = errors.New(constants.PreconditionFailed)
+	ErrInvalidRequest     = errors.New(constants.BadRequest)
+	ErrValidation         = errors.New(constants.ValidationFailed)
+	ErrThrottled          = errors.New(constants.Throttled)
+	ErrUnavailable        = errors.New(constants.Unavailable)
+	ErrTooLarge           = errors.New(constants.TooLarge)
+)
+
+// Error is a failure of a storage backend. It matches its kind and its cause with errors.Is and errors.As,
+// and reads as its cause.
+type Error struct {
+	Kind  error
+	Cause error
+}
+
+// Wrap returns the cause as an error of the kind, or nil if there is no cause.
+func Wrap(kind, cause error) error {
+	if cause == nil {
+		return nil
+	}
+	return &Error{Kind: kind, Cause: cause}
+}
+
+func (err *Error) Error() string {
+	return err.Cause.Error()
+}
+
+func (err *Error) Unwrap() []error {
+	return []error{err.Kind, err.Cause}
+}
@@ -0,0 +1,26 @@
+package dberrors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Wrap(t *testing.T) {
+	// arrange
+	cause := errors.New("throughput exceeded")
+
+	// act
+	err := Wrap(ErrThrottled, cause)
+	var dbErr *Error
+
+	// assert
+	assert.ErrorIs(t, err, ErrThrottled)
+	assert.ErrorIs(t, err, cause)
+	assert.NotErrorIs(t, err, ErrUnavailable)
+	assert.ErrorAs(t, err, &dbErr)
+	assert.Equal(t, ErrThrottled, dbErr.Kind)
+	assert.EqualError(t, err, "throughput exceeded")
+	assert.Nil(t, Wrap(ErrThrottled, nil))
+}
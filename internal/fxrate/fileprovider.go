@@ -0,0 +1,41 @@
+package fxrate
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"tariff-calculation-service/internal/models"
+)
+
+// FileProvider serves the exchange rates of a JSON file holding a list of rates. The file is read on first
+// use and its rates apply to every partition.
+type FileProvider struct {
+	path  string
+	once  sync.Once
+	rates []models.FxRate
+	err   error
+}
+
+func NewFileProvider(path string) *FileProvider {
+	return &FileProvider{path: path}
+}
+
+func (provider *FileProvider) GetFxRate(partitionId, baseCurrency, quoteCurrency string, date time.Time) (*models.FxRate, error) {
+	provider.once.Do(provider.load)
+	if provider.err != nil {
+		return nil, provider.err
+	}
+
+	return FindRate(provider.rates, baseCurrency, quoteCurrency, date)
+}
+
+func (provider *FileProvider) load() {
+	content, err := os.ReadFile(provider.path)
+	if err != nil {
+		provider.err = err
+		return
+	}
+	provider.err = json.Unmarshal(content, &provider.rates)
+}
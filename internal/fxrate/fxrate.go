@@ -0,0 +1,52 @@
+package fxrate
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/money"
+)
+
+const DateLayout = "2006-01-02"
+
+var ErrNoFxRate = errors.New("no exchange rate available")
+
+// FindRate returns the rate of the currency pair that is valid on the given date, i.e. the latest rate
+// on or before that date. The inverse rate of the reversed pair is used if it is more recent than the direct
+// rate; the direct rate wins if both are of the same date.
+func FindRate(rates []models.FxRate, baseCurrency, quoteCurrency string, date time.Time) (*models.FxRate, error) {
+	day := date.UTC().Format(DateLayout)
+	var direct, inverse *models.FxRate
+	for idx := range rates {
+		rate := &rates[idx]
+		if rate.Date > day {
+			continue
+		}
+		switch {
+		case rate.BaseCurrency == baseCurrency && rate.QuoteCurrency == quoteCurrency:
+			if direct == nil || rate.Date > direct.Date {
+				direct = rate
+			}
+		case rate.BaseCurrency == quoteCurrency && rate.QuoteCurrency == baseCurrency && !rate.Rate.IsZero():
+			if inverse == nil || rate.Date > inverse.Date {
+				inverse = rate
+			}
+		}
+	}
+
+	if direct != nil && (inverse == nil || direct.Date >= inverse.Date) {
+		return direct, nil
+	}
+	if inverse != nil {
+		return &models.FxRate{
+			BaseCurrency:  baseCurrency,
+			QuoteCurrency: quoteCurrency,
+			Date:          inverse.Date,
+			Rate:          money.NewFromInt(1).Div(inverse.Rate),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s/%s %s", ErrNoFxRate, baseCurrency, quoteCurrency, day)
+}
@@ -0,0 +1,89 @@
+package fxrate
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/money"
+	"tariff-calculation-service/test/data"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FindRate(t *testing.T) {
+	// arrange
+	testcases := []struct {
+		name          string
+		baseCurrency  string
+		quoteCurrency string
+		date          string
+		expectedRate  *models.FxRate
+		expectedError error
+	}{
+		{"Positive Test Rate Of Day", "GBP", "EUR", "2021-01-01T12:00:00Z", &data.FxRate, nil},
+		{"Positive Test Latest Rate", "GBP", "EUR", "2021-01-05T00:00:00Z", &data.FxRateNextDay, nil},
+		{"Positive Test Inverse Rate", "EUR", "GBP", "2021-01-01T00:00:00Z", &models.FxRate{BaseCurrency: "EUR", QuoteCurrency: "GBP", Date: "2021-01-01", Rate: money.RequireFromString("0.8888888888888889")}, nil},
+		{"Negative Test Before First Rate", "GBP", "EUR", "2020-12-31T23:59:59Z", nil, ErrNoFxRate},
+		{"Negative Test Unknown Currency Pair", "GBP", "CHF", "2021-01-01T00:00:00Z", nil, ErrNoFxRate},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			date, _ := time.Parse(time.RFC3339, tc.date)
+			rate, err := FindRate(data.FxRates, tc.baseCurrency, tc.quoteCurrency, date)
+
+			// assert
+			assert.True(t, errors.Is(err, tc.expectedError))
+			assert.Equal(t, tc.expectedRate, rate)
+		})
+	}
+}
+
+func Test_FindRate_MostRecent(t *testing.T) {
+	// arrange
+	inverse := models.FxRate{BaseCurrency: "EUR", QuoteCurrency: data.TestCurrency, Date: "2021-01-02", Rate: money.RequireFromString("0.8")}
+	inverseLater := inverse
+	inverseLater.Date = "2021-01-03"
+	testcases := []struct {
+		name         string
+		rates        []models.FxRate
+		expectedRate *models.FxRate
+	}{
+		{"Positive Test Later Inverse Rate", []models.FxRate{data.FxRate, data.FxRateNextDay, inverseLater},
+			&models.FxRate{BaseCurrency: data.TestCurrency, QuoteCurrency: "EUR", Date: "2021-01-03", Rate: money.RequireFromString("1.25")}},
+		{"Positive Test Direct Rate Of Same Date", []models.FxRate{inverse, data.FxRate, data.FxRateNextDay}, &data.FxRateNextDay},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			date, _ := time.Parse(time.RFC3339, "2021-01-05T00:00:00Z")
+			rate, err := FindRate(tc.rates, data.TestCurrency, "EUR", date)
+
+			// assert
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedRate, rate)
+		})
+	}
+}
+
+func Test_FileProvider(t *testing.T) {
+	// arrange
+	path := filepath.Join(t.TempDir(), "rates.json")
+	err := os.WriteFile(path, []byte(`[{"baseCurrency":"GBP","quoteCurrency":"EUR","date":"2021-01-01","rate":1.125}]`), 0o600)
+	assert.Nil(t, err)
+	date, _ := time.Parse(time.RFC3339, "2021-01-01T00:00:00Z")
+
+	// act
+	rate, err := NewFileProvider(path).GetFxRate(data.TestPartitionId, "GBP", "EUR", date)
+	_, errMissing := NewFileProvider(filepath.Join(t.TempDir(), "missing.json")).GetFxRate(data.TestPartitionId, "GBP", "EUR", date)
+
+	// assert
+	assert.Nil(t, err)
+	assert.Equal(t, &data.FxRate, rate)
+	assert.NotNil(t, errMissing)
+	assert.False(t, errors.Is(errMissing, ErrNoFxRate))
+}
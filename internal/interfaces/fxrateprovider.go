@@ -0,0 +1,14 @@
+//go:generate mockgen -source=fxrateprovider.go -destination=../readmodel/httphandler/testing/fxrateprovider_mocks.go -package=testing FxRateProvider
+
+package interfaces
+
+import (
+	"time"
+
+	"tariff-calculation-service/internal/models"
+)
+
+// FxRateProvider returns the exchange rate of a currency pair that is valid on the given date.
+type FxRateProvider interface {
+	GetFxRate(partitionId, baseCurrency, quoteCurrency string, date time.Time) (*models.FxRate, error)
+}
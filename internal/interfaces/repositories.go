@@ -0,0 +1,76 @@
+package interfaces
+
+import (
+	"time"
+
+	"tariff-calculation-service/internal/models"
+)
+
+// The repositories are implemented by every storage backend. Handlers depend on the subsets they need. The writes
+// of contracts, providers and tariffs take the audit records of the write and store them at once with it, so that
+// no write is left unaudited and no record is kept for a write that failed.
+
+type TariffRepository interface {
+	GetTariffs(partitionId string) (*[]models.Tariff, error)
+	GetTariffsPage(partitionId string, filter models.TariffFilter, pageRequest models.PageRequest) (*models.Page[models.Tariff], error)
+	GetTariff(partitionId, tariffId string) (*models.Tariff, error)
+	GetTariffWithVersion(partitionId, tariffId string) (*models.Tariff, int, error)
+	GetTariffVersions(partitionId, tariffId string) (*[]models.TariffVersion, error)
+	// GetAllTariffVersions returns the versions of every tariff of the partition, ordered by the id of the tariff.
+	GetAllTariffVersions(partitionId string) (*[][]models.TariffVersion, error)
+	CreateTariff(partitionId string, tariff models.Tariff, records ...models.AuditRecord) (*models.Tariff, error)
+	UpdateTariff(partitionId string, tariff models.Tariff, version int, records ...models.AuditRecord) (int, error)
+	// DeleteTariff deletes the tariff if it has the expected version, and writes the changes of the contracts that
+	// referred to it at once.
+	DeleteTariff(partitionId, tariffId string, version int, contracts []models.ContractChange, records ...models.AuditRecord) error
+}
+
+type ContractRepository interface {
+	GetContracts(partitionId string) (*[]models.Contract, error)
+	GetContractsPage(partitionId string, pageRequest models.PageRequest) (*models.Page[models.Contract], error)
+	GetContract(partitionId, contractId string) (*models.Contract, error)
+	GetContractWithVersion(partitionId, contractId string) (*models.Contract, int, error)
+	GetContractsByProvider(partitionId, providerId string, pageRequest models.PageRequest) (*models.Page[models.Contract], error)
+	GetContractsByTariff(partitionId, tariffId string, pageRequest models.PageRequest) (*models.Page[models.Contract], error)
+	CreateContract(partitionId string, contract models.Contract, records ...models.AuditRecord) (*models.Contract, error)
+	UpdateContract(partitionId string, contract models.Contract, version int, records ...models.AuditRecord) (int, error)
+	DeleteContract(partitionId, contractId string, version int, records ...models.AuditRecord) error
+}
+
+type ProviderRepository interface {
+	GetProviders(partitionId string) (*[]models.Provider, error)
+	GetProvidersPage(partitionId string, pageRequest models.PageRequest) (*models.Page[models.Provider], error)
+	GetProvider(partitionId, providerId string) (*models.Provider, error)
+	GetProviderWithVersion(partitionId, providerId string) (*models.Provider, int, error)
+	CreateProvider(partitionId string, provider models.Provider, records ...models.AuditRecord) (*models.Provider, error)
+	UpdateProvider(partitionId string, provider models.Provider, version int, records ...models.AuditRecord) (int, error)
+	// DeleteProvider deletes the provider if it has the expected version, and writes the changes of the contracts
+	// that referred to it at once.
+	DeleteProvider(partitionId, providerId string, version int, contracts []models.ContractChange, records ...models.AuditRecord) error
+}
+
+type TaxRuleRepository interface {
+	GetTaxRules(partitionId string) (*[]models.TaxRule, error)
+	GetTaxRule(partitionId, taxRuleId string) (*models.TaxRule, error)
+	CreateTaxRule(partitionId string, taxRule models.TaxRule) (*models.TaxRule, error)
+	UpdateTaxRule(partitionId string, taxRule models.TaxRule) error
+	DeleteTaxRule(partitionId, taxRuleId string) error
+}
+
+type SettingsRepository interface {
+	GetSettings(partitionId string) (*models.Settings, error)
+	PutSettings(partitionId string, settings models.Settings) error
+}
+
+type FxRateRepository interface {
+	GetFxRates(partitionId string) (*[]models.FxRate, error)
+	GetFxRate(partitionId, baseCurrency, quoteCurrency string, date time.Time) (*models.FxRate, error)
+	PutFxRate(partitionId string, fxRate models.FxRate) (*models.FxRate, error)
+}
+
+// AuditRepository stores the audit records append-only: records are never changed or deleted. The records of writes
+// are appended by the writes themselves.
+type AuditRepository interface {
+	AppendAuditRecord(partitionId string, record models.AuditRecord) error
+	GetAuditRecordsPage(partitionId string, filter models.AuditFilter, pageRequest models.PageRequest) (*models.Page[models.AuditRecord], error)
+}
@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"encoding/json"
+	"tariff-calculation-service/internal/audit"
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/pagination"
+)
+
+type AuditRepo struct {
+	Store *Store
+}
+
+func NewAuditRepo() AuditRepo {
+	return AuditRepo{
+		Store: SharedStore(),
+	}
+}
+
+// AppendAuditRecord stores the record under audit#<timestamp>#<id>, so that the records are ordered by time.
+func (ar AuditRepo) AppendAuditRecord(partitionId string, record models.AuditRecord) error {
+	return appendEntity(ar.Store, partitionId, auditKey(record), record, nil)
+}
+
+// auditAdditions returns the audit records as additions of the write they record.
+func auditAdditions(records []models.AuditRecord) ([]addition, error) {
+	additions := []addition{}
+	for _, record := range records {
+		value, err := json.Marshal(record)
+		if err != nil {
+			return nil, err
+		}
+		additions = append(additions, addition{key: auditKey(record), value: value})
+	}
+
+	return additions, nil
+}
+
+func auditKey(record models.AuditRecord) string {
+	return AuditKeyPrefix + record.Timestamp + "#" + record.Id
+}
+
+// GetAuditRecordsPage returns one page of the audit records of the partition that match the filter, ordered
+// by time.
+func (ar AuditRepo) GetAuditRecordsPage(partitionId string, filter models.AuditFilter, pageRequest models.PageRequest) (*models.Page[models.AuditRecord], error) {
+	records, err := queryEntities[models.AuditRecord](ar.Store, partitionId, AuditKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	matchingRecords := []models.AuditRecord{}
+	for _, record := range records {
+		if audit.Matches(record, filter) {
+			matchingRecords = append(matchingRecords, record)
+		}
+	}
+	items, nextCursor, err := pagination.OffsetPage(matchingRecords, pageRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Page[models.AuditRecord]{Items: items, NextCursor: nextCursor}, nil
+}
@@ -0,0 +1,19 @@
+package memory
+
+import (
+	"tariff-calculation-service/test/conformance"
+	"testing"
+)
+
+func Test_Conformance(t *testing.T) {
+	store := NewStore()
+	conformance.RunRepositoryTests(t, conformance.Repositories{
+		Tariffs:   TariffRepo{Store: store},
+		Contracts: ContractRepo{Store: store},
+		Providers: ProviderRepo{Store: store},
+		TaxRules:  TaxRuleRepo{Store: store},
+		Settings:  SettingsRepo{Store: store},
+		FxRates:   FxRateRepo{Store: store},
+		Audit:     AuditRepo{Store: store},
+	})
+}
@@ -0,0 +1,167 @@
+package memory
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/pagination"
+)
+
+type ContractRepo struct {
+	Store *Store
+}
+
+func NewContractRepo() ContractRepo {
+	return ContractRepo{
+		Store: SharedStore(),
+	}
+}
+
+func (cr ContractRepo) GetContracts(partitionId string) (*[]models.Contract, error) {
+	contracts, err := queryEntities[models.Contract](cr.Store, partitionId, ContractKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return &contracts, nil
+}
+
+// GetContractsPage returns one page of the contracts of the partition.
+func (cr ContractRepo) GetContractsPage(partitionId string, pageRequest models.PageRequest) (*models.Page[models.Contract], error) {
+	contracts, nextCursor, err := queryEntitiesPage[models.Contract](cr.Store, partitionId, ContractKeyPrefix, pageRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Page[models.Contract]{Items: contracts, NextCursor: nextCursor}, nil
+}
+
+// GetContractsByProvider returns one page of the contracts of the provider.
+func (cr ContractRepo) GetContractsByProvider(partitionId, providerId string, pageRequest models.PageRequest) (*models.Page[models.Contract], error) {
+	return cr.getMatchingContractsPage(partitionId, func(contract models.Contract) bool {
+		return contract.Provider == providerId
+	}, pageRequest)
+}
+
+// GetContractsByTariff returns one page of the contracts of the tariff.
+func (cr ContractRepo) GetContractsByTariff(partitionId, tariffId string, pageRequest models.PageRequest) (*models.Page[models.Contract], error) {
+	return cr.getMatchingContractsPage(partitionId, func(contract models.Contract) bool {
+		return slices.Contains(contract.Tariffs, tariffId)
+	}, pageRequest)
+}
+
+// getMatchingContractsPage returns one page of the contracts of the partition that match. The store holds no
+// index, so it reads all contracts like GetTariffsPage does with its filter.
+func (cr ContractRepo) getMatchingContractsPage(partitionId string, matches func(models.Contract) bool, pageRequest models.PageRequest) (*models.Page[models.Contract], error) {
+	contracts, err := queryEntities[models.Contract](cr.Store, partitionId, ContractKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	matchingContracts := []models.Contract{}
+	for _, contract := range contracts {
+		if matches(contract) {
+			matchingContracts = append(matchingContracts, contract)
+		}
+	}
+	items, nextCursor, err := pagination.OffsetPage(matchingContracts, pageRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Page[models.Contract]{Items: items, NextCursor: nextCursor}, nil
+}
+
+func (cr ContractRepo) GetContract(partitionId, contractId string) (*models.Contract, error) {
+	contract, err := getEntity[models.Contract](cr.Store, partitionId, ContractKeyPrefix+contractId)
+	if err != nil {
+		return &models.Contract{}, err
+	}
+
+	return contract, nil
+}
+
+// GetContractWithVersion returns the contract and its version.
+func (cr ContractRepo) GetContractWithVersion(partitionId, contractId string) (*models.Contract, int, error) {
+	return getVersionedEntity[models.Contract](cr.Store, partitionId, ContractKeyPrefix+contractId)
+}
+
+// CreateContract creates the contract with its audit records. It returns dberrors.ErrConflict if the contract exists
+// or if the provider or a tariff it refers to does not exist.
+func (cr ContractRepo) CreateContract(partitionId string, contract models.Contract, records ...models.AuditRecord) (*models.Contract, error) {
+	additions, err := auditAdditions(records)
+	if err != nil {
+		return &models.Contract{}, err
+	}
+	err = appendEntity(cr.Store, partitionId, ContractKeyPrefix+contract.Id, contract, additions, contractReferences(contract)...)
+	if err != nil {
+		return &models.Contract{}, err
+	}
+
+	return &contract, nil
+}
+
+// UpdateContract replaces the contract with its audit records if it has the expected version and returns the new
+// version. It returns dberrors.ErrConflict if the provider or a tariff it refers to does not exist.
+func (cr ContractRepo) UpdateContract(partitionId string, contract models.Contract, version int, records ...models.AuditRecord) (int, error) {
+	additions, err := auditAdditions(records)
+	if err != nil {
+		return 0, err
+	}
+
+	return replaceVersionedEntity(cr.Store, partitionId, ContractKeyPrefix+contract.Id, contract, version, additions, contractReferences(contract)...)
+}
+
+// DeleteContract deletes the contract with its audit records if it has the expected version.
+func (cr ContractRepo) DeleteContract(partitionId, contractId string, version int, records ...models.AuditRecord) error {
+	additions, err := auditAdditions(records)
+	if err != nil {
+		return err
+	}
+
+	return deleteVersionedEntity(cr.Store, partitionId, ContractKeyPrefix+contractId, version, nil, nil, additions)
+}
+
+// contractChanges returns the changes of the contracts as changes of the store.
+func contractChanges(changes []models.ContractChange) ([]change, error) {
+	contractChanges := []change{}
+	for _, contractChange := range changes {
+		before, err := json.Marshal(contractChange.Before)
+		if err != nil {
+			return nil, err
+		}
+		var after []byte
+		if contractChange.After != nil {
+			if after, err = json.Marshal(contractChange.After); err != nil {
+				return nil, err
+			}
+		}
+		contractChanges = append(contractChanges, change{key: ContractKeyPrefix + contractChange.Before.Id, before: before, after: after})
+	}
+
+	return contractChanges, nil
+}
+
+// contractReferences returns the keys of the provider and the tariffs the contract refers to.
+func contractReferences(contract models.Contract) []string {
+	keys := []string{ProviderKeyPrefix + contract.Provider}
+	for _, tariffId := range contract.Tariffs {
+		keys = append(keys, TariffKeyPrefix+tariffId)
+	}
+
+	return keys
+}
+
+// contractsReferring returns the referrer of the contracts that match.
+func contractsReferring(matches func(models.Contract) bool) referrer {
+	return func(key string, value []byte) (bool, error) {
+		if !strings.HasPrefix(key, ContractKeyPrefix) {
+			return false, nil
+		}
+		var contract models.Contract
+		if err := json.Unmarshal(value, &contract); err != nil {
+			return false, err
+		}
+		return matches(contract), nil
+	}
+}
@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"time"
+
+	"tariff-calculation-service/internal/fxrate"
+	"tariff-calculation-service/internal/models"
+)
+
+type FxRateRepo struct {
+	Store *Store
+}
+
+func NewFxRateRepo() FxRateRepo {
+	return FxRateRepo{
+		Store: SharedStore(),
+	}
+}
+
+func fxRatePairPrefix(baseCurrency, quoteCurrency string) string {
+	return FxRateKeyPrefix + baseCurrency + "#" + quoteCurrency + "#"
+}
+
+func (frr FxRateRepo) GetFxRates(partitionId string) (*[]models.FxRate, error) {
+	fxRates, err := queryEntities[models.FxRate](frr.Store, partitionId, FxRateKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fxRates, nil
+}
+
+// GetFxRate returns the rate of the currency pair that is valid on the given date, or the inverse rate
+// of the reversed pair.
+func (frr FxRateRepo) GetFxRate(partitionId, baseCurrency, quoteCurrency string, date time.Time) (*models.FxRate, error) {
+	fxRates, err := queryEntities[models.FxRate](frr.Store, partitionId, fxRatePairPrefix(baseCurrency, quoteCurrency))
+	if err != nil {
+		return nil, err
+	}
+	inverseRates, err := queryEntities[models.FxRate](frr.Store, partitionId, fxRatePairPrefix(quoteCurrency, baseCurrency))
+	if err != nil {
+		return nil, err
+	}
+
+	return fxrate.FindRate(append(fxRates, inverseRates...), baseCurrency, quoteCurrency, date)
+}
+
+// PutFxRate creates the rate of the currency pair and date, or replaces it if it exists.
+func (frr FxRateRepo) PutFxRate(partitionId string, fxRate models.FxRate) (*models.FxRate, error) {
+	err := putEntity(frr.Store, partitionId, fxRatePairPrefix(fxRate.BaseCurrency, fxRate.QuoteCurrency)+fxRate.Date, fxRate)
+	if err != nil {
+		return &models.FxRate{}, err
+	}
+
+	return &fxRate, nil
+}
@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"tariff-calculation-service/internal/models"
+)
+
+type ProviderRepo struct {
+	Store *Store
+}
+
+func NewProviderRepo() ProviderRepo {
+	return ProviderRepo{
+		Store: SharedStore(),
+	}
+}
+
+func (pr ProviderRepo) GetProviders(partitionId string) (*[]models.Provider, error) {
+	providers, err := queryEntities[models.Provider](pr.Store, partitionId, ProviderKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return &providers, nil
+}
+
+// GetProvidersPage returns one page of the providers of the partition.
+func (pr ProviderRepo) GetProvidersPage(partitionId string, pageRequest models.PageRequest) (*models.Page[models.Provider], error) {
+	providers, nextCursor, err := queryEntitiesPage[models.Provider](pr.Store, partitionId, ProviderKeyPrefix, pageRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Page[models.Provider]{Items: providers, NextCursor: nextCursor}, nil
+}
+
+func (pr ProviderRepo) GetProvider(partitionId, providerId string) (*models.Provider, error) {
+	provider, err := getEntity[models.Provider](pr.Store, partitionId, ProviderKeyPrefix+providerId)
+	if err != nil {
+		return &models.Provider{}, err
+	}
+
+	return provider, nil
+}
+
+// GetProviderWithVersion returns the provider and its version.
+func (pr ProviderRepo) GetProviderWithVersion(partitionId, providerId string) (*models.Provider, int, error) {
+	return getVersionedEntity[models.Provider](pr.Store, partitionId, ProviderKeyPrefix+providerId)
+}
+
+// CreateProvider creates the provider with its audit records. It returns dberrors.ErrConflict if the provider exists.
+func (pr ProviderRepo) CreateProvider(partitionId string, provider models.Provider, records ...models.AuditRecord) (*models.Provider, error) {
+	additions, err := auditAdditions(records)
+	if err != nil {
+		return &models.Provider{}, err
+	}
+	err = appendEntity(pr.Store, partitionId, ProviderKeyPrefix+provider.Id, provider, additions)
+	if err != nil {
+		return &models.Provider{}, err
+	}
+
+	return &provider, nil
+}
+
+// UpdateProvider replaces the provider with its audit records if it has the expected version and returns the new
+// version.
+func (pr ProviderRepo) UpdateProvider(partitionId string, provider models.Provider, version int, records ...models.AuditRecord) (int, error) {
+	additions, err := auditAdditions(records)
+	if err != nil {
+		return 0, err
+	}
+
+	return replaceVersionedEntity(pr.Store, partitionId, ProviderKeyPrefix+provider.Id, provider, version, additions)
+}
+
+// DeleteProvider deletes the provider if it has the expected version, and writes the changes of the contracts that
+// referred to it and the audit records at once. It returns dberrors.ErrConflict if a contract changed since it was
+// read, or if a contract still refers to the provider after the changes.
+func (pr ProviderRepo) DeleteProvider(partitionId, providerId string, version int, contracts []models.ContractChange, records ...models.AuditRecord) error {
+	changes, err := contractChanges(contracts)
+	if err != nil {
+		return err
+	}
+	additions, err := auditAdditions(records)
+	if err != nil {
+		return err
+	}
+
+	refersTo := contractsReferring(func(contract models.Contract) bool {
+		return contract.Provider == providerId
+	})
+
+	return deleteVersionedEntity(pr.Store, partitionId, ProviderKeyPrefix+providerId, version, refersTo, changes, additions)
+}
@@ -0,0 +1,34 @@
+package memory
+
+import (
+	"errors"
+	"tariff-calculation-service/internal/dberrors"
+	"tariff-calculation-service/internal/models"
+)
+
+type SettingsRepo struct {
+	Store *Store
+}
+
+func NewSettingsRepo() SettingsRepo {
+	return SettingsRepo{
+		Store: SharedStore(),
+	}
+}
+
+// GetSettings returns the settings of the partition, or the default settings if none are stored.
+func (sr SettingsRepo) GetSettings(partitionId string) (*models.Settings, error) {
+	settings, err := getEntity[models.Settings](sr.Store, partitionId, SettingsKey)
+	if errors.Is(err, dberrors.ErrNotFound) {
+		return &models.Settings{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+func (sr SettingsRepo) PutSettings(partitionId string, settings models.Settings) error {
+	return putEntity(sr.Store, partitionId, SettingsKey, settings)
+}
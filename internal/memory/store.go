@@ -0,0 +1,476 @@
+package memory
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+	"sort"
+	"strings"
+	"sync"
+	"tariff-calculation-service/internal/dberrors"
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/pagination"
+	"tariff-calculation-service/internal/versioning"
+)
+
+const (
+	ContractKeyPrefix = "contract#"
+	ProviderKeyPrefix = "provider#"
+	TariffKeyPrefix   = "tariff#"
+	TaxRuleKeyPrefix  = "taxrule#"
+	FxRateKeyPrefix   = "fxrate#"
+	AuditKeyPrefix    = "audit#"
+	SettingsKey       = "settings"
+
+	// The versions of a tariff are kept under tariff#<tariffId>#version#<incarnation>#<version>, with the version
+	// zero-padded so that the keys sort by version.
+	TariffVersionKeyInfix = "#version#"
+)
+
+// Store keeps the entities of every partition by key, like the DynamoDB table does by sort key. Entities are
+// stored as JSON, so callers never share memory with the store. Every put increments the version of the key.
+// Revised entities also have the incarnation that keys their revisions. It is safe for concurrent use.
+type Store struct {
+	mutex        sync.RWMutex
+	partitions   map[string]map[string][]byte
+	versions     map[string]map[string]int
+	incarnations map[string]map[string]string
+}
+
+var (
+	sharedStore     *Store
+	sharedStoreOnce sync.Once
+)
+
+func NewStore() *Store {
+	return &Store{
+		partitions:   map[string]map[string][]byte{},
+		versions:     map[string]map[string]int{},
+		incarnations: map[string]map[string]string{},
+	}
+}
+
+// SharedStore returns the store used by all repositories of the process.
+func SharedStore() *Store {
+	sharedStoreOnce.Do(func() {
+		sharedStore = NewStore()
+	})
+	return sharedStore
+}
+
+func getEntity[T any](store *Store, partitionId, key string) (*T, error) {
+	entity, _, err := getVersionedEntity[T](store, partitionId, key)
+	return entity, err
+}
+
+// getVersionedEntity returns the entity with the key and its version.
+func getVersionedEntity[T any](store *Store, partitionId, key string) (*T, int, error) {
+	store.mutex.RLock()
+	value, ok := store.partitions[partitionId][key]
+	version := store.versions[partitionId][key]
+	store.mutex.RUnlock()
+	if !ok {
+		return nil, 0, dberrors.ErrNotFound
+	}
+
+	var entity T
+	if err := json.Unmarshal(value, &entity); err != nil {
+		return nil, 0, err
+	}
+	return &entity, version, nil
+}
+
+// getIncarnation returns the incarnation of the revised entity with the key. It returns dberrors.ErrNotFound if the
+// entity does not exist.
+func getIncarnation(store *Store, partitionId, key string) (string, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+	if !store.exists(partitionId, key) {
+		return "", dberrors.ErrNotFound
+	}
+	return store.incarnations[partitionId][key], nil
+}
+
+func putEntity[T any](store *Store, partitionId, key string, entity T) error {
+	value, err := json.Marshal(entity)
+	if err != nil {
+		return err
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	store.put(partitionId, key, value)
+	return nil
+}
+
+// appendEntity creates the entity with the key together with the additions. It returns dberrors.ErrConflict if the
+// entity or an addition exists, so that an entity is never replaced, or if an entity it refers to by the references
+// does not exist.
+func appendEntity[T any](store *Store, partitionId, key string, entity T, additions []addition, references ...string) error {
+	value, err := json.Marshal(entity)
+	if err != nil {
+		return err
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	if store.exists(partitionId, key) {
+		return dberrors.ErrConflict
+	}
+	if err := store.checkReferences(partitionId, references); err != nil {
+		return err
+	}
+	if err := store.checkAdditions(partitionId, additions); err != nil {
+		return err
+	}
+	store.put(partitionId, key, value)
+	store.putAdditions(partitionId, additions)
+	return nil
+}
+
+// replaceEntity replaces the entity with the key. It returns dberrors.ErrNotFound if the entity does not
+// exist.
+func replaceEntity[T any](store *Store, partitionId, key string, entity T) error {
+	value, err := json.Marshal(entity)
+	if err != nil {
+		return err
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	if !store.exists(partitionId, key) {
+		return dberrors.ErrNotFound
+	}
+	store.put(partitionId, key, value)
+	return nil
+}
+
+// replaceVersionedEntity replaces the entity with the key if it has the expected version, creates the additions at
+// once and returns the incremented version. It returns versioning.ErrVersionMismatch if the entity has another
+// version, dberrors.ErrNotFound if it does not exist, and dberrors.ErrConflict if an entity it refers to by the
+// references does not exist or if an addition exists.
+func replaceVersionedEntity[T any](store *Store, partitionId, key string, entity T, version int, additions []addition, references ...string) (int, error) {
+	value, err := json.Marshal(entity)
+	if err != nil {
+		return 0, err
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	if err := store.checkVersion(partitionId, key, version); err != nil {
+		return 0, err
+	}
+	if err := store.checkReferences(partitionId, references); err != nil {
+		return 0, err
+	}
+	if err := store.checkAdditions(partitionId, additions); err != nil {
+		return 0, err
+	}
+	updatedVersion := store.put(partitionId, key, value)
+	store.putAdditions(partitionId, additions)
+	return updatedVersion, nil
+}
+
+// revision returns the key and the value of the revision of an entity in an incarnation at a version.
+type revision func(incarnation string, version int) (string, any)
+
+// createRevisedEntity creates the entity like appendEntity does in a new incarnation, and puts the revision of its
+// first version and creates the additions at once. It returns dberrors.ErrConflict if the entity or an addition
+// exists.
+func createRevisedEntity[T any](store *Store, partitionId, key string, entity T, revise revision, additions []addition) error {
+	value, err := json.Marshal(entity)
+	if err != nil {
+		return err
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	if store.exists(partitionId, key) {
+		return dberrors.ErrConflict
+	}
+	if err := store.checkAdditions(partitionId, additions); err != nil {
+		return err
+	}
+	if err := store.putRevision(partitionId, key, versioning.NewIncarnation(), value, revise); err != nil {
+		return err
+	}
+	store.putAdditions(partitionId, additions)
+	return nil
+}
+
+// replaceRevisedEntity replaces the entity like replaceVersionedEntity does, and puts the revision of its new
+// version and creates the additions at once.
+func replaceRevisedEntity[T any](store *Store, partitionId, key string, entity T, version int, revise revision, additions []addition) (int, error) {
+	value, err := json.Marshal(entity)
+	if err != nil {
+		return 0, err
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	if err := store.checkVersion(partitionId, key, version); err != nil {
+		return 0, err
+	}
+	if err := store.checkAdditions(partitionId, additions); err != nil {
+		return 0, err
+	}
+	if err := store.putRevision(partitionId, key, store.incarnations[partitionId][key], value, revise); err != nil {
+		return 0, err
+	}
+	store.putAdditions(partitionId, additions)
+	return store.versions[partitionId][key], nil
+}
+
+// deleteEntity deletes the entity with the key. It returns dberrors.ErrNotFound if the entity does not exist.
+func deleteEntity(store *Store, partitionId, key string) error {
+	return deleteVersionedEntity(store, partitionId, key, versioning.AnyVersion, nil, nil, nil)
+}
+
+// change is a write of an entity that is only applied if the entity still has the value it was read with. The value
+// after is nil for a delete.
+type change struct {
+	key    string
+	before []byte
+	after  []byte
+}
+
+// referrer reports whether the entity with the key and the value refers to an entity that is deleted.
+type referrer func(key string, value []byte) (bool, error)
+
+// addition is an entity that a write creates along with the entity it writes, such as the audit record of the
+// write. Like appended entities, additions are never replaced.
+type addition struct {
+	key   string
+	value []byte
+}
+
+// deleteVersionedEntity deletes the entity with the key if it has the expected version, and writes the changes and
+// creates the additions at once. It returns versioning.ErrVersionMismatch if the entity has another version,
+// dberrors.ErrNotFound if it does not exist, and dberrors.ErrConflict if an entity of the changes changed since it
+// was read, if an entity would still refer to it after the changes or if an addition exists.
+func deleteVersionedEntity(store *Store, partitionId, key string, version int, refersTo referrer, changes []change, additions []addition) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	if err := store.checkVersion(partitionId, key, version); err != nil {
+		return err
+	}
+	if err := store.checkChanges(partitionId, changes); err != nil {
+		return err
+	}
+	if err := store.checkReferrers(partitionId, refersTo, changes); err != nil {
+		return err
+	}
+	if err := store.checkAdditions(partitionId, additions); err != nil {
+		return err
+	}
+	store.delete(partitionId, key)
+	store.applyChanges(partitionId, changes)
+	store.putAdditions(partitionId, additions)
+	return nil
+}
+
+// put stores the value and returns its version. The caller must hold the write lock.
+func (store *Store) put(partitionId, key string, value []byte) int {
+	if store.partitions[partitionId] == nil {
+		store.partitions[partitionId] = map[string][]byte{}
+		store.versions[partitionId] = map[string]int{}
+		store.incarnations[partitionId] = map[string]string{}
+	}
+	store.partitions[partitionId][key] = value
+	store.versions[partitionId][key]++
+	return store.versions[partitionId][key]
+}
+
+// delete removes the value with the key. The caller must hold the write lock.
+func (store *Store) delete(partitionId, key string) {
+	delete(store.partitions[partitionId], key)
+	delete(store.versions[partitionId], key)
+	delete(store.incarnations[partitionId], key)
+}
+
+// checkChanges returns dberrors.ErrConflict unless every entity of the changes still has the value it was read
+// with. The caller must hold a lock.
+func (store *Store) checkChanges(partitionId string, changes []change) error {
+	for _, change := range changes {
+		value, ok := store.partitions[partitionId][change.key]
+		if !ok || !bytes.Equal(value, change.before) {
+			return dberrors.ErrConflict
+		}
+	}
+	return nil
+}
+
+// checkReferences returns dberrors.ErrConflict unless the entities with the keys exist. The caller must hold a
+// lock.
+func (store *Store) checkReferences(partitionId string, keys []string) error {
+	for _, key := range keys {
+		if !store.exists(partitionId, key) {
+			return dberrors.ErrConflict
+		}
+	}
+	return nil
+}
+
+// checkReferrers returns dberrors.ErrConflict if an entity of the partition refers to the deleted entity, as it is
+// stored or as the changes write it. The caller must hold a lock.
+func (store *Store) checkReferrers(partitionId string, refersTo referrer, changes []change) error {
+	if refersTo == nil {
+		return nil
+	}
+	values := maps.Clone(store.partitions[partitionId])
+	for _, change := range changes {
+		if change.after == nil {
+			delete(values, change.key)
+		} else {
+			values[change.key] = change.after
+		}
+	}
+	for key, value := range values {
+		refers, err := refersTo(key, value)
+		if err != nil {
+			return err
+		}
+		if refers {
+			return dberrors.ErrConflict
+		}
+	}
+	return nil
+}
+
+// applyChanges writes the changes. The caller must hold the write lock.
+func (store *Store) applyChanges(partitionId string, changes []change) {
+	for _, change := range changes {
+		if change.after == nil {
+			store.delete(partitionId, change.key)
+		} else {
+			store.put(partitionId, change.key, change.after)
+		}
+	}
+}
+
+// checkAdditions returns dberrors.ErrConflict if an entity of the additions exists. The caller must hold a lock.
+func (store *Store) checkAdditions(partitionId string, additions []addition) error {
+	for _, addition := range additions {
+		if store.exists(partitionId, addition.key) {
+			return dberrors.ErrConflict
+		}
+	}
+	return nil
+}
+
+// putAdditions stores the additions. The caller must hold the write lock.
+func (store *Store) putAdditions(partitionId string, additions []addition) {
+	for _, addition := range additions {
+		store.put(partitionId, addition.key, addition.value)
+	}
+}
+
+// putRevision stores the value in the incarnation and the revision of its new version. Revisions are immutable, so
+// it returns dberrors.ErrConflict if the revision exists. The caller must hold the write lock.
+func (store *Store) putRevision(partitionId, key, incarnation string, value []byte, revise revision) error {
+	revisionKey, revisionEntity := revise(incarnation, store.versions[partitionId][key]+1)
+	revisionValue, err := json.Marshal(revisionEntity)
+	if err != nil {
+		return err
+	}
+	if store.exists(partitionId, revisionKey) {
+		return dberrors.ErrConflict
+	}
+	store.put(partitionId, key, value)
+	store.incarnations[partitionId][key] = incarnation
+	store.put(partitionId, revisionKey, revisionValue)
+	return nil
+}
+
+// exists reports whether the entity with the key exists. The caller must hold a lock.
+func (store *Store) exists(partitionId, key string) bool {
+	_, ok := store.partitions[partitionId][key]
+	return ok
+}
+
+// checkVersion returns dberrors.ErrNotFound if the entity with the key does not exist, and
+// versioning.ErrVersionMismatch if it has another version. The caller must hold a lock.
+func (store *Store) checkVersion(partitionId, key string, version int) error {
+	if !store.exists(partitionId, key) {
+		return dberrors.ErrNotFound
+	}
+	if !versioning.Matches(store.versions[partitionId][key], version) {
+		return versioning.ErrVersionMismatch
+	}
+	return nil
+}
+
+// queryEntitiesPage returns one page of the entities of the partition whose key begins with the prefix, and
+// the cursor of the next page. The cursor is the key of the last entity of the page.
+func queryEntitiesPage[T any](store *Store, partitionId, keyPrefix string, pageRequest models.PageRequest) ([]T, string, error) {
+	cursor, err := pagination.DecodeCursor(pageRequest.Cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	startKey := cursor["key"]
+	if cursor != nil && !strings.HasPrefix(startKey, keyPrefix) {
+		return nil, "", pagination.ErrInvalidCursor
+	}
+	limit := pagination.Limit(pageRequest.Limit)
+
+	store.mutex.RLock()
+	keys := []string{}
+	for key := range store.partitions[partitionId] {
+		if strings.HasPrefix(key, keyPrefix) && key > startKey {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	nextCursor := ""
+	if len(keys) > limit {
+		keys = keys[:limit]
+		nextCursor = pagination.EncodeCursor(map[string]string{"key": keys[limit-1]})
+	}
+	values := [][]byte{}
+	for _, key := range keys {
+		values = append(values, store.partitions[partitionId][key])
+	}
+	store.mutex.RUnlock()
+
+	entities := []T{}
+	for _, value := range values {
+		var entity T
+		if err := json.Unmarshal(value, &entity); err != nil {
+			return nil, "", err
+		}
+		entities = append(entities, entity)
+	}
+	return entities, nextCursor, nil
+}
+
+// queryEntities returns the entities of the partition whose key begins with the prefix, ordered by key.
+func queryEntities[T any](store *Store, partitionId, keyPrefix string) ([]T, error) {
+	return queryMatchingEntities[T](store, partitionId, func(key string) bool {
+		return strings.HasPrefix(key, keyPrefix)
+	})
+}
+
+// queryMatchingEntities returns the entities of the partition whose key matches, ordered by key.
+func queryMatchingEntities[T any](store *Store, partitionId string, matches func(key string) bool) ([]T, error) {
+	store.mutex.RLock()
+	keys := []string{}
+	values := map[string][]byte{}
+	for key, value := range store.partitions[partitionId] {
+		if matches(key) {
+			keys = append(keys, key)
+			values[key] = value
+		}
+	}
+	store.mutex.RUnlock()
+	sort.Strings(keys)
+
+	entities := []T{}
+	for _, key := range keys {
+		var entity T
+		if err := json.Unmarshal(values[key], &entity); err != nil {
+			return nil, err
+		}
+		entities = append(entities, entity)
+	}
+	return entities, nil
+}
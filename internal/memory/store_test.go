@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"fmt"
+	"sync"
+	"tariff-calculation-service/internal/dberrors"
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/versioning"
+	"tariff-calculation-service/test/data"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Store_Isolation(t *testing.T) {
+	// arrange
+	store := NewStore()
+	tariff := data.Tariff
+	tariff.Name = "Original"
+
+	// act
+	_ = putEntity(store, data.TestPartitionId, TariffKeyPrefix+tariff.Id, tariff)
+	tariff.Name = "Changed"
+	stored, _ := getEntity[models.Tariff](store, data.TestPartitionId, TariffKeyPrefix+tariff.Id)
+	stored.Name = "Changed again"
+	storedAgain, _ := getEntity[models.Tariff](store, data.TestPartitionId, TariffKeyPrefix+tariff.Id)
+
+	// assert
+	assert.Equal(t, "Original", storedAgain.Name)
+}
+
+func Test_Store_QueryEntities(t *testing.T) {
+	// arrange
+	store := NewStore()
+	_ = putEntity(store, data.TestPartitionId, TariffKeyPrefix+"b", data.TariffGas)
+	_ = putEntity(store, data.TestPartitionId, TariffKeyPrefix+"a", data.Tariff)
+	_ = putEntity(store, data.TestPartitionId, ContractKeyPrefix+"a", data.Contract)
+
+	// act
+	tariffs, err := queryEntities[models.Tariff](store, data.TestPartitionId, TariffKeyPrefix)
+
+	// assert
+	assert.Nil(t, err)
+	assert.Equal(t, []models.Tariff{data.Tariff, data.TariffGas}, tariffs)
+}
+
+func Test_Store_Revisions(t *testing.T) {
+	// arrange
+	store := NewStore()
+	tariffRepo := TariffRepo{Store: store}
+	recreatedTariff := data.Tariff
+	recreatedTariff.Name = "Recreated"
+	fixedRevision := func(string, int) (string, any) {
+		return TariffKeyPrefix + "revision", data.Tariff
+	}
+
+	// act
+	_, createErr := tariffRepo.CreateTariff(data.TestPartitionId, data.Tariff)
+	deleteErr := tariffRepo.DeleteTariff(data.TestPartitionId, data.Tariff.Id, versioning.InitialVersion, nil)
+	_, recreateErr := tariffRepo.CreateTariff(data.TestPartitionId, recreatedTariff)
+	versions, err := queryEntities[models.TariffVersion](store, data.TestPartitionId, TariffKeyPrefix+data.Tariff.Id+TariffVersionKeyInfix)
+	firstErr := createRevisedEntity(store, data.TestPartitionId, TariffKeyPrefix+"first", data.Tariff, fixedRevision, nil)
+	secondErr := createRevisedEntity(store, data.TestPartitionId, TariffKeyPrefix+"second", data.Tariff, fixedRevision, nil)
+	_, getErr := getEntity[models.Tariff](store, data.TestPartitionId, TariffKeyPrefix+"second")
+
+	// assert
+	assert.Nil(t, createErr)
+	assert.Nil(t, deleteErr)
+	assert.Nil(t, recreateErr)
+	assert.Nil(t, err)
+	// the recreated tariff has a new incarnation, so the version of the deleted one is kept as it was
+	assert.Len(t, versions, 2)
+	assert.Equal(t, data.Tariff, versions[0].Tariff)
+	assert.Equal(t, recreatedTariff, versions[1].Tariff)
+	assert.Nil(t, firstErr)
+	assert.ErrorIs(t, secondErr, dberrors.ErrConflict)
+	assert.ErrorIs(t, getErr, dberrors.ErrNotFound)
+}
+
+func Test_Store_Concurrency(t *testing.T) {
+	// arrange
+	tariffRepo := TariffRepo{Store: NewStore()}
+	waitGroup := sync.WaitGroup{}
+
+	// act
+	for idx := 0; idx < 50; idx++ {
+		waitGroup.Add(1)
+		go func(idx int) {
+			defer waitGroup.Done()
+			tariff := data.Tariff
+			tariff.Id = fmt.Sprintf("%03d", idx)
+			_, _ = tariffRepo.CreateTariff(data.TestPartitionId, tariff)
+			_, _ = tariffRepo.GetTariffs(data.TestPartitionId)
+		}(idx)
+	}
+	waitGroup.Wait()
+	tariffs, _ := tariffRepo.GetTariffs(data.TestPartitionId)
+
+	// assert
+	assert.Len(t, *tariffs, 50)
+}
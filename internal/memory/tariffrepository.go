@@ -0,0 +1,170 @@
+package memory
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"tariff-calculation-service/internal/dberrors"
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/pagination"
+	"tariff-calculation-service/internal/tariffquery"
+	"time"
+)
+
+type TariffRepo struct {
+	Store *Store
+}
+
+func NewTariffRepo() TariffRepo {
+	return TariffRepo{
+		Store: SharedStore(),
+	}
+}
+
+func (tr TariffRepo) GetTariffs(partitionId string) (*[]models.Tariff, error) {
+	tariffs, err := queryMatchingEntities[models.Tariff](tr.Store, partitionId, isTariffKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tariffs, nil
+}
+
+// GetTariffsPage returns one page of the tariffs of the partition that match the filter.
+func (tr TariffRepo) GetTariffsPage(partitionId string, filter models.TariffFilter, pageRequest models.PageRequest) (*models.Page[models.Tariff], error) {
+	tariffs, err := queryMatchingEntities[models.Tariff](tr.Store, partitionId, isTariffKey)
+	if err != nil {
+		return nil, err
+	}
+	matchingTariffs := []models.Tariff{}
+	for _, tariff := range tariffs {
+		if tariffquery.Matches(tariff, filter) {
+			matchingTariffs = append(matchingTariffs, tariff)
+		}
+	}
+	tariffquery.Sort(matchingTariffs, filter.Sort)
+	items, nextCursor, err := pagination.OffsetPage(matchingTariffs, pageRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Page[models.Tariff]{Items: items, NextCursor: nextCursor}, nil
+}
+
+func (tr TariffRepo) GetTariff(partitionId, tariffId string) (*models.Tariff, error) {
+	tariff, err := getEntity[models.Tariff](tr.Store, partitionId, TariffKeyPrefix+tariffId)
+	if err != nil {
+		return &models.Tariff{}, err
+	}
+
+	return tariff, nil
+}
+
+// GetTariffWithVersion returns the tariff and its version.
+func (tr TariffRepo) GetTariffWithVersion(partitionId, tariffId string) (*models.Tariff, int, error) {
+	return getVersionedEntity[models.Tariff](tr.Store, partitionId, TariffKeyPrefix+tariffId)
+}
+
+// GetTariffVersions returns the versions of the tariff, ordered by version. Only the versions of its incarnation are
+// read, so the versions kept of a deleted tariff with the same id are not part of its history.
+func (tr TariffRepo) GetTariffVersions(partitionId, tariffId string) (*[]models.TariffVersion, error) {
+	incarnation, err := getIncarnation(tr.Store, partitionId, TariffKeyPrefix+tariffId)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := queryEntities[models.TariffVersion](tr.Store, partitionId, versionKeyPrefix(tariffId, incarnation))
+	if err != nil {
+		return nil, err
+	}
+
+	return &versions, nil
+}
+
+// GetAllTariffVersions returns the versions of every tariff of the partition like GetTariffVersions does, ordered by
+// the id of the tariff. A tariff deleted since the tariffs were read is skipped.
+func (tr TariffRepo) GetAllTariffVersions(partitionId string) (*[][]models.TariffVersion, error) {
+	tariffs, err := queryMatchingEntities[models.Tariff](tr.Store, partitionId, isTariffKey)
+	if err != nil {
+		return nil, err
+	}
+	allVersions := [][]models.TariffVersion{}
+	for _, tariff := range tariffs {
+		versions, err := tr.GetTariffVersions(partitionId, tariff.Id)
+		if errors.Is(err, dberrors.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		allVersions = append(allVersions, *versions)
+	}
+
+	return &allVersions, nil
+}
+
+// CreateTariff creates the tariff with its first version and its audit records. It returns dberrors.ErrConflict if
+// the tariff exists.
+func (tr TariffRepo) CreateTariff(partitionId string, tariff models.Tariff, records ...models.AuditRecord) (*models.Tariff, error) {
+	additions, err := auditAdditions(records)
+	if err != nil {
+		return &models.Tariff{}, err
+	}
+	err = createRevisedEntity(tr.Store, partitionId, TariffKeyPrefix+tariff.Id, tariff, tariffVersion(tariff), additions)
+	if err != nil {
+		return &models.Tariff{}, err
+	}
+
+	return &tariff, nil
+}
+
+// UpdateTariff replaces the tariff with its audit records if it has the expected version, adds the new version to
+// its history and returns the new version.
+func (tr TariffRepo) UpdateTariff(partitionId string, tariff models.Tariff, version int, records ...models.AuditRecord) (int, error) {
+	additions, err := auditAdditions(records)
+	if err != nil {
+		return 0, err
+	}
+
+	return replaceRevisedEntity(tr.Store, partitionId, TariffKeyPrefix+tariff.Id, tariff, version, tariffVersion(tariff), additions)
+}
+
+// DeleteTariff deletes the tariff if it has the expected version, and writes the changes of the contracts that referred
+// to it and the audit records at once. Its versions are kept. It returns dberrors.ErrConflict if a contract changed
+// since it was read, or if a contract still refers to the tariff after the changes.
+func (tr TariffRepo) DeleteTariff(partitionId, tariffId string, version int, contracts []models.ContractChange, records ...models.AuditRecord) error {
+	changes, err := contractChanges(contracts)
+	if err != nil {
+		return err
+	}
+	additions, err := auditAdditions(records)
+	if err != nil {
+		return err
+	}
+
+	refersTo := contractsReferring(func(contract models.Contract) bool {
+		return slices.Contains(contract.Tariffs, tariffId)
+	})
+
+	return deleteVersionedEntity(tr.Store, partitionId, TariffKeyPrefix+tariffId, version, refersTo, changes, additions)
+}
+
+// tariffVersion returns the version item of the tariff, effective from now.
+func tariffVersion(tariff models.Tariff) revision {
+	return func(incarnation string, version int) (string, any) {
+		return fmt.Sprintf("%s%010d", versionKeyPrefix(tariff.Id, incarnation), version), models.TariffVersion{
+			Version:       version,
+			EffectiveFrom: time.Now().UTC().Format(time.RFC3339),
+			Tariff:        tariff,
+		}
+	}
+}
+
+func versionKeyPrefix(tariffId, incarnation string) string {
+	return TariffKeyPrefix + tariffId + TariffVersionKeyInfix + incarnation + "#"
+}
+
+// isTariffKey reports whether the key is the key of a tariff rather than of one of its versions.
+func isTariffKey(key string) bool {
+	return strings.HasPrefix(key, TariffKeyPrefix) && !strings.Contains(key, TariffVersionKeyInfix)
+}
@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"tariff-calculation-service/internal/models"
+)
+
+type TaxRuleRepo struct {
+	Store *Store
+}
+
+func NewTaxRuleRepo() TaxRuleRepo {
+	return TaxRuleRepo{
+		Store: SharedStore(),
+	}
+}
+
+func (trr TaxRuleRepo) GetTaxRules(partitionId string) (*[]models.TaxRule, error) {
+	taxRules, err := queryEntities[models.TaxRule](trr.Store, partitionId, TaxRuleKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return &taxRules, nil
+}
+
+func (trr TaxRuleRepo) GetTaxRule(partitionId, taxRuleId string) (*models.TaxRule, error) {
+	taxRule, err := getEntity[models.TaxRule](trr.Store, partitionId, TaxRuleKeyPrefix+taxRuleId)
+	if err != nil {
+		return &models.TaxRule{}, err
+	}
+
+	return taxRule, nil
+}
+
+func (trr TaxRuleRepo) CreateTaxRule(partitionId string, taxRule models.TaxRule) (*models.TaxRule, error) {
+	err := appendEntity(trr.Store, partitionId, TaxRuleKeyPrefix+taxRule.Id, taxRule, nil)
+	if err != nil {
+		return &models.TaxRule{}, err
+	}
+
+	return &taxRule, nil
+}
+
+func (trr TaxRuleRepo) UpdateTaxRule(partitionId string, taxRule models.TaxRule) error {
+	return replaceEntity(trr.Store, partitionId, TaxRuleKeyPrefix+taxRule.Id, taxRule)
+}
+
+func (trr TaxRuleRepo) DeleteTaxRule(partitionId, taxRuleId string) error {
+	return deleteEntity(trr.Store, partitionId, TaxRuleKeyPrefix+taxRuleId)
+}
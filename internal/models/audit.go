@@ -0,0 +1,46 @@
+package models
+
+import "encoding/json"
+
+// The entity types and operations of audit records.
+const (
+	AuditEntityContract = "contract"
+	AuditEntityProvider = "provider"
+	AuditEntityTariff   = "tariff"
+
+	AuditOperationCreate = "create"
+	AuditOperationUpdate = "update"
+	AuditOperationDelete = "delete"
+)
+
+// AuditRecord records a write of an entity: who wrote it, when, and what changed. Records are appended and
+// never changed or deleted.
+type AuditRecord struct {
+	Id          string        `json:"id"`
+	Actor       string        `json:"actor"`
+	Timestamp   string        `json:"timestamp"`
+	PartitionId string        `json:"partitionId"`
+	EntityType  string        `json:"entityType"`
+	EntityId    string        `json:"entityId"`
+	Operation   string        `json:"operation"`
+	Changes     []AuditChange `json:"changes"`
+}
+
+// AuditChange is a value of an entity that a write changed, addressed by its JSON pointer. Before is missing
+// for a value that was added and After for a value that was removed, e.g. the whole entity on a create or
+// delete.
+type AuditChange struct {
+	Pointer string          `json:"pointer"`
+	Before  json.RawMessage `json:"before,omitempty"`
+	After   json.RawMessage `json:"after,omitempty"`
+}
+
+// AuditFilter are the query parameters to filter the audit records of a partition. From and To select the
+// records written in the half-open range [From, To).
+type AuditFilter struct {
+	EntityType string `form:"entityType" binding:"omitempty,oneof=contract provider tariff"`
+	EntityId   string `form:"entityId" binding:"max=64"`
+	Actor      string `form:"actor" binding:"max=256"`
+	From       string `form:"from" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	To         string `form:"to" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
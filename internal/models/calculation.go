@@ -0,0 +1,182 @@
+package models
+
+import (
+	"tariff-calculation-service/pkg/enums"
+	"tariff-calculation-service/pkg/money"
+)
+
+type CalculationRequest struct {
+	Quantity       money.Decimal `json:"quantity" binding:"gte=0"`
+	From           string        `json:"from" binding:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	To             string        `json:"to" binding:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	CountryCode    string        `json:"countryCode" binding:"omitempty,iso3166_1_alpha3"`
+	TargetCurrency string        `json:"targetCurrency" binding:"omitempty,iso4217"`
+}
+
+type Calculation struct {
+	TariffId        string        `json:"tariffId"`
+	Currency        string        `json:"currency"`
+	From            string        `json:"from"`
+	To              string        `json:"to"`
+	Quantity        money.Decimal `json:"quantity"`
+	PricePerUnit    money.Decimal `json:"pricePerUnit"`
+	ConsumptionCost money.Decimal `json:"consumptionCost"`
+	Cost            money.Decimal `json:"cost"`
+	Slots           []SlotCost    `json:"slots,omitempty"`
+	Tiers           []TierCost    `json:"tiers,omitempty"`
+	Charges         []ChargeCost  `json:"charges,omitempty"`
+	Tax             *Taxation     `json:"tax,omitempty"`
+	Conversion      *Conversion   `json:"conversion,omitempty"`
+}
+
+type SlotCost struct {
+	From         string        `json:"from"`
+	To           string        `json:"to"`
+	StartTime    string        `json:"startTime"`
+	Quantity     money.Decimal `json:"quantity"`
+	PricePerUnit money.Decimal `json:"pricePerUnit"`
+	Cost         money.Decimal `json:"cost"`
+}
+
+type TierCost struct {
+	From         money.Decimal  `json:"from"`
+	UpTo         *money.Decimal `json:"upTo,omitempty"`
+	Quantity     money.Decimal  `json:"quantity"`
+	PricePerUnit money.Decimal  `json:"pricePerUnit"`
+	Cost         money.Decimal  `json:"cost"`
+}
+
+type ChargeCost struct {
+	TariffId  string                `json:"tariffId"`
+	Name      string                `json:"name"`
+	Frequency enums.ChargeFrequency `json:"frequency"`
+	Currency  string                `json:"currency"`
+	Amount    money.Decimal         `json:"amount"`
+	From      string                `json:"from"`
+	To        string                `json:"to"`
+	Days      float64               `json:"days"`
+	Cost      money.Decimal         `json:"cost"`
+}
+
+type Taxation struct {
+	TaxRuleId   string        `json:"taxRuleId,omitempty"`
+	CountryCode string        `json:"countryCode,omitempty"`
+	NetCost     money.Decimal `json:"netCost"`
+	Taxes       []TaxCost     `json:"taxes"`
+	TaxCost     money.Decimal `json:"taxCost"`
+	GrossCost   money.Decimal `json:"grossCost"`
+}
+
+type TaxCost struct {
+	Name    string        `json:"name"`
+	TaxType enums.TaxType `json:"taxType"`
+	Rate    money.Decimal `json:"rate"`
+	Base    money.Decimal `json:"base"`
+	Cost    money.Decimal `json:"cost"`
+}
+
+// Conversion holds the costs of all currencies converted to the target currency of the request. Every amount
+// is converted with the rate that is valid on its date, the applied rates are listed.
+type Conversion struct {
+	Currency  string        `json:"currency"`
+	Cost      money.Decimal `json:"cost"`
+	TaxCost   money.Decimal `json:"taxCost"`
+	GrossCost money.Decimal `json:"grossCost"`
+	Rates     []FxRate      `json:"rates"`
+}
+
+type PriceRequest struct {
+	At string `form:"at" binding:"required,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+type Price struct {
+	TariffId     string        `json:"tariffId"`
+	Currency     string        `json:"currency"`
+	At           string        `json:"at"`
+	StartTime    string        `json:"startTime,omitempty"`
+	PricePerUnit money.Decimal `json:"pricePerUnit"`
+}
+
+type IntervalCalculationRequest struct {
+	TariffType     *enums.TariffType `json:"tariffType"`
+	CountryCode    string            `json:"countryCode" binding:"omitempty,iso3166_1_alpha3"`
+	TargetCurrency string            `json:"targetCurrency" binding:"omitempty,iso4217"`
+	Resolution     int               `json:"resolution" binding:"required,gt=0,lte=1440"`
+	Readings       []Reading         `json:"readings" binding:"required,min=1,dive"`
+}
+
+type Reading struct {
+	Timestamp string        `json:"timestamp" binding:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	Quantity  money.Decimal `json:"quantity" binding:"gte=0"`
+}
+
+type IntervalCalculation struct {
+	ContractId   string          `json:"contractId"`
+	Resolution   int             `json:"resolution"`
+	Intervals    []IntervalCost  `json:"intervals"`
+	Charges      []ChargeCost    `json:"charges"`
+	TariffTotals []IntervalTotal `json:"tariffTotals"`
+	Totals       []IntervalTotal `json:"totals"`
+	Conversion   *Conversion     `json:"conversion,omitempty"`
+}
+
+type IntervalCost struct {
+	From         string        `json:"from"`
+	To           string        `json:"to"`
+	TariffId     string        `json:"tariffId"`
+	Currency     string        `json:"currency"`
+	Quantity     money.Decimal `json:"quantity"`
+	PricePerUnit money.Decimal `json:"pricePerUnit"`
+	Cost         money.Decimal `json:"cost"`
+}
+
+type IntervalTotal struct {
+	TariffId string        `json:"tariffId,omitempty"`
+	Currency string        `json:"currency"`
+	Quantity money.Decimal `json:"quantity"`
+	Cost     money.Decimal `json:"cost"`
+	Tax      *Taxation     `json:"tax,omitempty"`
+}
+
+type BillRequest struct {
+	From           string            `json:"from" binding:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	To             string            `json:"to" binding:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	CountryCode    string            `json:"countryCode" binding:"omitempty,iso3166_1_alpha3"`
+	TargetCurrency string            `json:"targetCurrency" binding:"omitempty,iso4217"`
+	Consumptions   []BillConsumption `json:"consumptions" binding:"required,min=1,dive"`
+}
+
+type BillConsumption struct {
+	TariffType enums.TariffType `json:"tariffType" binding:"max=128"`
+	Quantity   money.Decimal    `json:"quantity" binding:"gte=0"`
+}
+
+type Bill struct {
+	ContractId       string       `json:"contractId"`
+	From             string       `json:"from"`
+	To               string       `json:"to"`
+	Lines            []BillLine   `json:"lines"`
+	Charges          []ChargeCost `json:"charges"`
+	TariffTypeTotals []BillTotal  `json:"tariffTypeTotals"`
+	Totals           []BillTotal  `json:"totals"`
+	Conversion       *Conversion  `json:"conversion,omitempty"`
+}
+
+type BillLine struct {
+	TariffType   enums.TariffType `json:"tariffType"`
+	TariffId     string           `json:"tariffId"`
+	From         string           `json:"from"`
+	To           string           `json:"to"`
+	Currency     string           `json:"currency"`
+	Quantity     money.Decimal    `json:"quantity"`
+	PricePerUnit money.Decimal    `json:"pricePerUnit"`
+	Cost         money.Decimal    `json:"cost"`
+}
+
+type BillTotal struct {
+	TariffType *enums.TariffType `json:"tariffType,omitempty"`
+	Currency   string            `json:"currency"`
+	Quantity   money.Decimal     `json:"quantity"`
+	Cost       money.Decimal     `json:"cost"`
+	Tax        *Taxation         `json:"tax,omitempty"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"tariff-calculation-service/pkg/enums"
+	"tariff-calculation-service/pkg/money"
+)
+
+type ComparisonRequest struct {
+	TariffType *enums.TariffType `json:"tariffType" binding:"omitempty,lte=4"`
+	Currency   string            `json:"currency" binding:"omitempty,iso4217"`
+	Resolution int               `json:"resolution" binding:"required,gt=0,lte=1440"`
+	Readings   []Reading         `json:"readings" binding:"required,min=1,dive"`
+}
+
+// Comparison ranks the tariffs of a partition by the cost of a consumption profile. Savings are the
+// difference to the cost of the current contract in the same currency.
+type Comparison struct {
+	ContractId string             `json:"contractId"`
+	Current    *ComparisonCost    `json:"current,omitempty"`
+	Tariffs    []TariffComparison `json:"tariffs"`
+}
+
+type ComparisonCost struct {
+	Currency string        `json:"currency"`
+	Quantity money.Decimal `json:"quantity"`
+	Cost     money.Decimal `json:"cost"`
+}
+
+type TariffComparison struct {
+	Rank       int              `json:"rank"`
+	TariffId   string           `json:"tariffId"`
+	Name       string           `json:"name"`
+	TariffType enums.TariffType `json:"tariffType"`
+	Current    bool             `json:"current"`
+	Currency   string           `json:"currency"`
+	Quantity   money.Decimal    `json:"quantity"`
+	Cost       money.Decimal    `json:"cost"`
+	Savings    *money.Decimal   `json:"savings,omitempty"`
+}
@@ -9,3 +9,10 @@ type Contract struct {
 	Provider    string   `json:"provider" binding:"uuid"`
 	Tariffs     []string `json:"tariffs" binding:"dive,uuid"`
 }
+
+// ContractChange is a write of a contract that is only applied if the contract is still as it was read. Before is
+// the contract as it was read, After the contract to write, or nil to delete the contract.
+type ContractChange struct {
+	Before Contract
+	After  *Contract
+}
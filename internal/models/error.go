@@ -1,57 +1,175 @@
 package models
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"reflect"
 	"strings"
 	"tariff-calculation-service/pkg/constants"
 
 	"github.com/go-playground/validator/v10"
 )
 
+// ProblemTypePrefix is the prefix of the type URIs of the problems of this service. The type URI ends in
+// the name of the problem, e.g. ResourceNotFound.
+const ProblemTypePrefix = "urn:tariff-calculation-service:problem:"
+
+// Error is a problem details document as of RFC 7807. TraceId is the id of the request that failed, Errors
+// lists the invalid fields of a bad request and Contracts the contracts that refer to an entity that cannot be
+// deleted.
 type Error struct {
-	Code   int
-	Name   string
-	Detail string
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail"`
+	TraceId   string       `json:"traceId,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+	Contracts []string     `json:"contracts,omitempty"`
 }
 
-func NewResourceNotFoundError() Error {
+// FieldError is an invalid field of a request. Pointer is the JSON pointer of the field, Rule the validation
+// rule it failed and Allowed the parameter of the rule, e.g. the maximum length of max=64.
+type FieldError struct {
+	Pointer string `json:"pointer"`
+	Rule    string `json:"rule"`
+	Allowed string `json:"allowed,omitempty"`
+}
+
+func newError(status int, name string, detail string) Error {
 	return Error{
-		Code:   404,
-		Name:   constants.ResourceNotFound,
-		Detail: "Resource not found",
+		Type:   ProblemTypePrefix + name,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
 	}
 }
 
+func NewResourceNotFoundError() Error {
+	return newError(http.StatusNotFound, constants.ResourceNotFound, "Resource not found")
+}
+
+func NewMethodNotAllowedError() Error {
+	return newError(http.StatusMethodNotAllowed, constants.MethodNotAllowed, "The method is not allowed for the resource")
+}
+
+func NewPreconditionFailedError() Error {
+	return newError(http.StatusPreconditionFailed, constants.PreconditionFailed, "The resource has been modified")
+}
+
+func NewPreconditionRequiredError() Error {
+	return newError(http.StatusPreconditionRequired, constants.PreconditionRequired, "The If-Match header is required")
+}
+
+func NewConflictError() Error {
+	return newError(http.StatusConflict, constants.Conflict, "The resource has been modified concurrently")
+}
+
+// NewReferencedError returns a conflict that lists the contracts that still refer to the entity to delete.
+func NewReferencedError(contractIds []string) Error {
+	problem := newError(http.StatusConflict, constants.Referenced, "The resource is referenced by contracts")
+	problem.Contracts = contractIds
+
+	return problem
+}
+
+// NewTooLargeError returns the problem of a write of more items than the storage writes at once.
+func NewTooLargeError() Error {
+	return newError(http.StatusUnprocessableEntity, constants.TooLarge, "The write changes too many items at once, e.g. a cascade over too many contracts")
+}
+
+func NewThrottledError() Error {
+	return newError(http.StatusTooManyRequests, constants.Throttled, "Too many requests, retry later")
+}
+
+func NewUnavailableError() Error {
+	return newError(http.StatusServiceUnavailable, constants.Unavailable, "The storage is unavailable, retry later")
+}
+
 func NewInternalServerError() Error {
-	return Error{
-		Code:   500,
-		Name:   constants.InternalServerError,
-		Detail: "Internal Server Error",
-	}
+	return newError(http.StatusInternalServerError, constants.InternalServerError, "Internal Server Error")
 }
 
+// NewBadRequestFieldValidationError returns a bad request that lists the fields that failed the validation of
+// the binding tags, or a field of a JSON body of the wrong type. Other errors are plain bad requests.
 func NewBadRequestFieldValidationError(err error) Error {
-	var validationError validator.ValidationErrors
-	if !errors.As(err, &validationError) {
-		return NewBadRequestError(err)
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		fieldErrors := make([]FieldError, 0, len(validationErrors))
+		for _, field := range validationErrors {
+			fieldErrors = append(fieldErrors, FieldError{
+				Pointer: fieldPointer(field.Namespace()),
+				Rule:    field.Tag(),
+				Allowed: field.Param(),
+			})
+		}
+		return NewFieldValidationError(fieldErrors)
 	}
-	var validationErrors []string
-	for _, field := range err.(validator.ValidationErrors) {
-		validationErrors = append(validationErrors, field.Field())
+	var typeError *json.UnmarshalTypeError
+	if errors.As(err, &typeError) && typeError.Field != "" {
+		return NewFieldValidationError([]FieldError{{
+			Pointer: "/" + strings.ReplaceAll(typeError.Field, ".", "/"),
+			Rule:    "type",
+			Allowed: jsonType(typeError.Type),
+		}})
 	}
 
-	return Error{
-		Code:   400,
-		Name:   constants.BadRequest,
-		Detail: fmt.Sprintf("Invalid value: %s", strings.Join(validationErrors, ", ")),
+	return NewBadRequestError(err)
+}
+
+// NewFieldValidationError returns a bad request that lists the invalid fields.
+func NewFieldValidationError(fieldErrors []FieldError) Error {
+	pointers := make([]string, 0, len(fieldErrors))
+	for _, fieldError := range fieldErrors {
+		pointers = append(pointers, fieldError.Pointer)
 	}
+	problem := newError(http.StatusBadRequest, constants.BadRequest, fmt.Sprintf("Invalid value: %s", strings.Join(pointers, ", ")))
+	problem.Errors = fieldErrors
+
+	return problem
 }
 
 func NewBadRequestError(err error) Error {
-	return Error{
-		Code:   400,
-		Name:   constants.BadRequest,
-		Detail: err.Error(),
+	return newError(http.StatusBadRequest, constants.BadRequest, err.Error())
+}
+
+// jsonType returns the JSON type of the values of a Go type.
+func jsonType(goType reflect.Type) string {
+	switch goType.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Struct, reflect.Map:
+		return "object"
+	default:
+		return "number"
 	}
 }
+
+// fieldPointer returns the JSON pointer of a field from its validator namespace, e.g. /fixedCharges/0/amount
+// for Tariff.fixedCharges[0].amount. The namespace starts with the name of the validated struct, which is the
+// root of the document.
+func fieldPointer(namespace string) string {
+	_, path, found := strings.Cut(namespace, ".")
+	if !found {
+		return ""
+	}
+	escaper := strings.NewReplacer("~", "~0", "/", "~1")
+	var pointer strings.Builder
+	for _, segment := range strings.Split(path, ".") {
+		name, indexes, _ := strings.Cut(segment, "[")
+		pointer.WriteString("/" + escaper.Replace(name))
+		if indexes == "" {
+			continue
+		}
+		for _, index := range strings.Split(strings.TrimSuffix(indexes, "]"), "][") {
+			pointer.WriteString("/" + escaper.Replace(index))
+		}
+	}
+
+	return pointer.String()
+}
@@ -0,0 +1,12 @@
+package models
+
+import "tariff-calculation-service/pkg/money"
+
+// FxRate is the daily exchange rate of one unit of BaseCurrency in QuoteCurrency. A rate is valid from its
+// Date until the date of the next rate of the currency pair.
+type FxRate struct {
+	BaseCurrency  string        `json:"baseCurrency" binding:"required,iso4217"`
+	QuoteCurrency string        `json:"quoteCurrency" binding:"required,iso4217,nefield=BaseCurrency"`
+	Date          string        `json:"date" binding:"required,datetime=2006-01-02"`
+	Rate          money.Decimal `json:"rate" binding:"gt=0"`
+}
@@ -0,0 +1,14 @@
+package models
+
+// PageRequest are the query parameters of paginated list endpoints. The cursor is the nextCursor of the
+// previous page.
+type PageRequest struct {
+	Limit  int    `form:"limit" binding:"omitempty,gte=1,lte=1000"`
+	Cursor string `form:"cursor"`
+}
+
+// Page is one page of a list. NextCursor is empty on the last page.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
@@ -0,0 +1,8 @@
+package models
+
+import "tariff-calculation-service/pkg/enums"
+
+// Settings holds the configuration of a partition. A partition without stored settings uses the zero value.
+type Settings struct {
+	RoundingMode enums.RoundingMode `json:"roundingMode" binding:"lte=1"`
+}
@@ -2,21 +2,25 @@ package models
 
 import (
 	"tariff-calculation-service/pkg/enums"
+	"tariff-calculation-service/pkg/money"
 )
 
 type Tariff struct {
-	Id            string           `json:"id" binding:"uuid"`
-	Name          string           `json:"name" binding:"required,max=64"`
-	Currency      string           `json:"currency" binding:"required,iso4217"`
-	ValidFrom     string           `json:"validFrom" binding:"required,datetime=2006-01-02T15:04:05Z07:00"`
-	ValidTo       string           `json:"validTo" binding:"required,datetime=2006-01-02T15:04:05Z07:00"`
-	TariffType    enums.TariffType `json:"tariffType" binding:"required,max=128"`
-	FixedTariff   FixedTariff      `json:"fixedTariff"`
-	DynamicTariff DynamicTariff    `json:"dynamicTariff"`
+	Id            string             `json:"id" binding:"uuid"`
+	Name          string             `json:"name" binding:"required,max=64"`
+	Currency      string             `json:"currency" binding:"required,iso4217"`
+	ValidFrom     string             `json:"validFrom" binding:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	ValidTo       string             `json:"validTo" binding:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	TariffType    enums.TariffType   `json:"tariffType" binding:"required,max=128"`
+	PricingModel  enums.PricingModel `json:"pricingModel" binding:"lte=2"`
+	FixedTariff   FixedTariff        `json:"fixedTariff"`
+	DynamicTariff DynamicTariff      `json:"dynamicTariff"`
+	TieredTariff  TieredTariff       `json:"tieredTariff"`
+	FixedCharges  []FixedCharge      `json:"fixedCharges" binding:"dive"`
 }
 
 type FixedTariff struct {
-	PricePerUnit float64 `json:"pricePerUnit" binding:"required,gte=0"`
+	PricePerUnit money.Decimal `json:"pricePerUnit" binding:"gte=0"`
 }
 
 type DynamicTariff struct {
@@ -24,7 +28,51 @@ type DynamicTariff struct {
 }
 
 type HourlyTariff struct {
-	StartTime    string  `json:"startTime" binding:"required,datetime=2006-01-02T15:04:05Z07:00"`
-	ValidDays    []uint8 `json:"validDays" binding:"required,min=1,max=7,gte=1,lte=7"`
-	PricePerUnit float64 `json:"pricePerUnit" binding:"required,gte=0"`
+	StartTime    string        `json:"startTime" binding:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	ValidDays    []uint8       `json:"validDays" binding:"required,min=1,max=7,gte=1,lte=7,dive,min=0,max=6"`
+	PricePerUnit money.Decimal `json:"pricePerUnit" binding:"required,gte=0"`
+}
+
+type TieredTariff struct {
+	Tiers []Tier `json:"tiers" binding:"dive"`
+}
+
+// Tier prices the consumption of a billing period up to UpTo units. The last tier has no upper bound.
+type Tier struct {
+	UpTo         money.Decimal `json:"upTo" binding:"gte=0"`
+	PricePerUnit money.Decimal `json:"pricePerUnit" binding:"gte=0"`
+}
+
+// FixedCharge is a recurring charge, e.g. a standing charge or base fee, that is due independently of the consumption.
+type FixedCharge struct {
+	Name      string                `json:"name" binding:"required,max=64"`
+	Amount    money.Decimal         `json:"amount" binding:"gte=0"`
+	Frequency enums.ChargeFrequency `json:"frequency" binding:"lte=2"`
+}
+
+// TariffFilter are the query parameters to filter and sort the tariffs of a partition. ValidAt selects the
+// tariffs that are valid at an instant, ValidFrom and ValidTo the tariffs that are valid for some time of
+// the range. Name is a prefix of the tariff name.
+type TariffFilter struct {
+	TariffType *enums.TariffType `form:"tariffType" binding:"omitempty,lte=4"`
+	Currency   string            `form:"currency" binding:"omitempty,iso4217"`
+	ValidAt    string            `form:"validAt" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	ValidFrom  string            `form:"validFrom" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	ValidTo    string            `form:"validTo" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	Name       string            `form:"name" binding:"max=64"`
+	Sort       string            `form:"sort" binding:"omitempty,oneof=name validFrom"`
+}
+
+// TariffVersion is an immutable revision of a tariff. Every create and update of the tariff adds a version, which
+// is effective from the time it was written until the next version is. Tariffs written before their history was
+// kept start with a version without EffectiveFrom.
+type TariffVersion struct {
+	Version       int    `json:"version"`
+	EffectiveFrom string `json:"effectiveFrom,omitempty"`
+	Tariff        Tariff `json:"tariff"`
+}
+
+// TariffAsOf is the query parameter to read a tariff as it was at an instant.
+type TariffAsOf struct {
+	AsOf string `form:"asOf" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
 }
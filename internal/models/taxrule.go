@@ -0,0 +1,13 @@
+package models
+
+import "tariff-calculation-service/pkg/money"
+
+// TaxRule holds the taxes of a jurisdiction. A tax rule without CountryCode is the default of its partition.
+type TaxRule struct {
+	Id               string        `json:"id" binding:"uuid"`
+	Name             string        `json:"name" binding:"required,max=64"`
+	CountryCode      string        `json:"countryCode" binding:"omitempty,iso3166_1_alpha3"`
+	VatRate          money.Decimal `json:"vatRate" binding:"gte=0,lte=100"`
+	EnergyTaxPerUnit money.Decimal `json:"energyTaxPerUnit" binding:"gte=0"`
+	Levies           []FixedCharge `json:"levies" binding:"dive"`
+}
@@ -0,0 +1,73 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"tariff-calculation-service/internal/dberrors"
+	"tariff-calculation-service/internal/models"
+)
+
+const (
+	DefaultLimit = 100
+	MaxLimit     = 1000
+)
+
+var ErrInvalidCursor = dberrors.Wrap(dberrors.ErrInvalidRequest, errors.New("invalid cursor"))
+
+// Limit returns the requested page size, or the default page size if none is requested.
+func Limit(limit int) int {
+	if limit <= 0 {
+		return DefaultLimit
+	}
+	return limit
+}
+
+// EncodeCursor returns an opaque cursor for the key of the last entity of a page. The key is specific to
+// the storage backend.
+func EncodeCursor(key map[string]string) string {
+	value, _ := json.Marshal(key)
+	return base64.RawURLEncoding.EncodeToString(value)
+}
+
+// DecodeCursor returns the key of a cursor, or nil if the cursor is empty.
+func DecodeCursor(cursor string) (map[string]string, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	value, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	key := map[string]string{}
+	if err := json.Unmarshal(value, &key); err != nil || len(key) == 0 {
+		return nil, ErrInvalidCursor
+	}
+	return key, nil
+}
+
+// OffsetPage returns the page of the items at the offset of the cursor. It pages through items that are
+// sorted in memory, where no storage key marks the position of an item.
+func OffsetPage[T any](items []T, pageRequest models.PageRequest) ([]T, string, error) {
+	cursor, err := DecodeCursor(pageRequest.Cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	offset := 0
+	if cursor != nil {
+		offset, err = strconv.Atoi(cursor["offset"])
+		if err != nil || offset < 0 {
+			return nil, "", ErrInvalidCursor
+		}
+	}
+	offset = min(offset, len(items))
+	end := min(offset+Limit(pageRequest.Limit), len(items))
+
+	nextCursor := ""
+	if end < len(items) {
+		nextCursor = EncodeCursor(map[string]string{"offset": strconv.Itoa(end)})
+	}
+	return items[offset:end], nextCursor, nil
+}
@@ -0,0 +1,72 @@
+package pagination
+
+import (
+	"testing"
+
+	"tariff-calculation-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Cursor(t *testing.T) {
+	// arrange
+	key := map[string]string{"id": "eb40ecd9-74c9-403c-9e11-33d3f1a26bfe"}
+
+	testcases := []struct {
+		name        string
+		cursor      string
+		expectedKey map[string]string
+		expectedErr error
+	}{
+		{"Positive Test", EncodeCursor(key), key, nil},
+		{"Positive Test Empty", "", nil, nil},
+		{"Negative Test Not Base64", "not a cursor!", nil, ErrInvalidCursor},
+		{"Negative Test Not A Key", EncodeCursor(map[string]string{}), nil, ErrInvalidCursor},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			actualKey, err := DecodeCursor(tc.cursor)
+
+			// assert
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedKey, actualKey)
+		})
+	}
+}
+
+func Test_Limit(t *testing.T) {
+	assert.Equal(t, DefaultLimit, Limit(0))
+	assert.Equal(t, 5, Limit(5))
+}
+
+func Test_OffsetPage(t *testing.T) {
+	// arrange
+	items := []int{1, 2, 3, 4, 5}
+
+	testcases := []struct {
+		name               string
+		pageRequest        models.PageRequest
+		expectedItems      []int
+		expectedNextCursor string
+		expectedErr        error
+	}{
+		{"Positive Test First Page", models.PageRequest{Limit: 2}, []int{1, 2}, EncodeCursor(map[string]string{"offset": "2"}), nil},
+		{"Positive Test Last Page", models.PageRequest{Limit: 2, Cursor: EncodeCursor(map[string]string{"offset": "4"})}, []int{5}, "", nil},
+		{"Positive Test Offset After Last Item", models.PageRequest{Cursor: EncodeCursor(map[string]string{"offset": "9"})}, []int{}, "", nil},
+		{"Positive Test Default Limit", models.PageRequest{}, items, "", nil},
+		{"Negative Test Cursor Without Offset", models.PageRequest{Cursor: EncodeCursor(map[string]string{"id": "1"})}, nil, "", ErrInvalidCursor},
+		{"Negative Test Negative Offset", models.PageRequest{Cursor: EncodeCursor(map[string]string{"offset": "-1"})}, nil, "", ErrInvalidCursor},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			page, nextCursor, err := OffsetPage(items, tc.pageRequest)
+
+			// assert
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedItems, page)
+			assert.Equal(t, tc.expectedNextCursor, nextCursor)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"tariff-calculation-service/internal/audit"
+	"tariff-calculation-service/internal/models"
+)
+
+const auditRecordsTable = "audit_records"
+
+type AuditRepo struct {
+	DBClient
+}
+
+func NewAuditRepo() AuditRepo {
+	return AuditRepo{
+		DBClient: NewDBClient(),
+	}
+}
+
+// AppendAuditRecord inserts the record. It returns dberrors.ErrConflict if a record with its id exists, as
+// records are never replaced.
+func (ar AuditRepo) AppendAuditRecord(partitionId string, record models.AuditRecord) error {
+	if err := ar.ensureSchema(); err != nil {
+		return err
+	}
+
+	return inTransaction(ar.DBClient, func(tx *sql.Tx) error {
+		return insertAuditRecords(tx, partitionId, []models.AuditRecord{record})
+	})
+}
+
+// insertAuditRecords inserts the records like AppendAuditRecord does, in the transaction of the write they record.
+func insertAuditRecords(tx *sql.Tx, partitionId string, records []models.AuditRecord) error {
+	for _, record := range records {
+		data, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`INSERT INTO audit_records (partition_id, id, recorded_at, entity_type, entity_id, actor, data)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`, partitionId, record.Id, record.Timestamp, record.EntityType, record.EntityId, record.Actor, string(data))
+		if err != nil {
+			return dbError(err)
+		}
+	}
+
+	return nil
+}
+
+// GetAuditRecordsPage returns one page of the audit records of the partition that match the filter, ordered
+// by time.
+func (ar AuditRepo) GetAuditRecordsPage(partitionId string, filter models.AuditFilter, pageRequest models.PageRequest) (*models.Page[models.AuditRecord], error) {
+	query, err := auditListQuery(partitionId, filter)
+	if err != nil {
+		return nil, err
+	}
+	records, nextCursor, err := queryEntitiesPage[models.AuditRecord](ar.DBClient, auditRecordsTable, query, pageRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit records: %w", err)
+	}
+
+	return &models.Page[models.AuditRecord]{Items: records, NextCursor: nextCursor}, nil
+}
+
+// auditListQuery selects the audit records of the filter. The timestamps of the filter are converted to the
+// layout of the records, which compare as strings.
+func auditListQuery(partitionId string, filter models.AuditFilter) (*listQuery, error) {
+	query := newListQuery(partitionId)
+	if filter.EntityType != "" {
+		query.where(`entity_type = %s`, filter.EntityType)
+	}
+	if filter.EntityId != "" {
+		query.where(`entity_id = %s`, filter.EntityId)
+	}
+	if filter.Actor != "" {
+		query.where(`actor = %s`, filter.Actor)
+	}
+	if filter.From != "" {
+		from, err := audit.FilterTimestamp(filter.From)
+		if err != nil {
+			return nil, err
+		}
+		query.where(`recorded_at >= %s`, from)
+	}
+	if filter.To != "" {
+		to, err := audit.FilterTimestamp(filter.To)
+		if err != nil {
+			return nil, err
+		}
+		query.where(`recorded_at < %s`, to)
+	}
+	query.sortBy = "recorded_at"
+
+	return query, nil
+}
@@ -0,0 +1,172 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"tariff-calculation-service/internal/dberrors"
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/versioning"
+
+	"github.com/lib/pq"
+)
+
+// The conditions on the contracts that refer to a provider or a tariff. The %s is replaced by the placeholder of
+// the id of the provider or tariff.
+const (
+	providerReference = `data->>'provider' = %s`
+	tariffReference   = `data->'tariffs' ? %s`
+)
+
+type ContractRepo struct {
+	DBClient
+}
+
+func NewContractRepo() ContractRepo {
+	return ContractRepo{
+		DBClient: NewDBClient(),
+	}
+}
+
+func (cr ContractRepo) GetContracts(partitionId string) (*[]models.Contract, error) {
+	contracts, err := listEntities[models.Contract](cr.DBClient, contractsTable, partitionId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query contracts: %w", err)
+	}
+
+	return &contracts, nil
+}
+
+// GetContractsPage returns one page of the contracts of the partition.
+func (cr ContractRepo) GetContractsPage(partitionId string, pageRequest models.PageRequest) (*models.Page[models.Contract], error) {
+	contracts, nextCursor, err := listEntitiesPage[models.Contract](cr.DBClient, contractsTable, partitionId, pageRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query contracts: %w", err)
+	}
+
+	return &models.Page[models.Contract]{Items: contracts, NextCursor: nextCursor}, nil
+}
+
+// GetContractsByProvider returns one page of the contracts of the provider.
+func (cr ContractRepo) GetContractsByProvider(partitionId, providerId string, pageRequest models.PageRequest) (*models.Page[models.Contract], error) {
+	return cr.queryContractsPage(newListQuery(partitionId).where(providerReference, providerId), pageRequest)
+}
+
+// GetContractsByTariff returns one page of the contracts of the tariff.
+func (cr ContractRepo) GetContractsByTariff(partitionId, tariffId string, pageRequest models.PageRequest) (*models.Page[models.Contract], error) {
+	return cr.queryContractsPage(newListQuery(partitionId).where(tariffReference, tariffId), pageRequest)
+}
+
+func (cr ContractRepo) queryContractsPage(query *listQuery, pageRequest models.PageRequest) (*models.Page[models.Contract], error) {
+	contracts, nextCursor, err := queryEntitiesPage[models.Contract](cr.DBClient, contractsTable, query, pageRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query contracts: %w", err)
+	}
+
+	return &models.Page[models.Contract]{Items: contracts, NextCursor: nextCursor}, nil
+}
+
+func (cr ContractRepo) GetContract(partitionId, contractId string) (*models.Contract, error) {
+	contract, err := getEntity[models.Contract](cr.DBClient, contractsTable, partitionId, contractId)
+	if err != nil {
+		return &models.Contract{}, err
+	}
+
+	return contract, nil
+}
+
+// GetContractWithVersion returns the contract and its version.
+func (cr ContractRepo) GetContractWithVersion(partitionId, contractId string) (*models.Contract, int, error) {
+	return getVersionedEntity[models.Contract](cr.DBClient, contractsTable, partitionId, contractId)
+}
+
+// CreateContract creates the contract with its audit records. It returns dberrors.ErrConflict if the contract exists
+// or if the provider or a tariff it refers to does not exist.
+func (cr ContractRepo) CreateContract(partitionId string, contract models.Contract, records ...models.AuditRecord) (*models.Contract, error) {
+	err := cr.writeContract(partitionId, contract, records, func(tx *sql.Tx, data string) error {
+		_, err := tx.Exec(`INSERT INTO contracts (partition_id, id, data) VALUES ($1, $2, $3)`, partitionId, contract.Id, data)
+		return dbError(err)
+	})
+	if err != nil {
+		return &models.Contract{}, err
+	}
+
+	return &contract, nil
+}
+
+// UpdateContract replaces the contract with its audit records if it has the expected version and returns the new
+// version. It returns dberrors.ErrConflict if the provider or a tariff it refers to does not exist.
+func (cr ContractRepo) UpdateContract(partitionId string, contract models.Contract, version int, records ...models.AuditRecord) (int, error) {
+	var updatedVersion int
+	err := cr.writeContract(partitionId, contract, records, func(tx *sql.Tx, data string) error {
+		err := tx.QueryRow(fmt.Sprintf(`UPDATE contracts SET data = $3, version = version + 1
+			WHERE partition_id = $1 AND id = $2 AND ($4 = %d OR version = $4) RETURNING version`, versioning.AnyVersion),
+			partitionId, contract.Id, data, version).Scan(&updatedVersion)
+		if errors.Is(err, sql.ErrNoRows) {
+			return versionError(cr.DBClient, contractsTable, partitionId, contract.Id)
+		}
+		return dbError(err)
+	})
+
+	return updatedVersion, err
+}
+
+// DeleteContract deletes the contract with its audit records if it has the expected version.
+func (cr ContractRepo) DeleteContract(partitionId, contractId string, version int, records ...models.AuditRecord) error {
+	return deleteVersionedEntity(cr.DBClient, contractsTable, partitionId, contractId, version, "", nil, records...)
+}
+
+// writeContract runs the write of the contract in a transaction that locks the provider and the tariffs it refers
+// to, so that they cannot be deleted before it commits, and inserts the audit records. The write gets the data of
+// the contract. It returns dberrors.ErrConflict if the provider or a tariff does not exist, unless the write itself
+// failed.
+func (cr ContractRepo) writeContract(partitionId string, contract models.Contract, records []models.AuditRecord, write func(tx *sql.Tx, data string) error) error {
+	if err := cr.ensureSchema(); err != nil {
+		return err
+	}
+	data, err := json.Marshal(contract)
+	if err != nil {
+		return err
+	}
+
+	return inTransaction(cr.DBClient, func(tx *sql.Tx) error {
+		providers, err := lockRows(tx, `SELECT id FROM providers WHERE partition_id = $1 AND id = $2 FOR KEY SHARE`,
+			partitionId, contract.Provider)
+		if err != nil {
+			return err
+		}
+		tariffIds := slices.Clone(contract.Tariffs)
+		slices.Sort(tariffIds)
+		tariffIds = slices.Compact(tariffIds)
+		tariffs, err := lockRows(tx, `SELECT id FROM tariffs WHERE partition_id = $1 AND id = ANY($2) FOR KEY SHARE`,
+			partitionId, pq.Array(tariffIds))
+		if err != nil {
+			return err
+		}
+		if err := write(tx, string(data)); err != nil {
+			return err
+		}
+		if providers != 1 || tariffs != len(tariffIds) {
+			return dberrors.ErrConflict
+		}
+		return insertAuditRecords(tx, partitionId, records)
+	})
+}
+
+// lockRows locks the rows of the query until the transaction ends and returns their number.
+func lockRows(tx *sql.Tx, query string, args ...any) (int, error) {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return 0, dbError(err)
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		count++
+	}
+
+	return count, dbError(rows.Err())
+}
@@ -0,0 +1,486 @@
+package postgres
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"tariff-calculation-service/internal/dberrors"
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/pagination"
+	"tariff-calculation-service/internal/versioning"
+
+	"github.com/lib/pq"
+)
+
+const (
+	tariffsTable   = "tariffs"
+	contractsTable = "contracts"
+	providersTable = "providers"
+	taxRulesTable  = "tax_rules"
+)
+
+type DBClient struct {
+	DB     *sql.DB
+	schema *schema
+}
+
+// schema migrates the database before its first use and retries after a failed migration.
+type schema struct {
+	mutex    sync.Mutex
+	migrated bool
+}
+
+var (
+	sharedClient     DBClient
+	sharedClientOnce sync.Once
+)
+
+// NewDBClient returns the client of the database in the env variable POSTGRES_DSN, shared by all
+// repositories. The connection is opened and migrated on first use.
+func NewDBClient() DBClient {
+	sharedClientOnce.Do(func() {
+		sharedClient = OpenDBClient(os.Getenv("POSTGRES_DSN"))
+	})
+	return sharedClient
+}
+
+func OpenDBClient(dsn string) DBClient {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return DBClient{schema: &schema{}}
+	}
+	return DBClient{DB: db, schema: &schema{}}
+}
+
+func (client DBClient) ensureSchema() error {
+	if client.DB == nil {
+		return dberrors.Wrap(dberrors.ErrUnavailable, errors.New("no database connection"))
+	}
+	client.schema.mutex.Lock()
+	defer client.schema.mutex.Unlock()
+	if client.schema.migrated {
+		return nil
+	}
+	if err := Migrate(client.DB); err != nil {
+		return dberrors.Wrap(dberrors.ErrUnavailable, err)
+	}
+	client.schema.migrated = true
+
+	return nil
+}
+
+// getEntity returns the entity of the partition with the id from one of the entity tables.
+func getEntity[T any](client DBClient, table, partitionId, id string) (*T, error) {
+	return queryEntity[T](client, fmt.Sprintf(`SELECT data FROM %s WHERE partition_id = $1 AND id = $2`, table), partitionId, id)
+}
+
+// insertEntity creates the entity in one of the entity tables, and inserts the audit records in the same
+// transaction. It returns dberrors.ErrConflict if the entity exists.
+func insertEntity[T any](client DBClient, table, partitionId, id string, entity T, records ...models.AuditRecord) error {
+	if err := client.ensureSchema(); err != nil {
+		return err
+	}
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return err
+	}
+
+	return inTransaction(client, func(tx *sql.Tx) error {
+		_, err := tx.Exec(fmt.Sprintf(`INSERT INTO %s (partition_id, id, data) VALUES ($1, $2, $3)`, table), partitionId, id, string(data))
+		if err != nil {
+			return dbError(err)
+		}
+		return insertAuditRecords(tx, partitionId, records)
+	})
+}
+
+// replaceEntity replaces the entity in one of the entity tables. It returns dberrors.ErrNotFound if the
+// entity does not exist.
+func replaceEntity[T any](client DBClient, table, partitionId, id string, entity T) error {
+	if err := client.ensureSchema(); err != nil {
+		return err
+	}
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return err
+	}
+	result, err := client.DB.Exec(fmt.Sprintf(`UPDATE %s SET data = $3 WHERE partition_id = $1 AND id = $2`, table),
+		partitionId, id, string(data))
+
+	return notFoundError(result, err)
+}
+
+// deleteEntity deletes the entity from one of the entity tables. It returns dberrors.ErrNotFound if the entity
+// does not exist.
+func deleteEntity(client DBClient, table, partitionId, id string) error {
+	if err := client.ensureSchema(); err != nil {
+		return err
+	}
+	result, err := client.DB.Exec(fmt.Sprintf(`DELETE FROM %s WHERE partition_id = $1 AND id = $2`, table), partitionId, id)
+
+	return notFoundError(result, err)
+}
+
+// changeContracts writes the changes of the contracts in the transaction. Each write is conditional on the
+// contract as it was read, so it returns dberrors.ErrConflict if a contract changed or was deleted since.
+func changeContracts(tx *sql.Tx, partitionId string, changes []models.ContractChange) error {
+	for _, change := range changes {
+		before, err := json.Marshal(change.Before)
+		if err != nil {
+			return err
+		}
+		var result sql.Result
+		if change.After == nil {
+			result, err = tx.Exec(`DELETE FROM contracts WHERE partition_id = $1 AND id = $2 AND data = $3`,
+				partitionId, change.Before.Id, string(before))
+		} else {
+			after, marshalErr := json.Marshal(change.After)
+			if marshalErr != nil {
+				return marshalErr
+			}
+			result, err = tx.Exec(`UPDATE contracts SET data = $4, version = version + 1 WHERE partition_id = $1 AND id = $2 AND data = $3`,
+				partitionId, change.Before.Id, string(before), string(after))
+		}
+		if err := notFoundError(result, err); err != nil {
+			if errors.Is(err, dberrors.ErrNotFound) {
+				return dberrors.ErrConflict
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// inTransaction runs the writes in a transaction, which is committed if they succeed and rolled back otherwise.
+func inTransaction(client DBClient, write func(tx *sql.Tx) error) error {
+	tx, err := client.DB.Begin()
+	if err != nil {
+		return dbError(err)
+	}
+	defer tx.Rollback()
+
+	if err := write(tx); err != nil {
+		return err
+	}
+
+	return dbError(tx.Commit())
+}
+
+// notFoundError returns dberrors.ErrNotFound if the statement affected no rows.
+func notFoundError(result sql.Result, err error) error {
+	if err != nil {
+		return dbError(err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return dberrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// getVersionedEntity returns the entity of the partition with the id and its version from a table with a
+// version column.
+func getVersionedEntity[T any](client DBClient, table, partitionId, id string) (*T, int, error) {
+	if err := client.ensureSchema(); err != nil {
+		return nil, 0, err
+	}
+	var data []byte
+	var version int
+	err := client.DB.QueryRow(fmt.Sprintf(`SELECT data, version FROM %s WHERE partition_id = $1 AND id = $2`, table),
+		partitionId, id).Scan(&data, &version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, 0, dberrors.ErrNotFound
+	}
+	if err != nil {
+		return nil, 0, dbError(err)
+	}
+
+	var entity T
+	if err := json.Unmarshal(data, &entity); err != nil {
+		return nil, 0, err
+	}
+	return &entity, version, nil
+}
+
+// updateVersionedEntity replaces the entity if it has the expected version, inserts the audit records in the same
+// transaction and returns the incremented version. It returns versioning.ErrVersionMismatch if the entity has
+// another version, and dberrors.ErrNotFound if it does not exist.
+func updateVersionedEntity[T any](client DBClient, table, partitionId, id string, entity T, version int, records ...models.AuditRecord) (int, error) {
+	if err := client.ensureSchema(); err != nil {
+		return 0, err
+	}
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return 0, err
+	}
+	var updatedVersion int
+	err = inTransaction(client, func(tx *sql.Tx) error {
+		err := tx.QueryRow(fmt.Sprintf(`UPDATE %s SET data = $4, version = version + 1
+			WHERE partition_id = $1 AND id = $2 AND ($3 = %d OR version = $3) RETURNING version`, table, versioning.AnyVersion),
+			partitionId, id, version, string(data)).Scan(&updatedVersion)
+		if errors.Is(err, sql.ErrNoRows) {
+			return versionError(client, table, partitionId, id)
+		}
+		if err != nil {
+			return dbError(err)
+		}
+		return insertAuditRecords(tx, partitionId, records)
+	})
+
+	return updatedVersion, err
+}
+
+// deleteVersionedEntity deletes the entity if it has the expected version, and writes the changes of the
+// contracts and inserts the audit records in the same transaction. The row of the entity is locked first, so that
+// contracts that refer to it by the reference condition, if there is one, cannot be written until the transaction
+// ends. It returns versioning.ErrVersionMismatch if the entity has another version, dberrors.ErrNotFound if it does
+// not exist, and dberrors.ErrConflict if a contract changed since it was read or still refers to the entity after
+// the changes.
+func deleteVersionedEntity(client DBClient, table, partitionId, id string, version int, reference string, contracts []models.ContractChange, records ...models.AuditRecord) error {
+	if err := client.ensureSchema(); err != nil {
+		return err
+	}
+
+	return inTransaction(client, func(tx *sql.Tx) error {
+		var storedVersion int
+		err := tx.QueryRow(fmt.Sprintf(`SELECT version FROM %s WHERE partition_id = $1 AND id = $2 FOR UPDATE`, table),
+			partitionId, id).Scan(&storedVersion)
+		if errors.Is(err, sql.ErrNoRows) {
+			return dberrors.ErrNotFound
+		}
+		if err != nil {
+			return dbError(err)
+		}
+		if !versioning.Matches(storedVersion, version) {
+			return versioning.ErrVersionMismatch
+		}
+		if _, err := tx.Exec(fmt.Sprintf(`DELETE FROM %s WHERE partition_id = $1 AND id = $2`, table), partitionId, id); err != nil {
+			return dbError(err)
+		}
+		if err := changeContracts(tx, partitionId, contracts); err != nil {
+			return err
+		}
+		if err := checkReferrers(tx, partitionId, reference, id); err != nil {
+			return err
+		}
+		return insertAuditRecords(tx, partitionId, records)
+	})
+}
+
+// checkReferrers returns dberrors.ErrConflict if a contract of the partition meets the reference condition on the
+// id. There is no condition for entities that contracts do not refer to.
+func checkReferrers(tx *sql.Tx, partitionId, reference, id string) error {
+	if reference == "" {
+		return nil
+	}
+	var referred bool
+	err := tx.QueryRow(fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM contracts WHERE partition_id = $1 AND %s)`,
+		fmt.Sprintf(reference, "$2")), partitionId, id).Scan(&referred)
+	if err != nil {
+		return dbError(err)
+	}
+	if referred {
+		return dberrors.ErrConflict
+	}
+
+	return nil
+}
+
+// versionError tells why a conditional write matched no row: versioning.ErrVersionMismatch if the entity
+// exists, and dberrors.ErrNotFound if it does not.
+func versionError(client DBClient, table, partitionId, id string) error {
+	var exists bool
+	err := client.DB.QueryRow(fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s WHERE partition_id = $1 AND id = $2)`, table),
+		partitionId, id).Scan(&exists)
+	if err != nil {
+		return dbError(err)
+	}
+	if !exists {
+		return dberrors.ErrNotFound
+	}
+
+	return versioning.ErrVersionMismatch
+}
+
+// listEntities returns all entities of the partition from one of the entity tables, ordered by id.
+func listEntities[T any](client DBClient, table, partitionId string) ([]T, error) {
+	return queryEntities[T](client, fmt.Sprintf(`SELECT data FROM %s WHERE partition_id = $1 ORDER BY id`, table), partitionId)
+}
+
+// listQuery selects and orders the entities of a partition. The entities are ordered by the sort expression
+// and then by id, so every entity has a distinct position to continue a page from.
+type listQuery struct {
+	conditions []string
+	args       []any
+	sortBy     string
+}
+
+func newListQuery(partitionId string) *listQuery {
+	return &listQuery{conditions: []string{"partition_id = $1"}, args: []any{partitionId}, sortBy: "''::text"}
+}
+
+// where adds a condition. The %s of the condition is replaced by the placeholder of the argument.
+func (query *listQuery) where(condition string, arg any) *listQuery {
+	query.args = append(query.args, arg)
+	query.conditions = append(query.conditions, fmt.Sprintf(condition, fmt.Sprintf("$%d", len(query.args))))
+	return query
+}
+
+// listEntitiesPage returns one page of the entities of the partition from one of the entity tables, and the
+// cursor of the next page. The cursor is the id of the last entity of the page.
+func listEntitiesPage[T any](client DBClient, table, partitionId string, pageRequest models.PageRequest) ([]T, string, error) {
+	return queryEntitiesPage[T](client, table, newListQuery(partitionId), pageRequest)
+}
+
+// queryEntitiesPage returns one page of the entities of the query, and the cursor of the next page. The
+// cursor is the sort value and the id of the last entity of the page.
+func queryEntitiesPage[T any](client DBClient, table string, query *listQuery, pageRequest models.PageRequest) ([]T, string, error) {
+	cursor, err := pagination.DecodeCursor(pageRequest.Cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	if cursor != nil && cursor["id"] == "" {
+		return nil, "", pagination.ErrInvalidCursor
+	}
+	if cursor != nil {
+		query.args = append(query.args, cursor["value"], cursor["id"])
+		query.conditions = append(query.conditions, fmt.Sprintf("(%s, id) > ($%d, $%d)", query.sortBy, len(query.args)-1, len(query.args)))
+	}
+	limit := pagination.Limit(pageRequest.Limit)
+	if err := client.ensureSchema(); err != nil {
+		return nil, "", err
+	}
+
+	rows, err := client.DB.Query(fmt.Sprintf(`SELECT id, data, (%s)::text FROM %s WHERE %s ORDER BY %s, id LIMIT %d`,
+		query.sortBy, table, strings.Join(query.conditions, " AND "), query.sortBy, limit+1), query.args...)
+	if err != nil {
+		return nil, "", dbError(err)
+	}
+	defer rows.Close()
+
+	entities := []T{}
+	lastId, lastValue, nextCursor := "", "", ""
+	for rows.Next() {
+		if len(entities) == limit {
+			nextCursor = pagination.EncodeCursor(map[string]string{"value": lastValue, "id": lastId})
+			break
+		}
+		var data []byte
+		if err := rows.Scan(&lastId, &data, &lastValue); err != nil {
+			return nil, "", dbError(err)
+		}
+		var entity T
+		if err := json.Unmarshal(data, &entity); err != nil {
+			return nil, "", err
+		}
+		entities = append(entities, entity)
+	}
+
+	return entities, nextCursor, dbError(rows.Err())
+}
+
+func exec(client DBClient, entity any, statement string, args ...any) error {
+	if err := client.ensureSchema(); err != nil {
+		return err
+	}
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return err
+	}
+	_, err = client.DB.Exec(statement, append(args, string(data))...)
+
+	return dbError(err)
+}
+
+func queryEntity[T any](client DBClient, query string, args ...any) (*T, error) {
+	if err := client.ensureSchema(); err != nil {
+		return nil, err
+	}
+	var data []byte
+	err := client.DB.QueryRow(query, args...).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, dberrors.ErrNotFound
+	}
+	if err != nil {
+		return nil, dbError(err)
+	}
+
+	var entity T
+	if err := json.Unmarshal(data, &entity); err != nil {
+		return nil, err
+	}
+	return &entity, nil
+}
+
+func queryEntities[T any](client DBClient, query string, args ...any) ([]T, error) {
+	if err := client.ensureSchema(); err != nil {
+		return nil, err
+	}
+	rows, err := client.DB.Query(query, args...)
+	if err != nil {
+		return nil, dbError(err)
+	}
+	defer rows.Close()
+
+	entities := []T{}
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, dbError(err)
+		}
+		var entity T
+		if err := json.Unmarshal(data, &entity); err != nil {
+			return nil, err
+		}
+		entities = append(entities, entity)
+	}
+
+	return entities, dbError(rows.Err())
+}
+
+// dbError maps the errors of PostgreSQL and of the connection to the kinds of dberrors.
+func dbError(err error) error {
+	var pqErr *pq.Error
+	var netErr net.Error
+	switch {
+	case errors.As(err, &pqErr) && pqErrorKind(pqErr) != nil:
+		return dberrors.Wrap(pqErrorKind(pqErr), err)
+	case errors.Is(err, driver.ErrBadConn), errors.Is(err, sql.ErrConnDone), errors.As(err, &netErr):
+		return dberrors.Wrap(dberrors.ErrUnavailable, err)
+	default:
+		return err
+	}
+}
+
+// pqErrorKind returns the kind of a PostgreSQL error by its SQLSTATE class, or nil for other classes. See
+// https://www.postgresql.org/docs/current/errcodes-appendix.html
+func pqErrorKind(err *pq.Error) error {
+	switch err.Code.Class() {
+	case "23":
+		if err.Code.Name() == "unique_violation" {
+			return dberrors.ErrConflict
+		}
+		return dberrors.ErrValidation
+	case "22":
+		return dberrors.ErrValidation
+	case "40":
+		return dberrors.ErrConflict
+	case "53":
+		return dberrors.ErrThrottled
+	case "08", "57":
+		return dberrors.ErrUnavailable
+	default:
+		return nil
+	}
+}
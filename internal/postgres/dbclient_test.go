@@ -0,0 +1,41 @@
+package postgres
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"tariff-calculation-service/internal/dberrors"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DbError(t *testing.T) {
+	// arrange
+	undefinedTable := &pq.Error{Code: "42P01"}
+	testcases := []struct {
+		name         string
+		err          error
+		expectedKind error
+	}{
+		{"Positive Test Unique Violation", &pq.Error{Code: "23505"}, dberrors.ErrConflict},
+		{"Positive Test Not Null Violation", &pq.Error{Code: "23502"}, dberrors.ErrValidation},
+		{"Positive Test Invalid Datetime", &pq.Error{Code: "22007"}, dberrors.ErrValidation},
+		{"Positive Test Serialization Failure", &pq.Error{Code: "40001"}, dberrors.ErrConflict},
+		{"Positive Test Too Many Connections", &pq.Error{Code: "53300"}, dberrors.ErrThrottled},
+		{"Positive Test Admin Shutdown", &pq.Error{Code: "57P01"}, dberrors.ErrUnavailable},
+		{"Positive Test Bad Connection", driver.ErrBadConn, dberrors.ErrUnavailable},
+		{"Positive Test Other Error", undefinedTable, undefinedTable},
+		{"Positive Test No Error", nil, nil},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := dbError(tc.err)
+
+			// assert
+			assert.ErrorIs(t, err, tc.expectedKind)
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+}
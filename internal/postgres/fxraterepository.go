@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"fmt"
+	"time"
+
+	"tariff-calculation-service/internal/fxrate"
+	"tariff-calculation-service/internal/models"
+)
+
+type FxRateRepo struct {
+	DBClient
+}
+
+func NewFxRateRepo() FxRateRepo {
+	return FxRateRepo{
+		DBClient: NewDBClient(),
+	}
+}
+
+func (frr FxRateRepo) GetFxRates(partitionId string) (*[]models.FxRate, error) {
+	fxRates, err := queryEntities[models.FxRate](frr.DBClient, `SELECT data FROM fx_rates WHERE partition_id = $1
+		ORDER BY base_currency, quote_currency, date`, partitionId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query fx rates: %w", err)
+	}
+
+	return &fxRates, nil
+}
+
+// GetFxRate returns the rate of the currency pair that is valid on the given date. The rates of the
+// reversed pair are queried as well to fall back to the inverse rate.
+func (frr FxRateRepo) GetFxRate(partitionId, baseCurrency, quoteCurrency string, date time.Time) (*models.FxRate, error) {
+	fxRates, err := queryEntities[models.FxRate](frr.DBClient, `SELECT data FROM fx_rates WHERE partition_id = $1
+		AND ((base_currency = $2 AND quote_currency = $3) OR (base_currency = $3 AND quote_currency = $2))
+		AND date <= $4`, partitionId, baseCurrency, quoteCurrency, date.UTC().Format(fxrate.DateLayout))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query fx rates: %w", err)
+	}
+
+	return fxrate.FindRate(fxRates, baseCurrency, quoteCurrency, date)
+}
+
+// PutFxRate creates the rate of the currency pair and date, or replaces it if it exists.
+func (frr FxRateRepo) PutFxRate(partitionId string, fxRate models.FxRate) (*models.FxRate, error) {
+	err := exec(frr.DBClient, fxRate, `INSERT INTO fx_rates (partition_id, base_currency, quote_currency, date, data)
+		VALUES ($1, $2, $3, $4, $5) ON CONFLICT (partition_id, base_currency, quote_currency, date)
+		DO UPDATE SET data = EXCLUDED.data`, partitionId, fxRate.BaseCurrency, fxRate.QuoteCurrency, fxRate.Date)
+	if err != nil {
+		return &models.FxRate{}, err
+	}
+
+	return &fxRate, nil
+}
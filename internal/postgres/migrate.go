@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// migrationLockId serializes concurrent migrations of several instances with an advisory lock.
+const migrationLockId = 4711
+
+// Migrate applies all migrations that are not recorded in schema_migrations yet, in the order of their
+// file names. Each migration runs in its own transaction.
+func Migrate(db *sql.DB) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (version TEXT PRIMARY KEY)`); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	versions, err := migrationVersions()
+	if err != nil {
+		return err
+	}
+	for _, version := range versions {
+		if err := applyMigration(db, version); err != nil {
+			return fmt.Errorf("failed to apply migration %s: %w", version, err)
+		}
+	}
+
+	return nil
+}
+
+func migrationVersions() ([]string, error) {
+	entries, err := migrations.ReadDir("migrations")
+	if err != nil {
+		return nil, err
+	}
+	versions := []string{}
+	for _, entry := range entries {
+		versions = append(versions, strings.TrimSuffix(entry.Name(), ".sql"))
+	}
+	sort.Strings(versions)
+
+	return versions, nil
+}
+
+func applyMigration(db *sql.DB, version string) error {
+	statements, err := migrations.ReadFile("migrations/" + version + ".sql")
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock($1)`, migrationLockId); err != nil {
+		return err
+	}
+	var applied bool
+	if err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)`, version).Scan(&applied); err != nil {
+		return err
+	}
+	if applied {
+		return nil
+	}
+	if _, err := tx.Exec(string(statements)); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`INSERT INTO schema_migrations (version) VALUES ($1)`, version); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,30 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"tariff-calculation-service/internal/dberrors"
+)
+
+func Test_MigrationVersions(t *testing.T) {
+	// act
+	versions, err := migrationVersions()
+
+	// assert
+	assert.Nil(t, err)
+	assert.Equal(t, "0001_create_tables", versions[0])
+	assert.IsIncreasing(t, versions)
+}
+
+func Test_EnsureSchema_NoDatabase(t *testing.T) {
+	// arrange
+	client := DBClient{schema: &schema{}}
+
+	// act
+	_, err := TariffRepo{DBClient: client}.GetTariffs("partition")
+
+	// assert
+	assert.EqualError(t, err, "failed to query tariffs: no database connection")
+	assert.ErrorIs(t, err, dberrors.ErrUnavailable)
+}
@@ -0,0 +1,50 @@
+-- Entities are stored as JSONB documents, including the hourly tariffs of dynamic tariffs. Every table is
+-- keyed by the partition first, so that each query is scoped to one partition. The version of a tariff, contract
+-- or provider is incremented by every update, so that concurrent updates can be detected. Every tariff is created
+-- in a new incarnation, the time it was created, which keys its versions, see tariff_versions.
+
+CREATE TABLE tariffs (
+    partition_id TEXT             NOT NULL,
+    id           TEXT             NOT NULL,
+    incarnation  TEXT COLLATE "C" NOT NULL,
+    version      INTEGER          NOT NULL DEFAULT 1,
+    data         JSONB            NOT NULL,
+    PRIMARY KEY (partition_id, id)
+);
+
+CREATE TABLE contracts (
+    partition_id TEXT    NOT NULL,
+    id           TEXT    NOT NULL,
+    version      INTEGER NOT NULL DEFAULT 1,
+    data         JSONB   NOT NULL,
+    PRIMARY KEY (partition_id, id)
+);
+
+CREATE TABLE providers (
+    partition_id TEXT    NOT NULL,
+    id           TEXT    NOT NULL,
+    version      INTEGER NOT NULL DEFAULT 1,
+    data         JSONB   NOT NULL,
+    PRIMARY KEY (partition_id, id)
+);
+
+CREATE TABLE tax_rules (
+    partition_id TEXT  NOT NULL,
+    id           TEXT  NOT NULL,
+    data         JSONB NOT NULL,
+    PRIMARY KEY (partition_id, id)
+);
+
+CREATE TABLE settings (
+    partition_id TEXT  NOT NULL PRIMARY KEY,
+    data         JSONB NOT NULL
+);
+
+CREATE TABLE fx_rates (
+    partition_id   TEXT  NOT NULL,
+    base_currency  TEXT  NOT NULL,
+    quote_currency TEXT  NOT NULL,
+    date           TEXT  NOT NULL,
+    data           JSONB NOT NULL,
+    PRIMARY KEY (partition_id, base_currency, quote_currency, date)
+);
@@ -0,0 +1,6 @@
+-- Contracts are looked up by their provider and by their tariffs. The tariffs are indexed with GIN, which
+-- serves the ? operator on the JSONB array.
+
+CREATE INDEX contracts_provider ON contracts (partition_id, (data->>'provider'));
+
+CREATE INDEX contracts_tariffs ON contracts USING GIN ((data->'tariffs'));
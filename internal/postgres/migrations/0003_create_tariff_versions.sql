@@ -0,0 +1,14 @@
+-- Every create and update of a tariff adds an immutable version, which is effective from the time it was
+-- written. The versions are kept when the tariff is deleted, so they have no foreign key to it. They are keyed by
+-- the incarnation of the tariff, so that a tariff created again with the same id never replaces the versions of
+-- the deleted one.
+
+CREATE TABLE tariff_versions (
+    partition_id   TEXT             NOT NULL,
+    tariff_id      TEXT             NOT NULL,
+    incarnation    TEXT COLLATE "C" NOT NULL,
+    version        INTEGER          NOT NULL,
+    effective_from TIMESTAMPTZ      NOT NULL,
+    data           JSONB            NOT NULL,
+    PRIMARY KEY (partition_id, tariff_id, incarnation, version)
+);
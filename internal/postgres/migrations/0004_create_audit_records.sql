@@ -0,0 +1,29 @@
+-- Every write of a contract, provider or tariff appends an audit record. The columns the records are filtered by
+-- are copied out of the document. The timestamps are kept as written, in a fixed-width UTC layout that sorts
+-- bytewise by time. The document is JSON rather than JSONB, so that it is kept exactly as written.
+-- Audit records are append-only: a trigger rejects every update, delete and truncate.
+
+CREATE TABLE audit_records (
+    partition_id TEXT              NOT NULL,
+    id           TEXT              NOT NULL,
+    recorded_at  TEXT COLLATE "C"  NOT NULL,
+    entity_type  TEXT              NOT NULL,
+    entity_id    TEXT              NOT NULL,
+    actor        TEXT              NOT NULL,
+    data         JSON              NOT NULL,
+    PRIMARY KEY (partition_id, id)
+);
+
+CREATE INDEX audit_records_recorded_at ON audit_records (partition_id, recorded_at, id);
+
+CREATE FUNCTION reject_audit_record_change() RETURNS trigger LANGUAGE plpgsql AS $$
+BEGIN
+    RAISE EXCEPTION 'audit records are append-only';
+END;
+$$;
+
+CREATE TRIGGER audit_records_append_only BEFORE UPDATE OR DELETE ON audit_records
+    FOR EACH ROW EXECUTE FUNCTION reject_audit_record_change();
+
+CREATE TRIGGER audit_records_no_truncate BEFORE TRUNCATE ON audit_records
+    FOR EACH STATEMENT EXECUTE FUNCTION reject_audit_record_change();
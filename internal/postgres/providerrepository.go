@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"fmt"
+	"tariff-calculation-service/internal/models"
+)
+
+type ProviderRepo struct {
+	DBClient
+}
+
+func NewProviderRepo() ProviderRepo {
+	return ProviderRepo{
+		DBClient: NewDBClient(),
+	}
+}
+
+func (pr ProviderRepo) GetProviders(partitionId string) (*[]models.Provider, error) {
+	providers, err := listEntities[models.Provider](pr.DBClient, providersTable, partitionId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query providers: %w", err)
+	}
+
+	return &providers, nil
+}
+
+// GetProvidersPage returns one page of the providers of the partition.
+func (pr ProviderRepo) GetProvidersPage(partitionId string, pageRequest models.PageRequest) (*models.Page[models.Provider], error) {
+	providers, nextCursor, err := listEntitiesPage[models.Provider](pr.DBClient, providersTable, partitionId, pageRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query providers: %w", err)
+	}
+
+	return &models.Page[models.Provider]{Items: providers, NextCursor: nextCursor}, nil
+}
+
+func (pr ProviderRepo) GetProvider(partitionId, providerId string) (*models.Provider, error) {
+	provider, err := getEntity[models.Provider](pr.DBClient, providersTable, partitionId, providerId)
+	if err != nil {
+		return &models.Provider{}, err
+	}
+
+	return provider, nil
+}
+
+// GetProviderWithVersion returns the provider and its version.
+func (pr ProviderRepo) GetProviderWithVersion(partitionId, providerId string) (*models.Provider, int, error) {
+	return getVersionedEntity[models.Provider](pr.DBClient, providersTable, partitionId, providerId)
+}
+
+// CreateProvider creates the provider with its audit records. It returns dberrors.ErrConflict if the provider exists.
+func (pr ProviderRepo) CreateProvider(partitionId string, provider models.Provider, records ...models.AuditRecord) (*models.Provider, error) {
+	err := insertEntity(pr.DBClient, providersTable, partitionId, provider.Id, provider, records...)
+	if err != nil {
+		return &models.Provider{}, err
+	}
+
+	return &provider, nil
+}
+
+// UpdateProvider replaces the provider with its audit records if it has the expected version and returns the new
+// version.
+func (pr ProviderRepo) UpdateProvider(partitionId string, provider models.Provider, version int, records ...models.AuditRecord) (int, error) {
+	return updateVersionedEntity(pr.DBClient, providersTable, partitionId, provider.Id, provider, version, records...)
+}
+
+// DeleteProvider deletes the provider if it has the expected version, and writes the changes of the contracts that
+// referred to it and the audit records in the same transaction.
+func (pr ProviderRepo) DeleteProvider(partitionId, providerId string, version int, contracts []models.ContractChange, records ...models.AuditRecord) error {
+	return deleteVersionedEntity(pr.DBClient, providersTable, partitionId, providerId, version, providerReference, contracts, records...)
+}
@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"errors"
+	"tariff-calculation-service/internal/dberrors"
+	"tariff-calculation-service/internal/models"
+)
+
+type SettingsRepo struct {
+	DBClient
+}
+
+func NewSettingsRepo() SettingsRepo {
+	return SettingsRepo{
+		DBClient: NewDBClient(),
+	}
+}
+
+// GetSettings returns the settings of the partition, or the default settings if none are stored.
+func (sr SettingsRepo) GetSettings(partitionId string) (*models.Settings, error) {
+	settings, err := queryEntity[models.Settings](sr.DBClient, `SELECT data FROM settings WHERE partition_id = $1`, partitionId)
+	if errors.Is(err, dberrors.ErrNotFound) {
+		return &models.Settings{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+func (sr SettingsRepo) PutSettings(partitionId string, settings models.Settings) error {
+	return exec(sr.DBClient, settings, `INSERT INTO settings (partition_id, data) VALUES ($1, $2)
+		ON CONFLICT (partition_id) DO UPDATE SET data = EXCLUDED.data`, partitionId)
+}
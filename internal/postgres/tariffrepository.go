@@ -0,0 +1,217 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"tariff-calculation-service/internal/dberrors"
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/tariffquery"
+	"tariff-calculation-service/internal/versioning"
+	"time"
+)
+
+type TariffRepo struct {
+	DBClient
+}
+
+func NewTariffRepo() TariffRepo {
+	return TariffRepo{
+		DBClient: NewDBClient(),
+	}
+}
+
+func (tr TariffRepo) GetTariffs(partitionId string) (*[]models.Tariff, error) {
+	tariffs, err := listEntities[models.Tariff](tr.DBClient, tariffsTable, partitionId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tariffs: %w", err)
+	}
+
+	return &tariffs, nil
+}
+
+// GetTariffsPage returns one page of the tariffs of the partition that match the filter.
+func (tr TariffRepo) GetTariffsPage(partitionId string, filter models.TariffFilter, pageRequest models.PageRequest) (*models.Page[models.Tariff], error) {
+	tariffs, nextCursor, err := queryEntitiesPage[models.Tariff](tr.DBClient, tariffsTable, tariffListQuery(partitionId, filter), pageRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tariffs: %w", err)
+	}
+
+	return &models.Page[models.Tariff]{Items: tariffs, NextCursor: nextCursor}, nil
+}
+
+// tariffListQuery selects the tariffs of the filter. Timestamps are cast, so that they are compared as
+// instants whatever their offsets.
+func tariffListQuery(partitionId string, filter models.TariffFilter) *listQuery {
+	query := newListQuery(partitionId)
+	if filter.TariffType != nil {
+		query.where(`(data->>'tariffType')::int = %s`, int(*filter.TariffType))
+	}
+	if filter.Currency != "" {
+		query.where(`data->>'currency' = %s`, filter.Currency)
+	}
+	if filter.Name != "" {
+		query.where(`starts_with(data->>'name', %s)`, filter.Name)
+	}
+	if filter.ValidAt != "" {
+		query.where(`(data->>'validFrom')::timestamptz <= %s`, filter.ValidAt)
+		query.where(`(data->>'validTo')::timestamptz > %s`, filter.ValidAt)
+	}
+	if filter.ValidFrom != "" {
+		query.where(`(data->>'validTo')::timestamptz > %s`, filter.ValidFrom)
+	}
+	if filter.ValidTo != "" {
+		query.where(`(data->>'validFrom')::timestamptz < %s`, filter.ValidTo)
+	}
+	switch filter.Sort {
+	case tariffquery.SortByName:
+		query.sortBy = `data->>'name' COLLATE "C"`
+	case tariffquery.SortByValidFrom:
+		query.sortBy = `(data->>'validFrom')::timestamptz`
+	}
+
+	return query
+}
+
+func (tr TariffRepo) GetTariff(partitionId, tariffId string) (*models.Tariff, error) {
+	tariff, err := getEntity[models.Tariff](tr.DBClient, tariffsTable, partitionId, tariffId)
+	if err != nil {
+		return &models.Tariff{}, err
+	}
+
+	return tariff, nil
+}
+
+// GetTariffWithVersion returns the tariff and its version.
+func (tr TariffRepo) GetTariffWithVersion(partitionId, tariffId string) (*models.Tariff, int, error) {
+	return getVersionedEntity[models.Tariff](tr.DBClient, tariffsTable, partitionId, tariffId)
+}
+
+// GetTariffVersions returns the versions of the tariff, ordered by version. Only the versions of its incarnation are
+// read, so the versions kept of a deleted tariff with the same id are not part of its history. Of a tariff created
+// before the incarnation was kept, the versions after its current one were left behind by such a tariff and are
+// skipped.
+func (tr TariffRepo) GetTariffVersions(partitionId, tariffId string) (*[]models.TariffVersion, error) {
+	if _, _, err := getVersionedEntity[models.Tariff](tr.DBClient, tariffsTable, partitionId, tariffId); err != nil {
+		return nil, err
+	}
+	allVersions, err := tr.queryTariffVersions(`t.partition_id = $1 AND t.id = $2`, partitionId, tariffId)
+	if err != nil || len(allVersions) == 0 {
+		return &[]models.TariffVersion{}, err
+	}
+
+	return &allVersions[0], nil
+}
+
+// GetAllTariffVersions returns the versions of every tariff of the partition like GetTariffVersions does, ordered by
+// the id of the tariff, in one query.
+func (tr TariffRepo) GetAllTariffVersions(partitionId string) (*[][]models.TariffVersion, error) {
+	if err := tr.ensureSchema(); err != nil {
+		return nil, err
+	}
+	allVersions, err := tr.queryTariffVersions(`t.partition_id = $1`, partitionId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &allVersions, nil
+}
+
+// queryTariffVersions returns the versions of the tariffs of the condition on the tariffs t, grouped by tariff and
+// ordered by the id of the tariff and by version.
+func (tr TariffRepo) queryTariffVersions(condition string, args ...any) ([][]models.TariffVersion, error) {
+	rows, err := tr.DB.Query(`SELECT t.id, v.version, v.effective_from, v.data FROM tariffs t
+		JOIN tariff_versions v ON v.partition_id = t.partition_id AND v.tariff_id = t.id
+			AND v.incarnation = t.incarnation AND v.version <= t.version
+		WHERE `+condition+` ORDER BY t.id, v.version`, args...)
+	if err != nil {
+		return nil, dbError(err)
+	}
+	defer rows.Close()
+
+	allVersions := [][]models.TariffVersion{}
+	lastTariffId := ""
+	for rows.Next() {
+		var tariffId string
+		var version models.TariffVersion
+		var effectiveFrom time.Time
+		var data []byte
+		if err := rows.Scan(&tariffId, &version.Version, &effectiveFrom, &data); err != nil {
+			return nil, dbError(err)
+		}
+		if err := json.Unmarshal(data, &version.Tariff); err != nil {
+			return nil, err
+		}
+		version.EffectiveFrom = effectiveFrom.UTC().Format(time.RFC3339)
+		if len(allVersions) == 0 || tariffId != lastTariffId {
+			allVersions = append(allVersions, []models.TariffVersion{})
+			lastTariffId = tariffId
+		}
+		allVersions[len(allVersions)-1] = append(allVersions[len(allVersions)-1], version)
+	}
+
+	return allVersions, dbError(rows.Err())
+}
+
+// CreateTariff creates the tariff in a new incarnation with its first version and its audit records. It returns
+// dberrors.ErrConflict if the tariff exists.
+func (tr TariffRepo) CreateTariff(partitionId string, tariff models.Tariff, records ...models.AuditRecord) (*models.Tariff, error) {
+	_, err := tr.writeTariff(`INSERT INTO tariffs (partition_id, id, data, incarnation) VALUES ($1, $2, $3, $4)`,
+		partitionId, tariff, records, versioning.NewIncarnation())
+	if err != nil {
+		return &models.Tariff{}, err
+	}
+
+	return &tariff, nil
+}
+
+// UpdateTariff replaces the tariff with its audit records if it has the expected version, adds the new version to
+// its history and returns the new version.
+func (tr TariffRepo) UpdateTariff(partitionId string, tariff models.Tariff, version int, records ...models.AuditRecord) (int, error) {
+	updatedVersion, err := tr.writeTariff(fmt.Sprintf(`UPDATE tariffs SET data = $3, version = version + 1
+		WHERE partition_id = $1 AND id = $2 AND ($4 = %d OR version = $4)`, versioning.AnyVersion), partitionId, tariff, records, version)
+	if errors.Is(err, dberrors.ErrNotFound) {
+		return 0, versionError(tr.DBClient, tariffsTable, partitionId, tariff.Id)
+	}
+
+	return updatedVersion, err
+}
+
+// writeTariff runs the insert or update of the tariff and adds the written version to the history of its
+// incarnation in the same statement, followed by the audit records in the same transaction. Versions are immutable,
+// so it returns dberrors.ErrConflict if the version exists. The write gets the partition, the id and the data of the
+// tariff as $1 to $3, followed by the args. It returns dberrors.ErrNotFound if it wrote no tariff.
+func (tr TariffRepo) writeTariff(write, partitionId string, tariff models.Tariff, records []models.AuditRecord, args ...any) (int, error) {
+	if err := tr.ensureSchema(); err != nil {
+		return 0, err
+	}
+	data, err := json.Marshal(tariff)
+	if err != nil {
+		return 0, err
+	}
+	effectiveFrom := time.Now().UTC().Format(time.RFC3339)
+	var version int
+	err = inTransaction(tr.DBClient, func(tx *sql.Tx) error {
+		err := tx.QueryRow(fmt.Sprintf(`WITH tariff AS (%s RETURNING partition_id, id, incarnation, version, data)
+			INSERT INTO tariff_versions (partition_id, tariff_id, incarnation, version, effective_from, data)
+			SELECT partition_id, id, incarnation, version, $%d, data FROM tariff
+			RETURNING version`, write, len(args)+4),
+			append([]any{partitionId, tariff.Id, string(data)}, append(args, effectiveFrom)...)...).Scan(&version)
+		if errors.Is(err, sql.ErrNoRows) {
+			return dberrors.ErrNotFound
+		}
+		if err != nil {
+			return dbError(err)
+		}
+		return insertAuditRecords(tx, partitionId, records)
+	})
+
+	return version, err
+}
+
+// DeleteTariff deletes the tariff if it has the expected version, and writes the changes of the contracts that
+// referred to it and the audit records in the same transaction. Its versions are kept.
+func (tr TariffRepo) DeleteTariff(partitionId, tariffId string, version int, contracts []models.ContractChange, records ...models.AuditRecord) error {
+	return deleteVersionedEntity(tr.DBClient, tariffsTable, partitionId, tariffId, version, tariffReference, contracts, records...)
+}
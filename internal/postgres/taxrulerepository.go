@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"fmt"
+	"tariff-calculation-service/internal/models"
+)
+
+type TaxRuleRepo struct {
+	DBClient
+}
+
+func NewTaxRuleRepo() TaxRuleRepo {
+	return TaxRuleRepo{
+		DBClient: NewDBClient(),
+	}
+}
+
+func (trr TaxRuleRepo) GetTaxRules(partitionId string) (*[]models.TaxRule, error) {
+	taxRules, err := listEntities[models.TaxRule](trr.DBClient, taxRulesTable, partitionId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tax rules: %w", err)
+	}
+
+	return &taxRules, nil
+}
+
+func (trr TaxRuleRepo) GetTaxRule(partitionId, taxRuleId string) (*models.TaxRule, error) {
+	taxRule, err := getEntity[models.TaxRule](trr.DBClient, taxRulesTable, partitionId, taxRuleId)
+	if err != nil {
+		return &models.TaxRule{}, err
+	}
+
+	return taxRule, nil
+}
+
+func (trr TaxRuleRepo) CreateTaxRule(partitionId string, taxRule models.TaxRule) (*models.TaxRule, error) {
+	err := insertEntity(trr.DBClient, taxRulesTable, partitionId, taxRule.Id, taxRule)
+	if err != nil {
+		return &models.TaxRule{}, err
+	}
+
+	return &taxRule, nil
+}
+
+func (trr TaxRuleRepo) UpdateTaxRule(partitionId string, taxRule models.TaxRule) error {
+	return replaceEntity(trr.DBClient, taxRulesTable, partitionId, taxRule.Id, taxRule)
+}
+
+func (trr TaxRuleRepo) DeleteTaxRule(partitionId, taxRuleId string) error {
+	return deleteEntity(trr.DBClient, taxRulesTable, partitionId, taxRuleId)
+}
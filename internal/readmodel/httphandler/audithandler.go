@@ -0,0 +1,78 @@
+//go:generate mockgen -source=audithandler.go -destination=testing/audithandler_mocks.go -package=testing AuditGetter
+
+package httphandler
+
+import (
+	"errors"
+	"net/http"
+
+	"tariff-calculation-service/internal/audit"
+	"tariff-calculation-service/internal/interfaces"
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/pagination"
+	"tariff-calculation-service/internal/repository"
+	"tariff-calculation-service/pkg"
+	"tariff-calculation-service/pkg/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuditGetter interface {
+	GetAuditRecordsPage(partitionId string, filter models.AuditFilter, pageRequest models.PageRequest) (*models.Page[models.AuditRecord], error)
+}
+
+type AuditHandler struct {
+	AuditRepo AuditGetter
+	Validator interfaces.Validator
+}
+
+func NewAuditHandler() AuditHandler {
+	return AuditHandler{
+		AuditRepo: repository.NewAuditRepo(),
+		Validator: validation.NewValidator(),
+	}
+}
+
+func (handler AuditHandler) HandleGetAuditRecords(context *gin.Context) {
+	pathParam := validation.PartitionId{}
+	if err := handler.Validator.ValidateAndSetPathParams(context, &pathParam); err != nil {
+		return
+	}
+
+	filter := models.AuditFilter{}
+	if err := context.ShouldBindQuery(&filter); err != nil {
+		pkg.RespondWithError(context, models.NewBadRequestFieldValidationError(err))
+		return
+	}
+	if !validRange(filter) {
+		pkg.RespondWithError(context, models.NewFieldValidationError([]models.FieldError{{Pointer: "/to", Rule: "gtefield", Allowed: "from"}}))
+		return
+	}
+	pageRequest := models.PageRequest{}
+	if err := context.ShouldBindQuery(&pageRequest); err != nil {
+		pkg.RespondWithError(context, models.NewBadRequestFieldValidationError(err))
+		return
+	}
+
+	records, err := handler.AuditRepo.GetAuditRecordsPage(pathParam.PartitionId, filter, pageRequest)
+	if errors.Is(err, pagination.ErrInvalidCursor) {
+		pkg.RespondWithError(context, models.NewBadRequestError(err))
+		return
+	}
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	context.IndentedJSON(http.StatusOK, records)
+}
+
+// validRange reports whether the time range of the filter does not end before it starts. The binding has
+// validated the timestamps.
+func validRange(filter models.AuditFilter) bool {
+	if filter.From == "" || filter.To == "" {
+		return true
+	}
+	from, fromErr := audit.FilterTimestamp(filter.From)
+	to, toErr := audit.FilterTimestamp(filter.To)
+	return fromErr != nil || toErr != nil || from <= to
+}
@@ -0,0 +1,154 @@
+package httphandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"tariff-calculation-service/internal/interfaces"
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/pagination"
+	repotesting "tariff-calculation-service/internal/readmodel/httphandler/testing"
+	"tariff-calculation-service/pkg"
+	"tariff-calculation-service/pkg/constants"
+	"tariff-calculation-service/test"
+	"tariff-calculation-service/test/data"
+	"tariff-calculation-service/test/mocks"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type dependenciesAuditHandler struct {
+	repo      AuditGetter
+	validator interfaces.Validator
+}
+
+type testCaseAuditHandler struct {
+	name                 string
+	ctx                  *gin.Context
+	deps                 dependenciesAuditHandler
+	expectedResponseCode int
+	expectedResponse     any
+	mockFunc             func()
+}
+
+func Test_HandleGetAuditRecords(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockAuditGetter := repotesting.NewMockAuditGetter(mockController)
+	mockValidator := mocks.NewValidatorPathPositive(mockController)
+	mockValidatorNegative := mocks.NewValidatorPathNegative(mockController)
+	partitionParams := map[string]string{"PartitionId": data.TestPartitionId}
+	filterQuery := "entityType=tariff&entityId=" + data.TestTariffId + "&actor=" + data.TestActor + "&from=2021-03-24T00:00:00Z&to=2021-03-25T00:00:00%2B01:00"
+
+	testCases := []testCaseAuditHandler{
+		{
+			"Positive Test",
+			test.GetTestGinContextWithParametersAndQuery(partitionParams, ""),
+			dependenciesAuditHandler{repo: mockAuditGetter, validator: mockValidator},
+			200,
+			&data.AuditRecordsPage,
+			func() {
+				mockAuditGetter.EXPECT().GetAuditRecordsPage(data.TestPartitionId, models.AuditFilter{}, models.PageRequest{}).Return(&data.AuditRecordsPage, nil)
+			},
+		},
+		{
+			"Positive Test Filter",
+			test.GetTestGinContextWithParametersAndQuery(partitionParams, filterQuery+"&limit=10&cursor="+data.TestCursor),
+			dependenciesAuditHandler{repo: mockAuditGetter, validator: mockValidator},
+			200,
+			&data.AuditRecordsPage,
+			func() {
+				mockAuditGetter.EXPECT().GetAuditRecordsPage(data.TestPartitionId, data.AuditFilter, models.PageRequest{Limit: 10, Cursor: data.TestCursor}).Return(&data.AuditRecordsPage, nil)
+			},
+		},
+		{
+			"Negative Test PartitionId Invalid",
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestIdInvalid}, ""),
+			dependenciesAuditHandler{repo: mockAuditGetter, validator: mockValidatorNegative},
+			400,
+			models.NewBadRequestFieldValidationError(errors.New("ValidationError")),
+			func() {},
+		},
+		{
+			"Negative Test Entity Type Invalid",
+			test.GetTestGinContextWithParametersAndQuery(partitionParams, "entityType=taxRule"),
+			dependenciesAuditHandler{repo: mockAuditGetter, validator: mockValidator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/entityType", Rule: "oneof", Allowed: "contract provider tariff"}}),
+			func() {},
+		},
+		{
+			"Negative Test From Invalid",
+			test.GetTestGinContextWithParametersAndQuery(partitionParams, "from=2021-03-24"),
+			dependenciesAuditHandler{repo: mockAuditGetter, validator: mockValidator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/from", Rule: "datetime", Allowed: "2006-01-02T15:04:05Z07:00"}}),
+			func() {},
+		},
+		{
+			"Negative Test To Before From",
+			test.GetTestGinContextWithParametersAndQuery(partitionParams, "from=2021-03-24T12:00:00Z&to=2021-03-24T12:00:00%2B01:00"),
+			dependenciesAuditHandler{repo: mockAuditGetter, validator: mockValidator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/to", Rule: "gtefield", Allowed: "from"}}),
+			func() {},
+		},
+		{
+			"Negative Test Invalid Cursor",
+			test.GetTestGinContextWithParametersAndQuery(partitionParams, "cursor=invalid"),
+			dependenciesAuditHandler{repo: mockAuditGetter, validator: mockValidator},
+			400,
+			models.NewBadRequestError(pagination.ErrInvalidCursor),
+			func() {
+				mockAuditGetter.EXPECT().GetAuditRecordsPage(data.TestPartitionId, models.AuditFilter{}, models.PageRequest{Cursor: "invalid"}).Return(nil, pagination.ErrInvalidCursor)
+			},
+		},
+		{
+			"Negative Test Internal Server Error",
+			test.GetTestGinContextWithParametersAndQuery(partitionParams, ""),
+			dependenciesAuditHandler{repo: mockAuditGetter, validator: mockValidator},
+			500,
+			models.NewInternalServerError(),
+			func() {
+				mockAuditGetter.EXPECT().GetAuditRecordsPage(data.TestPartitionId, models.AuditFilter{}, models.PageRequest{}).Return(nil, errors.New(constants.InternalServerError))
+			},
+		},
+	}
+	// act
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			auditHandler := AuditHandler{
+				AuditRepo: tc.deps.repo,
+				Validator: tc.deps.validator,
+			}
+			tc.mockFunc()
+			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
+			tc.ctx.Writer = blw
+			auditHandler.HandleGetAuditRecords(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
+			statusCode := tc.ctx.Writer.Status()
+
+			// assert
+			assert.Equal(t, tc.expectedResponseCode, statusCode)
+			if statusCode == 200 {
+				var actualRecords *models.Page[models.AuditRecord]
+				err := json.Unmarshal(blw.Body.Bytes(), &actualRecords)
+				if err != nil {
+					t.Fail()
+				}
+				assert.Equal(t, tc.expectedResponse, actualRecords)
+			} else {
+				var actualError models.Error
+				err := json.Unmarshal(blw.Body.Bytes(), &actualError)
+				if err != nil {
+					t.Fail()
+				}
+				assert.Equal(t, tc.expectedResponse, actualError)
+			}
+		})
+	}
+}
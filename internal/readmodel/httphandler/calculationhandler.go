@@ -0,0 +1,370 @@
+package httphandler
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"time"
+
+	"tariff-calculation-service/internal/calculation"
+	"tariff-calculation-service/internal/fxrate"
+	"tariff-calculation-service/internal/interfaces"
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/repository"
+	"tariff-calculation-service/pkg"
+	"tariff-calculation-service/pkg/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CalculationHandler struct {
+	TariffRepo   TariffGetter
+	ContractRepo ContractGetter
+	ProviderRepo ProviderGetter
+	TaxRuleRepo  TaxRuleGetter
+	SettingsRepo SettingsGetter
+	FxRates      interfaces.FxRateProvider
+	Validator    interfaces.Validator
+}
+
+func NewCalculationHandler() CalculationHandler {
+	return CalculationHandler{
+		TariffRepo:   repository.NewTariffRepo(),
+		ContractRepo: repository.NewContractRepo(),
+		ProviderRepo: repository.NewProviderRepo(),
+		TaxRuleRepo:  repository.NewTaxRuleRepo(),
+		SettingsRepo: repository.NewSettingsRepo(),
+		FxRates:      newFxRateProvider(),
+		Validator:    validation.NewValidator(),
+	}
+}
+
+// newFxRateProvider returns the rates of the file FX_RATES_FILE if it is set, the rates of the partition otherwise.
+func newFxRateProvider() interfaces.FxRateProvider {
+	if path := os.Getenv("FX_RATES_FILE"); path != "" {
+		return fxrate.NewFileProvider(path)
+	}
+
+	return repository.NewFxRateRepo()
+}
+
+func (handler CalculationHandler) HandlePostCalculation(context *gin.Context) {
+	pathParams := validation.PartitionIdWithId{}
+	if err := handler.Validator.ValidateAndSetPathParams(context, &pathParams); err != nil {
+		return
+	}
+
+	request := models.CalculationRequest{}
+	if err := context.ShouldBindJSON(&request); err != nil {
+		pkg.RespondWithError(context, models.NewBadRequestFieldValidationError(err))
+		return
+	}
+
+	consumption, err := calculation.ParseConsumption(request)
+	if err != nil {
+		pkg.RespondWithError(context, models.NewBadRequestError(err))
+		return
+	}
+
+	versions, err := handler.TariffRepo.GetTariffVersions(pathParams.PartitionId, pathParams.Id)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	result, err := calculation.CalculateVersionedCost(*versions, consumption)
+	if err != nil {
+		pkg.RespondWithError(context, models.NewBadRequestError(err))
+		return
+	}
+
+	settings, err := handler.SettingsRepo.GetSettings(pathParams.PartitionId)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	calculation.RoundCalculation(result, settings.RoundingMode)
+
+	taxRule, err := handler.getTaxRule(pathParams.PartitionId, request.CountryCode)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	result.Tax = calculation.CalculateTaxes(taxRule, result.Cost, result.Quantity, consumption.From, consumption.To, result.Currency, settings.RoundingMode)
+
+	if request.TargetCurrency != "" {
+		converter := calculation.NewConverter(handler.FxRates, pathParams.PartitionId, request.TargetCurrency, settings.RoundingMode)
+		if err := calculation.ConvertCalculation(result, converter); err != nil {
+			handleConversionError(context, err)
+			return
+		}
+	}
+
+	context.JSON(http.StatusOK, result)
+}
+
+func (handler CalculationHandler) HandleGetPrice(context *gin.Context) {
+	pathParams := validation.PartitionIdWithId{}
+	if err := handler.Validator.ValidateAndSetPathParams(context, &pathParams); err != nil {
+		return
+	}
+
+	request := models.PriceRequest{}
+	if err := context.ShouldBindQuery(&request); err != nil {
+		pkg.RespondWithError(context, models.NewBadRequestFieldValidationError(err))
+		return
+	}
+
+	at, err := time.Parse(time.RFC3339, request.At)
+	if err != nil {
+		pkg.RespondWithError(context, models.NewBadRequestError(err))
+		return
+	}
+
+	versions, err := handler.TariffRepo.GetTariffVersions(pathParams.PartitionId, pathParams.Id)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	price, err := calculation.PriceAt(calculation.TariffAt(*versions, at), at)
+	if err != nil {
+		pkg.RespondWithError(context, models.NewBadRequestError(err))
+		return
+	}
+
+	context.JSON(http.StatusOK, price)
+}
+
+func (handler CalculationHandler) HandlePostContractCalculation(context *gin.Context) {
+	pathParams := validation.PartitionIdWithId{}
+	if err := handler.Validator.ValidateAndSetPathParams(context, &pathParams); err != nil {
+		return
+	}
+
+	request := models.IntervalCalculationRequest{}
+	if err := context.ShouldBindJSON(&request); err != nil {
+		pkg.RespondWithError(context, models.NewBadRequestFieldValidationError(err))
+		return
+	}
+
+	contract, err := handler.ContractRepo.GetContract(pathParams.PartitionId, pathParams.Id)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	tariffs, err := handler.getTariffs(pathParams.PartitionId, contract.Tariffs)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	result, err := calculation.CalculateIntervals(tariffs, request)
+	if err != nil {
+		pkg.RespondWithError(context, models.NewBadRequestError(err))
+		return
+	}
+	result.ContractId = contract.Id
+
+	settings, err := handler.SettingsRepo.GetSettings(pathParams.PartitionId)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	calculation.RoundIntervalCalculation(result, settings.RoundingMode)
+
+	taxRule, err := handler.getContractTaxRule(pathParams.PartitionId, *contract, request.CountryCode)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	if err := calculation.ApplyIntervalTaxes(result, taxRule, settings.RoundingMode); err != nil {
+		pkg.RespondWithError(context, models.NewBadRequestError(err))
+		return
+	}
+
+	if request.TargetCurrency != "" {
+		converter := calculation.NewConverter(handler.FxRates, pathParams.PartitionId, request.TargetCurrency, settings.RoundingMode)
+		if err := calculation.ConvertIntervals(result, converter); err != nil {
+			handleConversionError(context, err)
+			return
+		}
+	}
+
+	context.JSON(http.StatusOK, result)
+}
+
+func (handler CalculationHandler) HandlePostBill(context *gin.Context) {
+	pathParams := validation.PartitionIdWithId{}
+	if err := handler.Validator.ValidateAndSetPathParams(context, &pathParams); err != nil {
+		return
+	}
+
+	request := models.BillRequest{}
+	if err := context.ShouldBindJSON(&request); err != nil {
+		pkg.RespondWithError(context, models.NewBadRequestFieldValidationError(err))
+		return
+	}
+
+	contract, err := handler.ContractRepo.GetContract(pathParams.PartitionId, pathParams.Id)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	tariffs, err := handler.getTariffs(pathParams.PartitionId, contract.Tariffs)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	bill, err := calculation.CalculateBill(*contract, tariffs, request)
+	if err != nil {
+		pkg.RespondWithError(context, models.NewBadRequestError(err))
+		return
+	}
+
+	settings, err := handler.SettingsRepo.GetSettings(pathParams.PartitionId)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	calculation.RoundBill(bill, settings.RoundingMode)
+
+	taxRule, err := handler.getContractTaxRule(pathParams.PartitionId, *contract, request.CountryCode)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	if err := calculation.ApplyBillTaxes(bill, taxRule, settings.RoundingMode); err != nil {
+		pkg.RespondWithError(context, models.NewBadRequestError(err))
+		return
+	}
+
+	if request.TargetCurrency != "" {
+		converter := calculation.NewConverter(handler.FxRates, pathParams.PartitionId, request.TargetCurrency, settings.RoundingMode)
+		if err := calculation.ConvertBill(bill, converter); err != nil {
+			handleConversionError(context, err)
+			return
+		}
+	}
+
+	context.JSON(http.StatusOK, bill)
+}
+
+func (handler CalculationHandler) HandlePostComparison(context *gin.Context) {
+	pathParams := validation.PartitionIdWithId{}
+	if err := handler.Validator.ValidateAndSetPathParams(context, &pathParams); err != nil {
+		return
+	}
+
+	request := models.ComparisonRequest{}
+	if err := context.ShouldBindJSON(&request); err != nil {
+		pkg.RespondWithError(context, models.NewBadRequestFieldValidationError(err))
+		return
+	}
+
+	contract, err := handler.ContractRepo.GetContract(pathParams.PartitionId, pathParams.Id)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	current, err := handler.getTariffs(pathParams.PartitionId, contract.Tariffs)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	tariffs, err := handler.getAllTariffs(pathParams.PartitionId)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	settings, err := handler.SettingsRepo.GetSettings(pathParams.PartitionId)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	comparison, err := calculation.CompareTariffs(current, tariffs, request, settings.RoundingMode)
+	if err != nil {
+		pkg.RespondWithError(context, models.NewBadRequestError(err))
+		return
+	}
+	comparison.ContractId = contract.Id
+
+	context.JSON(http.StatusOK, comparison)
+}
+
+// getTariffs returns the versions of the tariffs, as of calculation.TariffVersions, in the order of the ids.
+func (handler CalculationHandler) getTariffs(partitionId string, tariffIds []string) ([]models.Tariff, error) {
+	tariffs := []models.Tariff{}
+	for _, tariffId := range tariffIds {
+		versions, err := handler.TariffRepo.GetTariffVersions(partitionId, tariffId)
+		if err != nil {
+			return nil, err
+		}
+		tariffVersions, err := calculation.TariffVersions(*versions)
+		if err != nil {
+			return nil, err
+		}
+		tariffs = append(tariffs, tariffVersions...)
+	}
+
+	return tariffs, nil
+}
+
+// getAllTariffs returns the versions of every tariff of the partition, as of calculation.TariffVersions, read at
+// once.
+func (handler CalculationHandler) getAllTariffs(partitionId string) ([]models.Tariff, error) {
+	allVersions, err := handler.TariffRepo.GetAllTariffVersions(partitionId)
+	if err != nil {
+		return nil, err
+	}
+	tariffs := []models.Tariff{}
+	for _, versions := range *allVersions {
+		tariffVersions, err := calculation.TariffVersions(versions)
+		if err != nil {
+			return nil, err
+		}
+		tariffs = append(tariffs, tariffVersions...)
+	}
+
+	return tariffs, nil
+}
+
+func (handler CalculationHandler) getTaxRule(partitionId, countryCode string) (*models.TaxRule, error) {
+	taxRules, err := handler.TaxRuleRepo.GetTaxRules(partitionId)
+	if err != nil {
+		return nil, err
+	}
+
+	return calculation.FindTaxRule(*taxRules, countryCode), nil
+}
+
+// getContractTaxRule returns the tax rule of the requested country. The country of the contract's provider
+// is used if no country is requested.
+func (handler CalculationHandler) getContractTaxRule(partitionId string, contract models.Contract, countryCode string) (*models.TaxRule, error) {
+	if countryCode == "" && contract.Provider != "" {
+		provider, err := handler.ProviderRepo.GetProvider(partitionId, contract.Provider)
+		if err != nil {
+			return nil, err
+		}
+		countryCode = provider.Address.CountryCode
+	}
+
+	return handler.getTaxRule(partitionId, countryCode)
+}
+
+// handleConversionError responds with a bad request if no exchange rate is available, with an internal
+// server error otherwise.
+func handleConversionError(context *gin.Context, err error) {
+	if errors.Is(err, fxrate.ErrNoFxRate) {
+		pkg.RespondWithError(context, models.NewBadRequestError(err))
+		return
+	}
+	context.Error(err)
+}
@@ -0,0 +1,629 @@
+package httphandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"tariff-calculation-service/internal/calculation"
+	"tariff-calculation-service/internal/dberrors"
+	"tariff-calculation-service/internal/fxrate"
+	"tariff-calculation-service/internal/models"
+	repotesting "tariff-calculation-service/internal/readmodel/httphandler/testing"
+	"tariff-calculation-service/pkg"
+	"tariff-calculation-service/pkg/constants"
+	"tariff-calculation-service/pkg/enums"
+	"tariff-calculation-service/pkg/money"
+	"tariff-calculation-service/test"
+	"tariff-calculation-service/test/data"
+	"tariff-calculation-service/test/mocks"
+	"tariff-calculation-service/tools"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_HandlePostCalculation(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockTariffGetter := repotesting.NewMockTariffGetter(mockController)
+	mockTaxRuleGetter := repotesting.NewMockTaxRuleGetter(mockController)
+	mockSettingsGetter := repotesting.NewMockSettingsGetter(mockController)
+	mockFxRateProvider := repotesting.NewMockFxRateProvider(mockController)
+	mockValidator := mocks.NewValidatorPathPositive(mockController)
+	mockValidatorNegative := mocks.NewValidatorPathNegative(mockController)
+
+	expectedCalculation := data.Calculation
+	expectedCalculation.Tax = &models.Taxation{
+		TaxRuleId: data.TaxRuleDefault.Id,
+		NetCost:   money.RequireFromString("645"),
+		Taxes:     []models.TaxCost{{Name: enums.Vat.String(), TaxType: enums.Vat, Rate: money.RequireFromString("20"), Base: money.RequireFromString("645"), Cost: money.RequireFromString("129")}},
+		TaxCost:   money.RequireFromString("129"),
+		GrossCost: money.RequireFromString("774"),
+	}
+
+	revisionRequest := models.CalculationRequest{Quantity: money.RequireFromString("2"), From: "2021-05-31T00:00:00Z", To: "2021-06-02T00:00:00Z"}
+	expectedRevisionCalculation := models.Calculation{
+		TariffId:        data.TestTariffId,
+		Currency:        data.TestCurrency,
+		From:            revisionRequest.From,
+		To:              revisionRequest.To,
+		Quantity:        revisionRequest.Quantity,
+		PricePerUnit:    money.RequireFromString("67.3"),
+		ConsumptionCost: money.RequireFromString("134.6"),
+		Cost:            money.RequireFromString("134.6"),
+		Tax: &models.Taxation{
+			TaxRuleId: data.TaxRuleDefault.Id,
+			NetCost:   money.RequireFromString("134.6"),
+			Taxes:     []models.TaxCost{{Name: enums.Vat.String(), TaxType: enums.Vat, Rate: money.RequireFromString("20"), Base: money.RequireFromString("134.6"), Cost: money.RequireFromString("26.92")}},
+			TaxCost:   money.RequireFromString("26.92"),
+			GrossCost: money.RequireFromString("161.52"),
+		},
+	}
+
+	conversionRequest := data.CalculationRequest
+	conversionRequest.TargetCurrency = "EUR"
+	expectedConversion := expectedCalculation
+	expectedConversion.Conversion = &models.Conversion{
+		Currency:  "EUR",
+		Cost:      money.RequireFromString("725.62"),
+		TaxCost:   money.RequireFromString("145.12"),
+		GrossCost: money.RequireFromString("870.74"),
+		Rates:     []models.FxRate{data.FxRate},
+	}
+
+	testCases := []testCaseTariffHandler{
+		{
+			"Positive Test",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.CalculationRequest))),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			200,
+			&expectedCalculation,
+			func() {
+				mockTariffGetter.EXPECT().GetTariffVersions(gomock.Any(), gomock.Any()).Return(initialVersion(data.Tariff), nil)
+				mockTaxRuleGetter.EXPECT().GetTaxRules(data.TestPartitionId).Return(&data.TaxRules, nil)
+				mockSettingsGetter.EXPECT().GetSettings(data.TestPartitionId).Return(&data.Settings, nil)
+			},
+		},
+		{
+			"Positive Test Before Revision",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.CalculationRequest))),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			200,
+			&expectedCalculation,
+			func() {
+				mockTariffGetter.EXPECT().GetTariffVersions(data.TestPartitionId, data.TestTariffId).Return(&data.TariffVersions, nil)
+				mockTaxRuleGetter.EXPECT().GetTaxRules(data.TestPartitionId).Return(&data.TaxRules, nil)
+				mockSettingsGetter.EXPECT().GetSettings(data.TestPartitionId).Return(&data.Settings, nil)
+			},
+		},
+		{
+			"Positive Test Across Revision",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(revisionRequest))),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			200,
+			&expectedRevisionCalculation,
+			func() {
+				mockTariffGetter.EXPECT().GetTariffVersions(data.TestPartitionId, data.TestTariffId).Return(&data.TariffVersions, nil)
+				mockTaxRuleGetter.EXPECT().GetTaxRules(data.TestPartitionId).Return(&data.TaxRules, nil)
+				mockSettingsGetter.EXPECT().GetSettings(data.TestPartitionId).Return(&data.Settings, nil)
+			},
+		},
+		{
+			"Positive Test Target Currency",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(conversionRequest))),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			200,
+			&expectedConversion,
+			func() {
+				mockTariffGetter.EXPECT().GetTariffVersions(gomock.Any(), gomock.Any()).Return(initialVersion(data.Tariff), nil)
+				mockTaxRuleGetter.EXPECT().GetTaxRules(gomock.Any()).Return(&data.TaxRules, nil)
+				mockFxRateProvider.EXPECT().GetFxRate(data.TestPartitionId, data.TestCurrency, "EUR", gomock.Any()).Return(&data.FxRate, nil)
+				mockSettingsGetter.EXPECT().GetSettings(gomock.Any()).Return(&data.Settings, nil)
+			},
+		},
+		{
+			"Negative Test Target Currency No Rate",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(conversionRequest))),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			400,
+			models.NewBadRequestError(fxrate.ErrNoFxRate),
+			func() {
+				mockTariffGetter.EXPECT().GetTariffVersions(gomock.Any(), gomock.Any()).Return(initialVersion(data.Tariff), nil)
+				mockTaxRuleGetter.EXPECT().GetTaxRules(gomock.Any()).Return(&data.TaxRules, nil)
+				mockFxRateProvider.EXPECT().GetFxRate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fxrate.ErrNoFxRate)
+				mockSettingsGetter.EXPECT().GetSettings(gomock.Any()).Return(&data.Settings, nil)
+			},
+		},
+		{
+			"Negative Test Target Currency Internal Server Error",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(conversionRequest))),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			500,
+			models.NewInternalServerError(),
+			func() {
+				mockTariffGetter.EXPECT().GetTariffVersions(gomock.Any(), gomock.Any()).Return(initialVersion(data.Tariff), nil)
+				mockTaxRuleGetter.EXPECT().GetTaxRules(gomock.Any()).Return(&data.TaxRules, nil)
+				mockFxRateProvider.EXPECT().GetFxRate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New(constants.InternalServerError))
+				mockSettingsGetter.EXPECT().GetSettings(gomock.Any()).Return(&data.Settings, nil)
+			},
+		},
+		{
+			"Negative Test Settings Internal Server Error",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.CalculationRequest))),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			500,
+			models.NewInternalServerError(),
+			func() {
+				mockTariffGetter.EXPECT().GetTariffVersions(gomock.Any(), gomock.Any()).Return(initialVersion(data.Tariff), nil)
+				mockSettingsGetter.EXPECT().GetSettings(gomock.Any()).Return(nil, errors.New(constants.InternalServerError))
+			},
+		},
+		{
+			"Negative Test Tax Rules Internal Server Error",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.CalculationRequest))),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			500,
+			models.NewInternalServerError(),
+			func() {
+				mockTariffGetter.EXPECT().GetTariffVersions(gomock.Any(), gomock.Any()).Return(initialVersion(data.Tariff), nil)
+				mockTaxRuleGetter.EXPECT().GetTaxRules(gomock.Any()).Return(nil, errors.New(constants.InternalServerError))
+				mockSettingsGetter.EXPECT().GetSettings(gomock.Any()).Return(&data.Settings, nil)
+			},
+		},
+		{
+			"Negative Test PartitionId Invalid",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestIdInvalid, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.CalculationRequest))),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidatorNegative},
+			400,
+			models.NewBadRequestFieldValidationError(errors.New("ValidationError")),
+			func() {},
+		},
+		{
+			"Negative Test Outside Validity",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.CalculationRequestOutsideValidity))),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			400,
+			models.NewBadRequestError(calculation.ErrOutsideValidity),
+			func() {
+				mockTariffGetter.EXPECT().GetTariffVersions(gomock.Any(), gomock.Any()).Return(initialVersion(data.Tariff), nil)
+			},
+		},
+		{
+			"Negative Test Tariff Not Found",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.CalculationRequest))),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			404,
+			models.NewResourceNotFoundError(),
+			func() {
+				mockTariffGetter.EXPECT().GetTariffVersions(gomock.Any(), gomock.Any()).Return(nil, dberrors.ErrNotFound)
+			},
+		},
+	}
+	// act
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calculationHandler := CalculationHandler{
+				TariffRepo:   tc.deps.repo,
+				TaxRuleRepo:  mockTaxRuleGetter,
+				SettingsRepo: mockSettingsGetter,
+				FxRates:      mockFxRateProvider,
+				Validator:    tc.deps.validator,
+			}
+			tc.mockFunc()
+			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
+			tc.ctx.Writer = blw
+			calculationHandler.HandlePostCalculation(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
+			statusCode := tc.ctx.Writer.Status()
+
+			// assert
+			assert.Equal(t, tc.expectedResponseCode, statusCode)
+			if statusCode == 200 {
+				var actualCalculation *models.Calculation
+				err := json.Unmarshal(blw.Body.Bytes(), &actualCalculation)
+				if err != nil {
+					t.Fail()
+				}
+				assert.Equal(t, tc.expectedResponse, actualCalculation)
+			} else {
+				var actualError models.Error
+				err := json.Unmarshal(blw.Body.Bytes(), &actualError)
+				if err != nil {
+					t.Fail()
+				}
+				assert.Equal(t, tc.expectedResponse, actualError)
+			}
+		})
+	}
+}
+
+func Test_HandleGetPrice(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockTariffGetter := repotesting.NewMockTariffGetter(mockController)
+	mockValidator := mocks.NewValidatorPathPositive(mockController)
+
+	testCases := []testCaseTariffHandler{
+		{
+			"Positive Test Fixed Tariff",
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, "at=2021-01-11T08:30:00Z"),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			200,
+			&models.Price{TariffId: data.TestTariffId, Currency: data.TestCurrency, At: "2021-01-11T08:30:00Z", PricePerUnit: money.RequireFromString("64.5")},
+			func() {
+				mockTariffGetter.EXPECT().GetTariffVersions(gomock.Any(), gomock.Any()).Return(initialVersion(data.Tariff), nil)
+			},
+		},
+		{
+			"Positive Test Revised Tariff",
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, "at=2021-07-01T08:30:00Z"),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			200,
+			&models.Price{TariffId: data.TestTariffId, Currency: data.TestCurrency, At: "2021-07-01T08:30:00Z", PricePerUnit: money.RequireFromString("70.1")},
+			func() {
+				mockTariffGetter.EXPECT().GetTariffVersions(gomock.Any(), gomock.Any()).Return(&data.TariffVersions, nil)
+			},
+		},
+		{
+			"Positive Test Dynamic Tariff",
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, "at=2021-01-11T08:30:00Z"),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			200,
+			&models.Price{TariffId: data.TestTariffId, Currency: data.TestCurrency, At: "2021-01-11T08:30:00Z", StartTime: "2021-01-04T08:00:00+01:00", PricePerUnit: money.RequireFromString("0.4")},
+			func() {
+				mockTariffGetter.EXPECT().GetTariffVersions(gomock.Any(), gomock.Any()).Return(initialVersion(data.TariffDynamic), nil)
+			},
+		},
+		{
+			"Negative Test End Of Validity",
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, "at="+data.TestValidTo),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			400,
+			nil,
+			func() {
+				mockTariffGetter.EXPECT().GetTariffVersions(gomock.Any(), gomock.Any()).Return(initialVersion(data.Tariff), nil)
+			},
+		},
+		{
+			"Negative Test Missing Instant",
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, ""),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			400,
+			nil,
+			func() {},
+		},
+	}
+	// act
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calculationHandler := CalculationHandler{
+				TariffRepo: tc.deps.repo,
+				Validator:  tc.deps.validator,
+			}
+			tc.mockFunc()
+			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
+			tc.ctx.Writer = blw
+			calculationHandler.HandleGetPrice(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
+			statusCode := tc.ctx.Writer.Status()
+
+			// assert
+			assert.Equal(t, tc.expectedResponseCode, statusCode)
+			if statusCode == 200 {
+				var actualPrice *models.Price
+				err := json.Unmarshal(blw.Body.Bytes(), &actualPrice)
+				if err != nil {
+					t.Fail()
+				}
+				assert.Equal(t, tc.expectedResponse, actualPrice)
+			}
+		})
+	}
+}
+
+func Test_HandlePostContractCalculation(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockTariffGetter := repotesting.NewMockTariffGetter(mockController)
+	mockContractGetter := repotesting.NewMockContractGetter(mockController)
+	mockProviderGetter := repotesting.NewMockProviderGetter(mockController)
+	mockTaxRuleGetter := repotesting.NewMockTaxRuleGetter(mockController)
+	mockSettingsGetter := repotesting.NewMockSettingsGetter(mockController)
+	mockValidator := mocks.NewValidatorPathPositive(mockController)
+
+	expectedCalculation := data.IntervalCalculation
+	expectedCalculation.Totals = []models.IntervalTotal{{
+		Currency: data.TestCurrency,
+		Quantity: money.RequireFromString("2"),
+		Cost:     money.RequireFromString("129"),
+		Tax: &models.Taxation{
+			TaxRuleId: data.TaxRuleDefault.Id,
+			NetCost:   money.RequireFromString("129"),
+			Taxes:     []models.TaxCost{{Name: enums.Vat.String(), TaxType: enums.Vat, Rate: money.RequireFromString("20"), Base: money.RequireFromString("129"), Cost: money.RequireFromString("25.8")}},
+			TaxCost:   money.RequireFromString("25.8"),
+			GrossCost: money.RequireFromString("154.8"),
+		},
+	}}
+
+	testCases := []testCaseTariffHandler{
+		{
+			"Positive Test",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(data.IntervalCalculationRequest))),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			200,
+			&expectedCalculation,
+			func() {
+				mockContractGetter.EXPECT().GetContract(gomock.Any(), gomock.Any()).Return(&data.ContractWithTariff, nil)
+				mockTariffGetter.EXPECT().GetTariffVersions(gomock.Any(), data.TestTariffId).Return(initialVersion(data.Tariff), nil)
+				mockProviderGetter.EXPECT().GetProvider(gomock.Any(), data.TestProviderId).Return(&data.Provider, nil)
+				mockTaxRuleGetter.EXPECT().GetTaxRules(gomock.Any()).Return(&[]models.TaxRule{data.TaxRuleDefault}, nil)
+				mockSettingsGetter.EXPECT().GetSettings(gomock.Any()).Return(&data.Settings, nil)
+			},
+		},
+		{
+			"Negative Test Contract Not Found",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(data.IntervalCalculationRequest))),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			404,
+			models.NewResourceNotFoundError(),
+			func() {
+				mockContractGetter.EXPECT().GetContract(gomock.Any(), gomock.Any()).Return(&models.Contract{}, dberrors.ErrNotFound)
+			},
+		},
+		{
+			"Negative Test Missing Readings",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(models.IntervalCalculationRequest{Resolution: 15}))),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			400,
+			models.NewBadRequestFieldValidationError(errors.New("ValidationError")),
+			func() {},
+		},
+	}
+	// act
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calculationHandler := CalculationHandler{
+				TariffRepo:   tc.deps.repo,
+				ContractRepo: mockContractGetter,
+				ProviderRepo: mockProviderGetter,
+				TaxRuleRepo:  mockTaxRuleGetter,
+				SettingsRepo: mockSettingsGetter,
+				Validator:    tc.deps.validator,
+			}
+			tc.mockFunc()
+			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
+			tc.ctx.Writer = blw
+			calculationHandler.HandlePostContractCalculation(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
+			statusCode := tc.ctx.Writer.Status()
+
+			// assert
+			assert.Equal(t, tc.expectedResponseCode, statusCode)
+			if statusCode == 200 {
+				var actualCalculation *models.IntervalCalculation
+				err := json.Unmarshal(blw.Body.Bytes(), &actualCalculation)
+				if err != nil {
+					t.Fail()
+				}
+				assert.Equal(t, tc.expectedResponse, actualCalculation)
+			} else if statusCode == 404 {
+				var actualError models.Error
+				err := json.Unmarshal(blw.Body.Bytes(), &actualError)
+				if err != nil {
+					t.Fail()
+				}
+				assert.Equal(t, tc.expectedResponse, actualError)
+			}
+		})
+	}
+}
+
+func Test_HandlePostBill(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockTariffGetter := repotesting.NewMockTariffGetter(mockController)
+	mockContractGetter := repotesting.NewMockContractGetter(mockController)
+	mockProviderGetter := repotesting.NewMockProviderGetter(mockController)
+	mockTaxRuleGetter := repotesting.NewMockTaxRuleGetter(mockController)
+	mockSettingsGetter := repotesting.NewMockSettingsGetter(mockController)
+	mockValidator := mocks.NewValidatorPathPositive(mockController)
+
+	contract := data.Contract
+	contract.Tariffs = []string{data.TariffElectricityJanuary.Id, data.TariffElectricityFebruary.Id, data.TariffGas.Id}
+
+	testCases := []testCaseTariffHandler{
+		{
+			"Positive Test",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(data.BillRequest))),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			200,
+			3,
+			func() {
+				mockContractGetter.EXPECT().GetContract(gomock.Any(), gomock.Any()).Return(&contract, nil)
+				mockTariffGetter.EXPECT().GetTariffVersions(gomock.Any(), data.TariffElectricityJanuary.Id).Return(initialVersion(data.TariffElectricityJanuary), nil)
+				mockTariffGetter.EXPECT().GetTariffVersions(gomock.Any(), data.TariffElectricityFebruary.Id).Return(initialVersion(data.TariffElectricityFebruary), nil)
+				mockTariffGetter.EXPECT().GetTariffVersions(gomock.Any(), data.TariffGas.Id).Return(initialVersion(data.TariffGas), nil)
+				mockProviderGetter.EXPECT().GetProvider(gomock.Any(), data.TestProviderId).Return(&data.Provider, nil)
+				mockTaxRuleGetter.EXPECT().GetTaxRules(gomock.Any()).Return(&data.TaxRules, nil)
+				mockSettingsGetter.EXPECT().GetSettings(gomock.Any()).Return(&data.Settings, nil)
+			},
+		},
+		{
+			"Negative Test Tariff Not Found",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(data.BillRequest))),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			404,
+			models.NewResourceNotFoundError(),
+			func() {
+				mockContractGetter.EXPECT().GetContract(gomock.Any(), gomock.Any()).Return(&contract, nil)
+				mockTariffGetter.EXPECT().GetTariffVersions(gomock.Any(), gomock.Any()).Return(nil, dberrors.ErrNotFound)
+			},
+		},
+	}
+	// act
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calculationHandler := CalculationHandler{
+				TariffRepo:   tc.deps.repo,
+				ContractRepo: mockContractGetter,
+				ProviderRepo: mockProviderGetter,
+				TaxRuleRepo:  mockTaxRuleGetter,
+				SettingsRepo: mockSettingsGetter,
+				Validator:    tc.deps.validator,
+			}
+			tc.mockFunc()
+			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
+			tc.ctx.Writer = blw
+			calculationHandler.HandlePostBill(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
+			statusCode := tc.ctx.Writer.Status()
+
+			// assert
+			assert.Equal(t, tc.expectedResponseCode, statusCode)
+			if statusCode == 200 {
+				var actualBill *models.Bill
+				err := json.Unmarshal(blw.Body.Bytes(), &actualBill)
+				if err != nil {
+					t.Fail()
+				}
+				assert.Equal(t, data.TestContractId, actualBill.ContractId)
+				assert.Len(t, actualBill.Lines, tc.expectedResponse.(int))
+				assert.Equal(t, data.TestTaxRuleId, actualBill.Totals[0].Tax.TaxRuleId)
+				assert.Equal(t, "JPN", actualBill.Totals[0].Tax.CountryCode)
+				assert.Equal(t, actualBill.Totals[0].Tax.GrossCost.Round(2, data.Settings.RoundingMode), actualBill.Totals[0].Tax.GrossCost)
+			} else {
+				var actualError models.Error
+				err := json.Unmarshal(blw.Body.Bytes(), &actualError)
+				if err != nil {
+					t.Fail()
+				}
+				assert.Equal(t, tc.expectedResponse, actualError)
+			}
+		})
+	}
+}
+
+func Test_HandlePostComparison(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockTariffGetter := repotesting.NewMockTariffGetter(mockController)
+	mockContractGetter := repotesting.NewMockContractGetter(mockController)
+	mockSettingsGetter := repotesting.NewMockSettingsGetter(mockController)
+	mockValidator := mocks.NewValidatorPathPositive(mockController)
+
+	comparisonRequest := models.ComparisonRequest{Resolution: data.IntervalCalculationRequest.Resolution, Readings: data.IntervalCalculationRequest.Readings}
+	invalidTariffType := enums.TariffType(5)
+	comparisonRequestInvalidTariffType := comparisonRequest
+	comparisonRequestInvalidTariffType.TariffType = &invalidTariffType
+	savingsGas := money.RequireFromString("128.8")
+	savingsCurrent := money.RequireFromString("0")
+	expectedComparison := models.Comparison{
+		ContractId: data.TestContractId,
+		Current:    &models.ComparisonCost{Currency: data.TestCurrency, Quantity: money.RequireFromString("2"), Cost: money.RequireFromString("129")},
+		Tariffs: []models.TariffComparison{
+			{Rank: 1, TariffId: data.TariffGas.Id, Name: data.TariffGas.Name, TariffType: enums.Gas, Currency: data.TestCurrency, Quantity: money.RequireFromString("2"), Cost: money.RequireFromString("0.2"), Savings: &savingsGas},
+			{Rank: 2, TariffId: data.TestTariffId, Name: data.TestTariffName, TariffType: data.TestTariffType, Current: true, Currency: data.TestCurrency, Quantity: money.RequireFromString("2"), Cost: money.RequireFromString("129"), Savings: &savingsCurrent},
+		},
+	}
+
+	testCases := []testCaseTariffHandler{
+		{
+			"Positive Test",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(comparisonRequest))),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			200,
+			&expectedComparison,
+			func() {
+				mockContractGetter.EXPECT().GetContract(gomock.Any(), gomock.Any()).Return(&data.ContractWithTariff, nil)
+				mockTariffGetter.EXPECT().GetTariffVersions(gomock.Any(), data.TestTariffId).Return(initialVersion(data.Tariff), nil)
+				mockTariffGetter.EXPECT().GetAllTariffVersions(data.TestPartitionId).Return(&[][]models.TariffVersion{*initialVersion(data.Tariff), *initialVersion(data.TariffGas)}, nil)
+				mockSettingsGetter.EXPECT().GetSettings(gomock.Any()).Return(&data.Settings, nil)
+			},
+		},
+		{
+			"Negative Test Contract Not Found",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(comparisonRequest))),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			404,
+			models.NewResourceNotFoundError(),
+			func() {
+				mockContractGetter.EXPECT().GetContract(gomock.Any(), gomock.Any()).Return(&models.Contract{}, dberrors.ErrNotFound)
+			},
+		},
+		{
+			"Negative Test Tariffs Internal Server Error",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(comparisonRequest))),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			500,
+			models.NewInternalServerError(),
+			func() {
+				mockContractGetter.EXPECT().GetContract(gomock.Any(), gomock.Any()).Return(&data.ContractWithTariff, nil)
+				mockTariffGetter.EXPECT().GetTariffVersions(gomock.Any(), data.TestTariffId).Return(initialVersion(data.Tariff), nil)
+				mockTariffGetter.EXPECT().GetAllTariffVersions(gomock.Any()).Return(nil, errors.New(constants.InternalServerError))
+			},
+		},
+		{
+			"Negative Test Missing Readings",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(models.ComparisonRequest{Resolution: 15}))),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/readings", Rule: "required"}}),
+			func() {},
+		},
+		{
+			"Negative Test Invalid Tariff Type",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(comparisonRequestInvalidTariffType))),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/tariffType", Rule: "lte", Allowed: "4"}}),
+			func() {},
+		},
+	}
+	// act
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calculationHandler := CalculationHandler{
+				TariffRepo:   tc.deps.repo,
+				ContractRepo: mockContractGetter,
+				SettingsRepo: mockSettingsGetter,
+				Validator:    tc.deps.validator,
+			}
+			tc.mockFunc()
+			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
+			tc.ctx.Writer = blw
+			calculationHandler.HandlePostComparison(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
+			statusCode := tc.ctx.Writer.Status()
+
+			// assert
+			assert.Equal(t, tc.expectedResponseCode, statusCode)
+			if statusCode == 200 {
+				var actualComparison *models.Comparison
+				err := json.Unmarshal(blw.Body.Bytes(), &actualComparison)
+				if err != nil {
+					t.Fail()
+				}
+				assert.Equal(t, tc.expectedResponse, actualComparison)
+			} else if statusCode == 400 || statusCode == 404 || statusCode == 500 {
+				var actualError models.Error
+				err := json.Unmarshal(blw.Body.Bytes(), &actualError)
+				if err != nil {
+					t.Fail()
+				}
+				assert.Equal(t, tc.expectedResponse, actualError)
+			}
+		})
+	}
+}
+
+// initialVersion returns the versions of a tariff that was never updated.
+func initialVersion(tariff models.Tariff) *[]models.TariffVersion {
+	return &[]models.TariffVersion{{Version: 1, Tariff: tariff}}
+}
@@ -5,9 +5,9 @@ package httphandler
 import (
 	"net/http"
 
-	"tariff-calculation-service/internal/database"
 	"tariff-calculation-service/internal/interfaces"
 	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/repository"
 	"tariff-calculation-service/pkg"
 	"tariff-calculation-service/pkg/validation"
 
@@ -16,7 +16,11 @@ import (
 
 type ContractGetter interface {
 	GetContracts(partitionId string) (*[]models.Contract, error)
+	GetContractsPage(partitionId string, pageRequest models.PageRequest) (*models.Page[models.Contract], error)
 	GetContract(partitionId, contractId string) (*models.Contract, error)
+	GetContractWithVersion(partitionId, contractId string) (*models.Contract, int, error)
+	GetContractsByProvider(partitionId, providerId string, pageRequest models.PageRequest) (*models.Page[models.Contract], error)
+	GetContractsByTariff(partitionId, tariffId string, pageRequest models.PageRequest) (*models.Page[models.Contract], error)
 }
 
 type ContractHandler struct {
@@ -26,7 +30,7 @@ type ContractHandler struct {
 
 func NewContractHandler() ContractHandler {
 	return ContractHandler{
-		ContractRepo: database.NewContractRepo(),
+		ContractRepo: repository.NewContractRepo(),
 		Validator:    validation.NewValidator(),
 	}
 }
@@ -37,9 +41,15 @@ func (handler ContractHandler) HandleGetContracts(context *gin.Context) {
 		return
 	}
 
-	contracts, err := handler.ContractRepo.GetContracts(pathParam.PartitionId)
+	pageRequest := models.PageRequest{}
+	if err := context.ShouldBindQuery(&pageRequest); err != nil {
+		pkg.RespondWithError(context, models.NewBadRequestFieldValidationError(err))
+		return
+	}
+
+	contracts, err := handler.ContractRepo.GetContractsPage(pathParam.PartitionId, pageRequest)
 	if err != nil {
-		context.JSON(http.StatusInternalServerError, models.NewInternalServerError())
+		context.Error(err)
 		return
 	}
 
@@ -52,11 +62,48 @@ func (handler ContractHandler) HandleGetContract(context *gin.Context) {
 		return
 	}
 
-	contract, err := handler.ContractRepo.GetContract(pathParams.PartitionId, pathParams.Id)
+	contract, version, err := handler.ContractRepo.GetContractWithVersion(pathParams.PartitionId, pathParams.Id)
 	if err != nil {
-		pkg.HandleResourceNotFoundAndInternalServerError(context, err)
+		context.Error(err)
+		return
+	}
+	if pkg.NotModified(context, version) {
 		return
 	}
 
+	pkg.SetETag(context, version)
 	context.JSON(http.StatusOK, contract)
 }
+
+// HandleGetProviderContracts returns one page of the contracts of the provider.
+func (handler ContractHandler) HandleGetProviderContracts(context *gin.Context) {
+	handler.handleGetReferencingContracts(context, handler.ContractRepo.GetContractsByProvider)
+}
+
+// HandleGetTariffContracts returns one page of the contracts of the tariff.
+func (handler ContractHandler) HandleGetTariffContracts(context *gin.Context) {
+	handler.handleGetReferencingContracts(context, handler.ContractRepo.GetContractsByTariff)
+}
+
+// handleGetReferencingContracts returns one page of the contracts that refer to the entity of the path.
+func (handler ContractHandler) handleGetReferencingContracts(context *gin.Context,
+	getContracts func(partitionId, id string, pageRequest models.PageRequest) (*models.Page[models.Contract], error)) {
+	pathParams := validation.PartitionIdWithId{}
+	if err := handler.Validator.ValidateAndSetPathParams(context, &pathParams); err != nil {
+		return
+	}
+
+	pageRequest := models.PageRequest{}
+	if err := context.ShouldBindQuery(&pageRequest); err != nil {
+		pkg.RespondWithError(context, models.NewBadRequestFieldValidationError(err))
+		return
+	}
+
+	contracts, err := getContracts(pathParams.PartitionId, pathParams.Id, pageRequest)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusOK, contracts)
+}
@@ -2,9 +2,12 @@ package httphandler
 
 import (
 	"encoding/json"
+	"tariff-calculation-service/internal/dberrors"
 	"tariff-calculation-service/internal/interfaces"
 	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/pagination"
 	repoMocks "tariff-calculation-service/internal/readmodel/httphandler/testing"
+	"tariff-calculation-service/pkg"
 	"tariff-calculation-service/pkg/constants"
 	"tariff-calculation-service/test"
 	"tariff-calculation-service/test/data"
@@ -45,22 +48,51 @@ func Test_HandleGetContracts(t *testing.T) {
 	testcases := []testcase{
 		{
 			"Positive Test",
-			test.GetTestGinContext(),
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestPartitionId}, ""),
+			dependencies{repo: mockContractRepo, validator: mockValidator},
+			200,
+			&data.ContractsPage,
+			func() {
+				mockContractRepo.EXPECT().GetContractsPage(gomock.Any(), models.PageRequest{}).Return(&data.ContractsPage, nil)
+			},
+		},
+		{
+			"Positive Test Limit And Cursor",
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestPartitionId}, "limit=10&cursor="+data.TestCursor),
 			dependencies{repo: mockContractRepo, validator: mockValidator},
 			200,
-			&data.Contracts,
+			&data.ContractsPage,
+			func() {
+				mockContractRepo.EXPECT().GetContractsPage(gomock.Any(), models.PageRequest{Limit: 10, Cursor: data.TestCursor}).Return(&data.ContractsPage, nil)
+			},
+		},
+		{
+			"Negative Test Limit Out Of Range",
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestPartitionId}, "limit=1001"),
+			dependencies{repo: mockContractRepo, validator: mockValidator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/limit", Rule: "lte", Allowed: "1000"}}),
+			func() {
+			},
+		},
+		{
+			"Negative Test Invalid Cursor",
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestPartitionId}, "cursor=invalid"),
+			dependencies{repo: mockContractRepo, validator: mockValidator},
+			400,
+			models.NewBadRequestError(pagination.ErrInvalidCursor),
 			func() {
-				mockContractRepo.EXPECT().GetContracts(gomock.Any()).Return(&data.Contracts, nil)
+				mockContractRepo.EXPECT().GetContractsPage(gomock.Any(), models.PageRequest{Cursor: "invalid"}).Return(nil, pagination.ErrInvalidCursor)
 			},
 		},
 		{
 			"Negative Test",
-			test.GetTestGinContext(),
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestPartitionId}, ""),
 			dependencies{repo: mockContractRepo, validator: mockValidator},
 			500,
 			models.NewInternalServerError(),
 			func() {
-				mockContractRepo.EXPECT().GetContracts(gomock.Any()).Return(&[]models.Contract{}, errors.New(constants.InternalServerError))
+				mockContractRepo.EXPECT().GetContractsPage(gomock.Any(), models.PageRequest{}).Return(nil, errors.New(constants.InternalServerError))
 			},
 		},
 	}
@@ -76,18 +108,19 @@ func Test_HandleGetContracts(t *testing.T) {
 			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
 			tc.ctx.Writer = blw
 			contractHandler.HandleGetContracts(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
 			statusCode := tc.ctx.Writer.Status()
 
 			// assert
 			assert.Equal(t, tc.expectedResponseCode, statusCode)
 			if statusCode == 200 {
-				var actualContracts *[]models.Contract
+				var actualContracts *models.Page[models.Contract]
 				err := json.Unmarshal(blw.Body.Bytes(), &actualContracts)
 				if err != nil {
 					t.Fail()
 				}
 				assert.NotNil(t, actualContracts)
-				assert.GreaterOrEqual(t, 1, len(*actualContracts))
+				assert.GreaterOrEqual(t, 1, len(actualContracts.Items))
 				assert.Equal(t, tc.expectedResponse, actualContracts)
 			} else {
 				var actualError models.Error
@@ -102,6 +135,93 @@ func Test_HandleGetContracts(t *testing.T) {
 	}
 }
 
+func Test_HandleGetReferencingContracts(t *testing.T) {
+	// arrange
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockContractRepo := repoMocks.NewMockContractGetter(mockController)
+	mockValidator := mocks.NewValidatorPathPositive(mockController)
+	providerParams := map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestProviderId}
+	tariffParams := map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}
+
+	testcases := []struct {
+		name                 string
+		ctx                  *gin.Context
+		handle               func(handler ContractHandler, ctx *gin.Context)
+		expectedResponseCode int
+		expectedResponse     any
+		mockFunc             func()
+	}{
+		{
+			"Positive Test Provider",
+			test.GetTestGinContextWithParametersAndQuery(providerParams, "limit=10"),
+			ContractHandler.HandleGetProviderContracts,
+			200,
+			&data.ContractsPage,
+			func() {
+				mockContractRepo.EXPECT().GetContractsByProvider(data.TestPartitionId, data.TestProviderId, models.PageRequest{Limit: 10}).Return(&data.ContractsPage, nil)
+			},
+		},
+		{
+			"Positive Test Tariff",
+			test.GetTestGinContextWithParametersAndQuery(tariffParams, "cursor="+data.TestCursor),
+			ContractHandler.HandleGetTariffContracts,
+			200,
+			&data.ContractsPage,
+			func() {
+				mockContractRepo.EXPECT().GetContractsByTariff(data.TestPartitionId, data.TestTariffId, models.PageRequest{Cursor: data.TestCursor}).Return(&data.ContractsPage, nil)
+			},
+		},
+		{
+			"Negative Test Limit Out Of Range",
+			test.GetTestGinContextWithParametersAndQuery(tariffParams, "limit=1001"),
+			ContractHandler.HandleGetTariffContracts,
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/limit", Rule: "lte", Allowed: "1000"}}),
+			func() {},
+		},
+		{
+			"Negative Test Unavailable",
+			test.GetTestGinContextWithParametersAndQuery(providerParams, ""),
+			ContractHandler.HandleGetProviderContracts,
+			503,
+			models.NewUnavailableError(),
+			func() {
+				mockContractRepo.EXPECT().GetContractsByProvider(data.TestPartitionId, data.TestProviderId, models.PageRequest{}).Return(nil, dberrors.Wrap(dberrors.ErrUnavailable, errors.New("no database connection")))
+			},
+		},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			contractHandler := ContractHandler{
+				ContractRepo: mockContractRepo,
+				Validator:    mockValidator,
+			}
+
+			tc.mockFunc()
+			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
+			tc.ctx.Writer = blw
+			tc.handle(contractHandler, tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
+			statusCode := tc.ctx.Writer.Status()
+
+			// assert
+			assert.Equal(t, tc.expectedResponseCode, statusCode)
+			if statusCode == 200 {
+				var actualContracts *models.Page[models.Contract]
+				assert.NoError(t, json.Unmarshal(blw.Body.Bytes(), &actualContracts))
+				assert.Equal(t, tc.expectedResponse, actualContracts)
+			} else {
+				var actualError models.Error
+				assert.NoError(t, json.Unmarshal(blw.Body.Bytes(), &actualError))
+				assert.Equal(t, tc.expectedResponse, actualError)
+			}
+		})
+	}
+}
+
 func Test_HandleGetContracts_Validation(t *testing.T) {
 	// arrange
 	mockController := gomock.NewController(t)
@@ -114,17 +234,17 @@ func Test_HandleGetContracts_Validation(t *testing.T) {
 	testcases := []testcase{
 		{
 			"Positive Test",
-			test.GetTestGinContextWithParameters(map[string]string{"PartitionId": data.TestPartitionId}),
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestPartitionId}, ""),
 			dependencies{repo: mockContractRepo, validator: mockValidator},
 			200,
-			&data.Contracts,
+			&data.ContractsPage,
 			func() {
-				mockContractRepo.EXPECT().GetContracts(gomock.Any()).AnyTimes().Return(&data.Contracts, nil)
+				mockContractRepo.EXPECT().GetContractsPage(gomock.Any(), models.PageRequest{}).AnyTimes().Return(&data.ContractsPage, nil)
 			},
 		},
 		{
 			"Negative Test PartitionId Invalid",
-			test.GetTestGinContextWithParameters(map[string]string{"PartitionId": data.TestIdInvalid}),
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestIdInvalid}, ""),
 			dependencies{repo: mockContractRepo, validator: mockValidatorNegative},
 			400,
 			models.NewBadRequestFieldValidationError(errors.New("ValidationError")),
@@ -143,18 +263,19 @@ func Test_HandleGetContracts_Validation(t *testing.T) {
 			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
 			tc.ctx.Writer = blw
 			contractHandler.HandleGetContracts(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
 			statusCode := tc.ctx.Writer.Status()
 
 			// assert
 			assert.Equal(t, tc.expectedResponseCode, statusCode)
 			if statusCode == 200 {
-				var actualContracts *[]models.Contract
+				var actualContracts *models.Page[models.Contract]
 				err := json.Unmarshal(blw.Body.Bytes(), &actualContracts)
 				if err != nil {
 					t.Fail()
 				}
 				assert.NotNil(t, actualContracts)
-				assert.GreaterOrEqual(t, 1, len(*actualContracts))
+				assert.GreaterOrEqual(t, 1, len(actualContracts.Items))
 				assert.Equal(t, tc.expectedResponse, actualContracts)
 			} else {
 				var actualError models.Error
@@ -184,7 +305,19 @@ func Test_HandleGetContract(t *testing.T) {
 			dependencies{repo: mockContractRepo, validator: mockValidator},
 			200,
 			&data.Contract,
-			func() { mockContractRepo.EXPECT().GetContract(gomock.Any(), gomock.Any()).Return(&data.Contract, nil) },
+			func() {
+				mockContractRepo.EXPECT().GetContractWithVersion(gomock.Any(), gomock.Any()).Return(&data.Contract, 1, nil)
+			},
+		},
+		{
+			"Positive Test Not Modified",
+			test.WithHeader(test.GetTestGinContext(), "If-None-Match", `"1"`),
+			dependencies{repo: mockContractRepo, validator: mockValidator},
+			304,
+			nil,
+			func() {
+				mockContractRepo.EXPECT().GetContractWithVersion(gomock.Any(), gomock.Any()).Return(&data.Contract, 1, nil)
+			},
 		},
 		{
 			"Negative Test Contract Not Found",
@@ -193,7 +326,7 @@ func Test_HandleGetContract(t *testing.T) {
 			404,
 			models.NewResourceNotFoundError(),
 			func() {
-				mockContractRepo.EXPECT().GetContract(gomock.Any(), gomock.Any()).Return(&models.Contract{}, errors.New(constants.ResourceNotFound))
+				mockContractRepo.EXPECT().GetContractWithVersion(gomock.Any(), gomock.Any()).Return(&models.Contract{}, 0, dberrors.ErrNotFound)
 			},
 		},
 		{
@@ -203,7 +336,7 @@ func Test_HandleGetContract(t *testing.T) {
 			500,
 			models.NewInternalServerError(),
 			func() {
-				mockContractRepo.EXPECT().GetContract(gomock.Any(), gomock.Any()).Return(&models.Contract{}, errors.New(constants.InternalServerError))
+				mockContractRepo.EXPECT().GetContractWithVersion(gomock.Any(), gomock.Any()).Return(&models.Contract{}, 0, errors.New(constants.InternalServerError))
 			},
 		},
 	}
@@ -218,11 +351,16 @@ func Test_HandleGetContract(t *testing.T) {
 			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
 			tc.ctx.Writer = blw
 			contractHandler.HandleGetContract(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
 			statusCode := tc.ctx.Writer.Status()
 
 			// assert
 			assert.Equal(t, tc.expectedResponseCode, statusCode)
-			if statusCode == 200 {
+			if statusCode == 304 {
+				assert.Equal(t, `"1"`, tc.ctx.Writer.Header().Get("ETag"))
+				assert.Empty(t, blw.Body.Bytes())
+			} else if statusCode == 200 {
+				assert.Equal(t, `"1"`, tc.ctx.Writer.Header().Get("ETag"))
 				var actualContract *models.Contract
 				err := json.Unmarshal(blw.Body.Bytes(), &actualContract)
 				if err != nil {
@@ -260,7 +398,7 @@ func Test_HandleGetContract_Validation(t *testing.T) {
 			200,
 			&data.Contract,
 			func() {
-				mockContractRepo.EXPECT().GetContract(gomock.Any(), gomock.Any()).AnyTimes().Return(&data.Contract, nil)
+				mockContractRepo.EXPECT().GetContractWithVersion(gomock.Any(), gomock.Any()).AnyTimes().Return(&data.Contract, 1, nil)
 			},
 		},
 		{
@@ -270,7 +408,7 @@ func Test_HandleGetContract_Validation(t *testing.T) {
 			400,
 			models.NewBadRequestFieldValidationError(errors.New("ValidationError")),
 			func() {
-				mockContractRepo.EXPECT().GetContract(gomock.Any(), gomock.Any()).AnyTimes().Return(&data.Contract, nil)
+				mockContractRepo.EXPECT().GetContractWithVersion(gomock.Any(), gomock.Any()).AnyTimes().Return(&data.Contract, 1, nil)
 			},
 		},
 		{
@@ -280,7 +418,7 @@ func Test_HandleGetContract_Validation(t *testing.T) {
 			400,
 			models.NewBadRequestFieldValidationError(errors.New("ValidationError")),
 			func() {
-				mockContractRepo.EXPECT().GetContract(gomock.Any(), gomock.Any()).AnyTimes().Return(&data.Contract, nil)
+				mockContractRepo.EXPECT().GetContractWithVersion(gomock.Any(), gomock.Any()).AnyTimes().Return(&data.Contract, 1, nil)
 
 			},
 		},
@@ -305,6 +443,7 @@ func Test_HandleGetContract_Validation(t *testing.T) {
 			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
 			tc.ctx.Writer = blw
 			contractHandler.HandleGetContract(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
 			statusCode := tc.ctx.Writer.Status()
 
 			// assert
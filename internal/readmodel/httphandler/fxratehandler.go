@@ -0,0 +1,43 @@
+//go:generate mockgen -source=fxratehandler.go -destination=testing/fxratehandler_mocks.go -package=testing FxRateGetter
+
+package httphandler
+
+import (
+	"net/http"
+	"tariff-calculation-service/internal/interfaces"
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/repository"
+	"tariff-calculation-service/pkg/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FxRateGetter interface {
+	GetFxRates(partitionId string) (*[]models.FxRate, error)
+}
+
+type FxRateHandler struct {
+	FxRateRepo FxRateGetter
+	Validator  interfaces.Validator
+}
+
+func NewFxRateHandler() FxRateHandler {
+	return FxRateHandler{
+		FxRateRepo: repository.NewFxRateRepo(),
+		Validator:  validation.NewValidator(),
+	}
+}
+
+func (handler FxRateHandler) HandleGetFxRates(context *gin.Context) {
+	pathParam := validation.PartitionId{}
+	if err := handler.Validator.ValidateAndSetPathParams(context, &pathParam); err != nil {
+		return
+	}
+
+	fxRates, err := handler.FxRateRepo.GetFxRates(pathParam.PartitionId)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	context.IndentedJSON(http.StatusOK, fxRates)
+}
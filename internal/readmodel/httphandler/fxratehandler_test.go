@@ -0,0 +1,108 @@
+package httphandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"tariff-calculation-service/internal/interfaces"
+	"tariff-calculation-service/internal/models"
+	repotesting "tariff-calculation-service/internal/readmodel/httphandler/testing"
+	"tariff-calculation-service/pkg"
+	"tariff-calculation-service/pkg/constants"
+	"tariff-calculation-service/test"
+	"tariff-calculation-service/test/data"
+	"tariff-calculation-service/test/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type dependenciesFxRateHandler struct {
+	repo      FxRateGetter
+	validator interfaces.Validator
+}
+
+type testCaseFxRateHandler struct {
+	name                 string
+	ctx                  *gin.Context
+	deps                 dependenciesFxRateHandler
+	expectedResponseCode int
+	expectedResponse     any
+	mockFunc             func()
+}
+
+func Test_GetFxRates(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockFxRateGetter := repotesting.NewMockFxRateGetter(mockController)
+	mockValidator := mocks.NewValidatorPathPositive(mockController)
+	mockValidatorNegative := mocks.NewValidatorPathNegative(mockController)
+
+	testCases := []testCaseFxRateHandler{
+		{
+			"Positive Test",
+			test.GetTestGinContextWithParameters(map[string]string{"PartitionId": data.TestPartitionId}),
+			dependenciesFxRateHandler{repo: mockFxRateGetter, validator: mockValidator},
+			200,
+			&data.FxRates,
+			func() {
+				mockFxRateGetter.EXPECT().GetFxRates(gomock.Any()).Return(&data.FxRates, nil)
+			},
+		},
+		{
+			"Negative Test PartitionId Invalid",
+			test.GetTestGinContextWithParameters(map[string]string{"PartitionId": data.TestIdInvalid}),
+			dependenciesFxRateHandler{repo: mockFxRateGetter, validator: mockValidatorNegative},
+			400,
+			models.NewBadRequestFieldValidationError(errors.New("ValidationError")),
+			func() {},
+		},
+		{
+			"Negative Test Internal Server Error",
+			test.GetTestGinContextWithParameters(map[string]string{"PartitionId": data.TestPartitionId}),
+			dependenciesFxRateHandler{repo: mockFxRateGetter, validator: mockValidator},
+			500,
+			models.NewInternalServerError(),
+			func() {
+				mockFxRateGetter.EXPECT().GetFxRates(gomock.Any()).Return(nil, errors.New(constants.InternalServerError))
+			},
+		},
+	}
+	// act
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fxRateHandler := FxRateHandler{
+				FxRateRepo: tc.deps.repo,
+				Validator:  tc.deps.validator,
+			}
+			tc.mockFunc()
+			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
+			tc.ctx.Writer = blw
+			fxRateHandler.HandleGetFxRates(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
+			statusCode := tc.ctx.Writer.Status()
+
+			// assert
+			assert.Equal(t, tc.expectedResponseCode, statusCode)
+			if statusCode == 200 {
+				var actualFxRates *[]models.FxRate
+				err := json.Unmarshal(blw.Body.Bytes(), &actualFxRates)
+				if err != nil {
+					t.Fail()
+				}
+				assert.Equal(t, tc.expectedResponse, actualFxRates)
+			} else {
+				var actualError models.Error
+				err := json.Unmarshal(blw.Body.Bytes(), &actualError)
+				if err != nil {
+					t.Fail()
+				}
+				assert.Equal(t, tc.expectedResponse, actualError)
+			}
+		})
+	}
+}
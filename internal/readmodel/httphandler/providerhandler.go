@@ -3,11 +3,13 @@
 package httphandler
 
 import (
+	"errors"
 	"net/http"
 
-	"tariff-calculation-service/internal/database"
 	"tariff-calculation-service/internal/interfaces"
 	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/pagination"
+	"tariff-calculation-service/internal/repository"
 	"tariff-calculation-service/pkg"
 	"tariff-calculation-service/pkg/validation"
 
@@ -16,7 +18,9 @@ import (
 
 type ProviderGetter interface {
 	GetProviders(partitionId string) (*[]models.Provider, error)
+	GetProvidersPage(partitionId string, pageRequest models.PageRequest) (*models.Page[models.Provider], error)
 	GetProvider(partitionId, providerId string) (*models.Provider, error)
+	GetProviderWithVersion(partitionId, providerId string) (*models.Provider, int, error)
 }
 
 type ProviderHandler struct {
@@ -26,7 +30,7 @@ type ProviderHandler struct {
 
 func NewProviderHandler() ProviderHandler {
 	return ProviderHandler{
-		ProviderRepo: database.NewProviderRepo(),
+		ProviderRepo: repository.NewProviderRepo(),
 		Validator:    validation.NewValidator(),
 	}
 }
@@ -37,9 +41,19 @@ func (handler ProviderHandler) HandleGetProviders(context *gin.Context) {
 		return
 	}
 
-	providers, err := handler.ProviderRepo.GetProviders(pathParam.PartitionId)
+	pageRequest := models.PageRequest{}
+	if err := context.ShouldBindQuery(&pageRequest); err != nil {
+		pkg.RespondWithError(context, models.NewBadRequestFieldValidationError(err))
+		return
+	}
+
+	providers, err := handler.ProviderRepo.GetProvidersPage(pathParam.PartitionId, pageRequest)
+	if errors.Is(err, pagination.ErrInvalidCursor) {
+		pkg.RespondWithError(context, models.NewBadRequestError(err))
+		return
+	}
 	if err != nil {
-		context.IndentedJSON(http.StatusInternalServerError, models.NewInternalServerError())
+		context.Error(err)
 		return
 	}
 
@@ -52,10 +66,15 @@ func (handler ProviderHandler) HandleGetProvider(context *gin.Context) {
 		return
 	}
 
-	provider, err := handler.ProviderRepo.GetProvider(pathParams.PartitionId, pathParams.Id)
+	provider, version, err := handler.ProviderRepo.GetProviderWithVersion(pathParams.PartitionId, pathParams.Id)
 	if err != nil {
-		pkg.HandleResourceNotFoundAndInternalServerError(context, err)
+		context.Error(err)
+		return
+	}
+	if pkg.NotModified(context, version) {
 		return
 	}
+
+	pkg.SetETag(context, version)
 	context.IndentedJSON(http.StatusOK, provider)
 }
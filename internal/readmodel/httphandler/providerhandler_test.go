@@ -3,9 +3,12 @@ package httphandler
 import (
 	"bytes"
 	"encoding/json"
+	"tariff-calculation-service/internal/dberrors"
 	"tariff-calculation-service/internal/interfaces"
 	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/pagination"
 	repotesting "tariff-calculation-service/internal/readmodel/httphandler/testing"
+	"tariff-calculation-service/pkg"
 	"tariff-calculation-service/pkg/constants"
 	"tariff-calculation-service/test"
 	"tariff-calculation-service/test/data"
@@ -43,22 +46,51 @@ func Test_HandleGetProviders(t *testing.T) {
 	testCases := []testCaseProviderHandler{
 		{
 			"Positive Test",
-			test.GetTestGinContext(),
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestPartitionId}, ""),
+			dependenciesProviderHandler{repo: mockProviderGetter, validator: mockValidator},
+			200,
+			&data.ProvidersPage,
+			func() {
+				mockProviderGetter.EXPECT().GetProvidersPage(gomock.Any(), models.PageRequest{}).Return(&data.ProvidersPage, nil)
+			},
+		},
+		{
+			"Positive Test Limit And Cursor",
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestPartitionId}, "limit=10&cursor="+data.TestCursor),
 			dependenciesProviderHandler{repo: mockProviderGetter, validator: mockValidator},
 			200,
-			&data.Providers,
+			&data.ProvidersPage,
+			func() {
+				mockProviderGetter.EXPECT().GetProvidersPage(gomock.Any(), models.PageRequest{Limit: 10, Cursor: data.TestCursor}).Return(&data.ProvidersPage, nil)
+			},
+		},
+		{
+			"Negative Test Limit Out Of Range",
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestPartitionId}, "limit=1001"),
+			dependenciesProviderHandler{repo: mockProviderGetter, validator: mockValidator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/limit", Rule: "lte", Allowed: "1000"}}),
 			func() {
-				mockProviderGetter.EXPECT().GetProviders(gomock.Any()).Return(&data.Providers, nil)
+			},
+		},
+		{
+			"Negative Test Invalid Cursor",
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestPartitionId}, "cursor=invalid"),
+			dependenciesProviderHandler{repo: mockProviderGetter, validator: mockValidator},
+			400,
+			models.NewBadRequestError(pagination.ErrInvalidCursor),
+			func() {
+				mockProviderGetter.EXPECT().GetProvidersPage(gomock.Any(), models.PageRequest{Cursor: "invalid"}).Return(nil, pagination.ErrInvalidCursor)
 			},
 		},
 		{
 			"Negative Test Internal Server Error",
-			test.GetTestGinContext(),
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestPartitionId}, ""),
 			dependenciesProviderHandler{repo: mockProviderGetter, validator: mockValidator},
 			500,
 			models.NewInternalServerError(),
 			func() {
-				mockProviderGetter.EXPECT().GetProviders(gomock.Any()).Return(&[]models.Provider{}, errors.New(constants.InternalServerError))
+				mockProviderGetter.EXPECT().GetProvidersPage(gomock.Any(), models.PageRequest{}).Return(nil, errors.New(constants.InternalServerError))
 			},
 		},
 	}
@@ -73,18 +105,19 @@ func Test_HandleGetProviders(t *testing.T) {
 			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
 			tc.ctx.Writer = blw
 			providerHandler.HandleGetProviders(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
 			statusCode := tc.ctx.Writer.Status()
 
 			// assert
 			assert.Equal(t, tc.expectedResponseCode, statusCode)
 			if statusCode == 200 {
-				var actualProviders *[]models.Provider
+				var actualProviders *models.Page[models.Provider]
 				err := json.Unmarshal(blw.Body.Bytes(), &actualProviders)
 				if err != nil {
 					t.Fail()
 				}
 				assert.NotNil(t, actualProviders)
-				assert.GreaterOrEqual(t, 1, len(*actualProviders))
+				assert.GreaterOrEqual(t, 1, len(actualProviders.Items))
 				assert.Equal(t, tc.expectedResponse, actualProviders)
 			} else {
 				var actualError models.Error
@@ -110,17 +143,17 @@ func Test_HandleGetProviders_Validation(t *testing.T) {
 	testCases := []testCaseProviderHandler{
 		{
 			"Positive Test",
-			test.GetTestGinContextWithParameters(map[string]string{"PartitionId": data.TestPartitionId}),
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestPartitionId}, ""),
 			dependenciesProviderHandler{repo: mockProviderGetter, validator: mockValidator},
 			200,
-			&data.Providers,
+			&data.ProvidersPage,
 			func() {
-				mockProviderGetter.EXPECT().GetProviders(gomock.Any()).Return(&data.Providers, nil)
+				mockProviderGetter.EXPECT().GetProvidersPage(gomock.Any(), models.PageRequest{}).Return(&data.ProvidersPage, nil)
 			},
 		},
 		{
 			"Negative Test PartitionId Invalid",
-			test.GetTestGinContextWithParameters(map[string]string{"PartitionId": data.TestIdInvalid}),
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestIdInvalid}, ""),
 			dependenciesProviderHandler{repo: mockProviderGetter, validator: mockValidatorNegative},
 			400,
 			models.NewBadRequestFieldValidationError(errors.New("ValidationError")),
@@ -139,18 +172,19 @@ func Test_HandleGetProviders_Validation(t *testing.T) {
 			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
 			tc.ctx.Writer = blw
 			providerHandler.HandleGetProviders(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
 			statusCode := tc.ctx.Writer.Status()
 
 			// assert
 			assert.Equal(t, tc.expectedResponseCode, statusCode)
 			if statusCode == 200 {
-				var actualProviders *[]models.Provider
+				var actualProviders *models.Page[models.Provider]
 				err := json.Unmarshal(blw.Body.Bytes(), &actualProviders)
 				if err != nil {
 					t.Fail()
 				}
 				assert.NotNil(t, actualProviders)
-				assert.GreaterOrEqual(t, 1, len(*actualProviders))
+				assert.GreaterOrEqual(t, 1, len(actualProviders.Items))
 				assert.Equal(t, tc.expectedResponse, actualProviders)
 			} else {
 				var actualError models.Error
@@ -180,7 +214,17 @@ func Test_HandleGetProvider(t *testing.T) {
 			200,
 			&data.Provider,
 			func() {
-				mockProviderGetter.EXPECT().GetProvider(gomock.Any(), gomock.Any()).Return(&data.Provider, nil)
+				mockProviderGetter.EXPECT().GetProviderWithVersion(gomock.Any(), gomock.Any()).Return(&data.Provider, 1, nil)
+			},
+		},
+		{
+			"Positive Test Not Modified",
+			test.WithHeader(test.GetTestGinContext(), "If-None-Match", `"1"`),
+			dependenciesProviderHandler{repo: mockProviderGetter, validator: mockValidator},
+			304,
+			nil,
+			func() {
+				mockProviderGetter.EXPECT().GetProviderWithVersion(gomock.Any(), gomock.Any()).Return(&data.Provider, 1, nil)
 			},
 		},
 		{
@@ -190,7 +234,7 @@ func Test_HandleGetProvider(t *testing.T) {
 			404,
 			models.NewResourceNotFoundError(),
 			func() {
-				mockProviderGetter.EXPECT().GetProvider(gomock.Any(), gomock.Any()).Return(&models.Provider{}, errors.New(constants.ResourceNotFound))
+				mockProviderGetter.EXPECT().GetProviderWithVersion(gomock.Any(), gomock.Any()).Return(&models.Provider{}, 0, dberrors.ErrNotFound)
 			},
 		},
 		{
@@ -200,7 +244,7 @@ func Test_HandleGetProvider(t *testing.T) {
 			500,
 			models.NewInternalServerError(),
 			func() {
-				mockProviderGetter.EXPECT().GetProvider(gomock.Any(), gomock.Any()).Return(&models.Provider{}, errors.New(constants.InternalServerError))
+				mockProviderGetter.EXPECT().GetProviderWithVersion(gomock.Any(), gomock.Any()).Return(&models.Provider{}, 0, errors.New(constants.InternalServerError))
 			},
 		},
 	}
@@ -216,11 +260,16 @@ func Test_HandleGetProvider(t *testing.T) {
 			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
 			tc.ctx.Writer = blw
 			providerHandler.HandleGetProvider(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
 			statusCode := tc.ctx.Writer.Status()
 
 			// assert
 			assert.Equal(t, tc.expectedResponseCode, statusCode)
-			if statusCode == 200 {
+			if statusCode == 304 {
+				assert.Equal(t, `"1"`, tc.ctx.Writer.Header().Get("ETag"))
+				assert.Empty(t, blw.Body.Bytes())
+			} else if statusCode == 200 {
+				assert.Equal(t, `"1"`, tc.ctx.Writer.Header().Get("ETag"))
 				var actualProvider *models.Provider
 				err := json.Unmarshal(blw.Body.Bytes(), &actualProvider)
 				if err != nil {
@@ -257,7 +306,7 @@ func Test_HandleGetProvider_Validation(t *testing.T) {
 			200,
 			&data.Provider,
 			func() {
-				mockProviderGetter.EXPECT().GetProvider(gomock.Any(), gomock.Any()).Return(&data.Provider, nil)
+				mockProviderGetter.EXPECT().GetProviderWithVersion(gomock.Any(), gomock.Any()).Return(&data.Provider, 1, nil)
 			},
 		},
 		{
@@ -300,6 +349,7 @@ func Test_HandleGetProvider_Validation(t *testing.T) {
 			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
 			tc.ctx.Writer = blw
 			providerHandler.HandleGetProvider(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
 			statusCode := tc.ctx.Writer.Status()
 
 			// assert
@@ -0,0 +1,47 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	repotesting "tariff-calculation-service/internal/readmodel/httphandler/testing"
+	"tariff-calculation-service/pkg"
+	"tariff-calculation-service/pkg/constants"
+	"tariff-calculation-service/pkg/validation"
+	"tariff-calculation-service/test/data"
+	"tariff-calculation-service/tools"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// Test_CalculationRoute serves the calculation through its route with the real validator, so that route parameters
+// that are not named like the uri tags of pkg/validation fail the test instead of every request.
+func Test_CalculationRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockTariffGetter := repotesting.NewMockTariffGetter(mockController)
+	router := gin.New()
+	router.Use(pkg.ErrorHandler)
+	router.POST(constants.BasePath+constants.CalculationPath, CalculationHandler{
+		TariffRepo: mockTariffGetter,
+		Validator:  validation.NewValidator(),
+	}.HandlePostCalculation)
+	path := "/api/v1/partitions/" + data.TestPartitionId + "/tariffs/" + data.TestTariffId + "/calculate"
+	body := string(tools.GetFirstValue(json.Marshal(data.CalculationRequest)))
+	mockTariffGetter.EXPECT().GetTariffVersions(data.TestPartitionId, data.TestTariffId).Return(nil, errors.New(constants.InternalServerError))
+
+	// act
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+
+	// assert
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+}
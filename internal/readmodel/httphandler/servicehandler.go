@@ -3,6 +3,7 @@ package httphandler
 import (
 	"net/http"
 	"os"
+	"tariff-calculation-service/api"
 
 	"github.com/gin-gonic/gin"
 )
@@ -29,3 +30,7 @@ func (httpHandler HttpHandler) HandleGetVersion(context *gin.Context) {
 func (httpHandler HttpHandler) HandleGetRestVersion(context *gin.Context) {
 	context.IndentedJSON(http.StatusOK, os.Getenv("REST_API_VERSION"))
 }
+
+func (httpHandler HttpHandler) HandleGetOpenAPISpec(context *gin.Context) {
+	context.Data(http.StatusOK, "application/yaml", api.OpenAPISpec)
+}
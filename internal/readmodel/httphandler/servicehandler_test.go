@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"os"
+	"tariff-calculation-service/api"
 	"tariff-calculation-service/test"
 	"testing"
 
@@ -69,3 +70,18 @@ func Test_HandleGetRestVersion(t *testing.T) {
 	assert.NotNil(t, responseBody)
 	assert.Equal(t, os.Getenv("REST_API_VERSION"), responseBody)
 }
+
+func Test_HandleGetOpenAPISpec(t *testing.T) {
+	serviceHandler := NewHttpHandler()
+
+	testCtx := test.GetTestGinContext()
+
+	blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: testCtx.Writer}
+	testCtx.Writer = blw
+	serviceHandler.HandleGetOpenAPISpec(testCtx)
+	statusCode := testCtx.Writer.Status()
+
+	assert.Equal(t, 200, statusCode)
+	assert.Equal(t, "application/yaml", testCtx.Writer.Header().Get("Content-Type"))
+	assert.Equal(t, api.OpenAPISpec, blw.Body.Bytes())
+}
@@ -0,0 +1,43 @@
+//go:generate mockgen -source=settingshandler.go -destination=testing/settingshandler_mocks.go -package=testing SettingsGetter
+
+package httphandler
+
+import (
+	"net/http"
+	"tariff-calculation-service/internal/interfaces"
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/repository"
+	"tariff-calculation-service/pkg/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SettingsGetter interface {
+	GetSettings(partitionId string) (*models.Settings, error)
+}
+
+type SettingsHandler struct {
+	SettingsRepo SettingsGetter
+	Validator    interfaces.Validator
+}
+
+func NewSettingsHandler() SettingsHandler {
+	return SettingsHandler{
+		SettingsRepo: repository.NewSettingsRepo(),
+		Validator:    validation.NewValidator(),
+	}
+}
+
+func (handler SettingsHandler) HandleGetSettings(context *gin.Context) {
+	pathParam := validation.PartitionId{}
+	if err := handler.Validator.ValidateAndSetPathParams(context, &pathParam); err != nil {
+		return
+	}
+
+	settings, err := handler.SettingsRepo.GetSettings(pathParam.PartitionId)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	context.IndentedJSON(http.StatusOK, settings)
+}
@@ -0,0 +1,108 @@
+package httphandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"tariff-calculation-service/internal/interfaces"
+	"tariff-calculation-service/internal/models"
+	repotesting "tariff-calculation-service/internal/readmodel/httphandler/testing"
+	"tariff-calculation-service/pkg"
+	"tariff-calculation-service/pkg/constants"
+	"tariff-calculation-service/test"
+	"tariff-calculation-service/test/data"
+	"tariff-calculation-service/test/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type dependenciesSettingsHandler struct {
+	repo      SettingsGetter
+	validator interfaces.Validator
+}
+
+type testCaseSettingsHandler struct {
+	name                 string
+	ctx                  *gin.Context
+	deps                 dependenciesSettingsHandler
+	expectedResponseCode int
+	expectedResponse     any
+	mockFunc             func()
+}
+
+func Test_GetSettings(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockSettingsGetter := repotesting.NewMockSettingsGetter(mockController)
+	mockValidator := mocks.NewValidatorPathPositive(mockController)
+	mockValidatorNegative := mocks.NewValidatorPathNegative(mockController)
+
+	testCases := []testCaseSettingsHandler{
+		{
+			"Positive Test",
+			test.GetTestGinContextWithParameters(map[string]string{"PartitionId": data.TestPartitionId}),
+			dependenciesSettingsHandler{repo: mockSettingsGetter, validator: mockValidator},
+			200,
+			&data.Settings,
+			func() {
+				mockSettingsGetter.EXPECT().GetSettings(gomock.Any()).Return(&data.Settings, nil)
+			},
+		},
+		{
+			"Negative Test PartitionId Invalid",
+			test.GetTestGinContextWithParameters(map[string]string{"PartitionId": data.TestIdInvalid}),
+			dependenciesSettingsHandler{repo: mockSettingsGetter, validator: mockValidatorNegative},
+			400,
+			models.NewBadRequestFieldValidationError(errors.New("ValidationError")),
+			func() {},
+		},
+		{
+			"Negative Test Internal Server Error",
+			test.GetTestGinContextWithParameters(map[string]string{"PartitionId": data.TestPartitionId}),
+			dependenciesSettingsHandler{repo: mockSettingsGetter, validator: mockValidator},
+			500,
+			models.NewInternalServerError(),
+			func() {
+				mockSettingsGetter.EXPECT().GetSettings(gomock.Any()).Return(nil, errors.New(constants.InternalServerError))
+			},
+		},
+	}
+	// act
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			settingsHandler := SettingsHandler{
+				SettingsRepo: tc.deps.repo,
+				Validator:    tc.deps.validator,
+			}
+			tc.mockFunc()
+			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
+			tc.ctx.Writer = blw
+			settingsHandler.HandleGetSettings(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
+			statusCode := tc.ctx.Writer.Status()
+
+			// assert
+			assert.Equal(t, tc.expectedResponseCode, statusCode)
+			if statusCode == 200 {
+				var actualSettings *models.Settings
+				err := json.Unmarshal(blw.Body.Bytes(), &actualSettings)
+				if err != nil {
+					t.Fail()
+				}
+				assert.Equal(t, tc.expectedResponse, actualSettings)
+			} else {
+				var actualError models.Error
+				err := json.Unmarshal(blw.Body.Bytes(), &actualError)
+				if err != nil {
+					t.Fail()
+				}
+				assert.Equal(t, tc.expectedResponse, actualError)
+			}
+		})
+	}
+}
@@ -3,19 +3,28 @@
 package httphandler
 
 import (
+	"errors"
 	"net/http"
-	"tariff-calculation-service/internal/database"
+	"tariff-calculation-service/internal/dberrors"
 	"tariff-calculation-service/internal/interfaces"
 	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/pagination"
+	"tariff-calculation-service/internal/repository"
+	"tariff-calculation-service/internal/tariffquery"
 	"tariff-calculation-service/pkg"
 	"tariff-calculation-service/pkg/validation"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 type TariffGetter interface {
 	GetTariffs(partitionId string) (*[]models.Tariff, error)
+	GetTariffsPage(partitionId string, filter models.TariffFilter, pageRequest models.PageRequest) (*models.Page[models.Tariff], error)
 	GetTariff(partitionId, tariffId string) (*models.Tariff, error)
+	GetTariffWithVersion(partitionId, tariffId string) (*models.Tariff, int, error)
+	GetTariffVersions(partitionId, tariffId string) (*[]models.TariffVersion, error)
+	GetAllTariffVersions(partitionId string) (*[][]models.TariffVersion, error)
 }
 
 type TariffHandler struct {
@@ -25,7 +34,7 @@ type TariffHandler struct {
 
 func NewTariffHandler() TariffHandler {
 	return TariffHandler{
-		TariffRepo: database.NewTariffRepo(),
+		TariffRepo: repository.NewTariffRepo(),
 		Validator:  validation.NewValidator(),
 	}
 }
@@ -36,9 +45,24 @@ func (handler TariffHandler) HandleGetTariffs(context *gin.Context) {
 		return
 	}
 
-	tariffs, err := handler.TariffRepo.GetTariffs(pathParam.PartitionId)
+	filter := models.TariffFilter{}
+	if err := context.ShouldBindQuery(&filter); err != nil {
+		pkg.RespondWithError(context, models.NewBadRequestFieldValidationError(err))
+		return
+	}
+	pageRequest := models.PageRequest{}
+	if err := context.ShouldBindQuery(&pageRequest); err != nil {
+		pkg.RespondWithError(context, models.NewBadRequestFieldValidationError(err))
+		return
+	}
+
+	tariffs, err := handler.TariffRepo.GetTariffsPage(pathParam.PartitionId, filter, pageRequest)
+	if errors.Is(err, pagination.ErrInvalidCursor) || errors.Is(err, tariffquery.ErrTooManyToSort) {
+		pkg.RespondWithError(context, models.NewBadRequestError(err))
+		return
+	}
 	if err != nil {
-		context.IndentedJSON(http.StatusInternalServerError, models.NewInternalServerError())
+		context.Error(err)
 		return
 	}
 	context.IndentedJSON(http.StatusOK, tariffs)
@@ -50,11 +74,58 @@ func (handler TariffHandler) HandleGetTariff(context *gin.Context) {
 		return
 	}
 
-	tariff, err := handler.TariffRepo.GetTariff(pathParams.PartitionId, pathParams.Id)
+	query := models.TariffAsOf{}
+	if err := context.ShouldBindQuery(&query); err != nil {
+		pkg.RespondWithError(context, models.NewBadRequestFieldValidationError(err))
+		return
+	}
+
+	tariff, version, err := handler.getTariffAsOf(pathParams.PartitionId, pathParams.Id, query.AsOf)
 	if err != nil {
-		pkg.HandleResourceNotFoundAndInternalServerError(context, err)
+		context.Error(err)
+		return
+	}
+	if pkg.NotModified(context, version) {
 		return
 	}
 
+	pkg.SetETag(context, version)
 	context.IndentedJSON(http.StatusOK, tariff)
 }
+
+// HandleGetTariffVersions returns every version of the tariff, ordered by version.
+func (handler TariffHandler) HandleGetTariffVersions(context *gin.Context) {
+	pathParams := validation.PartitionIdWithId{}
+	if err := handler.Validator.ValidateAndSetPathParams(context, &pathParams); err != nil {
+		return
+	}
+
+	versions, err := handler.TariffRepo.GetTariffVersions(pathParams.PartitionId, pathParams.Id)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	context.IndentedJSON(http.StatusOK, versions)
+}
+
+// getTariffAsOf returns the version of the tariff that was effective at the instant, or the current version
+// without an instant. It returns dberrors.ErrNotFound if the tariff was not created yet.
+func (handler TariffHandler) getTariffAsOf(partitionId, tariffId, asOf string) (*models.Tariff, int, error) {
+	if asOf == "" {
+		return handler.TariffRepo.GetTariffWithVersion(partitionId, tariffId)
+	}
+	at, err := time.Parse(time.RFC3339, asOf)
+	if err != nil {
+		return nil, 0, err
+	}
+	versions, err := handler.TariffRepo.GetTariffVersions(partitionId, tariffId)
+	if err != nil {
+		return nil, 0, err
+	}
+	version, found := tariffquery.VersionAt(*versions, at)
+	if !found {
+		return nil, 0, dberrors.ErrNotFound
+	}
+
+	return &version.Tariff, version.Version, nil
+}
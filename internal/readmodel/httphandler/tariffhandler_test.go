@@ -6,9 +6,13 @@ import (
 	"errors"
 	"testing"
 
+	"tariff-calculation-service/internal/dberrors"
 	"tariff-calculation-service/internal/interfaces"
 	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/pagination"
 	repotesting "tariff-calculation-service/internal/readmodel/httphandler/testing"
+	"tariff-calculation-service/internal/tariffquery"
+	"tariff-calculation-service/pkg"
 	"tariff-calculation-service/pkg/constants"
 	"tariff-calculation-service/test"
 	"tariff-calculation-service/test/data"
@@ -43,22 +47,89 @@ func Test_GetTariffs(t *testing.T) {
 	testCases := []testCaseTariffHandler{
 		{
 			"Positive Test",
-			test.GetTestGinContext(),
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestPartitionId}, ""),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			200,
+			&data.TariffsPage,
+			func() {
+				mockTariffGetter.EXPECT().GetTariffsPage(gomock.Any(), models.TariffFilter{}, models.PageRequest{}).Return(&data.TariffsPage, nil)
+			},
+		},
+		{
+			"Positive Test Limit And Cursor",
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestPartitionId}, "limit=10&cursor="+data.TestCursor),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			200,
+			&data.TariffsPage,
+			func() {
+				mockTariffGetter.EXPECT().GetTariffsPage(gomock.Any(), models.TariffFilter{}, models.PageRequest{Limit: 10, Cursor: data.TestCursor}).Return(&data.TariffsPage, nil)
+			},
+		},
+		{
+			"Negative Test Limit Out Of Range",
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestPartitionId}, "limit=1001"),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/limit", Rule: "lte", Allowed: "1000"}}),
+			func() {
+			},
+		},
+		{
+			"Negative Test Invalid Cursor",
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestPartitionId}, "cursor=invalid"),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			400,
+			models.NewBadRequestError(pagination.ErrInvalidCursor),
+			func() {
+				mockTariffGetter.EXPECT().GetTariffsPage(gomock.Any(), models.TariffFilter{}, models.PageRequest{Cursor: "invalid"}).Return(nil, pagination.ErrInvalidCursor)
+			},
+		},
+		{
+			"Negative Test Too Many To Sort",
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestPartitionId}, "sort=name"),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			400,
+			models.NewBadRequestError(tariffquery.ErrTooManyToSort),
+			func() {
+				mockTariffGetter.EXPECT().GetTariffsPage(gomock.Any(), models.TariffFilter{Sort: "name"}, models.PageRequest{}).Return(nil, tariffquery.ErrTooManyToSort)
+			},
+		},
+		{
+			"Positive Test Filter",
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestPartitionId}, "tariffType=3&currency=GBP&validAt=2021-03-24T12:04:18Z&validFrom=2020-03-24T12:04:18Z&validTo=2022-03-24T12:04:18Z&name=Test&sort=validFrom"),
 			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
 			200,
-			&data.Tariffs,
+			&data.TariffsPage,
+			func() {
+				mockTariffGetter.EXPECT().GetTariffsPage(gomock.Any(), data.TariffFilter, models.PageRequest{}).Return(&data.TariffsPage, nil)
+			},
+		},
+		{
+			"Negative Test Filter Invalid",
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestPartitionId}, "currency=pounds"),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/currency", Rule: "iso4217"}}),
+			func() {
+			},
+		},
+		{
+			"Negative Test Sort Invalid",
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestPartitionId}, "sort=price"),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/sort", Rule: "oneof", Allowed: "name validFrom"}}),
 			func() {
-				mockTariffGetter.EXPECT().GetTariffs(gomock.Any()).Return(&data.Tariffs, nil)
 			},
 		},
 		{
 			"Negative Test Internal Server Error",
-			test.GetTestGinContext(),
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestPartitionId}, ""),
 			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
 			500,
 			models.NewInternalServerError(),
 			func() {
-				mockTariffGetter.EXPECT().GetTariffs(gomock.Any()).Return(&[]models.Tariff{}, errors.New(constants.InternalServerError))
+				mockTariffGetter.EXPECT().GetTariffsPage(gomock.Any(), models.TariffFilter{}, models.PageRequest{}).Return(nil, errors.New(constants.InternalServerError))
 			},
 		},
 	}
@@ -73,18 +144,19 @@ func Test_GetTariffs(t *testing.T) {
 			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
 			tc.ctx.Writer = blw
 			tariffHandler.HandleGetTariffs(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
 			statusCode := tc.ctx.Writer.Status()
 
 			// assert
 			assert.Equal(t, tc.expectedResponseCode, statusCode)
 			if statusCode == 200 {
-				var actualTariffs *[]models.Tariff
+				var actualTariffs *models.Page[models.Tariff]
 				err := json.Unmarshal(blw.Body.Bytes(), &actualTariffs)
 				if err != nil {
 					t.Fail()
 				}
 				assert.NotNil(t, actualTariffs)
-				assert.GreaterOrEqual(t, 1, len(*actualTariffs))
+				assert.GreaterOrEqual(t, 1, len(actualTariffs.Items))
 				assert.Equal(t, tc.expectedResponse, actualTariffs)
 			} else {
 				var actualError models.Error
@@ -110,17 +182,17 @@ func Test_GetTariffs_Validation(t *testing.T) {
 	testCases := []testCaseTariffHandler{
 		{
 			"Positive Test",
-			test.GetTestGinContextWithParameters(map[string]string{"PartitionId": data.TestPartitionId}),
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestPartitionId}, ""),
 			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
 			200,
-			&data.Tariffs,
+			&data.TariffsPage,
 			func() {
-				mockTariffGetter.EXPECT().GetTariffs(gomock.Any()).Return(&data.Tariffs, nil)
+				mockTariffGetter.EXPECT().GetTariffsPage(gomock.Any(), models.TariffFilter{}, models.PageRequest{}).Return(&data.TariffsPage, nil)
 			},
 		},
 		{
 			"Negative Test PartitionId Invalid",
-			test.GetTestGinContextWithParameters(map[string]string{"PartitionId": data.TestPartitionId}),
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestPartitionId}, ""),
 			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidatorNegative},
 			400,
 			models.NewBadRequestFieldValidationError(errors.New("ValidationError")),
@@ -139,18 +211,19 @@ func Test_GetTariffs_Validation(t *testing.T) {
 			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
 			tc.ctx.Writer = blw
 			tariffHandler.HandleGetTariffs(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
 			statusCode := tc.ctx.Writer.Status()
 
 			// assert
 			assert.Equal(t, tc.expectedResponseCode, statusCode)
 			if statusCode == 200 {
-				var actualTariffs *[]models.Tariff
+				var actualTariffs *models.Page[models.Tariff]
 				err := json.Unmarshal(blw.Body.Bytes(), &actualTariffs)
 				if err != nil {
 					t.Fail()
 				}
 				assert.NotNil(t, actualTariffs)
-				assert.GreaterOrEqual(t, 1, len(*actualTariffs))
+				assert.GreaterOrEqual(t, 1, len(actualTariffs.Items))
 				assert.Equal(t, tc.expectedResponse, actualTariffs)
 			} else {
 				var actualError models.Error
@@ -180,7 +253,27 @@ func Test_GetTariff(t *testing.T) {
 			200,
 			&data.Tariff,
 			func() {
-				mockTariffGetter.EXPECT().GetTariff(gomock.Any(), gomock.Any()).Return(&data.Tariff, nil)
+				mockTariffGetter.EXPECT().GetTariffWithVersion(gomock.Any(), gomock.Any()).Return(&data.Tariff, 1, nil)
+			},
+		},
+		{
+			"Positive Test Not Modified",
+			test.WithHeader(test.GetTestGinContext(), "If-None-Match", `"0", W/"1"`),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			304,
+			nil,
+			func() {
+				mockTariffGetter.EXPECT().GetTariffWithVersion(gomock.Any(), gomock.Any()).Return(&data.Tariff, 1, nil)
+			},
+		},
+		{
+			"Positive Test Modified",
+			test.WithHeader(test.GetTestGinContext(), "If-None-Match", `"0"`),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			200,
+			&data.Tariff,
+			func() {
+				mockTariffGetter.EXPECT().GetTariffWithVersion(gomock.Any(), gomock.Any()).Return(&data.Tariff, 1, nil)
 			},
 		},
 		{
@@ -190,7 +283,7 @@ func Test_GetTariff(t *testing.T) {
 			404,
 			models.NewResourceNotFoundError(),
 			func() {
-				mockTariffGetter.EXPECT().GetTariff(gomock.Any(), gomock.Any()).Return(&models.Tariff{}, errors.New(constants.ResourceNotFound))
+				mockTariffGetter.EXPECT().GetTariffWithVersion(gomock.Any(), gomock.Any()).Return(&models.Tariff{}, 1, dberrors.ErrNotFound)
 			},
 		},
 		{
@@ -200,7 +293,27 @@ func Test_GetTariff(t *testing.T) {
 			500,
 			models.NewInternalServerError(),
 			func() {
-				mockTariffGetter.EXPECT().GetTariff(gomock.Any(), gomock.Any()).Return(&models.Tariff{}, errors.New(constants.InternalServerError))
+				mockTariffGetter.EXPECT().GetTariffWithVersion(gomock.Any(), gomock.Any()).Return(&models.Tariff{}, 1, errors.New(constants.InternalServerError))
+			},
+		},
+		{
+			"Positive Test As Of",
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, "asOf=2021-03-24T12:04:18Z"),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			200,
+			&data.Tariff,
+			func() {
+				mockTariffGetter.EXPECT().GetTariffVersions(data.TestPartitionId, data.TestTariffId).Return(&data.TariffVersions, nil)
+			},
+		},
+		{
+			"Negative Test As Of Before First Version",
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, "asOf=2020-03-24T12:04:18Z"),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			404,
+			models.NewResourceNotFoundError(),
+			func() {
+				mockTariffGetter.EXPECT().GetTariffVersions(data.TestPartitionId, data.TestTariffId).Return(&data.TariffVersions, nil)
 			},
 		},
 	}
@@ -215,11 +328,16 @@ func Test_GetTariff(t *testing.T) {
 			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
 			tc.ctx.Writer = blw
 			tariffHandler.HandleGetTariff(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
 			statusCode := tc.ctx.Writer.Status()
 
 			// assert
 			assert.Equal(t, tc.expectedResponseCode, statusCode)
-			if statusCode == 200 {
+			if statusCode == 304 {
+				assert.Equal(t, `"1"`, tc.ctx.Writer.Header().Get("ETag"))
+				assert.Empty(t, blw.Body.Bytes())
+			} else if statusCode == 200 {
+				assert.Equal(t, `"1"`, tc.ctx.Writer.Header().Get("ETag"))
 				var actualTariff *models.Tariff
 				err := json.Unmarshal(blw.Body.Bytes(), &actualTariff)
 				if err != nil {
@@ -256,7 +374,7 @@ func Test_GetTariff_Validation(t *testing.T) {
 			200,
 			&data.Tariff,
 			func() {
-				mockTariffGetter.EXPECT().GetTariff(gomock.Any(), gomock.Any()).Return(&data.Tariff, nil)
+				mockTariffGetter.EXPECT().GetTariffWithVersion(gomock.Any(), gomock.Any()).Return(&data.Tariff, 1, nil)
 			},
 		},
 		{
@@ -286,6 +404,15 @@ func Test_GetTariff_Validation(t *testing.T) {
 			func() {
 			},
 		},
+		{
+			"Negative Test As Of Invalid",
+			test.GetTestGinContextWithParametersAndQuery(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, "asOf=2021-03-24"),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/asOf", Rule: "datetime", Allowed: "2006-01-02T15:04:05Z07:00"}}),
+			func() {
+			},
+		},
 	}
 	// act
 	for _, tc := range testCases {
@@ -298,6 +425,7 @@ func Test_GetTariff_Validation(t *testing.T) {
 			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
 			tc.ctx.Writer = blw
 			tariffHandler.HandleGetTariff(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
 			statusCode := tc.ctx.Writer.Status()
 
 			// assert
@@ -322,3 +450,79 @@ func Test_GetTariff_Validation(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetTariffVersions(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockTariffGetter := repotesting.NewMockTariffGetter(mockController)
+	mockValidator := mocks.NewValidatorPathPositive(mockController)
+	mockValidatorNegative := mocks.NewValidatorPathNegative(mockController)
+
+	testCases := []testCaseTariffHandler{
+		{
+			"Positive Test",
+			test.GetTestGinContextWithParameters(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			200,
+			&data.TariffVersions,
+			func() {
+				mockTariffGetter.EXPECT().GetTariffVersions(data.TestPartitionId, data.TestTariffId).Return(&data.TariffVersions, nil)
+			},
+		},
+		{
+			"Negative Test Not Found",
+			test.GetTestGinContextWithParameters(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidator},
+			404,
+			models.NewResourceNotFoundError(),
+			func() {
+				mockTariffGetter.EXPECT().GetTariffVersions(data.TestPartitionId, data.TestTariffId).Return(nil, dberrors.ErrNotFound)
+			},
+		},
+		{
+			"Negative Test Id Invalid",
+			test.GetTestGinContextWithParameters(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestIdInvalid}),
+			dependenciesTariffHandler{repo: mockTariffGetter, validator: mockValidatorNegative},
+			400,
+			models.NewBadRequestFieldValidationError(errors.New("ValidationError")),
+			func() {
+			},
+		},
+	}
+	// act
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tariffHandler := TariffHandler{
+				TariffRepo: tc.deps.repo,
+				Validator:  tc.deps.validator,
+			}
+			tc.mockFunc()
+			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
+			tc.ctx.Writer = blw
+			tariffHandler.HandleGetTariffVersions(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
+			statusCode := tc.ctx.Writer.Status()
+
+			// assert
+			assert.Equal(t, tc.expectedResponseCode, statusCode)
+			if statusCode == 200 {
+				var actualVersions *[]models.TariffVersion
+				err := json.Unmarshal(blw.Body.Bytes(), &actualVersions)
+				if err != nil {
+					t.Fail()
+				}
+				assert.NotNil(t, actualVersions)
+				assert.Equal(t, tc.expectedResponse, actualVersions)
+			} else {
+				var actualError models.Error
+				err := json.Unmarshal(blw.Body.Bytes(), &actualError)
+				if err != nil {
+					t.Fail()
+				}
+				assert.NotNil(t, actualError)
+				assert.Equal(t, tc.expectedResponse, actualError)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+//go:generate mockgen -source=taxrulehandler.go -destination=testing/taxrulehandler_mocks.go -package=testing TaxRuleGetter
+
+package httphandler
+
+import (
+	"net/http"
+	"tariff-calculation-service/internal/interfaces"
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/repository"
+	"tariff-calculation-service/pkg/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TaxRuleGetter interface {
+	GetTaxRules(partitionId string) (*[]models.TaxRule, error)
+	GetTaxRule(partitionId, taxRuleId string) (*models.TaxRule, error)
+}
+
+type TaxRuleHandler struct {
+	TaxRuleRepo TaxRuleGetter
+	Validator   interfaces.Validator
+}
+
+func NewTaxRuleHandler() TaxRuleHandler {
+	return TaxRuleHandler{
+		TaxRuleRepo: repository.NewTaxRuleRepo(),
+		Validator:   validation.NewValidator(),
+	}
+}
+
+func (handler TaxRuleHandler) HandleGetTaxRules(context *gin.Context) {
+	pathParam := validation.PartitionId{}
+	if err := handler.Validator.ValidateAndSetPathParams(context, &pathParam); err != nil {
+		return
+	}
+
+	taxRules, err := handler.TaxRuleRepo.GetTaxRules(pathParam.PartitionId)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	context.IndentedJSON(http.StatusOK, taxRules)
+}
+
+func (handler TaxRuleHandler) HandleGetTaxRule(context *gin.Context) {
+	pathParams := validation.PartitionIdWithId{}
+	if err := handler.Validator.ValidateAndSetPathParams(context, &pathParams); err != nil {
+		return
+	}
+
+	taxRule, err := handler.TaxRuleRepo.GetTaxRule(pathParams.PartitionId, pathParams.Id)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	context.IndentedJSON(http.StatusOK, taxRule)
+}
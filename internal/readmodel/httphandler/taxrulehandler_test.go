@@ -0,0 +1,183 @@
+package httphandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"tariff-calculation-service/internal/dberrors"
+	"tariff-calculation-service/internal/interfaces"
+	"tariff-calculation-service/internal/models"
+	repotesting "tariff-calculation-service/internal/readmodel/httphandler/testing"
+	"tariff-calculation-service/pkg"
+	"tariff-calculation-service/pkg/constants"
+	"tariff-calculation-service/test"
+	"tariff-calculation-service/test/data"
+	"tariff-calculation-service/test/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type dependenciesTaxRuleHandler struct {
+	repo      TaxRuleGetter
+	validator interfaces.Validator
+}
+
+type testCaseTaxRuleHandler struct {
+	name                 string
+	ctx                  *gin.Context
+	deps                 dependenciesTaxRuleHandler
+	expectedResponseCode int
+	expectedResponse     any
+	mockFunc             func()
+}
+
+func Test_GetTaxRules(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockTaxRuleGetter := repotesting.NewMockTaxRuleGetter(mockController)
+	mockValidator := mocks.NewValidatorPathPositive(mockController)
+	mockValidatorNegative := mocks.NewValidatorPathNegative(mockController)
+
+	testCases := []testCaseTaxRuleHandler{
+		{
+			"Positive Test",
+			test.GetTestGinContextWithParameters(map[string]string{"PartitionId": data.TestPartitionId}),
+			dependenciesTaxRuleHandler{repo: mockTaxRuleGetter, validator: mockValidator},
+			200,
+			&data.TaxRules,
+			func() {
+				mockTaxRuleGetter.EXPECT().GetTaxRules(gomock.Any()).Return(&data.TaxRules, nil)
+			},
+		},
+		{
+			"Negative Test PartitionId Invalid",
+			test.GetTestGinContextWithParameters(map[string]string{"PartitionId": data.TestIdInvalid}),
+			dependenciesTaxRuleHandler{repo: mockTaxRuleGetter, validator: mockValidatorNegative},
+			400,
+			models.NewBadRequestFieldValidationError(errors.New("ValidationError")),
+			func() {},
+		},
+		{
+			"Negative Test Internal Server Error",
+			test.GetTestGinContextWithParameters(map[string]string{"PartitionId": data.TestPartitionId}),
+			dependenciesTaxRuleHandler{repo: mockTaxRuleGetter, validator: mockValidator},
+			500,
+			models.NewInternalServerError(),
+			func() {
+				mockTaxRuleGetter.EXPECT().GetTaxRules(gomock.Any()).Return(&[]models.TaxRule{}, errors.New(constants.InternalServerError))
+			},
+		},
+	}
+	// act
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			taxRuleHandler := TaxRuleHandler{
+				TaxRuleRepo: tc.deps.repo,
+				Validator:   tc.deps.validator,
+			}
+			tc.mockFunc()
+			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
+			tc.ctx.Writer = blw
+			taxRuleHandler.HandleGetTaxRules(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
+			statusCode := tc.ctx.Writer.Status()
+
+			// assert
+			assert.Equal(t, tc.expectedResponseCode, statusCode)
+			if statusCode == 200 {
+				var actualTaxRules *[]models.TaxRule
+				err := json.Unmarshal(blw.Body.Bytes(), &actualTaxRules)
+				if err != nil {
+					t.Fail()
+				}
+				assert.Equal(t, tc.expectedResponse, actualTaxRules)
+			} else {
+				var actualError models.Error
+				err := json.Unmarshal(blw.Body.Bytes(), &actualError)
+				if err != nil {
+					t.Fail()
+				}
+				assert.Equal(t, tc.expectedResponse, actualError)
+			}
+		})
+	}
+}
+
+func Test_GetTaxRule(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockTaxRuleGetter := repotesting.NewMockTaxRuleGetter(mockController)
+	mockValidator := mocks.NewValidatorPathPositive(mockController)
+
+	testCases := []testCaseTaxRuleHandler{
+		{
+			"Positive Test",
+			test.GetTestGinContextWithParameters(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTaxRuleId}),
+			dependenciesTaxRuleHandler{repo: mockTaxRuleGetter, validator: mockValidator},
+			200,
+			&data.TaxRule,
+			func() {
+				mockTaxRuleGetter.EXPECT().GetTaxRule(gomock.Any(), gomock.Any()).Return(&data.TaxRule, nil)
+			},
+		},
+		{
+			"Negative Test Not Found",
+			test.GetTestGinContextWithParameters(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTaxRuleId}),
+			dependenciesTaxRuleHandler{repo: mockTaxRuleGetter, validator: mockValidator},
+			404,
+			models.NewResourceNotFoundError(),
+			func() {
+				mockTaxRuleGetter.EXPECT().GetTaxRule(gomock.Any(), gomock.Any()).Return(&models.TaxRule{}, dberrors.ErrNotFound)
+			},
+		},
+		{
+			"Negative Test Internal Server Error",
+			test.GetTestGinContextWithParameters(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTaxRuleId}),
+			dependenciesTaxRuleHandler{repo: mockTaxRuleGetter, validator: mockValidator},
+			500,
+			models.NewInternalServerError(),
+			func() {
+				mockTaxRuleGetter.EXPECT().GetTaxRule(gomock.Any(), gomock.Any()).Return(&models.TaxRule{}, errors.New(constants.InternalServerError))
+			},
+		},
+	}
+	// act
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			taxRuleHandler := TaxRuleHandler{
+				TaxRuleRepo: tc.deps.repo,
+				Validator:   tc.deps.validator,
+			}
+			tc.mockFunc()
+			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
+			tc.ctx.Writer = blw
+			taxRuleHandler.HandleGetTaxRule(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
+			statusCode := tc.ctx.Writer.Status()
+
+			// assert
+			assert.Equal(t, tc.expectedResponseCode, statusCode)
+			if statusCode == 200 {
+				var actualTaxRule *models.TaxRule
+				err := json.Unmarshal(blw.Body.Bytes(), &actualTaxRule)
+				if err != nil {
+					t.Fail()
+				}
+				assert.Equal(t, tc.expectedResponse, actualTaxRule)
+			} else {
+				var actualError models.Error
+				err := json.Unmarshal(blw.Body.Bytes(), &actualError)
+				if err != nil {
+					t.Fail()
+				}
+				assert.Equal(t, tc.expectedResponse, actualError)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: audithandler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=audithandler.go -destination=testing/audithandler_mocks.go -package=testing AuditGetter
+//
+
+// Package testing is a generated GoMock package.
+package testing
+
+import (
+	reflect "reflect"
+	models "tariff-calculation-service/internal/models"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuditGetter is a mock of AuditGetter interface.
+type MockAuditGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditGetterMockRecorder
+}
+
+// MockAuditGetterMockRecorder is the mock recorder for MockAuditGetter.
+type MockAuditGetterMockRecorder struct {
+	mock *MockAuditGetter
+}
+
+// NewMockAuditGetter creates a new mock instance.
+func NewMockAuditGetter(ctrl *gomock.Controller) *MockAuditGetter {
+	mock := &MockAuditGetter{ctrl: ctrl}
+	mock.recorder = &MockAuditGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditGetter) EXPECT() *MockAuditGetterMockRecorder {
+	return m.recorder
+}
+
+// GetAuditRecordsPage mocks base method.
+func (m *MockAuditGetter) GetAuditRecordsPage(partitionId string, filter models.AuditFilter, pageRequest models.PageRequest) (*models.Page[models.AuditRecord], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditRecordsPage", partitionId, filter, pageRequest)
+	ret0, _ := ret[0].(*models.Page[models.AuditRecord])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditRecordsPage indicates an expected call of GetAuditRecordsPage.
+func (mr *MockAuditGetterMockRecorder) GetAuditRecordsPage(partitionId, filter, pageRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditRecordsPage", reflect.TypeOf((*MockAuditGetter)(nil).GetAuditRecordsPage), partitionId, filter, pageRequest)
+}
@@ -55,6 +55,22 @@ func (mr *MockContractGetterMockRecorder) GetContract(partitionId, contractId an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContract", reflect.TypeOf((*MockContractGetter)(nil).GetContract), partitionId, contractId)
 }
 
+// GetContractWithVersion mocks base method.
+func (m *MockContractGetter) GetContractWithVersion(partitionId, contractId string) (*models.Contract, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContractWithVersion", partitionId, contractId)
+	ret0, _ := ret[0].(*models.Contract)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetContractWithVersion indicates an expected call of GetContractWithVersion.
+func (mr *MockContractGetterMockRecorder) GetContractWithVersion(partitionId, contractId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContractWithVersion", reflect.TypeOf((*MockContractGetter)(nil).GetContractWithVersion), partitionId, contractId)
+}
+
 // GetContracts mocks base method.
 func (m *MockContractGetter) GetContracts(partitionId string) (*[]models.Contract, error) {
 	m.ctrl.T.Helper()
@@ -70,6 +86,51 @@ func (mr *MockContractGetterMockRecorder) GetContracts(partitionId any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContracts", reflect.TypeOf((*MockContractGetter)(nil).GetContracts), partitionId)
 }
 
+// GetContractsByProvider mocks base method.
+func (m *MockContractGetter) GetContractsByProvider(partitionId, providerId string, pageRequest models.PageRequest) (*models.Page[models.Contract], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContractsByProvider", partitionId, providerId, pageRequest)
+	ret0, _ := ret[0].(*models.Page[models.Contract])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContractsByProvider indicates an expected call of GetContractsByProvider.
+func (mr *MockContractGetterMockRecorder) GetContractsByProvider(partitionId, providerId, pageRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContractsByProvider", reflect.TypeOf((*MockContractGetter)(nil).GetContractsByProvider), partitionId, providerId, pageRequest)
+}
+
+// GetContractsByTariff mocks base method.
+func (m *MockContractGetter) GetContractsByTariff(partitionId, tariffId string, pageRequest models.PageRequest) (*models.Page[models.Contract], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContractsByTariff", partitionId, tariffId, pageRequest)
+	ret0, _ := ret[0].(*models.Page[models.Contract])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContractsByTariff indicates an expected call of GetContractsByTariff.
+func (mr *MockContractGetterMockRecorder) GetContractsByTariff(partitionId, tariffId, pageRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContractsByTariff", reflect.TypeOf((*MockContractGetter)(nil).GetContractsByTariff), partitionId, tariffId, pageRequest)
+}
+
+// GetContractsPage mocks base method.
+func (m *MockContractGetter) GetContractsPage(partitionId string, pageRequest models.PageRequest) (*models.Page[models.Contract], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContractsPage", partitionId, pageRequest)
+	ret0, _ := ret[0].(*models.Page[models.Contract])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContractsPage indicates an expected call of GetContractsPage.
+func (mr *MockContractGetterMockRecorder) GetContractsPage(partitionId, pageRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContractsPage", reflect.TypeOf((*MockContractGetter)(nil).GetContractsPage), partitionId, pageRequest)
+}
+
 // MockValidator is a mock of Validator interface.
 type MockValidator struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: fxratehandler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=fxratehandler.go -destination=testing/fxratehandler_mocks.go -package=testing FxRateGetter
+//
+
+// Package testing is a generated GoMock package.
+package testing
+
+import (
+	reflect "reflect"
+	models "tariff-calculation-service/internal/models"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFxRateGetter is a mock of FxRateGetter interface.
+type MockFxRateGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockFxRateGetterMockRecorder
+}
+
+// MockFxRateGetterMockRecorder is the mock recorder for MockFxRateGetter.
+type MockFxRateGetterMockRecorder struct {
+	mock *MockFxRateGetter
+}
+
+// NewMockFxRateGetter creates a new mock instance.
+func NewMockFxRateGetter(ctrl *gomock.Controller) *MockFxRateGetter {
+	mock := &MockFxRateGetter{ctrl: ctrl}
+	mock.recorder = &MockFxRateGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFxRateGetter) EXPECT() *MockFxRateGetterMockRecorder {
+	return m.recorder
+}
+
+// GetFxRates mocks base method.
+func (m *MockFxRateGetter) GetFxRates(partitionId string) (*[]models.FxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFxRates", partitionId)
+	ret0, _ := ret[0].(*[]models.FxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFxRates indicates an expected call of GetFxRates.
+func (mr *MockFxRateGetterMockRecorder) GetFxRates(partitionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFxRates", reflect.TypeOf((*MockFxRateGetter)(nil).GetFxRates), partitionId)
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: fxrateprovider.go
+//
+// Generated by this command:
+//
+//	mockgen -source=fxrateprovider.go -destination=../readmodel/httphandler/testing/fxrateprovider_mocks.go -package=testing FxRateProvider
+//
+
+// Package testing is a generated GoMock package.
+package testing
+
+import (
+	reflect "reflect"
+	models "tariff-calculation-service/internal/models"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFxRateProvider is a mock of FxRateProvider interface.
+type MockFxRateProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockFxRateProviderMockRecorder
+}
+
+// MockFxRateProviderMockRecorder is the mock recorder for MockFxRateProvider.
+type MockFxRateProviderMockRecorder struct {
+	mock *MockFxRateProvider
+}
+
+// NewMockFxRateProvider creates a new mock instance.
+func NewMockFxRateProvider(ctrl *gomock.Controller) *MockFxRateProvider {
+	mock := &MockFxRateProvider{ctrl: ctrl}
+	mock.recorder = &MockFxRateProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFxRateProvider) EXPECT() *MockFxRateProviderMockRecorder {
+	return m.recorder
+}
+
+// GetFxRate mocks base method.
+func (m *MockFxRateProvider) GetFxRate(partitionId, baseCurrency, quoteCurrency string, date time.Time) (*models.FxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFxRate", partitionId, baseCurrency, quoteCurrency, date)
+	ret0, _ := ret[0].(*models.FxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFxRate indicates an expected call of GetFxRate.
+func (mr *MockFxRateProviderMockRecorder) GetFxRate(partitionId, baseCurrency, quoteCurrency, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFxRate", reflect.TypeOf((*MockFxRateProvider)(nil).GetFxRate), partitionId, baseCurrency, quoteCurrency, date)
+}
@@ -54,6 +54,22 @@ func (mr *MockProviderGetterMockRecorder) GetProvider(partitionId, providerId an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProvider", reflect.TypeOf((*MockProviderGetter)(nil).GetProvider), partitionId, providerId)
 }
 
+// GetProviderWithVersion mocks base method.
+func (m *MockProviderGetter) GetProviderWithVersion(partitionId, providerId string) (*models.Provider, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProviderWithVersion", partitionId, providerId)
+	ret0, _ := ret[0].(*models.Provider)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetProviderWithVersion indicates an expected call of GetProviderWithVersion.
+func (mr *MockProviderGetterMockRecorder) GetProviderWithVersion(partitionId, providerId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProviderWithVersion", reflect.TypeOf((*MockProviderGetter)(nil).GetProviderWithVersion), partitionId, providerId)
+}
+
 // GetProviders mocks base method.
 func (m *MockProviderGetter) GetProviders(partitionId string) (*[]models.Provider, error) {
 	m.ctrl.T.Helper()
@@ -68,3 +84,18 @@ func (mr *MockProviderGetterMockRecorder) GetProviders(partitionId any) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProviders", reflect.TypeOf((*MockProviderGetter)(nil).GetProviders), partitionId)
 }
+
+// GetProvidersPage mocks base method.
+func (m *MockProviderGetter) GetProvidersPage(partitionId string, pageRequest models.PageRequest) (*models.Page[models.Provider], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProvidersPage", partitionId, pageRequest)
+	ret0, _ := ret[0].(*models.Page[models.Provider])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProvidersPage indicates an expected call of GetProvidersPage.
+func (mr *MockProviderGetterMockRecorder) GetProvidersPage(partitionId, pageRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProvidersPage", reflect.TypeOf((*MockProviderGetter)(nil).GetProvidersPage), partitionId, pageRequest)
+}
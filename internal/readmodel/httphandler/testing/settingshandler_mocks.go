@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: settingshandler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=settingshandler.go -destination=testing/settingshandler_mocks.go -package=testing SettingsGetter
+//
+
+// Package testing is a generated GoMock package.
+package testing
+
+import (
+	reflect "reflect"
+	models "tariff-calculation-service/internal/models"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSettingsGetter is a mock of SettingsGetter interface.
+type MockSettingsGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockSettingsGetterMockRecorder
+}
+
+// MockSettingsGetterMockRecorder is the mock recorder for MockSettingsGetter.
+type MockSettingsGetterMockRecorder struct {
+	mock *MockSettingsGetter
+}
+
+// NewMockSettingsGetter creates a new mock instance.
+func NewMockSettingsGetter(ctrl *gomock.Controller) *MockSettingsGetter {
+	mock := &MockSettingsGetter{ctrl: ctrl}
+	mock.recorder = &MockSettingsGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSettingsGetter) EXPECT() *MockSettingsGetterMockRecorder {
+	return m.recorder
+}
+
+// GetSettings mocks base method.
+func (m *MockSettingsGetter) GetSettings(partitionId string) (*models.Settings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSettings", partitionId)
+	ret0, _ := ret[0].(*models.Settings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSettings indicates an expected call of GetSettings.
+func (mr *MockSettingsGetterMockRecorder) GetSettings(partitionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSettings", reflect.TypeOf((*MockSettingsGetter)(nil).GetSettings), partitionId)
+}
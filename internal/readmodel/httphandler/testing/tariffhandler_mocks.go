@@ -39,6 +39,21 @@ func (m *MockTariffGetter) EXPECT() *MockTariffGetterMockRecorder {
 	return m.recorder
 }
 
+// GetAllTariffVersions mocks base method.
+func (m *MockTariffGetter) GetAllTariffVersions(partitionId string) (*[][]models.TariffVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllTariffVersions", partitionId)
+	ret0, _ := ret[0].(*[][]models.TariffVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllTariffVersions indicates an expected call of GetAllTariffVersions.
+func (mr *MockTariffGetterMockRecorder) GetAllTariffVersions(partitionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTariffVersions", reflect.TypeOf((*MockTariffGetter)(nil).GetAllTariffVersions), partitionId)
+}
+
 // GetTariff mocks base method.
 func (m *MockTariffGetter) GetTariff(partitionId, tariffId string) (*models.Tariff, error) {
 	m.ctrl.T.Helper()
@@ -54,6 +69,37 @@ func (mr *MockTariffGetterMockRecorder) GetTariff(partitionId, tariffId any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTariff", reflect.TypeOf((*MockTariffGetter)(nil).GetTariff), partitionId, tariffId)
 }
 
+// GetTariffVersions mocks base method.
+func (m *MockTariffGetter) GetTariffVersions(partitionId, tariffId string) (*[]models.TariffVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTariffVersions", partitionId, tariffId)
+	ret0, _ := ret[0].(*[]models.TariffVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTariffVersions indicates an expected call of GetTariffVersions.
+func (mr *MockTariffGetterMockRecorder) GetTariffVersions(partitionId, tariffId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTariffVersions", reflect.TypeOf((*MockTariffGetter)(nil).GetTariffVersions), partitionId, tariffId)
+}
+
+// GetTariffWithVersion mocks base method.
+func (m *MockTariffGetter) GetTariffWithVersion(partitionId, tariffId string) (*models.Tariff, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTariffWithVersion", partitionId, tariffId)
+	ret0, _ := ret[0].(*models.Tariff)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTariffWithVersion indicates an expected call of GetTariffWithVersion.
+func (mr *MockTariffGetterMockRecorder) GetTariffWithVersion(partitionId, tariffId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTariffWithVersion", reflect.TypeOf((*MockTariffGetter)(nil).GetTariffWithVersion), partitionId, tariffId)
+}
+
 // GetTariffs mocks base method.
 func (m *MockTariffGetter) GetTariffs(partitionId string) (*[]models.Tariff, error) {
 	m.ctrl.T.Helper()
@@ -68,3 +114,18 @@ func (mr *MockTariffGetterMockRecorder) GetTariffs(partitionId any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTariffs", reflect.TypeOf((*MockTariffGetter)(nil).GetTariffs), partitionId)
 }
+
+// GetTariffsPage mocks base method.
+func (m *MockTariffGetter) GetTariffsPage(partitionId string, filter models.TariffFilter, pageRequest models.PageRequest) (*models.Page[models.Tariff], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTariffsPage", partitionId, filter, pageRequest)
+	ret0, _ := ret[0].(*models.Page[models.Tariff])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTariffsPage indicates an expected call of GetTariffsPage.
+func (mr *MockTariffGetterMockRecorder) GetTariffsPage(partitionId, filter, pageRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTariffsPage", reflect.TypeOf((*MockTariffGetter)(nil).GetTariffsPage), partitionId, filter, pageRequest)
+}
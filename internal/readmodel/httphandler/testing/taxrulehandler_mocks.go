@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: taxrulehandler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=taxrulehandler.go -destination=testing/taxrulehandler_mocks.go -package=testing TaxRuleGetter
+//
+
+// Package testing is a generated GoMock package.
+package testing
+
+import (
+	reflect "reflect"
+	models "tariff-calculation-service/internal/models"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTaxRuleGetter is a mock of TaxRuleGetter interface.
+type MockTaxRuleGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaxRuleGetterMockRecorder
+}
+
+// MockTaxRuleGetterMockRecorder is the mock recorder for MockTaxRuleGetter.
+type MockTaxRuleGetterMockRecorder struct {
+	mock *MockTaxRuleGetter
+}
+
+// NewMockTaxRuleGetter creates a new mock instance.
+func NewMockTaxRuleGetter(ctrl *gomock.Controller) *MockTaxRuleGetter {
+	mock := &MockTaxRuleGetter{ctrl: ctrl}
+	mock.recorder = &MockTaxRuleGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaxRuleGetter) EXPECT() *MockTaxRuleGetterMockRecorder {
+	return m.recorder
+}
+
+// GetTaxRule mocks base method.
+func (m *MockTaxRuleGetter) GetTaxRule(partitionId, taxRuleId string) (*models.TaxRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaxRule", partitionId, taxRuleId)
+	ret0, _ := ret[0].(*models.TaxRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaxRule indicates an expected call of GetTaxRule.
+func (mr *MockTaxRuleGetterMockRecorder) GetTaxRule(partitionId, taxRuleId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaxRule", reflect.TypeOf((*MockTaxRuleGetter)(nil).GetTaxRule), partitionId, taxRuleId)
+}
+
+// GetTaxRules mocks base method.
+func (m *MockTaxRuleGetter) GetTaxRules(partitionId string) (*[]models.TaxRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaxRules", partitionId)
+	ret0, _ := ret[0].(*[]models.TaxRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaxRules indicates an expected call of GetTaxRules.
+func (mr *MockTaxRuleGetterMockRecorder) GetTaxRules(partitionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaxRules", reflect.TypeOf((*MockTaxRuleGetter)(nil).GetTaxRules), partitionId)
+}
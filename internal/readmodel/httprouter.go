@@ -13,6 +13,11 @@ func RouteReadmodelCalls(router *gin.Engine) {
 	tariffHandler := httphandler.NewTariffHandler()
 	contractHandler := httphandler.NewContractHandler()
 	providerHandler := httphandler.NewProviderHandler()
+	taxRuleHandler := httphandler.NewTaxRuleHandler()
+	settingsHandler := httphandler.NewSettingsHandler()
+	fxRateHandler := httphandler.NewFxRateHandler()
+	calculationHandler := httphandler.NewCalculationHandler()
+	auditHandler := httphandler.NewAuditHandler()
 
 	// Base routes
 	subRouter.GET(constants.HealthPath, serviceHandler.HandleGetHealth)
@@ -22,6 +27,8 @@ func RouteReadmodelCalls(router *gin.Engine) {
 	// Tariff routes
 	subRouter.GET(constants.TariffsPath, tariffHandler.HandleGetTariffs)
 	subRouter.GET(constants.SingleTariffPath, tariffHandler.HandleGetTariff)
+	subRouter.GET(constants.TariffContractsPath, contractHandler.HandleGetTariffContracts)
+	subRouter.GET(constants.TariffVersionsPath, tariffHandler.HandleGetTariffVersions)
 
 	// Contract routes
 	subRouter.GET(constants.ContractsPath, contractHandler.HandleGetContracts)
@@ -30,4 +37,25 @@ func RouteReadmodelCalls(router *gin.Engine) {
 	// Provider routes
 	subRouter.GET(constants.ProvidersPath, providerHandler.HandleGetProviders)
 	subRouter.GET(constants.SingleProviderPath, providerHandler.HandleGetProvider)
+	subRouter.GET(constants.ProviderContractsPath, contractHandler.HandleGetProviderContracts)
+
+	// Tax rule routes
+	subRouter.GET(constants.TaxRulesPath, taxRuleHandler.HandleGetTaxRules)
+	subRouter.GET(constants.SingleTaxRulePath, taxRuleHandler.HandleGetTaxRule)
+
+	// Settings routes
+	subRouter.GET(constants.SettingsPath, settingsHandler.HandleGetSettings)
+
+	// FX rate routes
+	subRouter.GET(constants.FxRatesPath, fxRateHandler.HandleGetFxRates)
+
+	// Audit routes
+	subRouter.GET(constants.AuditPath, auditHandler.HandleGetAuditRecords)
+
+	// Calculation routes
+	subRouter.POST(constants.CalculationPath, calculationHandler.HandlePostCalculation)
+	subRouter.GET(constants.PricePath, calculationHandler.HandleGetPrice)
+	subRouter.POST(constants.ContractCalculationPath, calculationHandler.HandlePostContractCalculation)
+	subRouter.POST(constants.ContractBillPath, calculationHandler.HandlePostBill)
+	subRouter.POST(constants.ContractComparisonPath, calculationHandler.HandlePostComparison)
 }
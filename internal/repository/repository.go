@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"os"
+	"tariff-calculation-service/internal/database"
+	"tariff-calculation-service/internal/interfaces"
+	"tariff-calculation-service/internal/memory"
+	"tariff-calculation-service/internal/postgres"
+)
+
+const (
+	// StorageBackendEnv selects the storage backend of all repositories, DynamoDB if not set.
+	StorageBackendEnv = "STORAGE_BACKEND"
+	DynamoDBBackend   = "dynamodb"
+	MemoryBackend     = "memory"
+	PostgresBackend   = "postgres"
+)
+
+func NewTariffRepo() interfaces.TariffRepository {
+	switch os.Getenv(StorageBackendEnv) {
+	case MemoryBackend:
+		return memory.NewTariffRepo()
+	case PostgresBackend:
+		return postgres.NewTariffRepo()
+	default:
+		return database.NewTariffRepo()
+	}
+}
+
+func NewContractRepo() interfaces.ContractRepository {
+	switch os.Getenv(StorageBackendEnv) {
+	case MemoryBackend:
+		return memory.NewContractRepo()
+	case PostgresBackend:
+		return postgres.NewContractRepo()
+	default:
+		return database.NewContractRepo()
+	}
+}
+
+func NewProviderRepo() interfaces.ProviderRepository {
+	switch os.Getenv(StorageBackendEnv) {
+	case MemoryBackend:
+		return memory.NewProviderRepo()
+	case PostgresBackend:
+		return postgres.NewProviderRepo()
+	default:
+		return database.NewProviderRepo()
+	}
+}
+
+func NewTaxRuleRepo() interfaces.TaxRuleRepository {
+	switch os.Getenv(StorageBackendEnv) {
+	case MemoryBackend:
+		return memory.NewTaxRuleRepo()
+	case PostgresBackend:
+		return postgres.NewTaxRuleRepo()
+	default:
+		return database.NewTaxRuleRepo()
+	}
+}
+
+func NewSettingsRepo() interfaces.SettingsRepository {
+	switch os.Getenv(StorageBackendEnv) {
+	case MemoryBackend:
+		return memory.NewSettingsRepo()
+	case PostgresBackend:
+		return postgres.NewSettingsRepo()
+	default:
+		return database.NewSettingsRepo()
+	}
+}
+
+func NewFxRateRepo() interfaces.FxRateRepository {
+	switch os.Getenv(StorageBackendEnv) {
+	case MemoryBackend:
+		return memory.NewFxRateRepo()
+	case PostgresBackend:
+		return postgres.NewFxRateRepo()
+	default:
+		return database.NewFxRateRepo()
+	}
+}
+
+func NewAuditRepo() interfaces.AuditRepository {
+	switch os.Getenv(StorageBackendEnv) {
+	case MemoryBackend:
+		return memory.NewAuditRepo()
+	case PostgresBackend:
+		return postgres.NewAuditRepo()
+	default:
+		return database.NewAuditRepo()
+	}
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"tariff-calculation-service/internal/database"
+	"tariff-calculation-service/internal/memory"
+	"tariff-calculation-service/internal/postgres"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewRepos_Memory(t *testing.T) {
+	t.Setenv(StorageBackendEnv, MemoryBackend)
+
+	assert.IsType(t, memory.TariffRepo{}, NewTariffRepo())
+	assert.IsType(t, memory.ContractRepo{}, NewContractRepo())
+	assert.IsType(t, memory.ProviderRepo{}, NewProviderRepo())
+	assert.IsType(t, memory.TaxRuleRepo{}, NewTaxRuleRepo())
+	assert.IsType(t, memory.SettingsRepo{}, NewSettingsRepo())
+	assert.IsType(t, memory.FxRateRepo{}, NewFxRateRepo())
+}
+
+func Test_NewRepos_DynamoDB(t *testing.T) {
+	t.Setenv(StorageBackendEnv, DynamoDBBackend)
+
+	assert.IsType(t, database.TariffRepo{}, NewTariffRepo())
+	assert.IsType(t, database.ContractRepo{}, NewContractRepo())
+	assert.IsType(t, database.ProviderRepo{}, NewProviderRepo())
+	assert.IsType(t, database.TaxRuleRepo{}, NewTaxRuleRepo())
+	assert.IsType(t, database.SettingsRepo{}, NewSettingsRepo())
+	assert.IsType(t, database.FxRateRepo{}, NewFxRateRepo())
+}
+
+func Test_NewRepos_Postgres(t *testing.T) {
+	t.Setenv(StorageBackendEnv, PostgresBackend)
+
+	assert.IsType(t, postgres.TariffRepo{}, NewTariffRepo())
+	assert.IsType(t, postgres.ContractRepo{}, NewContractRepo())
+	assert.IsType(t, postgres.ProviderRepo{}, NewProviderRepo())
+	assert.IsType(t, postgres.TaxRuleRepo{}, NewTaxRuleRepo())
+	assert.IsType(t, postgres.SettingsRepo{}, NewSettingsRepo())
+	assert.IsType(t, postgres.FxRateRepo{}, NewFxRateRepo())
+}
@@ -1,6 +1,11 @@
 package router
 
 import (
+	"log"
+
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -9,7 +14,37 @@ var cachedRouter *gin.Engine
 // Return a new gin router if there is none yet
 func NewRouter() *gin.Engine {
 	if cachedRouter == nil {
-		cachedRouter = gin.Default()
+		cachedRouter = newRouter()
 	}
 	return cachedRouter
 }
+
+// newRouter returns a router that responds to unknown routes, methods the route does not allow and panics of
+// handlers with problems. The request id is set before the recovery, so that the problems carry it as well.
+func newRouter() *gin.Engine {
+	router := gin.New()
+	router.HandleMethodNotAllowed = true
+	router.Use(gin.Logger(), pkg.RequestIdHandler, gin.CustomRecovery(recoveryHandler), pkg.ErrorHandler)
+	router.NoRoute(noRouteHandler)
+	router.NoMethod(noMethodHandler)
+
+	return router
+}
+
+func noRouteHandler(ctx *gin.Context) {
+	pkg.RespondWithError(ctx, models.NewResourceNotFoundError())
+}
+
+func noMethodHandler(ctx *gin.Context) {
+	pkg.RespondWithError(ctx, models.NewMethodNotAllowedError())
+}
+
+// recoveryHandler responds to a panic of a handler with an internal server error, unless the handler has
+// responded already.
+func recoveryHandler(ctx *gin.Context, recovered any) {
+	log.Printf("request %s panicked: %v", pkg.RequestId(ctx), recovered)
+	if !ctx.Writer.Written() {
+		pkg.RespondWithError(ctx, models.NewInternalServerError())
+	}
+	ctx.Abort()
+}
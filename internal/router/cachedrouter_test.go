@@ -0,0 +1,82 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewRouter(t *testing.T) {
+	// arrange
+	gin.SetMode(gin.TestMode)
+	router := newRouter()
+	router.GET("/tariffs", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+	router.GET("/panic", func(ctx *gin.Context) { panic("handler failed") })
+	router.GET("/panic-after-response", func(ctx *gin.Context) {
+		ctx.Status(http.StatusAccepted)
+		ctx.Writer.WriteHeaderNow()
+		panic("handler failed")
+	})
+
+	testcases := []struct {
+		name               string
+		method             string
+		path               string
+		expectedStatusCode int
+		expectedError      *models.Error
+	}{
+		{"Positive Test Route", http.MethodGet, "/tariffs", 200, nil},
+		{"Negative Test No Route", http.MethodGet, "/unknown", 404, ptr(models.NewResourceNotFoundError())},
+		{"Negative Test No Method", http.MethodDelete, "/tariffs", 405, ptr(models.NewMethodNotAllowedError())},
+		{"Negative Test Panic", http.MethodGet, "/panic", 500, ptr(models.NewInternalServerError())},
+		{"Negative Test Panic After Response", http.MethodGet, "/panic-after-response", 202, nil},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := httptest.NewRequest(tc.method, tc.path, nil)
+			request.Header.Set(pkg.RequestIdHeader, "request-id")
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			// assert
+			assert.Equal(t, tc.expectedStatusCode, recorder.Code)
+			assert.Equal(t, "request-id", recorder.Header().Get(pkg.RequestIdHeader))
+			if tc.expectedError == nil {
+				assert.Empty(t, recorder.Body.String())
+				return
+			}
+			expectedError := *tc.expectedError
+			expectedError.TraceId = "request-id"
+			var actualError models.Error
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &actualError))
+			assert.Equal(t, pkg.ProblemContentType, recorder.Header().Get("Content-Type"))
+			assert.Equal(t, expectedError, actualError)
+		})
+	}
+}
+
+func Test_NewRouter_Cached(t *testing.T) {
+	// arrange
+	gin.SetMode(gin.TestMode)
+	cachedRouter = nil
+	t.Cleanup(func() { cachedRouter = nil })
+
+	// act
+	router := NewRouter()
+
+	// assert
+	assert.Same(t, router, NewRouter())
+	assert.True(t, router.HandleMethodNotAllowed)
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
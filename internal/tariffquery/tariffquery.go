@@ -0,0 +1,115 @@
+package tariffquery
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"tariff-calculation-service/internal/dberrors"
+	"tariff-calculation-service/internal/models"
+)
+
+const (
+	SortByName      = "name"
+	SortByValidFrom = "validFrom"
+
+	// MaxSorted is the most tariffs a storage backend reads to sort them in memory.
+	MaxSorted = 1000
+)
+
+// ErrTooManyToSort is returned by storage backends that sort in memory if more than MaxSorted tariffs match the
+// filter.
+var ErrTooManyToSort = dberrors.Wrap(dberrors.ErrInvalidRequest,
+	fmt.Errorf("more than %d tariffs match the filter, narrow the filter to sort them", MaxSorted))
+
+// Matches reports whether the tariff matches every criterion of the filter.
+func Matches(tariff models.Tariff, filter models.TariffFilter) bool {
+	if filter.TariffType != nil && tariff.TariffType != *filter.TariffType {
+		return false
+	}
+	if filter.Currency != "" && tariff.Currency != filter.Currency {
+		return false
+	}
+	if !strings.HasPrefix(tariff.Name, filter.Name) {
+		return false
+	}
+
+	return MatchesValidity(tariff, filter)
+}
+
+// MatchesValidity reports whether the tariff is valid at ValidAt and for some time of the ValidFrom/ValidTo
+// range of the filter. The validity of a tariff includes ValidFrom and excludes ValidTo. Timestamps are
+// compared as instants, since they may be written with different offsets.
+func MatchesValidity(tariff models.Tariff, filter models.TariffFilter) bool {
+	if filter.ValidAt == "" && filter.ValidFrom == "" && filter.ValidTo == "" {
+		return true
+	}
+	validFrom, err := time.Parse(time.RFC3339, tariff.ValidFrom)
+	if err != nil {
+		return false
+	}
+	validTo, err := time.Parse(time.RFC3339, tariff.ValidTo)
+	if err != nil {
+		return false
+	}
+
+	if at, err := time.Parse(time.RFC3339, filter.ValidAt); err == nil && (at.Before(validFrom) || !at.Before(validTo)) {
+		return false
+	}
+	if from, err := time.Parse(time.RFC3339, filter.ValidFrom); err == nil && !from.Before(validTo) {
+		return false
+	}
+	if to, err := time.Parse(time.RFC3339, filter.ValidTo); err == nil && !validFrom.Before(to) {
+		return false
+	}
+
+	return true
+}
+
+// UTC returns the timestamp in UTC, so that timestamps compare as strings the way they compare as instants. A
+// timestamp that is not RFC 3339 is returned unchanged.
+func UTC(timestamp string) string {
+	instant, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return timestamp
+	}
+	return instant.UTC().Format(time.RFC3339)
+}
+
+// Sort sorts the tariffs by name or by the start of their validity. Tariffs with equal values are sorted
+// by id, so that the order is stable across requests.
+func Sort(tariffs []models.Tariff, sortBy string) {
+	sort.SliceStable(tariffs, func(i, j int) bool {
+		switch sortBy {
+		case SortByName:
+			if tariffs[i].Name != tariffs[j].Name {
+				return tariffs[i].Name < tariffs[j].Name
+			}
+		case SortByValidFrom:
+			validFromI, _ := time.Parse(time.RFC3339, tariffs[i].ValidFrom)
+			validFromJ, _ := time.Parse(time.RFC3339, tariffs[j].ValidFrom)
+			if !validFromI.Equal(validFromJ) {
+				return validFromI.Before(validFromJ)
+			}
+		}
+		return tariffs[i].Id < tariffs[j].Id
+	})
+}
+
+// VersionAt returns the version of a tariff that was effective at the instant, which is the last version written
+// up to then, or false if the tariff had no version yet. The versions are ordered by version; a version without
+// EffectiveFrom has been effective since the tariff was created.
+func VersionAt(versions []models.TariffVersion, at time.Time) (models.TariffVersion, bool) {
+	for idx := len(versions) - 1; idx >= 0; idx-- {
+		if versions[idx].EffectiveFrom == "" {
+			return versions[idx], true
+		}
+		effectiveFrom, err := time.Parse(time.RFC3339, versions[idx].EffectiveFrom)
+		if err == nil && !effectiveFrom.After(at) {
+			return versions[idx], true
+		}
+	}
+
+	return models.TariffVersion{}, false
+}
@@ -0,0 +1,108 @@
+package tariffquery
+
+import (
+	"testing"
+	"time"
+
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/enums"
+	"tariff-calculation-service/test/data"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Matches(t *testing.T) {
+	// arrange
+	biogas, gas := enums.Biogas, enums.Gas
+
+	testcases := []struct {
+		name     string
+		filter   models.TariffFilter
+		expected bool
+	}{
+		{"Positive Test No Filter", models.TariffFilter{}, true},
+		{"Positive Test All Criteria", data.TariffFilter, true},
+		{"Positive Test Tariff Type", models.TariffFilter{TariffType: &biogas}, true},
+		{"Positive Test Name Prefix", models.TariffFilter{Name: "Test T"}, true},
+		{"Positive Test Valid At Start", models.TariffFilter{ValidAt: data.TestValidFrom}, true},
+		{"Positive Test Valid At With Offset", models.TariffFilter{ValidAt: "2020-03-24T13:04:18+01:00"}, true},
+		{"Positive Test Range Overlapping Start", models.TariffFilter{ValidFrom: "2019-01-01T00:00:00Z", ValidTo: "2020-04-01T00:00:00Z"}, true},
+		{"Negative Test Tariff Type", models.TariffFilter{TariffType: &gas}, false},
+		{"Negative Test Currency", models.TariffFilter{Currency: "EUR"}, false},
+		{"Negative Test Name", models.TariffFilter{Name: "Tariff"}, false},
+		{"Negative Test Valid At End", models.TariffFilter{ValidAt: data.TestValidTo}, false},
+		{"Negative Test Valid At Before Start", models.TariffFilter{ValidAt: "2020-03-24T12:04:18+01:00"}, false},
+		{"Negative Test Range Before Validity", models.TariffFilter{ValidTo: data.TestValidFrom}, false},
+		{"Negative Test Range After Validity", models.TariffFilter{ValidFrom: data.TestValidTo}, false},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := Matches(data.Tariff, tc.filter)
+
+			// assert
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func Test_Sort(t *testing.T) {
+	// arrange
+	newTariff := func(id, name, validFrom string) models.Tariff {
+		return models.Tariff{Id: id, Name: name, ValidFrom: validFrom}
+	}
+	first := newTariff("1", "B", "2021-01-01T00:00:00Z")
+	second := newTariff("2", "A", "2021-01-01T00:30:00+01:00")
+	third := newTariff("3", "A", "2022-01-01T00:00:00Z")
+
+	testcases := []struct {
+		name     string
+		sortBy   string
+		expected []models.Tariff
+	}{
+		{"Positive Test Name", SortByName, []models.Tariff{second, third, first}},
+		{"Positive Test Valid From", SortByValidFrom, []models.Tariff{second, first, third}},
+		{"Positive Test Id", "", []models.Tariff{first, second, third}},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			tariffs := []models.Tariff{third, first, second}
+			Sort(tariffs, tc.sortBy)
+
+			// assert
+			assert.Equal(t, tc.expected, tariffs)
+		})
+	}
+}
+
+func Test_VersionAt(t *testing.T) {
+	// arrange
+	backfilled := []models.TariffVersion{{Version: 1, Tariff: data.Tariff}, data.TariffVersions[1]}
+
+	testcases := []struct {
+		name     string
+		versions []models.TariffVersion
+		at       string
+		expected int
+		found    bool
+	}{
+		{"Positive Test First Version", data.TariffVersions, "2021-03-24T12:04:18Z", 1, true},
+		{"Positive Test Effective From", data.TariffVersions, "2021-06-01T00:00:00Z", 2, true},
+		{"Positive Test Last Version", data.TariffVersions, "2023-01-01T00:00:00Z", 2, true},
+		{"Positive Test Without Effective From", backfilled, "2019-01-01T00:00:00Z", 1, true},
+		{"Negative Test Before First Version", data.TariffVersions, "2020-12-31T23:59:59Z", 0, false},
+		{"Negative Test No Versions", []models.TariffVersion{}, "2021-03-24T12:04:18Z", 0, false},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			at, _ := time.Parse(time.RFC3339, tc.at)
+			actual, found := VersionAt(tc.versions, at)
+
+			// assert
+			assert.Equal(t, tc.found, found)
+			assert.Equal(t, tc.expected, actual.Version)
+		})
+	}
+}
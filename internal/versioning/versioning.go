@@ -0,0 +1,34 @@
+package versioning
+
+import (
+	"errors"
+	"time"
+
+	"tariff-calculation-service/internal/dberrors"
+)
+
+const (
+	// InitialVersion is the version of a created entity. Every update increments it.
+	InitialVersion = 1
+	// AnyVersion matches every version of an existing entity, as If-Match: * does.
+	AnyVersion = -1
+
+	// IncarnationLayout is the layout of incarnations: RFC 3339 in UTC with nanoseconds and a fixed width, so that
+	// incarnations sort bytewise by the time their entities were created.
+	IncarnationLayout = "2006-01-02T15:04:05.000000000Z"
+)
+
+// ErrVersionMismatch is a failed precondition of a write to an entity of another version.
+var ErrVersionMismatch = dberrors.Wrap(dberrors.ErrPreconditionFailed, errors.New("version mismatch"))
+
+// Matches reports whether the version of an entity is the expected version.
+func Matches(version, expectedVersion int) bool {
+	return expectedVersion == AnyVersion || version == expectedVersion
+}
+
+// NewIncarnation returns the incarnation of an entity created now. An entity that is created again with the id of a
+// deleted one starts at the initial version again, so its versions are told apart from those kept of the deleted
+// entity by the incarnation rather than by the version.
+func NewIncarnation() string {
+	return time.Now().UTC().Format(IncarnationLayout)
+}
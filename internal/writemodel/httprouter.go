@@ -7,11 +7,14 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func RouteReadmodelCalls(router *gin.Engine) {
+func RouteWritemodelCalls(router *gin.Engine) {
 	subRouter := router.Group(constants.BasePath)
 	contractHandler := writehandlers.NewContractWriteHandler()
 	providerHandler := writehandlers.NewProviderHandler()
 	tariffHandler := writehandlers.NewTariffHandler()
+	taxRuleHandler := writehandlers.NewTaxRuleHandler()
+	settingsHandler := writehandlers.NewSettingsHandler()
+	fxRateHandler := writehandlers.NewFxRateHandler()
 
 	// Tariff routes
 	subRouter.POST(constants.TariffsPath, tariffHandler.HandlePostTariff)
@@ -27,4 +30,15 @@ func RouteReadmodelCalls(router *gin.Engine) {
 	subRouter.POST(constants.ProvidersPath, providerHandler.HandlePostProvider)
 	subRouter.PUT(constants.SingleProviderPath, providerHandler.HandlePutProvider)
 	subRouter.DELETE(constants.SingleProviderPath, providerHandler.HandleDeleteProvider)
+
+	// Tax rule routes
+	subRouter.POST(constants.TaxRulesPath, taxRuleHandler.HandlePostTaxRule)
+	subRouter.PUT(constants.SingleTaxRulePath, taxRuleHandler.HandlePutTaxRule)
+	subRouter.DELETE(constants.SingleTaxRulePath, taxRuleHandler.HandleDeleteTaxRule)
+
+	// Settings routes
+	subRouter.PUT(constants.SettingsPath, settingsHandler.HandlePutSettings)
+
+	// FX rate routes
+	subRouter.POST(constants.FxRatesPath, fxRateHandler.HandlePostFxRate)
 }
@@ -0,0 +1,34 @@
+package writehandlers
+
+import (
+	"time"
+
+	"tariff-calculation-service/internal/audit"
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// auditRecord returns the audit record of a write of the entity, which was before and is after the write. before
+// is nil for a create and after for a delete. The record is passed to the write, which stores it at once with the
+// entity. It returns false after attaching the error if the changes cannot be diffed, before anything is written.
+func auditRecord(context *gin.Context, partitionId, entityType, entityId, operation string, before, after any) (models.AuditRecord, bool) {
+	changes, err := audit.Diff(before, after)
+	if err != nil {
+		context.Error(err)
+		return models.AuditRecord{}, false
+	}
+
+	return models.AuditRecord{
+		Id:          uuid.New().String(),
+		Actor:       pkg.Actor(context),
+		Timestamp:   audit.Timestamp(time.Now()),
+		PartitionId: partitionId,
+		EntityType:  entityType,
+		EntityId:    entityId,
+		Operation:   operation,
+		Changes:     changes,
+	}, true
+}
@@ -0,0 +1,62 @@
+package writehandlers
+
+import (
+	"encoding/json"
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg"
+	"tariff-calculation-service/test"
+	"tariff-calculation-service/test/data"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+// auditRecordOf matches the audit record of a write of the entity in the partition of the test. An empty entityId
+// matches the id generated for a created entity.
+func auditRecordOf(entityType, entityId, operation string) gomock.Matcher {
+	return gomock.Cond(func(x any) bool {
+		record, ok := x.(models.AuditRecord)
+		return ok && record.PartitionId == data.TestPartitionId && record.EntityType == entityType &&
+			(record.EntityId == entityId || entityId == "" && uuid.Validate(record.EntityId) == nil) && record.Operation == operation
+	})
+}
+
+func Test_auditRecord(t *testing.T) {
+	renamed := data.Provider
+	renamed.Name = "Renamed"
+	changes := []models.AuditChange{{Pointer: "/name", Before: json.RawMessage(`"` + data.Provider.Name + `"`), After: json.RawMessage(`"Renamed"`)}}
+
+	testCases := []struct {
+		name          string
+		actor         string
+		trustHeader   string
+		expectedActor string
+	}{
+		{"Positive Test", data.TestActor, "true", data.TestActor},
+		{"Positive Test Anonymous", "", "true", pkg.AnonymousActor},
+		{"Positive Test Untrusted Header", data.TestActor, "", pkg.AnonymousActor},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(pkg.TrustActorHeaderEnv, tc.trustHeader)
+			ctx := test.GetTestGinContext()
+			if tc.actor != "" {
+				ctx = test.WithHeader(ctx, pkg.ActorHeader, tc.actor)
+			}
+
+			record, ok := auditRecord(ctx, data.TestPartitionId, models.AuditEntityProvider, data.TestProviderId, models.AuditOperationUpdate, data.Provider, renamed)
+
+			assert.Equal(t, true, ok)
+			assert.Equal(t, nil, uuid.Validate(record.Id))
+			assert.Equal(t, tc.expectedActor, record.Actor)
+			assert.Equal(t, data.TestPartitionId, record.PartitionId)
+			assert.Equal(t, models.AuditEntityProvider, record.EntityType)
+			assert.Equal(t, data.TestProviderId, record.EntityId)
+			assert.Equal(t, models.AuditOperationUpdate, record.Operation)
+			assert.Equal(t, changes, record.Changes)
+		})
+	}
+}
@@ -4,9 +4,10 @@ package writehandlers
 
 import (
 	"net/http"
-	"tariff-calculation-service/internal/database"
 	"tariff-calculation-service/internal/interfaces"
 	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/repository"
+	"tariff-calculation-service/internal/versioning"
 	"tariff-calculation-service/pkg"
 	"tariff-calculation-service/pkg/validation"
 
@@ -15,18 +16,26 @@ import (
 )
 
 type ContractWriter interface {
-	CreateContract(partitionId string, contract models.Contract) (*models.Contract, error)
-	UpdateContract(partitionId string, contract models.Contract) error
-	DeleteContract(partitionId, contractId string) error
+	GetContractWithVersion(partitionId, contractId string) (*models.Contract, int, error)
+	CreateContract(partitionId string, contract models.Contract, records ...models.AuditRecord) (*models.Contract, error)
+	UpdateContract(partitionId string, contract models.Contract, version int, records ...models.AuditRecord) (int, error)
+	DeleteContract(partitionId, contractId string, version int, records ...models.AuditRecord) error
 }
 
 type ContractWriteHandler struct {
 	ContractWriter ContractWriter
+	ProviderRepo   ProviderGetter
+	TariffRepo     TariffGetter
 	Validator      interfaces.Validator
 }
 
 func NewContractWriteHandler() ContractWriteHandler {
-	return ContractWriteHandler{ContractWriter: database.NewContractRepo(), Validator: validation.NewValidator()}
+	return ContractWriteHandler{
+		ContractWriter: repository.NewContractRepo(),
+		ProviderRepo:   repository.NewProviderRepo(),
+		TariffRepo:     repository.NewTariffRepo(),
+		Validator:      validation.NewValidator(),
+	}
 }
 
 func (handler ContractWriteHandler) HandlePostContract(context *gin.Context) {
@@ -37,18 +46,28 @@ func (handler ContractWriteHandler) HandlePostContract(context *gin.Context) {
 
 	newContract := models.Contract{}
 	if err := context.ShouldBindJSON(&newContract); err != nil {
-		context.JSON(http.StatusBadRequest, models.NewBadRequestFieldValidationError(err))
+		pkg.RespondWithError(context, models.NewBadRequestFieldValidationError(err))
+		return
+	}
+
+	if !checkReferences(context, handler.ProviderRepo, handler.TariffRepo, pathParam.PartitionId, newContract) {
 		return
 	}
 
 	newContract.Id = uuid.New().String()
 
-	contract, err := handler.ContractWriter.CreateContract(pathParam.PartitionId, newContract)
+	record, ok := auditRecord(context, pathParam.PartitionId, models.AuditEntityContract, newContract.Id, models.AuditOperationCreate, nil, newContract)
+	if !ok {
+		return
+	}
+	contract, err := handler.ContractWriter.CreateContract(pathParam.PartitionId, newContract, record)
 	if err != nil {
-		context.JSON(http.StatusInternalServerError, models.NewInternalServerError())
+		context.Error(err)
 		return
 	}
 
+	pkg.SetLocation(context, newContract.Id)
+	pkg.SetETag(context, versioning.InitialVersion)
 	context.JSON(http.StatusCreated, contract)
 }
 
@@ -60,19 +79,47 @@ func (handler ContractWriteHandler) HandlePutContract(context *gin.Context) {
 
 	contract := models.Contract{}
 	if err := context.ShouldBindJSON(&contract); err != nil {
-		context.JSON(http.StatusBadRequest, models.NewBadRequestFieldValidationError(err))
+		pkg.RespondWithError(context, models.NewBadRequestFieldValidationError(err))
 		return
 	}
 
-	if contract.Id == "" {
-		contract.Id = pathParam.Id
+	id, ok := entityId(context, pathParam.Id, contract.Id)
+	if !ok {
+		return
 	}
+	contract.Id = id
 
-	if err := handler.ContractWriter.UpdateContract(pathParam.PartitionId, contract); err != nil {
-		pkg.HandleResourceNotFoundAndInternalServerError(context, err)
+	version, ok := pkg.IfMatchVersion(context)
+	if !ok {
 		return
 	}
 
+	// the update is conditional on the version read, so that the audit record has the contract it replaced
+	before, storedVersion, err := handler.ContractWriter.GetContractWithVersion(pathParam.PartitionId, contract.Id)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	if !versioning.Matches(storedVersion, version) {
+		context.Error(versioning.ErrVersionMismatch)
+		return
+	}
+
+	if !checkReferences(context, handler.ProviderRepo, handler.TariffRepo, pathParam.PartitionId, contract) {
+		return
+	}
+
+	record, ok := auditRecord(context, pathParam.PartitionId, models.AuditEntityContract, contract.Id, models.AuditOperationUpdate, before, contract)
+	if !ok {
+		return
+	}
+	updatedVersion, err := handler.ContractWriter.UpdateContract(pathParam.PartitionId, contract, storedVersion, record)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	pkg.SetETag(context, updatedVersion)
 	context.JSON(http.StatusNoContent, nil)
 }
 
@@ -82,8 +129,27 @@ func (handler ContractWriteHandler) HandleDeleteContract(context *gin.Context) {
 		return
 	}
 
-	if err := handler.ContractWriter.DeleteContract(pathParam.PartitionId, pathParam.Id); err != nil {
-		pkg.HandleResourceNotFoundAndInternalServerError(context, err)
+	version, ok := pkg.IfMatchVersion(context)
+	if !ok {
+		return
+	}
+
+	before, storedVersion, err := handler.ContractWriter.GetContractWithVersion(pathParam.PartitionId, pathParam.Id)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	if !versioning.Matches(storedVersion, version) {
+		context.Error(versioning.ErrVersionMismatch)
+		return
+	}
+
+	record, ok := auditRecord(context, pathParam.PartitionId, models.AuditEntityContract, pathParam.Id, models.AuditOperationDelete, before, nil)
+	if !ok {
+		return
+	}
+	if err := handler.ContractWriter.DeleteContract(pathParam.PartitionId, pathParam.Id, storedVersion, record); err != nil {
+		context.Error(err)
 		return
 	}
 
@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"errors"
 	"strings"
+	"tariff-calculation-service/internal/dberrors"
 	"tariff-calculation-service/internal/interfaces"
 	"tariff-calculation-service/internal/models"
 	repotesting "tariff-calculation-service/internal/writemodel/writehandlers/testing"
+	"tariff-calculation-service/pkg"
 	"tariff-calculation-service/pkg/constants"
 	"tariff-calculation-service/test"
 	"tariff-calculation-service/test/data"
@@ -22,6 +24,8 @@ import (
 
 type dependencies struct {
 	repo      ContractWriter
+	providers ProviderGetter
+	tariffs   TariffGetter
 	validator interfaces.Validator
 }
 
@@ -39,37 +43,67 @@ func Test_HandlePostContract(t *testing.T) {
 	defer mockController.Finish()
 
 	contractRepo := repotesting.NewMockContractWriter(mockController)
+	providerRepo := repotesting.NewMockProviderGetter(mockController)
+	providerRepo.EXPECT().GetProvider(gomock.Any(), gomock.Any()).Return(&data.Provider, nil).AnyTimes()
+	tariffRepo := repotesting.NewMockTariffGetter(mockController)
+	tariffRepo.EXPECT().GetTariff(gomock.Any(), gomock.Any()).Return(&data.Tariff, nil).AnyTimes()
 	mockValidator := mocks.NewValidatorPathPositive(mockController)
+	providerLookup := repotesting.NewMockProviderGetter(mockController)
+	tariffLookup := repotesting.NewMockTariffGetter(mockController)
 
 	testCases := []testCaseCWH{
 		{
 			"Positive Test",
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(data.Contract))),
-			dependencies{repo: contractRepo, validator: mockValidator},
+			dependencies{repo: contractRepo, providers: providerRepo, tariffs: tariffRepo, validator: mockValidator},
 			201,
 			&data.Contract,
-			func() { contractRepo.EXPECT().CreateContract(gomock.Any(), gomock.Any()).Return(&data.Contract, nil) },
+			func() {
+				contractRepo.EXPECT().CreateContract(gomock.Any(), gomock.Any(), auditRecordOf(models.AuditEntityContract, "", models.AuditOperationCreate)).Return(&data.Contract, nil)
+			},
 		},
 		{
 			"Negative Test Internal Server Error",
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(data.Contract))),
-			dependencies{repo: contractRepo, validator: mockValidator},
+			dependencies{repo: contractRepo, providers: providerRepo, tariffs: tariffRepo, validator: mockValidator},
 			500,
 			models.NewInternalServerError(),
 			func() {
-				contractRepo.EXPECT().CreateContract(gomock.Any(), gomock.Any()).Return(nil, errors.New(constants.InternalServerError))
+				contractRepo.EXPECT().CreateContract(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New(constants.InternalServerError))
+			},
+		},
+		{
+			"Negative Test Missing References",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(data.ContractWithTariff))),
+			dependencies{repo: contractRepo, providers: providerLookup, tariffs: tariffLookup, validator: mockValidator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/provider", Rule: "exists"}, {Pointer: "/tariffs/0", Rule: "exists"}}),
+			func() {
+				providerLookup.EXPECT().GetProvider(data.TestPartitionId, data.TestProviderId).Return(nil, dberrors.ErrNotFound)
+				tariffLookup.EXPECT().GetTariff(data.TestPartitionId, data.TestTariffId).Return(nil, dberrors.ErrNotFound)
+			},
+		},
+		{
+			"Negative Test References Unavailable",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(data.ContractWithTariff))),
+			dependencies{repo: contractRepo, providers: providerLookup, tariffs: tariffLookup, validator: mockValidator},
+			503,
+			models.NewUnavailableError(),
+			func() {
+				providerLookup.EXPECT().GetProvider(data.TestPartitionId, data.TestProviderId).Return(nil, dberrors.Wrap(dberrors.ErrUnavailable, errors.New("no database connection")))
 			},
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			contractWriteHandler := ContractWriteHandler{ContractWriter: tc.deps.repo, Validator: tc.deps.validator}
+			contractWriteHandler := ContractWriteHandler{ContractWriter: tc.deps.repo, ProviderRepo: tc.deps.providers, TariffRepo: tc.deps.tariffs, Validator: tc.deps.validator}
 			tc.mockFunc()
 			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
 			tc.ctx.Writer = blw
 
 			contractWriteHandler.HandlePostContract(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
 			statusCode := tc.ctx.Writer.Status()
 
 			assert.Equal(t, tc.expectedResponseCode, statusCode)
@@ -98,6 +132,10 @@ func Test_HandlePostContract_Validation(t *testing.T) {
 	defer mockController.Finish()
 
 	contractRepo := repotesting.NewMockContractWriter(mockController)
+	providerRepo := repotesting.NewMockProviderGetter(mockController)
+	providerRepo.EXPECT().GetProvider(gomock.Any(), gomock.Any()).Return(&data.Provider, nil).AnyTimes()
+	tariffRepo := repotesting.NewMockTariffGetter(mockController)
+	tariffRepo.EXPECT().GetTariff(gomock.Any(), gomock.Any()).Return(&data.Tariff, nil).AnyTimes()
 	validator := mocks.NewValidatorPathPositive(mockController)
 	validatorNegative := mocks.NewValidatorPathNegative(mockController)
 
@@ -132,15 +170,17 @@ func Test_HandlePostContract_Validation(t *testing.T) {
 		{
 			"Positive Test",
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(data.Contract))),
-			dependencies{repo: contractRepo, validator: validator},
+			dependencies{repo: contractRepo, providers: providerRepo, tariffs: tariffRepo, validator: validator},
 			201,
 			&data.Contract,
-			func() { contractRepo.EXPECT().CreateContract(gomock.Any(), gomock.Any()).Return(&data.Contract, nil) },
+			func() {
+				contractRepo.EXPECT().CreateContract(gomock.Any(), gomock.Any(), auditRecordOf(models.AuditEntityContract, "", models.AuditOperationCreate)).Return(&data.Contract, nil)
+			},
 		},
 		{
 			"Negative Test PartitionId Invalid",
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestIdInvalid}, tools.GetFirstValue(json.Marshal(data.Contract))),
-			dependencies{repo: contractRepo, validator: validatorNegative},
+			dependencies{repo: contractRepo, providers: providerRepo, tariffs: tariffRepo, validator: validatorNegative},
 			400,
 			models.NewBadRequestFieldValidationError(errors.New("ValidationError")),
 			func() {
@@ -149,81 +189,81 @@ func Test_HandlePostContract_Validation(t *testing.T) {
 		{
 			"Negative Test Contract Empty Name",
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(contractEmptyName))),
-			dependencies{repo: contractRepo, validator: validator},
+			dependencies{repo: contractRepo, providers: providerRepo, tariffs: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"Name", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/name", Rule: "required"}}),
 			func() {
 			},
 		},
 		{
 			"Negative Test Contract Name Max Length Exceeded",
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(contractNameLenExceeded))),
-			dependencies{repo: contractRepo, validator: validator},
+			dependencies{repo: contractRepo, providers: providerRepo, tariffs: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"Name", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/name", Rule: "max", Allowed: "64"}}),
 			func() {
 			},
 		},
 		{
 			"Negative Test Contract Description Max Length Exceeded",
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(contractDescriptionExceededd))),
-			dependencies{repo: contractRepo, validator: validator},
+			dependencies{repo: contractRepo, providers: providerRepo, tariffs: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"Description", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/description", Rule: "max", Allowed: "128"}}),
 			func() {
 			},
 		},
 		{
 			"Negative Test Contract StartDate Empty",
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(contractStartDateEmpty))),
-			dependencies{repo: contractRepo, validator: validator},
+			dependencies{repo: contractRepo, providers: providerRepo, tariffs: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"StartDate", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/startDate", Rule: "required"}}),
 			func() {
 			},
 		},
 		{
 			"Negative Test Contract StartDate Invalid Format",
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(contractStartDateInvalid))),
-			dependencies{repo: contractRepo, validator: validator},
+			dependencies{repo: contractRepo, providers: providerRepo, tariffs: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"StartDate", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/startDate", Rule: "datetime", Allowed: "2006-01-02T15:04:05Z07:00"}}),
 			func() {
 			},
 		},
 		{
 			"Negative Test Contract EndDate Empty",
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(contractEndDateEmpty))),
-			dependencies{repo: contractRepo, validator: validator},
+			dependencies{repo: contractRepo, providers: providerRepo, tariffs: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"EndDate", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/endDate", Rule: "required"}}),
 			func() {
 			},
 		},
 		{
 			"Negative Test Contract EndDate Invalid Format",
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(contractEndDateInvalid))),
-			dependencies{repo: contractRepo, validator: validator},
+			dependencies{repo: contractRepo, providers: providerRepo, tariffs: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"EndDate", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/endDate", Rule: "datetime", Allowed: "2006-01-02T15:04:05Z07:00"}}),
 			func() {
 			},
 		},
 		{
 			"Negative Test Contract Provider Invalid Format",
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(contractInvalidProvider))),
-			dependencies{repo: contractRepo, validator: validator},
+			dependencies{repo: contractRepo, providers: providerRepo, tariffs: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"Provider", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/provider", Rule: "uuid"}}),
 			func() {
 			},
 		},
 		{
 			"Negative Test Contract Tariff Invalid Format",
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(contractInvalidTariff))),
-			dependencies{repo: contractRepo, validator: validator},
+			dependencies{repo: contractRepo, providers: providerRepo, tariffs: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"Tariffs[0]", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/tariffs/0", Rule: "uuid"}}),
 			func() {
 			},
 		},
@@ -231,12 +271,13 @@ func Test_HandlePostContract_Validation(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			contractWriteHandler := ContractWriteHandler{ContractWriter: tc.deps.repo, Validator: tc.deps.validator}
+			contractWriteHandler := ContractWriteHandler{ContractWriter: tc.deps.repo, ProviderRepo: tc.deps.providers, TariffRepo: tc.deps.tariffs, Validator: tc.deps.validator}
 			tc.mockFunc()
 			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
 			tc.ctx.Writer = blw
 
 			contractWriteHandler.HandlePostContract(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
 			statusCode := tc.ctx.Writer.Status()
 
 			assert.Equal(t, tc.expectedResponseCode, statusCode)
@@ -265,47 +306,96 @@ func Test_HandlePutContract(t *testing.T) {
 	defer mockController.Finish()
 
 	contractRepo := repotesting.NewMockContractWriter(mockController)
+	providerRepo := repotesting.NewMockProviderGetter(mockController)
+	providerRepo.EXPECT().GetProvider(gomock.Any(), gomock.Any()).Return(&data.Provider, nil).AnyTimes()
+	tariffRepo := repotesting.NewMockTariffGetter(mockController)
+	tariffRepo.EXPECT().GetTariff(gomock.Any(), gomock.Any()).Return(&data.Tariff, nil).AnyTimes()
 	mockValidator := mocks.NewValidatorPathPositive(mockController)
+	providerLookup := repotesting.NewMockProviderGetter(mockController)
 
 	testCases := []testCaseCWH{
 		{
 			"Positive Test",
-			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(data.Contract))),
-			dependencies{repo: contractRepo, validator: mockValidator},
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(data.Contract))), "If-Match", `"1"`),
+			dependencies{repo: contractRepo, providers: providerRepo, tariffs: tariffRepo, validator: mockValidator},
 			204,
 			nil,
-			func() { contractRepo.EXPECT().UpdateContract(gomock.Any(), gomock.Any()).Return(nil) },
+			func() {
+				contractRepo.EXPECT().GetContractWithVersion(data.TestPartitionId, data.TestContractId).Return(&data.Contract, 1, nil)
+				contractRepo.EXPECT().UpdateContract(gomock.Any(), gomock.Any(), 1, auditRecordOf(models.AuditEntityContract, data.TestContractId, models.AuditOperationUpdate)).Return(2, nil)
+			},
 		},
 		{
 			"Negative Test Resource Not Found",
-			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(data.Contract))),
-			dependencies{repo: contractRepo, validator: mockValidator},
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(data.Contract))), "If-Match", `"1"`),
+			dependencies{repo: contractRepo, providers: providerRepo, tariffs: tariffRepo, validator: mockValidator},
 			404,
 			models.NewResourceNotFoundError(),
 			func() {
-				contractRepo.EXPECT().UpdateContract(gomock.Any(), gomock.Any()).Return(errors.New(constants.ResourceNotFound))
+				contractRepo.EXPECT().GetContractWithVersion(data.TestPartitionId, data.TestContractId).Return(nil, 0, dberrors.ErrNotFound)
 			},
 		},
 		{
 			"Negative Test Internal Server Error",
-			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(data.Contract))),
-			dependencies{repo: contractRepo, validator: mockValidator},
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(data.Contract))), "If-Match", `"1"`),
+			dependencies{repo: contractRepo, providers: providerRepo, tariffs: tariffRepo, validator: mockValidator},
 			500,
 			models.NewInternalServerError(),
 			func() {
-				contractRepo.EXPECT().UpdateContract(gomock.Any(), gomock.Any()).Return(errors.New(constants.InternalServerError))
+				contractRepo.EXPECT().GetContractWithVersion(data.TestPartitionId, data.TestContractId).Return(&data.Contract, 1, nil)
+				contractRepo.EXPECT().UpdateContract(gomock.Any(), gomock.Any(), 1, gomock.Any()).Return(0, errors.New(constants.InternalServerError))
+			},
+		},
+		{
+			"Negative Test Missing Provider",
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(data.Contract))), "If-Match", `"1"`),
+			dependencies{repo: contractRepo, providers: providerLookup, tariffs: tariffRepo, validator: mockValidator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/provider", Rule: "exists"}}),
+			func() {
+				contractRepo.EXPECT().GetContractWithVersion(data.TestPartitionId, data.TestContractId).Return(&data.Contract, 1, nil)
+				providerLookup.EXPECT().GetProvider(data.TestPartitionId, data.TestProviderId).Return(nil, dberrors.ErrNotFound)
+			},
+		},
+		{
+			"Negative Test Id Mismatch",
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestProviderId}, tools.GetFirstValue(json.Marshal(data.Contract))), "If-Match", `"1"`),
+			dependencies{repo: contractRepo, providers: providerRepo, tariffs: tariffRepo, validator: mockValidator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/id", Rule: "eq", Allowed: data.TestProviderId}}),
+			func() {
+			},
+		},
+		{
+			"Negative Test If-Match Missing",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(data.Contract))),
+			dependencies{repo: contractRepo, providers: providerRepo, tariffs: tariffRepo, validator: mockValidator},
+			428,
+			models.NewPreconditionRequiredError(),
+			func() {
+			},
+		},
+		{
+			"Negative Test Version Mismatch",
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(data.Contract))), "If-Match", `"1"`),
+			dependencies{repo: contractRepo, providers: providerRepo, tariffs: tariffRepo, validator: mockValidator},
+			412,
+			models.NewPreconditionFailedError(),
+			func() {
+				contractRepo.EXPECT().GetContractWithVersion(data.TestPartitionId, data.TestContractId).Return(&data.Contract, 2, nil)
 			},
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			contractWriteHandler := ContractWriteHandler{ContractWriter: tc.deps.repo, Validator: tc.deps.validator}
+			contractWriteHandler := ContractWriteHandler{ContractWriter: tc.deps.repo, ProviderRepo: tc.deps.providers, TariffRepo: tc.deps.tariffs, Validator: tc.deps.validator}
 			tc.mockFunc()
 			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
 			tc.ctx.Writer = blw
 
 			contractWriteHandler.HandlePutContract(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
 			statusCode := tc.ctx.Writer.Status()
 			if statusCode != 204 {
 				var actualError models.Error
@@ -326,6 +416,10 @@ func Test_HandlePutContract_Validation(t *testing.T) {
 	defer mockController.Finish()
 
 	contractRepo := repotesting.NewMockContractWriter(mockController)
+	providerRepo := repotesting.NewMockProviderGetter(mockController)
+	providerRepo.EXPECT().GetProvider(gomock.Any(), gomock.Any()).Return(&data.Provider, nil).AnyTimes()
+	tariffRepo := repotesting.NewMockTariffGetter(mockController)
+	tariffRepo.EXPECT().GetTariff(gomock.Any(), gomock.Any()).Return(&data.Tariff, nil).AnyTimes()
 	validator := mocks.NewValidatorPathPositive(mockController)
 	validatorNegative := mocks.NewValidatorPathNegative(mockController)
 
@@ -359,16 +453,19 @@ func Test_HandlePutContract_Validation(t *testing.T) {
 	testCases := []testCaseCWH{
 		{
 			"Positive Test",
-			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(data.Contract))),
-			dependencies{repo: contractRepo, validator: validator},
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(data.Contract))), "If-Match", `"1"`),
+			dependencies{repo: contractRepo, providers: providerRepo, tariffs: tariffRepo, validator: validator},
 			204,
 			nil,
-			func() { contractRepo.EXPECT().UpdateContract(gomock.Any(), gomock.Any()).Return(nil) },
+			func() {
+				contractRepo.EXPECT().GetContractWithVersion(data.TestPartitionId, data.TestContractId).Return(&data.Contract, 1, nil)
+				contractRepo.EXPECT().UpdateContract(gomock.Any(), gomock.Any(), 1, auditRecordOf(models.AuditEntityContract, data.TestContractId, models.AuditOperationUpdate)).Return(2, nil)
+			},
 		},
 		{
 			"Negative Test PartitionId Invalid",
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(data.Contract))),
-			dependencies{repo: contractRepo, validator: validatorNegative},
+			dependencies{repo: contractRepo, providers: providerRepo, tariffs: tariffRepo, validator: validatorNegative},
 			400,
 			models.NewBadRequestFieldValidationError(errors.New("ValidationError")),
 			func() {
@@ -377,81 +474,81 @@ func Test_HandlePutContract_Validation(t *testing.T) {
 		{
 			"Negative Test Contract Empty Name",
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(contractEmptyName))),
-			dependencies{repo: contractRepo, validator: validator},
+			dependencies{repo: contractRepo, providers: providerRepo, tariffs: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"Name", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/name", Rule: "required"}}),
 			func() {
 			},
 		},
 		{
 			"Negative Test Contract Name Max Length Exceeded",
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(contractNameLenExceeded))),
-			dependencies{repo: contractRepo, validator: validator},
+			dependencies{repo: contractRepo, providers: providerRepo, tariffs: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"Name", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/name", Rule: "max", Allowed: "64"}}),
 			func() {
 			},
 		},
 		{
 			"Negative Test Contract Description Max Length Exceeded",
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(contractDescriptionExceededd))),
-			dependencies{repo: contractRepo, validator: validator},
+			dependencies{repo: contractRepo, providers: providerRepo, tariffs: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"Description", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/description", Rule: "max", Allowed: "128"}}),
 			func() {
 			},
 		},
 		{
 			"Negative Test Contract StartDate Empty",
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(contractStartDateEmpty))),
-			dependencies{repo: contractRepo, validator: validator},
+			dependencies{repo: contractRepo, providers: providerRepo, tariffs: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"StartDate", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/startDate", Rule: "required"}}),
 			func() {
 			},
 		},
 		{
 			"Negative Test Contract StartDate Invalid Format",
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(contractStartDateInvalid))),
-			dependencies{repo: contractRepo, validator: validator},
+			dependencies{repo: contractRepo, providers: providerRepo, tariffs: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"StartDate", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/startDate", Rule: "datetime", Allowed: "2006-01-02T15:04:05Z07:00"}}),
 			func() {
 			},
 		},
 		{
 			"Negative Test Contract EndDate Empty",
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(contractEndDateEmpty))),
-			dependencies{repo: contractRepo, validator: validator},
+			dependencies{repo: contractRepo, providers: providerRepo, tariffs: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"EndDate", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/endDate", Rule: "required"}}),
 			func() {
 			},
 		},
 		{
 			"Negative Test Contract EndDate Invalid Format",
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(contractEndDateInvalid))),
-			dependencies{repo: contractRepo, validator: validator},
+			dependencies{repo: contractRepo, providers: providerRepo, tariffs: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"EndDate", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/endDate", Rule: "datetime", Allowed: "2006-01-02T15:04:05Z07:00"}}),
 			func() {
 			},
 		},
 		{
 			"Negative Test Contract Provider Invalid Format",
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(contractInvalidProvider))),
-			dependencies{repo: contractRepo, validator: validator},
+			dependencies{repo: contractRepo, providers: providerRepo, tariffs: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"Provider", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/provider", Rule: "uuid"}}),
 			func() {
 			},
 		},
 		{
 			"Negative Test Contract Tariff Invalid Format",
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(contractInvalidTariff))),
-			dependencies{repo: contractRepo, validator: validator},
+			dependencies{repo: contractRepo, providers: providerRepo, tariffs: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"Tariffs[0]", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/tariffs/0", Rule: "uuid"}}),
 			func() {
 			},
 		},
@@ -459,12 +556,13 @@ func Test_HandlePutContract_Validation(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			contractWriteHandler := ContractWriteHandler{ContractWriter: tc.deps.repo, Validator: tc.deps.validator}
+			contractWriteHandler := ContractWriteHandler{ContractWriter: tc.deps.repo, ProviderRepo: tc.deps.providers, TariffRepo: tc.deps.tariffs, Validator: tc.deps.validator}
 			tc.mockFunc()
 			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
 			tc.ctx.Writer = blw
 
 			contractWriteHandler.HandlePutContract(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
 			statusCode := tc.ctx.Writer.Status()
 			if statusCode != 204 {
 				var actualError models.Error
@@ -490,30 +588,53 @@ func Test_HandleDeleteContract(t *testing.T) {
 	testCases := []testCaseCWH{
 		{
 			"Positive Test",
-			test.GetTestGinContext(),
+			test.WithHeader(test.GetTestGinContextWithParameters(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}), "If-Match", `"1"`),
 			dependencies{repo: contractRepo, validator: mockValidator},
 			204,
 			nil,
-			func() { contractRepo.EXPECT().DeleteContract(gomock.Any(), gomock.Any()).Return(nil) },
+			func() {
+				contractRepo.EXPECT().GetContractWithVersion(gomock.Any(), gomock.Any()).Return(&data.Contract, 1, nil)
+				contractRepo.EXPECT().DeleteContract(gomock.Any(), gomock.Any(), 1, auditRecordOf(models.AuditEntityContract, data.TestContractId, models.AuditOperationDelete)).Return(nil)
+			},
 		},
 		{
 			"Negative Test Resource Not Found",
-			test.GetTestGinContext(),
+			test.WithHeader(test.GetTestGinContextWithParameters(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}), "If-Match", `"1"`),
 			dependencies{repo: contractRepo, validator: mockValidator},
 			404,
 			models.NewResourceNotFoundError(),
 			func() {
-				contractRepo.EXPECT().DeleteContract(gomock.Any(), gomock.Any()).Return(errors.New(constants.ResourceNotFound))
+				contractRepo.EXPECT().GetContractWithVersion(gomock.Any(), gomock.Any()).Return(nil, 0, dberrors.ErrNotFound)
 			},
 		},
 		{
 			"Negative Test Internal Server Error",
-			test.GetTestGinContext(),
+			test.WithHeader(test.GetTestGinContextWithParameters(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}), "If-Match", `"1"`),
 			dependencies{repo: contractRepo, validator: mockValidator},
 			500,
 			models.NewInternalServerError(),
 			func() {
-				contractRepo.EXPECT().DeleteContract(gomock.Any(), gomock.Any()).Return(errors.New(constants.InternalServerError))
+				contractRepo.EXPECT().GetContractWithVersion(gomock.Any(), gomock.Any()).Return(&data.Contract, 1, nil)
+				contractRepo.EXPECT().DeleteContract(gomock.Any(), gomock.Any(), 1, gomock.Any()).Return(errors.New(constants.InternalServerError))
+			},
+		},
+		{
+			"Negative Test If-Match Missing",
+			test.GetTestGinContextWithParameters(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}),
+			dependencies{repo: contractRepo, validator: mockValidator},
+			428,
+			models.NewPreconditionRequiredError(),
+			func() {
+			},
+		},
+		{
+			"Negative Test Version Mismatch",
+			test.WithHeader(test.GetTestGinContextWithParameters(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}), "If-Match", `"1"`),
+			dependencies{repo: contractRepo, validator: mockValidator},
+			412,
+			models.NewPreconditionFailedError(),
+			func() {
+				contractRepo.EXPECT().GetContractWithVersion(gomock.Any(), gomock.Any()).Return(&data.Contract, 2, nil)
 			},
 		},
 	}
@@ -526,6 +647,7 @@ func Test_HandleDeleteContract(t *testing.T) {
 			tc.ctx.Writer = blw
 
 			contractWriteHandler.HandleDeleteContract(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
 			statusCode := tc.ctx.Writer.Status()
 			if statusCode != 204 {
 				var actualError models.Error
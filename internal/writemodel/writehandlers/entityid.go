@@ -0,0 +1,20 @@
+package writehandlers
+
+import (
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg"
+
+	"github.com/gin-gonic/gin"
+)
+
+// entityId returns the id of the entity that a PUT replaces, which is the id of its path. The id of the body must
+// be the same, so that a PUT never writes to an entity other than the one its path names. It responds with a bad
+// request if the ids differ.
+func entityId(context *gin.Context, pathId, bodyId string) (string, bool) {
+	if bodyId != pathId {
+		pkg.RespondWithError(context, models.NewFieldValidationError([]models.FieldError{{Pointer: "/id", Rule: "eq", Allowed: pathId}}))
+		return "", false
+	}
+
+	return pathId, true
+}
@@ -0,0 +1,49 @@
+//go:generate mockgen -source=fxratewritehandler.go -destination=testing/fxratewritehandler_mocks.go -package=testing FxRateWriter
+
+package writehandlers
+
+import (
+	"net/http"
+	"tariff-calculation-service/internal/interfaces"
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/repository"
+	"tariff-calculation-service/pkg"
+	"tariff-calculation-service/pkg/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FxRateWriter interface {
+	PutFxRate(partitionId string, fxRate models.FxRate) (*models.FxRate, error)
+}
+
+type FxRateHandler struct {
+	FxRateWriter FxRateWriter
+	Validator    interfaces.Validator
+}
+
+func NewFxRateHandler() FxRateHandler {
+	return FxRateHandler{FxRateWriter: repository.NewFxRateRepo(), Validator: validation.NewValidator()}
+}
+
+// HandlePostFxRate creates the rate of a currency pair and date, or replaces the existing one.
+func (handler FxRateHandler) HandlePostFxRate(context *gin.Context) {
+	pathParams := validation.PartitionId{}
+	if err := handler.Validator.ValidateAndSetPathParams(context, &pathParams); err != nil {
+		return
+	}
+
+	newFxRate := models.FxRate{}
+	if err := context.ShouldBindJSON(&newFxRate); err != nil {
+		pkg.RespondWithError(context, models.NewBadRequestFieldValidationError(err))
+		return
+	}
+
+	fxRate, err := handler.FxRateWriter.PutFxRate(pathParams.PartitionId, newFxRate)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusCreated, fxRate)
+}
@@ -0,0 +1,131 @@
+package writehandlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"tariff-calculation-service/internal/interfaces"
+	"tariff-calculation-service/internal/models"
+	repotesting "tariff-calculation-service/internal/writemodel/writehandlers/testing"
+	"tariff-calculation-service/pkg"
+	"tariff-calculation-service/pkg/constants"
+	"tariff-calculation-service/pkg/money"
+	"tariff-calculation-service/test"
+	"tariff-calculation-service/test/data"
+	"tariff-calculation-service/test/mocks"
+	"tariff-calculation-service/tools"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+	"go.uber.org/mock/gomock"
+)
+
+type depsFxRate struct {
+	repo      FxRateWriter
+	validator interfaces.Validator
+}
+
+type testCaseFRWH struct {
+	name                 string
+	ctx                  *gin.Context
+	deps                 depsFxRate
+	expectedResponseCode int
+	expectedResponse     any
+	mockFunc             func()
+}
+
+func Test_HandlePostFxRate(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	fxRateRepo := repotesting.NewMockFxRateWriter(mockController)
+	validator := mocks.NewValidatorPathPositive(mockController)
+
+	fxRateSameCurrency := data.FxRate
+	fxRateSameCurrency.QuoteCurrency = fxRateSameCurrency.BaseCurrency
+
+	fxRateInvalidDate := data.FxRate
+	fxRateInvalidDate.Date = "2021-01-01T00:00:00Z"
+
+	fxRateZero := data.FxRate
+	fxRateZero.Rate = money.Zero
+
+	testCases := []testCaseFRWH{
+		{
+			"Positive Test",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(data.FxRate))),
+			depsFxRate{repo: fxRateRepo, validator: validator},
+			201,
+			data.FxRate,
+			func() {
+				fxRateRepo.EXPECT().PutFxRate(data.TestPartitionId, data.FxRate).Return(&data.FxRate, nil)
+			},
+		},
+		{
+			"Negative Test Internal Server Error",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(data.FxRate))),
+			depsFxRate{repo: fxRateRepo, validator: validator},
+			500,
+			models.NewInternalServerError(),
+			func() {
+				fxRateRepo.EXPECT().PutFxRate(gomock.Any(), gomock.Any()).Return(&models.FxRate{}, errors.New(constants.InternalServerError))
+			},
+		},
+		{
+			"Negative Test FxRate Same Currency",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(fxRateSameCurrency))),
+			depsFxRate{repo: fxRateRepo, validator: validator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/quoteCurrency", Rule: "nefield", Allowed: "BaseCurrency"}}),
+			func() {},
+		},
+		{
+			"Negative Test FxRate Invalid Date",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(fxRateInvalidDate))),
+			depsFxRate{repo: fxRateRepo, validator: validator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/date", Rule: "datetime", Allowed: "2006-01-02"}}),
+			func() {},
+		},
+		{
+			"Negative Test FxRate Zero Rate",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(fxRateZero))),
+			depsFxRate{repo: fxRateRepo, validator: validator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/rate", Rule: "gt", Allowed: "0"}}),
+			func() {},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fxRateWriteHandler := FxRateHandler{FxRateWriter: tc.deps.repo, Validator: tc.deps.validator}
+			tc.mockFunc()
+			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
+			tc.ctx.Writer = blw
+
+			fxRateWriteHandler.HandlePostFxRate(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
+			statusCode := tc.ctx.Writer.Status()
+
+			assert.Equal(t, tc.expectedResponseCode, statusCode)
+			if statusCode == 201 {
+				actualFxRate := models.FxRate{}
+				err := json.Unmarshal(blw.Body.Bytes(), &actualFxRate)
+				if err != nil {
+					t.Fail()
+				}
+
+				assert.Equal(t, tc.expectedResponse, actualFxRate)
+			} else {
+				var actualError models.Error
+				err := json.Unmarshal(blw.Body.Bytes(), &actualError)
+				if err != nil {
+					t.Fail()
+				}
+				assert.Equal(t, tc.expectedResponse, actualError)
+			}
+		})
+	}
+}
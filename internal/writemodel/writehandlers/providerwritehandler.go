@@ -4,9 +4,10 @@ package writehandlers
 
 import (
 	"net/http"
-	"tariff-calculation-service/internal/database"
 	"tariff-calculation-service/internal/interfaces"
 	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/repository"
+	"tariff-calculation-service/internal/versioning"
 	"tariff-calculation-service/pkg"
 	"tariff-calculation-service/pkg/validation"
 
@@ -15,18 +16,24 @@ import (
 )
 
 type ProviderWriter interface {
-	CreateProvider(partitionId string, provider models.Provider) (*models.Provider, error)
-	UpdateProvider(partitionId string, provider models.Provider) error
-	DeleteProvider(partitionId, providerId string) error
+	GetProviderWithVersion(partitionId, providerId string) (*models.Provider, int, error)
+	CreateProvider(partitionId string, provider models.Provider, records ...models.AuditRecord) (*models.Provider, error)
+	UpdateProvider(partitionId string, provider models.Provider, version int, records ...models.AuditRecord) (int, error)
+	DeleteProvider(partitionId, providerId string, version int, contracts []models.ContractChange, records ...models.AuditRecord) error
 }
 
 type ProviderHandler struct {
 	ProviderWriter ProviderWriter
+	ContractRepo   ContractReferences
 	Validator      interfaces.Validator
 }
 
 func NewProviderHandler() ProviderHandler {
-	return ProviderHandler{ProviderWriter: database.NewProviderRepo(), Validator: validation.NewValidator()}
+	return ProviderHandler{
+		ProviderWriter: repository.NewProviderRepo(),
+		ContractRepo:   repository.NewContractRepo(),
+		Validator:      validation.NewValidator(),
+	}
 }
 
 func (handler ProviderHandler) HandlePostProvider(context *gin.Context) {
@@ -37,18 +44,24 @@ func (handler ProviderHandler) HandlePostProvider(context *gin.Context) {
 
 	newProvider := models.Provider{}
 	if err := context.ShouldBindJSON(&newProvider); err != nil {
-		context.JSON(http.StatusBadRequest, models.NewBadRequestFieldValidationError(err))
+		pkg.RespondWithError(context, models.NewBadRequestFieldValidationError(err))
 		return
 	}
 
 	newProvider.Id = uuid.New().String()
 
-	provider, err := handler.ProviderWriter.CreateProvider(pathParams.PartitionId, newProvider)
+	record, ok := auditRecord(context, pathParams.PartitionId, models.AuditEntityProvider, newProvider.Id, models.AuditOperationCreate, nil, newProvider)
+	if !ok {
+		return
+	}
+	provider, err := handler.ProviderWriter.CreateProvider(pathParams.PartitionId, newProvider, record)
 	if err != nil {
-		context.JSON(http.StatusInternalServerError, models.NewInternalServerError())
+		context.Error(err)
 		return
 	}
 
+	pkg.SetLocation(context, newProvider.Id)
+	pkg.SetETag(context, versioning.InitialVersion)
 	context.JSON(http.StatusCreated, provider)
 }
 
@@ -60,19 +73,43 @@ func (handler ProviderHandler) HandlePutProvider(context *gin.Context) {
 
 	provider := models.Provider{}
 	if err := context.ShouldBindJSON(&provider); err != nil {
-		context.JSON(http.StatusBadRequest, models.NewBadRequestFieldValidationError(err))
+		pkg.RespondWithError(context, models.NewBadRequestFieldValidationError(err))
+		return
+	}
+
+	id, ok := entityId(context, pathParams.Id, provider.Id)
+	if !ok {
 		return
 	}
+	provider.Id = id
 
-	if provider.Id == "" {
-		provider.Id = pathParams.Id
+	version, ok := pkg.IfMatchVersion(context)
+	if !ok {
+		return
 	}
 
-	if err := handler.ProviderWriter.UpdateProvider(pathParams.PartitionId, provider); err != nil {
-		pkg.HandleResourceNotFoundAndInternalServerError(context, err)
+	// the update is conditional on the version read, so that the audit record has the provider it replaced
+	before, storedVersion, err := handler.ProviderWriter.GetProviderWithVersion(pathParams.PartitionId, provider.Id)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	if !versioning.Matches(storedVersion, version) {
+		context.Error(versioning.ErrVersionMismatch)
 		return
 	}
 
+	record, ok := auditRecord(context, pathParams.PartitionId, models.AuditEntityProvider, provider.Id, models.AuditOperationUpdate, before, provider)
+	if !ok {
+		return
+	}
+	updatedVersion, err := handler.ProviderWriter.UpdateProvider(pathParams.PartitionId, provider, storedVersion, record)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	pkg.SetETag(context, updatedVersion)
 	context.JSON(http.StatusNoContent, nil)
 }
 
@@ -82,8 +119,51 @@ func (handler ProviderHandler) HandleDeleteProvider(context *gin.Context) {
 		return
 	}
 
-	if err := handler.ProviderWriter.DeleteProvider(pathParams.PartitionId, pathParams.Id); err != nil {
-		pkg.HandleResourceNotFoundAndInternalServerError(context, err)
+	version, ok := pkg.IfMatchVersion(context)
+	if !ok {
+		return
+	}
+
+	options := DeleteOptions{}
+	if err := context.ShouldBindQuery(&options); err != nil {
+		pkg.RespondWithError(context, models.NewBadRequestFieldValidationError(err))
+		return
+	}
+
+	// a stale version fails the precondition before the references are looked at
+	before, storedVersion, err := handler.ProviderWriter.GetProviderWithVersion(pathParams.PartitionId, pathParams.Id)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	if !versioning.Matches(storedVersion, version) {
+		context.Error(versioning.ErrVersionMismatch)
+		return
+	}
+
+	contracts, err := referencingContracts(func(pageRequest models.PageRequest) (*models.Page[models.Contract], error) {
+		return handler.ContractRepo.GetContractsByProvider(pathParams.PartitionId, pathParams.Id, pageRequest)
+	})
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	if len(contracts) > 0 && !options.Cascade {
+		pkg.RespondWithError(context, models.NewReferencedError(contractIds(contracts)))
+		return
+	}
+
+	// the contracts of the provider are deleted with it, unless one changed since it was read
+	changes := make([]models.ContractChange, 0, len(contracts))
+	for _, contract := range contracts {
+		changes = append(changes, models.ContractChange{Before: contract})
+	}
+	records, ok := deleteRecords(context, pathParams.PartitionId, models.AuditEntityProvider, pathParams.Id, before, changes)
+	if !ok {
+		return
+	}
+	if err := handler.ProviderWriter.DeleteProvider(pathParams.PartitionId, pathParams.Id, storedVersion, changes, records...); err != nil {
+		context.Error(err)
 		return
 	}
 
@@ -5,9 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"strings"
+	"tariff-calculation-service/internal/dberrors"
 	"tariff-calculation-service/internal/interfaces"
 	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/pagination"
 	repotesting "tariff-calculation-service/internal/writemodel/writehandlers/testing"
+	"tariff-calculation-service/pkg"
 	"tariff-calculation-service/pkg/constants"
 	"tariff-calculation-service/test"
 	"tariff-calculation-service/test/data"
@@ -22,6 +25,7 @@ import (
 
 type depsProvider struct {
 	repo      ProviderWriter
+	contracts ContractReferences
 	validator interfaces.Validator
 }
 
@@ -48,7 +52,9 @@ func Test_HandlePostProvider(t *testing.T) {
 			depsProvider{repo: providerRepo, validator: mockValidator},
 			201,
 			&data.Provider,
-			func() { providerRepo.EXPECT().CreateProvider(gomock.Any(), gomock.Any()).Return(&data.Provider, nil) },
+			func() {
+				providerRepo.EXPECT().CreateProvider(gomock.Any(), gomock.Any(), auditRecordOf(models.AuditEntityProvider, "", models.AuditOperationCreate)).Return(&data.Provider, nil)
+			},
 		},
 		{
 			"Negative Test Internal Server Error",
@@ -57,7 +63,7 @@ func Test_HandlePostProvider(t *testing.T) {
 			500,
 			models.NewInternalServerError(),
 			func() {
-				providerRepo.EXPECT().CreateProvider(gomock.Any(), gomock.Any()).Return(nil, errors.New(constants.InternalServerError))
+				providerRepo.EXPECT().CreateProvider(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New(constants.InternalServerError))
 			},
 		},
 	}
@@ -70,6 +76,7 @@ func Test_HandlePostProvider(t *testing.T) {
 			tc.ctx.Writer = blw
 
 			providerWriteHandler.HandlePostProvider(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
 			statusCode := tc.ctx.Writer.Status()
 
 			assert.Equal(t, tc.expectedResponseCode, statusCode)
@@ -141,7 +148,9 @@ func Test_HandlePostProvider_Validation(t *testing.T) {
 			depsProvider{repo: providerRepo, validator: validator},
 			201,
 			&data.Provider,
-			func() { providerRepo.EXPECT().CreateProvider(gomock.Any(), gomock.Any()).Return(&data.Provider, nil) },
+			func() {
+				providerRepo.EXPECT().CreateProvider(gomock.Any(), gomock.Any(), auditRecordOf(models.AuditEntityProvider, "", models.AuditOperationCreate)).Return(&data.Provider, nil)
+			},
 		},
 		{
 			"Negative Test PartitionId Invalid",
@@ -157,7 +166,7 @@ func Test_HandlePostProvider_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(providerNameEmpty))),
 			depsProvider{repo: providerRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"Name", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/name", Rule: "required"}}),
 			func() {
 			},
 		},
@@ -166,7 +175,7 @@ func Test_HandlePostProvider_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(providerNameLenExceeded))),
 			depsProvider{repo: providerRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"Name", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/name", Rule: "max", Allowed: "64"}}),
 			func() {
 			},
 		},
@@ -175,7 +184,7 @@ func Test_HandlePostProvider_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(providerEmailInvalid))),
 			depsProvider{repo: providerRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"Email", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/email", Rule: "email"}}),
 			func() {
 			},
 		},
@@ -184,7 +193,7 @@ func Test_HandlePostProvider_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(providerAddressStreetEmpty))),
 			depsProvider{repo: providerRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"Street", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/address/street", Rule: "required"}}),
 			func() {
 			},
 		},
@@ -193,7 +202,7 @@ func Test_HandlePostProvider_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(providerAddressStreetLenExceeded))),
 			depsProvider{repo: providerRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"Street", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/address/street", Rule: "max", Allowed: "64"}}),
 			func() {
 			},
 		},
@@ -202,7 +211,7 @@ func Test_HandlePostProvider_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(providerAddressPostalCodeEmpty))),
 			depsProvider{repo: providerRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"PostalCode", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/address/postalCode", Rule: "required"}}),
 			func() {
 			},
 		},
@@ -211,7 +220,7 @@ func Test_HandlePostProvider_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(providerAddressPostalCodeLenExceeded))),
 			depsProvider{repo: providerRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"PostalCode", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/address/postalCode", Rule: "max", Allowed: "12"}}),
 			func() {
 			},
 		},
@@ -220,7 +229,7 @@ func Test_HandlePostProvider_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(providerAddressCityEmpty))),
 			depsProvider{repo: providerRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"City", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/address/city", Rule: "required"}}),
 			func() {
 			},
 		},
@@ -229,7 +238,7 @@ func Test_HandlePostProvider_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(providerAddressCityLenExceeded))),
 			depsProvider{repo: providerRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"City", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/address/city", Rule: "max", Allowed: "64"}}),
 			func() {
 			},
 		},
@@ -238,7 +247,7 @@ func Test_HandlePostProvider_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(providerAddressCountryCodeEmpty))),
 			depsProvider{repo: providerRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"CountryCode", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/address/country", Rule: "required"}}),
 			func() {
 			},
 		},
@@ -247,7 +256,7 @@ func Test_HandlePostProvider_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(providerAddressCountryCodeInvalid))),
 			depsProvider{repo: providerRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"CountryCode", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/address/country", Rule: "iso3166_1_alpha3"}}),
 			func() {
 			},
 		},
@@ -261,6 +270,7 @@ func Test_HandlePostProvider_Validation(t *testing.T) {
 			tc.ctx.Writer = blw
 
 			providerWriteHandler.HandlePostProvider(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
 			statusCode := tc.ctx.Writer.Status()
 
 			assert.Equal(t, tc.expectedResponseCode, statusCode)
@@ -294,30 +304,60 @@ func Test_HandlePutProvider(t *testing.T) {
 	testCases := []testCasePWH{
 		{
 			"Positive Test",
-			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestProviderId}, tools.GetFirstValue(json.Marshal(data.Provider))),
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestProviderId}, tools.GetFirstValue(json.Marshal(data.Provider))), "If-Match", `"1"`),
 			depsProvider{repo: providerRepo, validator: mockValidator},
 			204,
 			nil,
-			func() { providerRepo.EXPECT().UpdateProvider(gomock.Any(), gomock.Any()).Return(nil) },
+			func() {
+				providerRepo.EXPECT().GetProviderWithVersion(data.TestPartitionId, data.TestProviderId).Return(&data.Provider, 1, nil)
+				providerRepo.EXPECT().UpdateProvider(gomock.Any(), gomock.Any(), 1, auditRecordOf(models.AuditEntityProvider, data.TestProviderId, models.AuditOperationUpdate)).Return(2, nil)
+			},
 		},
 		{
 			"Negative Test Resource Not Found",
-			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestProviderId}, tools.GetFirstValue(json.Marshal(data.Provider))),
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestProviderId}, tools.GetFirstValue(json.Marshal(data.Provider))), "If-Match", `"1"`),
 			depsProvider{repo: providerRepo, validator: mockValidator},
 			404,
 			models.NewResourceNotFoundError(),
 			func() {
-				providerRepo.EXPECT().UpdateProvider(gomock.Any(), gomock.Any()).Return(errors.New(constants.ResourceNotFound))
+				providerRepo.EXPECT().GetProviderWithVersion(data.TestPartitionId, data.TestProviderId).Return(nil, 0, dberrors.ErrNotFound)
 			},
 		},
 		{
 			"Negative Test Internal Server Error",
-			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestProviderId}, tools.GetFirstValue(json.Marshal(data.Provider))),
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestProviderId}, tools.GetFirstValue(json.Marshal(data.Provider))), "If-Match", `"1"`),
 			depsProvider{repo: providerRepo, validator: mockValidator},
 			500,
 			models.NewInternalServerError(),
 			func() {
-				providerRepo.EXPECT().UpdateProvider(gomock.Any(), gomock.Any()).Return(errors.New(constants.InternalServerError))
+				providerRepo.EXPECT().GetProviderWithVersion(data.TestPartitionId, data.TestProviderId).Return(&data.Provider, 1, nil)
+				providerRepo.EXPECT().UpdateProvider(gomock.Any(), gomock.Any(), 1, gomock.Any()).Return(0, errors.New(constants.InternalServerError))
+			},
+		},
+		{
+			"Negative Test Id Mismatch",
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestContractId}, tools.GetFirstValue(json.Marshal(data.Provider))), "If-Match", `"1"`),
+			depsProvider{repo: providerRepo, validator: mockValidator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/id", Rule: "eq", Allowed: data.TestContractId}}),
+			func() {},
+		},
+		{
+			"Negative Test If-Match Missing",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestProviderId}, tools.GetFirstValue(json.Marshal(data.Provider))),
+			depsProvider{repo: providerRepo, validator: mockValidator},
+			428,
+			models.NewPreconditionRequiredError(),
+			func() {},
+		},
+		{
+			"Negative Test Version Mismatch",
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestProviderId}, tools.GetFirstValue(json.Marshal(data.Provider))), "If-Match", `"1"`),
+			depsProvider{repo: providerRepo, validator: mockValidator},
+			412,
+			models.NewPreconditionFailedError(),
+			func() {
+				providerRepo.EXPECT().GetProviderWithVersion(data.TestPartitionId, data.TestProviderId).Return(&data.Provider, 2, nil)
 			},
 		},
 	}
@@ -330,6 +370,7 @@ func Test_HandlePutProvider(t *testing.T) {
 			tc.ctx.Writer = blw
 
 			providerWriteHandler.HandlePutProvider(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
 			statusCode := tc.ctx.Writer.Status()
 			if statusCode != 204 {
 				var actualError models.Error
@@ -389,11 +430,14 @@ func Test_HandlePutProvider_Validation(t *testing.T) {
 	testCases := []testCasePWH{
 		{
 			"Positive Test",
-			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestProviderId}, tools.GetFirstValue(json.Marshal(data.Provider))),
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestProviderId}, tools.GetFirstValue(json.Marshal(data.Provider))), "If-Match", `"1"`),
 			depsProvider{repo: providerRepo, validator: validator},
 			204,
 			nil,
-			func() { providerRepo.EXPECT().UpdateProvider(gomock.Any(), gomock.Any()).Return(nil) },
+			func() {
+				providerRepo.EXPECT().GetProviderWithVersion(data.TestPartitionId, data.TestProviderId).Return(&data.Provider, 1, nil)
+				providerRepo.EXPECT().UpdateProvider(gomock.Any(), gomock.Any(), 1, auditRecordOf(models.AuditEntityProvider, data.TestProviderId, models.AuditOperationUpdate)).Return(2, nil)
+			},
 		},
 		{
 			"Negative Test PartitionId Invalid",
@@ -409,7 +453,7 @@ func Test_HandlePutProvider_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestProviderId}, tools.GetFirstValue(json.Marshal(providerNameEmpty))),
 			depsProvider{repo: providerRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"Name", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/name", Rule: "required"}}),
 			func() {
 			},
 		},
@@ -418,7 +462,7 @@ func Test_HandlePutProvider_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestProviderId}, tools.GetFirstValue(json.Marshal(providerNameLenExceeded))),
 			depsProvider{repo: providerRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"Name", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/name", Rule: "max", Allowed: "64"}}),
 			func() {
 			},
 		},
@@ -427,7 +471,7 @@ func Test_HandlePutProvider_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestProviderId}, tools.GetFirstValue(json.Marshal(providerEmailInvalid))),
 			depsProvider{repo: providerRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"Email", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/email", Rule: "email"}}),
 			func() {
 			},
 		},
@@ -436,7 +480,7 @@ func Test_HandlePutProvider_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestProviderId}, tools.GetFirstValue(json.Marshal(providerAddressStreetEmpty))),
 			depsProvider{repo: providerRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"Street", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/address/street", Rule: "required"}}),
 			func() {
 			},
 		},
@@ -445,7 +489,7 @@ func Test_HandlePutProvider_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestProviderId}, tools.GetFirstValue(json.Marshal(providerAddressStreetLenExceeded))),
 			depsProvider{repo: providerRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"Street", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/address/street", Rule: "max", Allowed: "64"}}),
 			func() {
 			},
 		},
@@ -454,7 +498,7 @@ func Test_HandlePutProvider_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestProviderId}, tools.GetFirstValue(json.Marshal(providerAddressPostalCodeEmpty))),
 			depsProvider{repo: providerRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"PostalCode", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/address/postalCode", Rule: "required"}}),
 			func() {
 			},
 		},
@@ -463,7 +507,7 @@ func Test_HandlePutProvider_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestProviderId}, tools.GetFirstValue(json.Marshal(providerAddressPostalCodeLenExceeded))),
 			depsProvider{repo: providerRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"PostalCode", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/address/postalCode", Rule: "max", Allowed: "12"}}),
 			func() {
 			},
 		},
@@ -472,7 +516,7 @@ func Test_HandlePutProvider_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestProviderId}, tools.GetFirstValue(json.Marshal(providerAddressCityEmpty))),
 			depsProvider{repo: providerRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"City", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/address/city", Rule: "required"}}),
 			func() {
 			},
 		},
@@ -481,7 +525,7 @@ func Test_HandlePutProvider_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestProviderId}, tools.GetFirstValue(json.Marshal(providerAddressCityLenExceeded))),
 			depsProvider{repo: providerRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"City", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/address/city", Rule: "max", Allowed: "64"}}),
 			func() {
 			},
 		},
@@ -490,7 +534,7 @@ func Test_HandlePutProvider_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestProviderId}, tools.GetFirstValue(json.Marshal(providerAddressCountryCodeEmpty))),
 			depsProvider{repo: providerRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"CountryCode", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/address/country", Rule: "required"}}),
 			func() {
 			},
 		},
@@ -499,7 +543,7 @@ func Test_HandlePutProvider_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestProviderId}, tools.GetFirstValue(json.Marshal(providerAddressCountryCodeInvalid))),
 			depsProvider{repo: providerRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"CountryCode", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/address/country", Rule: "iso3166_1_alpha3"}}),
 			func() {
 			},
 		},
@@ -513,6 +557,7 @@ func Test_HandlePutProvider_Validation(t *testing.T) {
 			tc.ctx.Writer = blw
 
 			providerWriteHandler.HandlePutProvider(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
 			statusCode := tc.ctx.Writer.Status()
 			if statusCode != 204 {
 				var actualError models.Error
@@ -533,47 +578,131 @@ func Test_HandleDeleteProvider(t *testing.T) {
 	defer mockController.Finish()
 
 	providerRepo := repotesting.NewMockProviderWriter(mockController)
+	contractRepo := repotesting.NewMockContractReferences(mockController)
 	mockValidator := mocks.NewValidatorPathPositive(mockController)
+	providerParams := map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestProviderId}
 
 	testCases := []testCasePWH{
 		{
 			"Positive Test",
-			test.GetTestGinContext(),
-			depsProvider{repo: providerRepo, validator: mockValidator},
+			test.WithHeader(test.GetTestGinContextWithParameters(providerParams), "If-Match", `"1"`),
+			depsProvider{repo: providerRepo, contracts: contractRepo, validator: mockValidator},
+			204,
+			nil,
+			func() {
+				contractRepo.EXPECT().GetContractsByProvider(gomock.Any(), gomock.Any(), gomock.Any()).Return(&models.Page[models.Contract]{Items: []models.Contract{}}, nil)
+				providerRepo.EXPECT().GetProviderWithVersion(data.TestPartitionId, data.TestProviderId).Return(&data.Provider, 1, nil)
+				providerRepo.EXPECT().DeleteProvider(gomock.Any(), gomock.Any(), 1, gomock.Any(), auditRecordOf(models.AuditEntityProvider, data.TestProviderId, models.AuditOperationDelete)).Return(nil)
+			},
+		},
+		{
+			"Positive Test Cascade",
+			test.WithHeader(test.GetTestGinContextWithParametersAndQuery(providerParams, "cascade=true"), "If-Match", `"1"`),
+			depsProvider{repo: providerRepo, contracts: contractRepo, validator: mockValidator},
 			204,
 			nil,
-			func() { providerRepo.EXPECT().DeleteProvider(gomock.Any(), gomock.Any()).Return(nil) },
+			func() {
+				firstPage := models.PageRequest{Limit: pagination.MaxLimit}
+				nextPage := models.PageRequest{Limit: pagination.MaxLimit, Cursor: data.TestCursor}
+				contractRepo.EXPECT().GetContractsByProvider(data.TestPartitionId, data.TestProviderId, firstPage).Return(&models.Page[models.Contract]{Items: []models.Contract{data.Contract}, NextCursor: data.TestCursor}, nil)
+				contractRepo.EXPECT().GetContractsByProvider(data.TestPartitionId, data.TestProviderId, nextPage).Return(&models.Page[models.Contract]{Items: []models.Contract{}}, nil)
+				providerRepo.EXPECT().GetProviderWithVersion(data.TestPartitionId, data.TestProviderId).Return(&data.Provider, 1, nil)
+				providerRepo.EXPECT().DeleteProvider(data.TestPartitionId, data.TestProviderId, 1, []models.ContractChange{{Before: data.Contract}}, auditRecordOf(models.AuditEntityProvider, data.TestProviderId, models.AuditOperationDelete), auditRecordOf(models.AuditEntityContract, data.TestContractId, models.AuditOperationDelete)).Return(nil)
+			},
+		},
+		{
+			"Negative Test Cascade Contract Changed Meanwhile",
+			test.WithHeader(test.GetTestGinContextWithParametersAndQuery(providerParams, "cascade=true"), "If-Match", `"1"`),
+			depsProvider{repo: providerRepo, contracts: contractRepo, validator: mockValidator},
+			409,
+			models.NewConflictError(),
+			func() {
+				contractRepo.EXPECT().GetContractsByProvider(data.TestPartitionId, data.TestProviderId, gomock.Any()).Return(&models.Page[models.Contract]{Items: []models.Contract{data.Contract}}, nil)
+				providerRepo.EXPECT().GetProviderWithVersion(data.TestPartitionId, data.TestProviderId).Return(&data.Provider, 1, nil)
+				providerRepo.EXPECT().DeleteProvider(data.TestPartitionId, data.TestProviderId, 1, []models.ContractChange{{Before: data.Contract}}, gomock.Any()).Return(dberrors.ErrConflict)
+			},
+		},
+		{
+			"Negative Test Referenced",
+			test.WithHeader(test.GetTestGinContextWithParameters(providerParams), "If-Match", `"1"`),
+			depsProvider{repo: providerRepo, contracts: contractRepo, validator: mockValidator},
+			409,
+			models.NewReferencedError([]string{data.TestContractId}),
+			func() {
+				providerRepo.EXPECT().GetProviderWithVersion(data.TestPartitionId, data.TestProviderId).Return(&data.Provider, 1, nil)
+				contractRepo.EXPECT().GetContractsByProvider(data.TestPartitionId, data.TestProviderId, gomock.Any()).Return(&models.Page[models.Contract]{Items: []models.Contract{data.Contract}}, nil)
+			},
+		},
+		{
+			"Negative Test Invalid Cascade",
+			test.WithHeader(test.GetTestGinContextWithParametersAndQuery(providerParams, "cascade=maybe"), "If-Match", `"1"`),
+			depsProvider{repo: providerRepo, contracts: contractRepo, validator: mockValidator},
+			400,
+			models.NewBadRequestError(errors.New(`strconv.ParseBool: parsing "maybe": invalid syntax`)),
+			func() {},
+		},
+		{
+			"Negative Test Contracts Unavailable",
+			test.WithHeader(test.GetTestGinContext(), "If-Match", `"1"`),
+			depsProvider{repo: providerRepo, contracts: contractRepo, validator: mockValidator},
+			503,
+			models.NewUnavailableError(),
+			func() {
+				providerRepo.EXPECT().GetProviderWithVersion(gomock.Any(), gomock.Any()).Return(&data.Provider, 1, nil)
+				contractRepo.EXPECT().GetContractsByProvider(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, dberrors.ErrUnavailable)
+			},
 		},
 		{
 			"Negative Test Resource Not Found",
-			test.GetTestGinContext(),
-			depsProvider{repo: providerRepo, validator: mockValidator},
+			test.WithHeader(test.GetTestGinContext(), "If-Match", `"1"`),
+			depsProvider{repo: providerRepo, contracts: contractRepo, validator: mockValidator},
 			404,
 			models.NewResourceNotFoundError(),
 			func() {
-				providerRepo.EXPECT().DeleteProvider(gomock.Any(), gomock.Any()).Return(errors.New(constants.ResourceNotFound))
+				providerRepo.EXPECT().GetProviderWithVersion(gomock.Any(), gomock.Any()).Return(nil, 0, dberrors.ErrNotFound)
 			},
 		},
 		{
 			"Negative Test Internal Server Error",
-			test.GetTestGinContext(),
-			depsProvider{repo: providerRepo, validator: mockValidator},
+			test.WithHeader(test.GetTestGinContext(), "If-Match", `"1"`),
+			depsProvider{repo: providerRepo, contracts: contractRepo, validator: mockValidator},
 			500,
 			models.NewInternalServerError(),
 			func() {
-				providerRepo.EXPECT().DeleteProvider(gomock.Any(), gomock.Any()).Return(errors.New(constants.InternalServerError))
+				contractRepo.EXPECT().GetContractsByProvider(gomock.Any(), gomock.Any(), gomock.Any()).Return(&models.Page[models.Contract]{Items: []models.Contract{}}, nil)
+				providerRepo.EXPECT().GetProviderWithVersion(gomock.Any(), gomock.Any()).Return(&data.Provider, 1, nil)
+				providerRepo.EXPECT().DeleteProvider(gomock.Any(), gomock.Any(), 1, gomock.Any(), gomock.Any()).Return(errors.New(constants.InternalServerError))
+			},
+		},
+		{
+			"Negative Test If-Match Missing",
+			test.GetTestGinContextWithParameters(providerParams),
+			depsProvider{repo: providerRepo, contracts: contractRepo, validator: mockValidator},
+			428,
+			models.NewPreconditionRequiredError(),
+			func() {},
+		},
+		{
+			"Negative Test Version Mismatch Before References",
+			test.WithHeader(test.GetTestGinContextWithParameters(providerParams), "If-Match", `"1"`),
+			depsProvider{repo: providerRepo, contracts: contractRepo, validator: mockValidator},
+			412,
+			models.NewPreconditionFailedError(),
+			func() {
+				providerRepo.EXPECT().GetProviderWithVersion(data.TestPartitionId, data.TestProviderId).Return(&data.Provider, 2, nil)
 			},
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			providerWriteHandler := ProviderHandler{ProviderWriter: tc.deps.repo, Validator: tc.deps.validator}
+			providerWriteHandler := ProviderHandler{ProviderWriter: tc.deps.repo, ContractRepo: tc.deps.contracts, Validator: tc.deps.validator}
 			tc.mockFunc()
 			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
 			tc.ctx.Writer = blw
 
 			providerWriteHandler.HandleDeleteProvider(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
 			statusCode := tc.ctx.Writer.Status()
 			if statusCode != 204 {
 				var actualError models.Error
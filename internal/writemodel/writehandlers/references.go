@@ -0,0 +1,128 @@
+//go:generate mockgen -source=references.go -destination=testing/references_mocks.go -package=testing ProviderGetter,TariffGetter,ContractReferences
+
+package writehandlers
+
+import (
+	"errors"
+	"fmt"
+	"tariff-calculation-service/internal/dberrors"
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/pagination"
+	"tariff-calculation-service/pkg"
+
+	"github.com/gin-gonic/gin"
+)
+
+// The repositories of the entities a contract refers to. Contracts refer to a provider and to tariffs of
+// their partition.
+
+type ProviderGetter interface {
+	GetProvider(partitionId, providerId string) (*models.Provider, error)
+}
+
+type TariffGetter interface {
+	GetTariff(partitionId, tariffId string) (*models.Tariff, error)
+}
+
+// ContractReferences finds the contracts that refer to a provider or tariff. The repository of the provider or
+// tariff writes the changes of the contracts together with its deletion when it cascades.
+type ContractReferences interface {
+	GetContractsByProvider(partitionId, providerId string, pageRequest models.PageRequest) (*models.Page[models.Contract], error)
+	GetContractsByTariff(partitionId, tariffId string, pageRequest models.PageRequest) (*models.Page[models.Contract], error)
+}
+
+// DeleteOptions are the query parameters of the deletion of a provider or tariff. Cascade deletes the contracts
+// of a provider, and removes a tariff from its contracts, instead of refusing to delete what contracts refer to.
+type DeleteOptions struct {
+	Cascade bool `form:"cascade"`
+}
+
+// checkReferences responds with a bad request listing the references of the contract that do not exist in the
+// partition and returns false, or returns false after attaching the error if the lookup fails.
+func checkReferences(context *gin.Context, providers ProviderGetter, tariffs TariffGetter, partitionId string, contract models.Contract) bool {
+	var fieldErrors []models.FieldError
+	if _, err := providers.GetProvider(partitionId, contract.Provider); err != nil {
+		if !errors.Is(err, dberrors.ErrNotFound) {
+			context.Error(err)
+			return false
+		}
+		fieldErrors = append(fieldErrors, models.FieldError{Pointer: "/provider", Rule: "exists"})
+	}
+	for idx, tariffId := range contract.Tariffs {
+		if _, err := tariffs.GetTariff(partitionId, tariffId); err != nil {
+			if !errors.Is(err, dberrors.ErrNotFound) {
+				context.Error(err)
+				return false
+			}
+			fieldErrors = append(fieldErrors, models.FieldError{Pointer: fmt.Sprintf("/tariffs/%d", idx), Rule: "exists"})
+		}
+	}
+	if len(fieldErrors) > 0 {
+		pkg.RespondWithError(context, models.NewFieldValidationError(fieldErrors))
+		return false
+	}
+
+	return true
+}
+
+// referencingContracts returns all contracts of a lookup by provider or tariff, reading page by page.
+func referencingContracts(getPage func(pageRequest models.PageRequest) (*models.Page[models.Contract], error)) ([]models.Contract, error) {
+	contracts := []models.Contract{}
+	pageRequest := models.PageRequest{Limit: pagination.MaxLimit}
+	for {
+		page, err := getPage(pageRequest)
+		if err != nil {
+			return nil, err
+		}
+		contracts = append(contracts, page.Items...)
+		if page.NextCursor == "" {
+			return contracts, nil
+		}
+		pageRequest.Cursor = page.NextCursor
+	}
+}
+
+func contractIds(contracts []models.Contract) []string {
+	ids := make([]string, 0, len(contracts))
+	for _, contract := range contracts {
+		ids = append(ids, contract.Id)
+	}
+
+	return ids
+}
+
+// deleteRecords returns the audit records of the deletion of the provider or tariff, which was before, and of the
+// changes of its contracts. It returns false after attaching the error if one cannot be diffed.
+func deleteRecords(context *gin.Context, partitionId, entityType, entityId string, before any, changes []models.ContractChange) ([]models.AuditRecord, bool) {
+	record, ok := auditRecord(context, partitionId, entityType, entityId, models.AuditOperationDelete, before, nil)
+	if !ok {
+		return nil, false
+	}
+	changeRecords, ok := contractChangeRecords(context, partitionId, changes)
+	if !ok {
+		return nil, false
+	}
+
+	return append([]models.AuditRecord{record}, changeRecords...), true
+}
+
+// contractChangeRecords returns the audit records of the changes of the contracts, and false after attaching the
+// error if one cannot be diffed.
+func contractChangeRecords(context *gin.Context, partitionId string, changes []models.ContractChange) ([]models.AuditRecord, bool) {
+	records := make([]models.AuditRecord, 0, len(changes))
+	for _, change := range changes {
+		var record models.AuditRecord
+		var ok bool
+		if change.After == nil {
+			record, ok = auditRecord(context, partitionId, models.AuditEntityContract, change.Before.Id, models.AuditOperationDelete, change.Before, nil)
+		} else {
+			record, ok = auditRecord(context, partitionId, models.AuditEntityContract, change.Before.Id, models.AuditOperationUpdate, change.Before, *change.After)
+		}
+		if !ok {
+			return nil, false
+		}
+		records = append(records, record)
+	}
+
+	return records, true
+}
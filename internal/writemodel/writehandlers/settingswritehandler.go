@@ -0,0 +1,47 @@
+//go:generate mockgen -source=settingswritehandler.go -destination=testing/settingswritehandler_mocks.go -package=testing SettingsWriter
+
+package writehandlers
+
+import (
+	"net/http"
+	"tariff-calculation-service/internal/interfaces"
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/repository"
+	"tariff-calculation-service/pkg"
+	"tariff-calculation-service/pkg/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SettingsWriter interface {
+	PutSettings(partitionId string, settings models.Settings) error
+}
+
+type SettingsHandler struct {
+	SettingsWriter SettingsWriter
+	Validator      interfaces.Validator
+}
+
+func NewSettingsHandler() SettingsHandler {
+	return SettingsHandler{SettingsWriter: repository.NewSettingsRepo(), Validator: validation.NewValidator()}
+}
+
+func (handler SettingsHandler) HandlePutSettings(context *gin.Context) {
+	pathParams := validation.PartitionId{}
+	if err := handler.Validator.ValidateAndSetPathParams(context, &pathParams); err != nil {
+		return
+	}
+
+	settings := models.Settings{}
+	if err := context.ShouldBindJSON(&settings); err != nil {
+		pkg.RespondWithError(context, models.NewBadRequestFieldValidationError(err))
+		return
+	}
+
+	if err := handler.SettingsWriter.PutSettings(pathParams.PartitionId, settings); err != nil {
+		context.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusNoContent, nil)
+}
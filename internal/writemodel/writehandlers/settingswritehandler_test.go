@@ -0,0 +1,95 @@
+package writehandlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"tariff-calculation-service/internal/interfaces"
+	"tariff-calculation-service/internal/models"
+	repotesting "tariff-calculation-service/internal/writemodel/writehandlers/testing"
+	"tariff-calculation-service/pkg"
+	"tariff-calculation-service/pkg/constants"
+	"tariff-calculation-service/test"
+	"tariff-calculation-service/test/data"
+	"tariff-calculation-service/test/mocks"
+	"tariff-calculation-service/tools"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+	"go.uber.org/mock/gomock"
+)
+
+type depsSettings struct {
+	repo      SettingsWriter
+	validator interfaces.Validator
+}
+
+type testCaseSWH struct {
+	name                 string
+	ctx                  *gin.Context
+	deps                 depsSettings
+	expectedResponseCode int
+	expectedResponse     any
+	mockFunc             func()
+}
+
+func Test_HandlePutSettings(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	settingsRepo := repotesting.NewMockSettingsWriter(mockController)
+	mockValidator := mocks.NewValidatorPathPositive(mockController)
+
+	testCases := []testCaseSWH{
+		{
+			"Positive Test",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(data.Settings))),
+			depsSettings{repo: settingsRepo, validator: mockValidator},
+			204,
+			nil,
+			func() { settingsRepo.EXPECT().PutSettings(data.TestPartitionId, data.Settings).Return(nil) },
+		},
+		{
+			"Negative Test Invalid Rounding Mode",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, []byte(`{"roundingMode": 2}`)),
+			depsSettings{repo: settingsRepo, validator: mockValidator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/roundingMode", Rule: "lte", Allowed: "1"}}),
+			func() {},
+		},
+		{
+			"Negative Test Internal Server Error",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(data.Settings))),
+			depsSettings{repo: settingsRepo, validator: mockValidator},
+			500,
+			models.NewInternalServerError(),
+			func() {
+				settingsRepo.EXPECT().PutSettings(gomock.Any(), gomock.Any()).Return(errors.New(constants.InternalServerError))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			settingsWriteHandler := SettingsHandler{SettingsWriter: tc.deps.repo, Validator: tc.deps.validator}
+			tc.mockFunc()
+			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
+			tc.ctx.Writer = blw
+
+			settingsWriteHandler.HandlePutSettings(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
+			statusCode := tc.ctx.Writer.Status()
+			if statusCode != 204 {
+				var actualError models.Error
+				err := json.Unmarshal(blw.Body.Bytes(), &actualError)
+				if err != nil {
+					t.Fail()
+				}
+				assert.Equal(t, tc.expectedResponse, actualError)
+			}
+
+			assert.Equal(t, tc.expectedResponseCode, statusCode)
+		})
+	}
+}
@@ -4,9 +4,11 @@ package writehandlers
 
 import (
 	"net/http"
-	"tariff-calculation-service/internal/database"
+	"slices"
 	"tariff-calculation-service/internal/interfaces"
 	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/repository"
+	"tariff-calculation-service/internal/versioning"
 	"tariff-calculation-service/pkg"
 	"tariff-calculation-service/pkg/validation"
 
@@ -15,18 +17,24 @@ import (
 )
 
 type TariffWriter interface {
-	CreateTariff(partitionId string, tariff models.Tariff) (*models.Tariff, error)
-	UpdateTariff(partitionId string, tariff models.Tariff) error
-	DeleteTariff(partitionId, tariffId string) error
+	GetTariffWithVersion(partitionId, tariffId string) (*models.Tariff, int, error)
+	CreateTariff(partitionId string, tariff models.Tariff, records ...models.AuditRecord) (*models.Tariff, error)
+	UpdateTariff(partitionId string, tariff models.Tariff, version int, records ...models.AuditRecord) (int, error)
+	DeleteTariff(partitionId, tariffId string, version int, contracts []models.ContractChange, records ...models.AuditRecord) error
 }
 
 type TariffHandler struct {
 	TariffWriter TariffWriter
+	ContractRepo ContractReferences
 	Validator    interfaces.Validator
 }
 
 func NewTariffHandler() TariffHandler {
-	return TariffHandler{TariffWriter: database.NewTariffRepo(), Validator: validation.NewValidator()}
+	return TariffHandler{
+		TariffWriter: repository.NewTariffRepo(),
+		ContractRepo: repository.NewContractRepo(),
+		Validator:    validation.NewValidator(),
+	}
 }
 
 func (handler TariffHandler) HandlePostTariff(context *gin.Context) {
@@ -37,18 +45,24 @@ func (handler TariffHandler) HandlePostTariff(context *gin.Context) {
 
 	newTariff := models.Tariff{}
 	if err := context.ShouldBindJSON(&newTariff); err != nil {
-		context.JSON(http.StatusBadRequest, models.NewBadRequestFieldValidationError(err))
+		pkg.RespondWithError(context, models.NewBadRequestFieldValidationError(err))
 		return
 	}
 
 	newTariff.Id = uuid.New().String()
 
-	tariff, err := handler.TariffWriter.CreateTariff(pathParams.PartitionId, newTariff)
+	record, ok := auditRecord(context, pathParams.PartitionId, models.AuditEntityTariff, newTariff.Id, models.AuditOperationCreate, nil, newTariff)
+	if !ok {
+		return
+	}
+	tariff, err := handler.TariffWriter.CreateTariff(pathParams.PartitionId, newTariff, record)
 	if err != nil {
-		context.JSON(http.StatusInternalServerError, models.NewInternalServerError())
+		context.Error(err)
 		return
 	}
 
+	pkg.SetLocation(context, newTariff.Id)
+	pkg.SetETag(context, versioning.InitialVersion)
 	context.JSON(http.StatusCreated, tariff)
 }
 
@@ -60,19 +74,43 @@ func (handler TariffHandler) HandlePutTariff(context *gin.Context) {
 
 	tariff := models.Tariff{}
 	if err := context.ShouldBindJSON(&tariff); err != nil {
-		context.JSON(http.StatusBadRequest, models.NewBadRequestFieldValidationError(err))
+		pkg.RespondWithError(context, models.NewBadRequestFieldValidationError(err))
+		return
+	}
+
+	id, ok := entityId(context, pathParams.Id, tariff.Id)
+	if !ok {
 		return
 	}
+	tariff.Id = id
 
-	if tariff.Id == "" {
-		tariff.Id = pathParams.Id
+	version, ok := pkg.IfMatchVersion(context)
+	if !ok {
+		return
+	}
+
+	// the update is conditional on the version read, so that the audit record has the tariff it replaced
+	before, storedVersion, err := handler.TariffWriter.GetTariffWithVersion(pathParams.PartitionId, tariff.Id)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	if !versioning.Matches(storedVersion, version) {
+		context.Error(versioning.ErrVersionMismatch)
+		return
 	}
 
-	if err := handler.TariffWriter.UpdateTariff(pathParams.PartitionId, tariff); err != nil {
-		pkg.HandleResourceNotFoundAndInternalServerError(context, err)
+	record, ok := auditRecord(context, pathParams.PartitionId, models.AuditEntityTariff, tariff.Id, models.AuditOperationUpdate, before, tariff)
+	if !ok {
+		return
+	}
+	updatedVersion, err := handler.TariffWriter.UpdateTariff(pathParams.PartitionId, tariff, storedVersion, record)
+	if err != nil {
+		context.Error(err)
 		return
 	}
 
+	pkg.SetETag(context, updatedVersion)
 	context.JSON(http.StatusNoContent, nil)
 }
 
@@ -82,9 +120,56 @@ func (handler TariffHandler) HandleDeleteTariff(context *gin.Context) {
 		return
 	}
 
-	if err := handler.TariffWriter.DeleteTariff(pathParams.PartitionId, pathParams.Id); err != nil {
-		pkg.HandleResourceNotFoundAndInternalServerError(context, err)
+	version, ok := pkg.IfMatchVersion(context)
+	if !ok {
 		return
 	}
+
+	options := DeleteOptions{}
+	if err := context.ShouldBindQuery(&options); err != nil {
+		pkg.RespondWithError(context, models.NewBadRequestFieldValidationError(err))
+		return
+	}
+
+	// a stale version fails the precondition before the references are looked at
+	before, storedVersion, err := handler.TariffWriter.GetTariffWithVersion(pathParams.PartitionId, pathParams.Id)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	if !versioning.Matches(storedVersion, version) {
+		context.Error(versioning.ErrVersionMismatch)
+		return
+	}
+
+	contracts, err := referencingContracts(func(pageRequest models.PageRequest) (*models.Page[models.Contract], error) {
+		return handler.ContractRepo.GetContractsByTariff(pathParams.PartitionId, pathParams.Id, pageRequest)
+	})
+	if err != nil {
+		context.Error(err)
+		return
+	}
+	if len(contracts) > 0 && !options.Cascade {
+		pkg.RespondWithError(context, models.NewReferencedError(contractIds(contracts)))
+		return
+	}
+
+	// the tariff is removed from its contracts with its deletion, unless one changed since it was read
+	changes := make([]models.ContractChange, 0, len(contracts))
+	for _, contract := range contracts {
+		updated := contract
+		updated.Tariffs = slices.DeleteFunc(slices.Clone(contract.Tariffs), func(tariffId string) bool { return tariffId == pathParams.Id })
+		changes = append(changes, models.ContractChange{Before: contract, After: &updated})
+	}
+	records, ok := deleteRecords(context, pathParams.PartitionId, models.AuditEntityTariff, pathParams.Id, before, changes)
+	if !ok {
+		return
+	}
+	err = handler.TariffWriter.DeleteTariff(pathParams.PartitionId, pathParams.Id, storedVersion, changes, records...)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
 	context.JSON(http.StatusNoContent, nil)
 }
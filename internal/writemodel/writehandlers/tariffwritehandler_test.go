@@ -5,10 +5,14 @@ import (
 	"encoding/json"
 	"errors"
 	"strings"
+	"tariff-calculation-service/internal/dberrors"
 	"tariff-calculation-service/internal/interfaces"
 	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/versioning"
 	repotesting "tariff-calculation-service/internal/writemodel/writehandlers/testing"
+	"tariff-calculation-service/pkg"
 	"tariff-calculation-service/pkg/constants"
+	"tariff-calculation-service/pkg/money"
 	"tariff-calculation-service/test"
 	"tariff-calculation-service/test/data"
 	"tariff-calculation-service/test/mocks"
@@ -22,6 +26,7 @@ import (
 
 type depsTariff struct {
 	repo      TariffWriter
+	contracts ContractReferences
 	validator interfaces.Validator
 }
 
@@ -48,7 +53,9 @@ func Test_HandlePostTariff(t *testing.T) {
 			depsTariff{repo: tariffRepo, validator: mockValidator},
 			201,
 			&data.Tariff,
-			func() { tariffRepo.EXPECT().CreateTariff(gomock.Any(), gomock.Any()).Return(&data.Tariff, nil) },
+			func() {
+				tariffRepo.EXPECT().CreateTariff(gomock.Any(), gomock.Any(), auditRecordOf(models.AuditEntityTariff, "", models.AuditOperationCreate)).Return(&data.Tariff, nil)
+			},
 		},
 		{
 			"Negative Test Internal Server Error",
@@ -57,7 +64,7 @@ func Test_HandlePostTariff(t *testing.T) {
 			500,
 			models.NewInternalServerError(),
 			func() {
-				tariffRepo.EXPECT().CreateTariff(gomock.Any(), gomock.Any()).Return(nil, errors.New(constants.InternalServerError))
+				tariffRepo.EXPECT().CreateTariff(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New(constants.InternalServerError))
 			},
 		},
 	}
@@ -70,10 +77,12 @@ func Test_HandlePostTariff(t *testing.T) {
 			tc.ctx.Writer = blw
 
 			tariffWriteHandler.HandlePostTariff(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
 			statusCode := tc.ctx.Writer.Status()
 
 			assert.Equal(t, tc.expectedResponseCode, statusCode)
 			if statusCode == 201 {
+				assert.Equal(t, `"1"`, tc.ctx.Writer.Header().Get("ETag"))
 				actualTariff := models.Tariff{}
 				err := json.Unmarshal(blw.Body.Bytes(), &actualTariff)
 				if err != nil {
@@ -126,13 +135,27 @@ func Test_HandlePostTariff_Validation(t *testing.T) {
 	tariffValidToInvalid.ValidTo = "01/01/2023"
 
 	tariffInvalidFixedPrice := data.Tariff
-	tariffInvalidFixedPrice.FixedTariff = models.FixedTariff{PricePerUnit: -1}
+	tariffInvalidFixedPrice.FixedTariff = models.FixedTariff{PricePerUnit: money.RequireFromString("-1")}
 
 	tariffInvalidStartTimeHourly := data.TariffInvalidHourlyStartTime
 	tariffInvalidStartTimeHourly.DynamicTariff.HourlyTariffs[0].StartTime = "01/01/2023"
 
 	tariffInvalidValidDaysHourly := data.TariffInvalidHourlyValidDays
-	tariffInvalidValidDaysHourly.DynamicTariff.HourlyTariffs[0].ValidDays = []uint8{1, 2, 3, 4, 5, 6, 7, 7}
+	hourlyTariffInvalidValidDays := tariffInvalidValidDaysHourly.DynamicTariff.HourlyTariffs[0]
+	hourlyTariffInvalidValidDays.ValidDays = []uint8{1, 2, 3, 4, 5, 6, 7, 7}
+	tariffInvalidValidDaysHourly.DynamicTariff = models.DynamicTariff{HourlyTariffs: []models.HourlyTariff{hourlyTariffInvalidValidDays}}
+
+	tariffInvalidPriceTiered := data.TariffTiered
+	tariffInvalidPriceTiered.TieredTariff = models.TieredTariff{Tiers: []models.Tier{{UpTo: money.RequireFromString("100"), PricePerUnit: money.RequireFromString("-1")}}}
+
+	tariffWithoutTiers := data.TariffTiered
+	tariffWithoutTiers.TieredTariff = models.TieredTariff{}
+
+	tariffDescendingTiers := data.TariffTiered
+	tariffDescendingTiers.TieredTariff = models.TieredTariff{Tiers: []models.Tier{{UpTo: money.RequireFromString("100")}, {UpTo: money.RequireFromString("100")}, {UpTo: money.RequireFromString("50")}}}
+
+	tariffInvalidChargeFrequency := data.Tariff
+	tariffInvalidChargeFrequency.FixedCharges = []models.FixedCharge{{Name: "Base Fee", Amount: money.RequireFromString("10"), Frequency: 3}}
 
 	testCases := []testCaseTWH{
 		{
@@ -141,7 +164,9 @@ func Test_HandlePostTariff_Validation(t *testing.T) {
 			depsTariff{repo: tariffRepo, validator: validator},
 			201,
 			&data.Tariff,
-			func() { tariffRepo.EXPECT().CreateTariff(gomock.Any(), gomock.Any()).Return(&data.Tariff, nil) },
+			func() {
+				tariffRepo.EXPECT().CreateTariff(gomock.Any(), gomock.Any(), auditRecordOf(models.AuditEntityTariff, "", models.AuditOperationCreate)).Return(&data.Tariff, nil)
+			},
 		},
 		{
 			"Negative Test PartitionId Invalid",
@@ -157,7 +182,7 @@ func Test_HandlePostTariff_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(tariffEmptyName))),
 			depsTariff{repo: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"Name", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/name", Rule: "required"}}),
 			func() {
 			},
 		},
@@ -166,7 +191,7 @@ func Test_HandlePostTariff_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(tariffNameLenExceeded))),
 			depsTariff{repo: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"Name", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/name", Rule: "max", Allowed: "64"}}),
 			func() {
 			},
 		},
@@ -175,7 +200,7 @@ func Test_HandlePostTariff_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(tariffEmptyCurrency))),
 			depsTariff{repo: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"Currency", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/currency", Rule: "required"}}),
 			func() {
 			},
 		},
@@ -184,7 +209,7 @@ func Test_HandlePostTariff_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(tariffCurrencyInvalid))),
 			depsTariff{repo: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"Currency", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/currency", Rule: "iso4217"}}),
 			func() {
 			},
 		},
@@ -193,7 +218,7 @@ func Test_HandlePostTariff_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(tariffEmptyValidFrom))),
 			depsTariff{repo: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"ValidFrom", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/validFrom", Rule: "required"}}),
 			func() {
 			},
 		},
@@ -202,7 +227,7 @@ func Test_HandlePostTariff_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(tariffValidFromInvalid))),
 			depsTariff{repo: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"ValidFrom", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/validFrom", Rule: "datetime", Allowed: "2006-01-02T15:04:05Z07:00"}}),
 			func() {
 			},
 		},
@@ -211,7 +236,7 @@ func Test_HandlePostTariff_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(tariffEmptyValidTo))),
 			depsTariff{repo: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"ValidTo", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/validTo", Rule: "required"}}),
 			func() {
 			},
 		},
@@ -220,7 +245,7 @@ func Test_HandlePostTariff_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(tariffValidToInvalid))),
 			depsTariff{repo: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"ValidTo", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/validTo", Rule: "datetime", Allowed: "2006-01-02T15:04:05Z07:00"}}),
 			func() {
 			},
 		},
@@ -229,7 +254,7 @@ func Test_HandlePostTariff_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(tariffInvalidFixedPrice))),
 			depsTariff{repo: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"PricePerUnit", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/fixedTariff/pricePerUnit", Rule: "gte", Allowed: "0"}}),
 			func() {
 			},
 		},
@@ -238,7 +263,7 @@ func Test_HandlePostTariff_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(data.TariffInvalidHourlyStartTime))),
 			depsTariff{repo: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"StartTime", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/dynamicTariff/hourlyTariffs/0/startTime", Rule: "datetime", Allowed: "2006-01-02T15:04:05Z07:00"}}),
 			func() {
 			},
 		},
@@ -247,7 +272,7 @@ func Test_HandlePostTariff_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(tariffInvalidStartTimeHourly))),
 			depsTariff{repo: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"StartTime", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/dynamicTariff/hourlyTariffs/0/startTime", Rule: "datetime", Allowed: "2006-01-02T15:04:05Z07:00"}}),
 			func() {
 			},
 		},
@@ -256,7 +281,7 @@ func Test_HandlePostTariff_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(data.TariffInvalidHourlyValidDays))),
 			depsTariff{repo: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"ValidDays", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/dynamicTariff/hourlyTariffs/0/validDays/0", Rule: "max", Allowed: "6"}}),
 			func() {
 			},
 		},
@@ -265,7 +290,7 @@ func Test_HandlePostTariff_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(tariffInvalidValidDaysHourly))),
 			depsTariff{repo: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"ValidDays", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/dynamicTariff/hourlyTariffs/0/validDays", Rule: "max", Allowed: "7"}}),
 			func() {
 			},
 		},
@@ -274,10 +299,86 @@ func Test_HandlePostTariff_Validation(t *testing.T) {
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(data.TariffInvalidHourlyPricePerUnit))),
 			depsTariff{repo: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"PricePerUnit", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/dynamicTariff/hourlyTariffs/0/pricePerUnit", Rule: "gte", Allowed: "0"}}),
+			func() {
+			},
+		},
+		{
+			"Negative Test Tariff Invalid PricePerUnit Tiered",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(tariffInvalidPriceTiered))),
+			depsTariff{repo: tariffRepo, validator: validator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/tieredTariff/tiers/0/pricePerUnit", Rule: "gte", Allowed: "0"}}),
+			func() {
+			},
+		},
+		{
+			"Negative Test Tariff Tiered Without Tiers",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(tariffWithoutTiers))),
+			depsTariff{repo: tariffRepo, validator: validator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/tieredTariff/tiers", Rule: "required_if", Allowed: "pricingModel 2"}}),
 			func() {
 			},
 		},
+		{
+			"Negative Test Tariff Tiered Descending Tiers",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(tariffDescendingTiers))),
+			depsTariff{repo: tariffRepo, validator: validator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{
+				{Pointer: "/tieredTariff/tiers/1/upTo", Rule: "gt", Allowed: "100"},
+				{Pointer: "/tieredTariff/tiers/2/upTo", Rule: "gt", Allowed: "100"},
+			}),
+			func() {
+			},
+		},
+		{
+			"Negative Test Tariff Invalid Charge Frequency",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(tariffInvalidChargeFrequency))),
+			depsTariff{repo: tariffRepo, validator: validator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/fixedCharges/0/frequency", Rule: "lte", Allowed: "2"}}),
+			func() {
+			},
+		},
+		{
+			"Negative Test Tariff ValidTo Before ValidFrom",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(data.TariffValidToBeforeValidFrom))),
+			depsTariff{repo: tariffRepo, validator: validator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/validTo", Rule: "gtfield", Allowed: "validFrom"}}),
+			func() {
+			},
+		},
+		{
+			"Negative Test Tariff Dynamic Without Hourly Tariffs",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(data.TariffDynamicWithoutHourlyTariffs))),
+			depsTariff{repo: tariffRepo, validator: validator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/dynamicTariff/hourlyTariffs", Rule: "required_if", Allowed: "pricingModel 1"}}),
+			func() {
+			},
+		},
+		{
+			"Negative Test Tariff Dynamic Overlapping Hourly Tariffs",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(data.TariffDynamicOverlap))),
+			depsTariff{repo: tariffRepo, validator: validator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/dynamicTariff/hourlyTariffs/2/startTime", Rule: "no_overlap"}}),
+			func() {
+			},
+		},
+		{
+			"Positive Test Tariff Dynamic Weekdays Only",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(data.TariffDynamicWeekdays))),
+			depsTariff{repo: tariffRepo, validator: validator},
+			201,
+			&data.TariffDynamicWeekdays,
+			func() {
+				tariffRepo.EXPECT().CreateTariff(gomock.Any(), gomock.Any(), gomock.Any()).Return(&data.TariffDynamicWeekdays, nil)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -288,6 +389,7 @@ func Test_HandlePostTariff_Validation(t *testing.T) {
 			tc.ctx.Writer = blw
 
 			tariffWriteHandler.HandlePostTariff(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
 			statusCode := tc.ctx.Writer.Status()
 
 			assert.Equal(t, tc.expectedResponseCode, statusCode)
@@ -321,30 +423,90 @@ func Test_HandlePutTariff(t *testing.T) {
 	testCases := []testCaseTWH{
 		{
 			"Positive Test",
-			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.Tariff))),
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.Tariff))), "If-Match", `"1"`),
+			depsTariff{repo: tariffRepo, validator: mockValidator},
+			204,
+			nil,
+			func() {
+				tariffRepo.EXPECT().GetTariffWithVersion(data.TestPartitionId, data.TestTariffId).Return(&data.Tariff, 1, nil)
+				tariffRepo.EXPECT().UpdateTariff(gomock.Any(), gomock.Any(), 1, auditRecordOf(models.AuditEntityTariff, data.TestTariffId, models.AuditOperationUpdate)).Return(2, nil)
+			},
+		},
+		{
+			"Positive Test If-Match Any Version",
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.Tariff))), "If-Match", "*"),
 			depsTariff{repo: tariffRepo, validator: mockValidator},
 			204,
 			nil,
-			func() { tariffRepo.EXPECT().UpdateTariff(gomock.Any(), gomock.Any()).Return(nil) },
+			func() {
+				tariffRepo.EXPECT().GetTariffWithVersion(data.TestPartitionId, data.TestTariffId).Return(&data.Tariff, 1, nil)
+				tariffRepo.EXPECT().UpdateTariff(gomock.Any(), gomock.Any(), 1, auditRecordOf(models.AuditEntityTariff, data.TestTariffId, models.AuditOperationUpdate)).Return(2, nil)
+			},
 		},
 		{
-			"Negative Test Resource Not Found",
+			"Negative Test Id Mismatch",
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.TariffGas))), "If-Match", `"1"`),
+			depsTariff{repo: tariffRepo, validator: mockValidator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/id", Rule: "eq", Allowed: data.TestTariffId}}),
+			func() {},
+		},
+		{
+			"Negative Test If-Match Missing",
 			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.Tariff))),
 			depsTariff{repo: tariffRepo, validator: mockValidator},
+			428,
+			models.NewPreconditionRequiredError(),
+			func() {},
+		},
+		{
+			"Negative Test If-Match Invalid",
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.Tariff))), "If-Match", "1"),
+			depsTariff{repo: tariffRepo, validator: mockValidator},
+			412,
+			models.NewPreconditionFailedError(),
+			func() {},
+		},
+		{
+			"Negative Test Version Mismatch",
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.Tariff))), "If-Match", `"1"`),
+			depsTariff{repo: tariffRepo, validator: mockValidator},
+			412,
+			models.NewPreconditionFailedError(),
+			func() {
+				tariffRepo.EXPECT().GetTariffWithVersion(data.TestPartitionId, data.TestTariffId).Return(&data.Tariff, 2, nil)
+			},
+		},
+		{
+			"Negative Test Concurrent Update",
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.Tariff))), "If-Match", `"1"`),
+			depsTariff{repo: tariffRepo, validator: mockValidator},
+			412,
+			models.NewPreconditionFailedError(),
+			func() {
+				tariffRepo.EXPECT().GetTariffWithVersion(data.TestPartitionId, data.TestTariffId).Return(&data.Tariff, 1, nil)
+				tariffRepo.EXPECT().UpdateTariff(gomock.Any(), gomock.Any(), 1, gomock.Any()).Return(0, versioning.ErrVersionMismatch)
+			},
+		},
+		{
+			"Negative Test Resource Not Found",
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.Tariff))), "If-Match", `"1"`),
+			depsTariff{repo: tariffRepo, validator: mockValidator},
 			404,
 			models.NewResourceNotFoundError(),
 			func() {
-				tariffRepo.EXPECT().UpdateTariff(gomock.Any(), gomock.Any()).Return(errors.New(constants.ResourceNotFound))
+				tariffRepo.EXPECT().GetTariffWithVersion(data.TestPartitionId, data.TestTariffId).Return(nil, 0, dberrors.ErrNotFound)
 			},
 		},
 		{
 			"Negative Test Internal Server Error",
-			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.Tariff))),
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.Tariff))), "If-Match", `"1"`),
 			depsTariff{repo: tariffRepo, validator: mockValidator},
 			500,
 			models.NewInternalServerError(),
 			func() {
-				tariffRepo.EXPECT().UpdateTariff(gomock.Any(), gomock.Any()).Return(errors.New(constants.InternalServerError))
+				tariffRepo.EXPECT().GetTariffWithVersion(data.TestPartitionId, data.TestTariffId).Return(&data.Tariff, 1, nil)
+				tariffRepo.EXPECT().UpdateTariff(gomock.Any(), gomock.Any(), 1, gomock.Any()).Return(0, errors.New(constants.InternalServerError))
 			},
 		},
 	}
@@ -357,8 +519,11 @@ func Test_HandlePutTariff(t *testing.T) {
 			tc.ctx.Writer = blw
 
 			tariffWriteHandler.HandlePutTariff(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
 			statusCode := tc.ctx.Writer.Status()
-			if statusCode != 204 {
+			if statusCode == 204 {
+				assert.Equal(t, `"2"`, tc.ctx.Writer.Header().Get("ETag"))
+			} else {
 				var actualError models.Error
 				err := json.Unmarshal(blw.Body.Bytes(), &actualError)
 				if err != nil {
@@ -405,26 +570,31 @@ func Test_HandlePutTariff_Validation(t *testing.T) {
 	tariffValidToInvalid.ValidTo = "01/01/2023"
 
 	tariffInvalidFixedPrice := data.Tariff
-	tariffInvalidFixedPrice.FixedTariff = models.FixedTariff{PricePerUnit: -1}
+	tariffInvalidFixedPrice.FixedTariff = models.FixedTariff{PricePerUnit: money.RequireFromString("-1")}
 
 	tariffInvalidStartTimeHourly := data.TariffInvalidHourlyStartTime
 	tariffInvalidStartTimeHourly.DynamicTariff.HourlyTariffs[0].StartTime = "01/01/2023"
 
 	tariffInvalidValidDaysHourly := data.TariffInvalidHourlyValidDays
-	tariffInvalidValidDaysHourly.DynamicTariff.HourlyTariffs[0].ValidDays = []uint8{1, 2, 3, 4, 5, 6, 7, 7}
+	hourlyTariffInvalidValidDays := tariffInvalidValidDaysHourly.DynamicTariff.HourlyTariffs[0]
+	hourlyTariffInvalidValidDays.ValidDays = []uint8{1, 2, 3, 4, 5, 6, 7, 7}
+	tariffInvalidValidDaysHourly.DynamicTariff = models.DynamicTariff{HourlyTariffs: []models.HourlyTariff{hourlyTariffInvalidValidDays}}
 
 	testCases := []testCaseTWH{
 		{
 			"Positive Test",
-			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.Tariff))),
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.Tariff))), "If-Match", `"1"`),
 			depsTariff{repo: tariffRepo, validator: validator},
 			204,
 			nil,
-			func() { tariffRepo.EXPECT().UpdateTariff(gomock.Any(), gomock.Any()).Return(nil) },
+			func() {
+				tariffRepo.EXPECT().GetTariffWithVersion(data.TestPartitionId, data.TestTariffId).Return(&data.Tariff, 1, nil)
+				tariffRepo.EXPECT().UpdateTariff(gomock.Any(), gomock.Any(), 1, auditRecordOf(models.AuditEntityTariff, data.TestTariffId, models.AuditOperationUpdate)).Return(2, nil)
+			},
 		},
 		{
 			"Negative Test PartitionId Invalid",
-			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.Tariff))),
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.Tariff))), "If-Match", `"1"`),
 			depsTariff{repo: tariffRepo, validator: validatorNegative},
 			400,
 			models.NewBadRequestFieldValidationError(errors.New("ValidationError")),
@@ -433,130 +603,168 @@ func Test_HandlePutTariff_Validation(t *testing.T) {
 		},
 		{
 			"Negative Test Tariff Empty Name",
-			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(tariffEmptyName))),
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(tariffEmptyName))), "If-Match", `"1"`),
 			depsTariff{repo: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"Name", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/name", Rule: "required"}}),
 			func() {
 			},
 		},
 		{
 			"Negative Test Tariff Name Max Length Exceeded",
-			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(tariffNameLenExceeded))),
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(tariffNameLenExceeded))), "If-Match", `"1"`),
 			depsTariff{repo: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"Name", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/name", Rule: "max", Allowed: "64"}}),
 			func() {
 			},
 		},
 		{
 			"Negative Test Tariff Empty Currency",
-			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(tariffEmptyCurrency))),
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(tariffEmptyCurrency))), "If-Match", `"1"`),
 			depsTariff{repo: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"Currency", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/currency", Rule: "required"}}),
 			func() {
 			},
 		},
 		{
 			"Negative Test Tariff Invalid Currency",
-			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(tariffCurrencyInvalid))),
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(tariffCurrencyInvalid))), "If-Match", `"1"`),
 			depsTariff{repo: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"Currency", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/currency", Rule: "iso4217"}}),
 			func() {
 			},
 		},
 		{
 			"Negative Test Tariff Empty ValidFrom",
-			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(tariffEmptyValidFrom))),
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(tariffEmptyValidFrom))), "If-Match", `"1"`),
 			depsTariff{repo: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"ValidFrom", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/validFrom", Rule: "required"}}),
 			func() {
 			},
 		},
 		{
 			"Negative Test Tariff Invalid ValidFrom",
-			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(tariffValidFromInvalid))),
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(tariffValidFromInvalid))), "If-Match", `"1"`),
 			depsTariff{repo: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"ValidFrom", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/validFrom", Rule: "datetime", Allowed: "2006-01-02T15:04:05Z07:00"}}),
 			func() {
 			},
 		},
 		{
 			"Negative Test Tariff Empty ValidTo",
-			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(tariffEmptyValidTo))),
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(tariffEmptyValidTo))), "If-Match", `"1"`),
 			depsTariff{repo: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"ValidTo", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/validTo", Rule: "required"}}),
 			func() {
 			},
 		},
 		{
 			"Negative Test Tariff Invalid ValidTo",
-			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(tariffValidToInvalid))),
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(tariffValidToInvalid))), "If-Match", `"1"`),
 			depsTariff{repo: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"ValidTo", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/validTo", Rule: "datetime", Allowed: "2006-01-02T15:04:05Z07:00"}}),
 			func() {
 			},
 		},
 		{
 			"Negative Test Tariff Invalid FixedPrice",
-			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(tariffInvalidFixedPrice))),
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(tariffInvalidFixedPrice))), "If-Match", `"1"`),
 			depsTariff{repo: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"PricePerUnit", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/fixedTariff/pricePerUnit", Rule: "gte", Allowed: "0"}}),
 			func() {
 			},
 		},
 		{
 			"Negative Test Tariff Empty StartTime Hourly",
-			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.TariffInvalidHourlyStartTime))),
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.TariffInvalidHourlyStartTime))), "If-Match", `"1"`),
 			depsTariff{repo: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"StartTime", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/dynamicTariff/hourlyTariffs/0/startTime", Rule: "datetime", Allowed: "2006-01-02T15:04:05Z07:00"}}),
 			func() {
 			},
 		},
 		{
 			"Negative Test Tariff Invalid StartTime Hourly",
-			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(tariffInvalidStartTimeHourly))),
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(tariffInvalidStartTimeHourly))), "If-Match", `"1"`),
 			depsTariff{repo: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"StartTime", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/dynamicTariff/hourlyTariffs/0/startTime", Rule: "datetime", Allowed: "2006-01-02T15:04:05Z07:00"}}),
 			func() {
 			},
 		},
 		{
 			"Negative Test Tariff ValidDays Max Value Exceeded Hourly",
-			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.TariffInvalidHourlyValidDays))),
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.TariffInvalidHourlyValidDays))), "If-Match", `"1"`),
 			depsTariff{repo: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"ValidDays", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/dynamicTariff/hourlyTariffs/0/validDays/0", Rule: "max", Allowed: "6"}}),
 			func() {
 			},
 		},
 		{
 			"Negative Test Tariff ValidDays Max Len Exceeded Hourly",
-			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(tariffInvalidValidDaysHourly))),
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(tariffInvalidValidDaysHourly))), "If-Match", `"1"`),
 			depsTariff{repo: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"ValidDays", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/dynamicTariff/hourlyTariffs/0/validDays", Rule: "max", Allowed: "7"}}),
 			func() {
 			},
 		},
 		{
 			"Negative Test Tariff Invalid PricePerUnit Hourly",
-			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.TariffInvalidHourlyPricePerUnit))),
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.TariffInvalidHourlyPricePerUnit))), "If-Match", `"1"`),
 			depsTariff{repo: tariffRepo, validator: validator},
 			400,
-			models.NewBadRequestFieldValidationError(data.FieldValidationError([][2]string{{"PricePerUnit", ""}})),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/dynamicTariff/hourlyTariffs/0/pricePerUnit", Rule: "gte", Allowed: "0"}}),
 			func() {
 			},
 		},
+		{
+			"Negative Test Tariff ValidTo Before ValidFrom",
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.TariffValidToBeforeValidFrom))), "If-Match", `"1"`),
+			depsTariff{repo: tariffRepo, validator: validator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/validTo", Rule: "gtfield", Allowed: "validFrom"}}),
+			func() {
+			},
+		},
+		{
+			"Negative Test Tariff Dynamic Without Hourly Tariffs",
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.TariffDynamicWithoutHourlyTariffs))), "If-Match", `"1"`),
+			depsTariff{repo: tariffRepo, validator: validator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/dynamicTariff/hourlyTariffs", Rule: "required_if", Allowed: "pricingModel 1"}}),
+			func() {
+			},
+		},
+		{
+			"Negative Test Tariff Dynamic Overlapping Hourly Tariffs",
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.TariffDynamicOverlap))), "If-Match", `"1"`),
+			depsTariff{repo: tariffRepo, validator: validator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/dynamicTariff/hourlyTariffs/2/startTime", Rule: "no_overlap"}}),
+			func() {
+			},
+		},
+		{
+			"Positive Test Tariff Dynamic Weekdays Only",
+			test.WithHeader(test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.TariffDynamicWeekdays))), "If-Match", `"1"`),
+			depsTariff{repo: tariffRepo, validator: validator},
+			204,
+			nil,
+			func() {
+				tariffRepo.EXPECT().GetTariffWithVersion(data.TestPartitionId, data.TestTariffId).Return(&data.Tariff, 1, nil)
+				tariffRepo.EXPECT().UpdateTariff(gomock.Any(), gomock.Any(), 1, gomock.Any()).Return(2, nil)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -567,6 +775,7 @@ func Test_HandlePutTariff_Validation(t *testing.T) {
 			tc.ctx.Writer = blw
 
 			tariffWriteHandler.HandlePutTariff(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
 			statusCode := tc.ctx.Writer.Status()
 			if statusCode != 204 {
 				var actualError models.Error
@@ -587,47 +796,123 @@ func Test_HandleDeleteTariff(t *testing.T) {
 	defer mockController.Finish()
 
 	tariffRepo := repotesting.NewMockTariffWriter(mockController)
+	contractRepo := repotesting.NewMockContractReferences(mockController)
 	mockValidator := mocks.NewValidatorPathPositive(mockController)
+	tariffParams := map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}
+	contractWithoutTariff := data.ContractWithTariff
+	contractWithoutTariff.Tariffs = []string{}
 
 	testCases := []testCaseTWH{
 		{
 			"Positive Test",
-			test.GetTestGinContext(),
-			depsTariff{repo: tariffRepo, validator: mockValidator},
+			test.WithHeader(test.GetTestGinContextWithParameters(tariffParams), "If-Match", `"1"`),
+			depsTariff{repo: tariffRepo, contracts: contractRepo, validator: mockValidator},
 			204,
 			nil,
-			func() { tariffRepo.EXPECT().DeleteTariff(gomock.Any(), gomock.Any()).Return(nil) },
+			func() {
+				contractRepo.EXPECT().GetContractsByTariff(gomock.Any(), gomock.Any(), gomock.Any()).Return(&models.Page[models.Contract]{Items: []models.Contract{}}, nil)
+				tariffRepo.EXPECT().GetTariffWithVersion(data.TestPartitionId, data.TestTariffId).Return(&data.Tariff, 1, nil)
+				tariffRepo.EXPECT().DeleteTariff(gomock.Any(), gomock.Any(), 1, gomock.Any(), auditRecordOf(models.AuditEntityTariff, data.TestTariffId, models.AuditOperationDelete)).Return(nil)
+			},
 		},
 		{
-			"Negative Test Resource Not Found",
+			"Positive Test Cascade",
+			test.WithHeader(test.GetTestGinContextWithParametersAndQuery(tariffParams, "cascade=true"), "If-Match", `"1"`),
+			depsTariff{repo: tariffRepo, contracts: contractRepo, validator: mockValidator},
+			204,
+			nil,
+			func() {
+				contractRepo.EXPECT().GetContractsByTariff(data.TestPartitionId, data.TestTariffId, gomock.Any()).Return(&models.Page[models.Contract]{Items: []models.Contract{data.ContractWithTariff}}, nil)
+				tariffRepo.EXPECT().GetTariffWithVersion(data.TestPartitionId, data.TestTariffId).Return(&data.Tariff, 1, nil)
+				tariffRepo.EXPECT().DeleteTariff(data.TestPartitionId, data.TestTariffId, 1, []models.ContractChange{{Before: data.ContractWithTariff, After: &contractWithoutTariff}}, auditRecordOf(models.AuditEntityTariff, data.TestTariffId, models.AuditOperationDelete), auditRecordOf(models.AuditEntityContract, data.TestContractId, models.AuditOperationUpdate)).Return(nil)
+			},
+		},
+		{
+			"Negative Test Cascade Contract Changed Meanwhile",
+			test.WithHeader(test.GetTestGinContextWithParametersAndQuery(tariffParams, "cascade=true"), "If-Match", `"1"`),
+			depsTariff{repo: tariffRepo, contracts: contractRepo, validator: mockValidator},
+			409,
+			models.NewConflictError(),
+			func() {
+				contractRepo.EXPECT().GetContractsByTariff(data.TestPartitionId, data.TestTariffId, gomock.Any()).Return(&models.Page[models.Contract]{Items: []models.Contract{data.ContractWithTariff}}, nil)
+				tariffRepo.EXPECT().GetTariffWithVersion(data.TestPartitionId, data.TestTariffId).Return(&data.Tariff, 1, nil)
+				tariffRepo.EXPECT().DeleteTariff(data.TestPartitionId, data.TestTariffId, 1, gomock.Any(), gomock.Any()).Return(dberrors.ErrConflict)
+			},
+		},
+		{
+			"Negative Test Referenced",
+			test.WithHeader(test.GetTestGinContextWithParameters(tariffParams), "If-Match", `"1"`),
+			depsTariff{repo: tariffRepo, contracts: contractRepo, validator: mockValidator},
+			409,
+			models.NewReferencedError([]string{data.TestContractId}),
+			func() {
+				tariffRepo.EXPECT().GetTariffWithVersion(data.TestPartitionId, data.TestTariffId).Return(&data.Tariff, 1, nil)
+				contractRepo.EXPECT().GetContractsByTariff(data.TestPartitionId, data.TestTariffId, gomock.Any()).Return(&models.Page[models.Contract]{Items: []models.Contract{data.ContractWithTariff}}, nil)
+			},
+		},
+		{
+			"Negative Test Referenced Version Mismatch",
+			test.WithHeader(test.GetTestGinContextWithParameters(tariffParams), "If-Match", `"1"`),
+			depsTariff{repo: tariffRepo, contracts: contractRepo, validator: mockValidator},
+			412,
+			models.NewPreconditionFailedError(),
+			func() {
+				tariffRepo.EXPECT().GetTariffWithVersion(data.TestPartitionId, data.TestTariffId).Return(&data.Tariff, 2, nil)
+			},
+		},
+		{
+			"Negative Test If-Match Missing",
 			test.GetTestGinContext(),
-			depsTariff{repo: tariffRepo, validator: mockValidator},
+			depsTariff{repo: tariffRepo, contracts: contractRepo, validator: mockValidator},
+			428,
+			models.NewPreconditionRequiredError(),
+			func() {},
+		},
+		{
+			"Negative Test Version Mismatch",
+			test.WithHeader(test.GetTestGinContext(), "If-Match", `"1"`),
+			depsTariff{repo: tariffRepo, contracts: contractRepo, validator: mockValidator},
+			412,
+			models.NewPreconditionFailedError(),
+			func() {
+				contractRepo.EXPECT().GetContractsByTariff(gomock.Any(), gomock.Any(), gomock.Any()).Return(&models.Page[models.Contract]{Items: []models.Contract{}}, nil)
+				tariffRepo.EXPECT().GetTariffWithVersion(gomock.Any(), gomock.Any()).Return(&data.Tariff, 1, nil)
+				tariffRepo.EXPECT().DeleteTariff(gomock.Any(), gomock.Any(), 1, gomock.Any(), gomock.Any()).Return(versioning.ErrVersionMismatch)
+			},
+		},
+		{
+			"Negative Test Resource Not Found",
+			test.WithHeader(test.GetTestGinContext(), "If-Match", `"1"`),
+			depsTariff{repo: tariffRepo, contracts: contractRepo, validator: mockValidator},
 			404,
 			models.NewResourceNotFoundError(),
 			func() {
-				tariffRepo.EXPECT().DeleteTariff(gomock.Any(), gomock.Any()).Return(errors.New(constants.ResourceNotFound))
+				tariffRepo.EXPECT().GetTariffWithVersion(gomock.Any(), gomock.Any()).Return(nil, 0, dberrors.ErrNotFound)
 			},
 		},
 		{
 			"Negative Test Internal Server Error",
-			test.GetTestGinContext(),
-			depsTariff{repo: tariffRepo, validator: mockValidator},
+			test.WithHeader(test.GetTestGinContext(), "If-Match", `"1"`),
+			depsTariff{repo: tariffRepo, contracts: contractRepo, validator: mockValidator},
 			500,
 			models.NewInternalServerError(),
 			func() {
-				tariffRepo.EXPECT().DeleteTariff(gomock.Any(), gomock.Any()).Return(errors.New(constants.InternalServerError))
+				contractRepo.EXPECT().GetContractsByTariff(gomock.Any(), gomock.Any(), gomock.Any()).Return(&models.Page[models.Contract]{Items: []models.Contract{}}, nil)
+				tariffRepo.EXPECT().GetTariffWithVersion(gomock.Any(), gomock.Any()).Return(&data.Tariff, 1, nil)
+				tariffRepo.EXPECT().DeleteTariff(gomock.Any(), gomock.Any(), 1, gomock.Any(), gomock.Any()).Return(errors.New(constants.InternalServerError))
 			},
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			tariffWriteHandler := TariffHandler{TariffWriter: tc.deps.repo, Validator: tc.deps.validator}
+			tariffWriteHandler := TariffHandler{TariffWriter: tc.deps.repo, ContractRepo: tc.deps.contracts, Validator: tc.deps.validator}
 			tc.mockFunc()
 			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
 			tc.ctx.Writer = blw
 
 			tariffWriteHandler.HandleDeleteTariff(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
 			statusCode := tc.ctx.Writer.Status()
 			if statusCode != 204 {
 				var actualError models.Error
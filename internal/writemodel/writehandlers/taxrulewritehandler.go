@@ -0,0 +1,93 @@
+//go:generate mockgen -source=taxrulewritehandler.go -destination=testing/taxrulewritehandler_mocks.go -package=testing TaxRuleWriter
+
+package writehandlers
+
+import (
+	"net/http"
+	"tariff-calculation-service/internal/interfaces"
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/repository"
+	"tariff-calculation-service/pkg"
+	"tariff-calculation-service/pkg/validation"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TaxRuleWriter interface {
+	CreateTaxRule(partitionId string, taxRule models.TaxRule) (*models.TaxRule, error)
+	UpdateTaxRule(partitionId string, taxRule models.TaxRule) error
+	DeleteTaxRule(partitionId, taxRuleId string) error
+}
+
+type TaxRuleHandler struct {
+	TaxRuleWriter TaxRuleWriter
+	Validator     interfaces.Validator
+}
+
+func NewTaxRuleHandler() TaxRuleHandler {
+	return TaxRuleHandler{TaxRuleWriter: repository.NewTaxRuleRepo(), Validator: validation.NewValidator()}
+}
+
+func (handler TaxRuleHandler) HandlePostTaxRule(context *gin.Context) {
+	pathParams := validation.PartitionId{}
+	if err := handler.Validator.ValidateAndSetPathParams(context, &pathParams); err != nil {
+		return
+	}
+
+	newTaxRule := models.TaxRule{}
+	if err := context.ShouldBindJSON(&newTaxRule); err != nil {
+		pkg.RespondWithError(context, models.NewBadRequestFieldValidationError(err))
+		return
+	}
+
+	newTaxRule.Id = uuid.New().String()
+
+	taxRule, err := handler.TaxRuleWriter.CreateTaxRule(pathParams.PartitionId, newTaxRule)
+	if err != nil {
+		context.Error(err)
+		return
+	}
+
+	pkg.SetLocation(context, newTaxRule.Id)
+	context.JSON(http.StatusCreated, taxRule)
+}
+
+func (handler TaxRuleHandler) HandlePutTaxRule(context *gin.Context) {
+	pathParams := validation.PartitionIdWithId{}
+	if err := handler.Validator.ValidateAndSetPathParams(context, &pathParams); err != nil {
+		return
+	}
+
+	taxRule := models.TaxRule{}
+	if err := context.ShouldBindJSON(&taxRule); err != nil {
+		pkg.RespondWithError(context, models.NewBadRequestFieldValidationError(err))
+		return
+	}
+
+	id, ok := entityId(context, pathParams.Id, taxRule.Id)
+	if !ok {
+		return
+	}
+	taxRule.Id = id
+
+	if err := handler.TaxRuleWriter.UpdateTaxRule(pathParams.PartitionId, taxRule); err != nil {
+		context.Error(err)
+		return
+	}
+
+	context.JSON(http.StatusNoContent, nil)
+}
+
+func (handler TaxRuleHandler) HandleDeleteTaxRule(context *gin.Context) {
+	pathParams := validation.PartitionIdWithId{}
+	if err := handler.Validator.ValidateAndSetPathParams(context, &pathParams); err != nil {
+		return
+	}
+
+	if err := handler.TaxRuleWriter.DeleteTaxRule(pathParams.PartitionId, pathParams.Id); err != nil {
+		context.Error(err)
+		return
+	}
+	context.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,354 @@
+package writehandlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"tariff-calculation-service/internal/dberrors"
+	"tariff-calculation-service/internal/interfaces"
+	"tariff-calculation-service/internal/models"
+	repotesting "tariff-calculation-service/internal/writemodel/writehandlers/testing"
+	"tariff-calculation-service/pkg"
+	"tariff-calculation-service/pkg/constants"
+	"tariff-calculation-service/pkg/money"
+	"tariff-calculation-service/test"
+	"tariff-calculation-service/test/data"
+	"tariff-calculation-service/test/mocks"
+	"tariff-calculation-service/tools"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+	"go.uber.org/mock/gomock"
+)
+
+type depsTaxRule struct {
+	repo      TaxRuleWriter
+	validator interfaces.Validator
+}
+
+type testCaseTRWH struct {
+	name                 string
+	ctx                  *gin.Context
+	deps                 depsTaxRule
+	expectedResponseCode int
+	expectedResponse     any
+	mockFunc             func()
+}
+
+func Test_HandlePostTaxRule(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	taxRuleRepo := repotesting.NewMockTaxRuleWriter(mockController)
+	mockValidator := mocks.NewValidatorPathPositive(mockController)
+
+	testCases := []testCaseTRWH{
+		{
+			"Positive Test",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(data.TaxRule))),
+			depsTaxRule{repo: taxRuleRepo, validator: mockValidator},
+			201,
+			data.TaxRule,
+			func() { taxRuleRepo.EXPECT().CreateTaxRule(gomock.Any(), gomock.Any()).Return(&data.TaxRule, nil) },
+		},
+		{
+			"Negative Test Internal Server Error",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(data.TaxRule))),
+			depsTaxRule{repo: taxRuleRepo, validator: mockValidator},
+			500,
+			models.NewInternalServerError(),
+			func() {
+				taxRuleRepo.EXPECT().CreateTaxRule(gomock.Any(), gomock.Any()).Return(nil, errors.New(constants.InternalServerError))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			taxRuleWriteHandler := TaxRuleHandler{TaxRuleWriter: tc.deps.repo, Validator: tc.deps.validator}
+			tc.mockFunc()
+			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
+			tc.ctx.Writer = blw
+
+			taxRuleWriteHandler.HandlePostTaxRule(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
+			statusCode := tc.ctx.Writer.Status()
+
+			assert.Equal(t, tc.expectedResponseCode, statusCode)
+			if statusCode == 201 {
+				actualTaxRule := models.TaxRule{}
+				err := json.Unmarshal(blw.Body.Bytes(), &actualTaxRule)
+				if err != nil {
+					t.Fail()
+				}
+
+				assert.Equal(t, tc.expectedResponse, actualTaxRule)
+			} else {
+				var actualError models.Error
+				err := json.Unmarshal(blw.Body.Bytes(), &actualError)
+				if err != nil {
+					t.Fail()
+				}
+				assert.Equal(t, tc.expectedResponse, actualError)
+			}
+		})
+	}
+}
+
+func Test_HandlePostTaxRule_Validation(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	taxRuleRepo := repotesting.NewMockTaxRuleWriter(mockController)
+	validator := mocks.NewValidatorPathPositive(mockController)
+	validatorNegative := mocks.NewValidatorPathNegative(mockController)
+
+	taxRuleEmptyName := data.TaxRule
+	taxRuleEmptyName.Name = ""
+
+	taxRuleNameLenExceeded := data.TaxRule
+	taxRuleNameLenExceeded.Name = strings.Repeat("a", 65)
+
+	taxRuleInvalidCountryCode := data.TaxRule
+	taxRuleInvalidCountryCode.CountryCode = "Japan"
+
+	taxRuleInvalidVatRate := data.TaxRule
+	taxRuleInvalidVatRate.VatRate = money.NewFromInt(101)
+
+	taxRuleInvalidEnergyTax := data.TaxRule
+	taxRuleInvalidEnergyTax.EnergyTaxPerUnit = money.NewFromInt(-1)
+
+	taxRuleInvalidLevy := data.TaxRule
+	taxRuleInvalidLevy.Levies = []models.FixedCharge{{Name: "Levy", Amount: money.RequireFromString("-1")}}
+
+	testCases := []testCaseTRWH{
+		{
+			"Positive Test Partition Default",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(data.TaxRuleDefault))),
+			depsTaxRule{repo: taxRuleRepo, validator: validator},
+			201,
+			data.TaxRuleDefault,
+			func() {
+				taxRuleRepo.EXPECT().CreateTaxRule(gomock.Any(), gomock.Any()).Return(&data.TaxRuleDefault, nil)
+			},
+		},
+		{
+			"Negative Test PartitionId Invalid",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(data.TaxRule))),
+			depsTaxRule{repo: taxRuleRepo, validator: validatorNegative},
+			400,
+			models.NewBadRequestFieldValidationError(errors.New("ValidationError")),
+			func() {
+			},
+		},
+		{
+			"Negative Test TaxRule Empty Name",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(taxRuleEmptyName))),
+			depsTaxRule{repo: taxRuleRepo, validator: validator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/name", Rule: "required"}}),
+			func() {
+			},
+		},
+		{
+			"Negative Test TaxRule Name Max Length Exceeded",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(taxRuleNameLenExceeded))),
+			depsTaxRule{repo: taxRuleRepo, validator: validator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/name", Rule: "max", Allowed: "64"}}),
+			func() {
+			},
+		},
+		{
+			"Negative Test TaxRule Invalid CountryCode",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(taxRuleInvalidCountryCode))),
+			depsTaxRule{repo: taxRuleRepo, validator: validator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/countryCode", Rule: "iso3166_1_alpha3"}}),
+			func() {
+			},
+		},
+		{
+			"Negative Test TaxRule Invalid VatRate",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(taxRuleInvalidVatRate))),
+			depsTaxRule{repo: taxRuleRepo, validator: validator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/vatRate", Rule: "lte", Allowed: "100"}}),
+			func() {
+			},
+		},
+		{
+			"Negative Test TaxRule Invalid EnergyTaxPerUnit",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(taxRuleInvalidEnergyTax))),
+			depsTaxRule{repo: taxRuleRepo, validator: validator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/energyTaxPerUnit", Rule: "gte", Allowed: "0"}}),
+			func() {
+			},
+		},
+		{
+			"Negative Test TaxRule Invalid Levy Amount",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId}, tools.GetFirstValue(json.Marshal(taxRuleInvalidLevy))),
+			depsTaxRule{repo: taxRuleRepo, validator: validator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/levies/0/amount", Rule: "gte", Allowed: "0"}}),
+			func() {
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			taxRuleWriteHandler := TaxRuleHandler{TaxRuleWriter: tc.deps.repo, Validator: tc.deps.validator}
+			tc.mockFunc()
+			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
+			tc.ctx.Writer = blw
+
+			taxRuleWriteHandler.HandlePostTaxRule(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
+			statusCode := tc.ctx.Writer.Status()
+
+			assert.Equal(t, tc.expectedResponseCode, statusCode)
+			if statusCode == 201 {
+				actualTaxRule := models.TaxRule{}
+				err := json.Unmarshal(blw.Body.Bytes(), &actualTaxRule)
+				if err != nil {
+					t.Fail()
+				}
+
+				assert.Equal(t, tc.expectedResponse, actualTaxRule)
+			} else {
+				var actualError models.Error
+				err := json.Unmarshal(blw.Body.Bytes(), &actualError)
+				if err != nil {
+					t.Fail()
+				}
+				assert.Equal(t, tc.expectedResponse, actualError)
+			}
+		})
+	}
+}
+
+func Test_HandlePutTaxRule(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	taxRuleRepo := repotesting.NewMockTaxRuleWriter(mockController)
+	mockValidator := mocks.NewValidatorPathPositive(mockController)
+
+	testCases := []testCaseTRWH{
+		{
+			"Positive Test",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTaxRuleId}, tools.GetFirstValue(json.Marshal(data.TaxRule))),
+			depsTaxRule{repo: taxRuleRepo, validator: mockValidator},
+			204,
+			nil,
+			func() { taxRuleRepo.EXPECT().UpdateTaxRule(gomock.Any(), gomock.Any()).Return(nil) },
+		},
+		{
+			"Negative Test Id Mismatch",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTariffId}, tools.GetFirstValue(json.Marshal(data.TaxRule))),
+			depsTaxRule{repo: taxRuleRepo, validator: mockValidator},
+			400,
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/id", Rule: "eq", Allowed: data.TestTariffId}}),
+			func() {},
+		},
+		{
+			"Negative Test Resource Not Found",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTaxRuleId}, tools.GetFirstValue(json.Marshal(data.TaxRule))),
+			depsTaxRule{repo: taxRuleRepo, validator: mockValidator},
+			404,
+			models.NewResourceNotFoundError(),
+			func() {
+				taxRuleRepo.EXPECT().UpdateTaxRule(gomock.Any(), gomock.Any()).Return(dberrors.ErrNotFound)
+			},
+		},
+		{
+			"Negative Test Internal Server Error",
+			test.GetTestGinContextWithParametersAndBody(map[string]string{"PartitionId": data.TestPartitionId, "Id": data.TestTaxRuleId}, tools.GetFirstValue(json.Marshal(data.TaxRule))),
+			depsTaxRule{repo: taxRuleRepo, validator: mockValidator},
+			500,
+			models.NewInternalServerError(),
+			func() {
+				taxRuleRepo.EXPECT().UpdateTaxRule(gomock.Any(), gomock.Any()).Return(errors.New(constants.InternalServerError))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			taxRuleWriteHandler := TaxRuleHandler{TaxRuleWriter: tc.deps.repo, Validator: tc.deps.validator}
+			tc.mockFunc()
+			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
+			tc.ctx.Writer = blw
+
+			taxRuleWriteHandler.HandlePutTaxRule(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
+			statusCode := tc.ctx.Writer.Status()
+			if statusCode != 204 {
+				var actualError models.Error
+				err := json.Unmarshal(blw.Body.Bytes(), &actualError)
+				if err != nil {
+					t.Fail()
+				}
+				assert.Equal(t, tc.expectedResponse, actualError)
+			}
+
+			assert.Equal(t, tc.expectedResponseCode, statusCode)
+		})
+	}
+}
+
+func Test_HandleDeleteTaxRule(t *testing.T) {
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+
+	taxRuleRepo := repotesting.NewMockTaxRuleWriter(mockController)
+	mockValidator := mocks.NewValidatorPathPositive(mockController)
+
+	testCases := []testCaseTRWH{
+		{
+			"Positive Test",
+			test.GetTestGinContext(),
+			depsTaxRule{repo: taxRuleRepo, validator: mockValidator},
+			204,
+			nil,
+			func() { taxRuleRepo.EXPECT().DeleteTaxRule(gomock.Any(), gomock.Any()).Return(nil) },
+		},
+		{
+			"Negative Test Resource Not Found",
+			test.GetTestGinContext(),
+			depsTaxRule{repo: taxRuleRepo, validator: mockValidator},
+			404,
+			models.NewResourceNotFoundError(),
+			func() {
+				taxRuleRepo.EXPECT().DeleteTaxRule(gomock.Any(), gomock.Any()).Return(dberrors.ErrNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			taxRuleWriteHandler := TaxRuleHandler{TaxRuleWriter: tc.deps.repo, Validator: tc.deps.validator}
+			tc.mockFunc()
+			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
+			tc.ctx.Writer = blw
+
+			taxRuleWriteHandler.HandleDeleteTaxRule(tc.ctx)
+			pkg.ErrorHandler(tc.ctx)
+			statusCode := tc.ctx.Writer.Status()
+			if statusCode != 204 {
+				var actualError models.Error
+				err := json.Unmarshal(blw.Body.Bytes(), &actualError)
+				if err != nil {
+					t.Fail()
+				}
+				assert.Equal(t, tc.expectedResponse, actualError)
+			}
+
+			assert.Equal(t, tc.expectedResponseCode, statusCode)
+		})
+	}
+}
@@ -40,44 +40,76 @@ func (m *MockContractWriter) EXPECT() *MockContractWriterMockRecorder {
 }
 
 // CreateContract mocks base method.
-func (m *MockContractWriter) CreateContract(partitionId string, contract models.Contract) (*models.Contract, error) {
+func (m *MockContractWriter) CreateContract(partitionId string, contract models.Contract, records ...models.AuditRecord) (*models.Contract, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateContract", partitionId, contract)
+	varargs := []any{partitionId, contract}
+	for _, a := range records {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateContract", varargs...)
 	ret0, _ := ret[0].(*models.Contract)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateContract indicates an expected call of CreateContract.
-func (mr *MockContractWriterMockRecorder) CreateContract(partitionId, contract any) *gomock.Call {
+func (mr *MockContractWriterMockRecorder) CreateContract(partitionId, contract any, records ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateContract", reflect.TypeOf((*MockContractWriter)(nil).CreateContract), partitionId, contract)
+	varargs := append([]any{partitionId, contract}, records...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateContract", reflect.TypeOf((*MockContractWriter)(nil).CreateContract), varargs...)
 }
 
 // DeleteContract mocks base method.
-func (m *MockContractWriter) DeleteContract(partitionId, contractId string) error {
+func (m *MockContractWriter) DeleteContract(partitionId, contractId string, version int, records ...models.AuditRecord) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteContract", partitionId, contractId)
+	varargs := []any{partitionId, contractId, version}
+	for _, a := range records {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteContract", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteContract indicates an expected call of DeleteContract.
-func (mr *MockContractWriterMockRecorder) DeleteContract(partitionId, contractId any) *gomock.Call {
+func (mr *MockContractWriterMockRecorder) DeleteContract(partitionId, contractId, version any, records ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContract", reflect.TypeOf((*MockContractWriter)(nil).DeleteContract), partitionId, contractId)
+	varargs := append([]any{partitionId, contractId, version}, records...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContract", reflect.TypeOf((*MockContractWriter)(nil).DeleteContract), varargs...)
+}
+
+// GetContractWithVersion mocks base method.
+func (m *MockContractWriter) GetContractWithVersion(partitionId, contractId string) (*models.Contract, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContractWithVersion", partitionId, contractId)
+	ret0, _ := ret[0].(*models.Contract)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetContractWithVersion indicates an expected call of GetContractWithVersion.
+func (mr *MockContractWriterMockRecorder) GetContractWithVersion(partitionId, contractId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContractWithVersion", reflect.TypeOf((*MockContractWriter)(nil).GetContractWithVersion), partitionId, contractId)
 }
 
 // UpdateContract mocks base method.
-func (m *MockContractWriter) UpdateContract(partitionId string, contract models.Contract) error {
+func (m *MockContractWriter) UpdateContract(partitionId string, contract models.Contract, version int, records ...models.AuditRecord) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateContract", partitionId, contract)
-	ret0, _ := ret[0].(error)
-	return ret0
+	varargs := []any{partitionId, contract, version}
+	for _, a := range records {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateContract", varargs...)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // UpdateContract indicates an expected call of UpdateContract.
-func (mr *MockContractWriterMockRecorder) UpdateContract(partitionId, contract any) *gomock.Call {
+func (mr *MockContractWriterMockRecorder) UpdateContract(partitionId, contract, version any, records ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContract", reflect.TypeOf((*MockContractWriter)(nil).UpdateContract), partitionId, contract)
+	varargs := append([]any{partitionId, contract, version}, records...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContract", reflect.TypeOf((*MockContractWriter)(nil).UpdateContract), varargs...)
 }
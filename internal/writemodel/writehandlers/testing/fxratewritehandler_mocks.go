@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: fxratewritehandler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=fxratewritehandler.go -destination=testing/fxratewritehandler_mocks.go -package=testing FxRateWriter
+//
+
+// Package testing is a generated GoMock package.
+package testing
+
+import (
+	reflect "reflect"
+	models "tariff-calculation-service/internal/models"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFxRateWriter is a mock of FxRateWriter interface.
+type MockFxRateWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockFxRateWriterMockRecorder
+}
+
+// MockFxRateWriterMockRecorder is the mock recorder for MockFxRateWriter.
+type MockFxRateWriterMockRecorder struct {
+	mock *MockFxRateWriter
+}
+
+// NewMockFxRateWriter creates a new mock instance.
+func NewMockFxRateWriter(ctrl *gomock.Controller) *MockFxRateWriter {
+	mock := &MockFxRateWriter{ctrl: ctrl}
+	mock.recorder = &MockFxRateWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFxRateWriter) EXPECT() *MockFxRateWriterMockRecorder {
+	return m.recorder
+}
+
+// PutFxRate mocks base method.
+func (m *MockFxRateWriter) PutFxRate(partitionId string, fxRate models.FxRate) (*models.FxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutFxRate", partitionId, fxRate)
+	ret0, _ := ret[0].(*models.FxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutFxRate indicates an expected call of PutFxRate.
+func (mr *MockFxRateWriterMockRecorder) PutFxRate(partitionId, fxRate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutFxRate", reflect.TypeOf((*MockFxRateWriter)(nil).PutFxRate), partitionId, fxRate)
+}
@@ -40,44 +40,76 @@ func (m *MockProviderWriter) EXPECT() *MockProviderWriterMockRecorder {
 }
 
 // CreateProvider mocks base method.
-func (m *MockProviderWriter) CreateProvider(partitionId string, provider models.Provider) (*models.Provider, error) {
+func (m *MockProviderWriter) CreateProvider(partitionId string, provider models.Provider, records ...models.AuditRecord) (*models.Provider, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateProvider", partitionId, provider)
+	varargs := []any{partitionId, provider}
+	for _, a := range records {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateProvider", varargs...)
 	ret0, _ := ret[0].(*models.Provider)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateProvider indicates an expected call of CreateProvider.
-func (mr *MockProviderWriterMockRecorder) CreateProvider(partitionId, provider any) *gomock.Call {
+func (mr *MockProviderWriterMockRecorder) CreateProvider(partitionId, provider any, records ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProvider", reflect.TypeOf((*MockProviderWriter)(nil).CreateProvider), partitionId, provider)
+	varargs := append([]any{partitionId, provider}, records...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProvider", reflect.TypeOf((*MockProviderWriter)(nil).CreateProvider), varargs...)
 }
 
 // DeleteProvider mocks base method.
-func (m *MockProviderWriter) DeleteProvider(partitionId, providerId string) error {
+func (m *MockProviderWriter) DeleteProvider(partitionId, providerId string, version int, contracts []models.ContractChange, records ...models.AuditRecord) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteProvider", partitionId, providerId)
+	varargs := []any{partitionId, providerId, version, contracts}
+	for _, a := range records {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteProvider", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteProvider indicates an expected call of DeleteProvider.
-func (mr *MockProviderWriterMockRecorder) DeleteProvider(partitionId, providerId any) *gomock.Call {
+func (mr *MockProviderWriterMockRecorder) DeleteProvider(partitionId, providerId, version, contracts any, records ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProvider", reflect.TypeOf((*MockProviderWriter)(nil).DeleteProvider), partitionId, providerId)
+	varargs := append([]any{partitionId, providerId, version, contracts}, records...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProvider", reflect.TypeOf((*MockProviderWriter)(nil).DeleteProvider), varargs...)
+}
+
+// GetProviderWithVersion mocks base method.
+func (m *MockProviderWriter) GetProviderWithVersion(partitionId, providerId string) (*models.Provider, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProviderWithVersion", partitionId, providerId)
+	ret0, _ := ret[0].(*models.Provider)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetProviderWithVersion indicates an expected call of GetProviderWithVersion.
+func (mr *MockProviderWriterMockRecorder) GetProviderWithVersion(partitionId, providerId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProviderWithVersion", reflect.TypeOf((*MockProviderWriter)(nil).GetProviderWithVersion), partitionId, providerId)
 }
 
 // UpdateProvider mocks base method.
-func (m *MockProviderWriter) UpdateProvider(partitionId string, provider models.Provider) error {
+func (m *MockProviderWriter) UpdateProvider(partitionId string, provider models.Provider, version int, records ...models.AuditRecord) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateProvider", partitionId, provider)
-	ret0, _ := ret[0].(error)
-	return ret0
+	varargs := []any{partitionId, provider, version}
+	for _, a := range records {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateProvider", varargs...)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // UpdateProvider indicates an expected call of UpdateProvider.
-func (mr *MockProviderWriterMockRecorder) UpdateProvider(partitionId, provider any) *gomock.Call {
+func (mr *MockProviderWriterMockRecorder) UpdateProvider(partitionId, provider, version any, records ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProvider", reflect.TypeOf((*MockProviderWriter)(nil).UpdateProvider), partitionId, provider)
+	varargs := append([]any{partitionId, provider, version}, records...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProvider", reflect.TypeOf((*MockProviderWriter)(nil).UpdateProvider), varargs...)
 }
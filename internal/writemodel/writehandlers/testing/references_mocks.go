@@ -0,0 +1,146 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: references.go
+//
+// Generated by this command:
+//
+//	mockgen -source=references.go -destination=testing/references_mocks.go -package=testing ProviderGetter,TariffGetter,ContractReferences
+//
+
+// Package testing is a generated GoMock package.
+package testing
+
+import (
+	reflect "reflect"
+	models "tariff-calculation-service/internal/models"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProviderGetter is a mock of ProviderGetter interface.
+type MockProviderGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockProviderGetterMockRecorder
+}
+
+// MockProviderGetterMockRecorder is the mock recorder for MockProviderGetter.
+type MockProviderGetterMockRecorder struct {
+	mock *MockProviderGetter
+}
+
+// NewMockProviderGetter creates a new mock instance.
+func NewMockProviderGetter(ctrl *gomock.Controller) *MockProviderGetter {
+	mock := &MockProviderGetter{ctrl: ctrl}
+	mock.recorder = &MockProviderGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProviderGetter) EXPECT() *MockProviderGetterMockRecorder {
+	return m.recorder
+}
+
+// GetProvider mocks base method.
+func (m *MockProviderGetter) GetProvider(partitionId, providerId string) (*models.Provider, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProvider", partitionId, providerId)
+	ret0, _ := ret[0].(*models.Provider)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProvider indicates an expected call of GetProvider.
+func (mr *MockProviderGetterMockRecorder) GetProvider(partitionId, providerId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProvider", reflect.TypeOf((*MockProviderGetter)(nil).GetProvider), partitionId, providerId)
+}
+
+// MockTariffGetter is a mock of TariffGetter interface.
+type MockTariffGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockTariffGetterMockRecorder
+}
+
+// MockTariffGetterMockRecorder is the mock recorder for MockTariffGetter.
+type MockTariffGetterMockRecorder struct {
+	mock *MockTariffGetter
+}
+
+// NewMockTariffGetter creates a new mock instance.
+func NewMockTariffGetter(ctrl *gomock.Controller) *MockTariffGetter {
+	mock := &MockTariffGetter{ctrl: ctrl}
+	mock.recorder = &MockTariffGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTariffGetter) EXPECT() *MockTariffGetterMockRecorder {
+	return m.recorder
+}
+
+// GetTariff mocks base method.
+func (m *MockTariffGetter) GetTariff(partitionId, tariffId string) (*models.Tariff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTariff", partitionId, tariffId)
+	ret0, _ := ret[0].(*models.Tariff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTariff indicates an expected call of GetTariff.
+func (mr *MockTariffGetterMockRecorder) GetTariff(partitionId, tariffId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTariff", reflect.TypeOf((*MockTariffGetter)(nil).GetTariff), partitionId, tariffId)
+}
+
+// MockContractReferences is a mock of ContractReferences interface.
+type MockContractReferences struct {
+	ctrl     *gomock.Controller
+	recorder *MockContractReferencesMockRecorder
+}
+
+// MockContractReferencesMockRecorder is the mock recorder for MockContractReferences.
+type MockContractReferencesMockRecorder struct {
+	mock *MockContractReferences
+}
+
+// NewMockContractReferences creates a new mock instance.
+func NewMockContractReferences(ctrl *gomock.Controller) *MockContractReferences {
+	mock := &MockContractReferences{ctrl: ctrl}
+	mock.recorder = &MockContractReferencesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockContractReferences) EXPECT() *MockContractReferencesMockRecorder {
+	return m.recorder
+}
+
+// GetContractsByProvider mocks base method.
+func (m *MockContractReferences) GetContractsByProvider(partitionId, providerId string, pageRequest models.PageRequest) (*models.Page[models.Contract], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContractsByProvider", partitionId, providerId, pageRequest)
+	ret0, _ := ret[0].(*models.Page[models.Contract])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContractsByProvider indicates an expected call of GetContractsByProvider.
+func (mr *MockContractReferencesMockRecorder) GetContractsByProvider(partitionId, providerId, pageRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContractsByProvider", reflect.TypeOf((*MockContractReferences)(nil).GetContractsByProvider), partitionId, providerId, pageRequest)
+}
+
+// GetContractsByTariff mocks base method.
+func (m *MockContractReferences) GetContractsByTariff(partitionId, tariffId string, pageRequest models.PageRequest) (*models.Page[models.Contract], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContractsByTariff", partitionId, tariffId, pageRequest)
+	ret0, _ := ret[0].(*models.Page[models.Contract])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContractsByTariff indicates an expected call of GetContractsByTariff.
+func (mr *MockContractReferencesMockRecorder) GetContractsByTariff(partitionId, tariffId, pageRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContractsByTariff", reflect.TypeOf((*MockContractReferences)(nil).GetContractsByTariff), partitionId, tariffId, pageRequest)
+}
@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: settingswritehandler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=settingswritehandler.go -destination=testing/settingswritehandler_mocks.go -package=testing SettingsWriter
+//
+
+// Package testing is a generated GoMock package.
+package testing
+
+import (
+	reflect "reflect"
+	models "tariff-calculation-service/internal/models"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSettingsWriter is a mock of SettingsWriter interface.
+type MockSettingsWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockSettingsWriterMockRecorder
+}
+
+// MockSettingsWriterMockRecorder is the mock recorder for MockSettingsWriter.
+type MockSettingsWriterMockRecorder struct {
+	mock *MockSettingsWriter
+}
+
+// NewMockSettingsWriter creates a new mock instance.
+func NewMockSettingsWriter(ctrl *gomock.Controller) *MockSettingsWriter {
+	mock := &MockSettingsWriter{ctrl: ctrl}
+	mock.recorder = &MockSettingsWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSettingsWriter) EXPECT() *MockSettingsWriterMockRecorder {
+	return m.recorder
+}
+
+// PutSettings mocks base method.
+func (m *MockSettingsWriter) PutSettings(partitionId string, settings models.Settings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutSettings", partitionId, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutSettings indicates an expected call of PutSettings.
+func (mr *MockSettingsWriterMockRecorder) PutSettings(partitionId, settings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutSettings", reflect.TypeOf((*MockSettingsWriter)(nil).PutSettings), partitionId, settings)
+}
@@ -40,44 +40,76 @@ func (m *MockTariffWriter) EXPECT() *MockTariffWriterMockRecorder {
 }
 
 // CreateTariff mocks base method.
-func (m *MockTariffWriter) CreateTariff(partitionId string, tariff models.Tariff) (*models.Tariff, error) {
+func (m *MockTariffWriter) CreateTariff(partitionId string, tariff models.Tariff, records ...models.AuditRecord) (*models.Tariff, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTariff", partitionId, tariff)
+	varargs := []any{partitionId, tariff}
+	for _, a := range records {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateTariff", varargs...)
 	ret0, _ := ret[0].(*models.Tariff)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateTariff indicates an expected call of CreateTariff.
-func (mr *MockTariffWriterMockRecorder) CreateTariff(partitionId, tariff any) *gomock.Call {
+func (mr *MockTariffWriterMockRecorder) CreateTariff(partitionId, tariff any, records ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTariff", reflect.TypeOf((*MockTariffWriter)(nil).CreateTariff), partitionId, tariff)
+	varargs := append([]any{partitionId, tariff}, records...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTariff", reflect.TypeOf((*MockTariffWriter)(nil).CreateTariff), varargs...)
 }
 
 // DeleteTariff mocks base method.
-func (m *MockTariffWriter) DeleteTariff(partitionId, tariffId string) error {
+func (m *MockTariffWriter) DeleteTariff(partitionId, tariffId string, version int, contracts []models.ContractChange, records ...models.AuditRecord) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTariff", partitionId, tariffId)
+	varargs := []any{partitionId, tariffId, version, contracts}
+	for _, a := range records {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteTariff", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteTariff indicates an expected call of DeleteTariff.
-func (mr *MockTariffWriterMockRecorder) DeleteTariff(partitionId, tariffId any) *gomock.Call {
+func (mr *MockTariffWriterMockRecorder) DeleteTariff(partitionId, tariffId, version, contracts any, records ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTariff", reflect.TypeOf((*MockTariffWriter)(nil).DeleteTariff), partitionId, tariffId)
+	varargs := append([]any{partitionId, tariffId, version, contracts}, records...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTariff", reflect.TypeOf((*MockTariffWriter)(nil).DeleteTariff), varargs...)
+}
+
+// GetTariffWithVersion mocks base method.
+func (m *MockTariffWriter) GetTariffWithVersion(partitionId, tariffId string) (*models.Tariff, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTariffWithVersion", partitionId, tariffId)
+	ret0, _ := ret[0].(*models.Tariff)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTariffWithVersion indicates an expected call of GetTariffWithVersion.
+func (mr *MockTariffWriterMockRecorder) GetTariffWithVersion(partitionId, tariffId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTariffWithVersion", reflect.TypeOf((*MockTariffWriter)(nil).GetTariffWithVersion), partitionId, tariffId)
 }
 
 // UpdateTariff mocks base method.
-func (m *MockTariffWriter) UpdateTariff(partitionId string, tariff models.Tariff) error {
+func (m *MockTariffWriter) UpdateTariff(partitionId string, tariff models.Tariff, version int, records ...models.AuditRecord) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTariff", partitionId, tariff)
-	ret0, _ := ret[0].(error)
-	return ret0
+	varargs := []any{partitionId, tariff, version}
+	for _, a := range records {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateTariff", varargs...)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // UpdateTariff indicates an expected call of UpdateTariff.
-func (mr *MockTariffWriterMockRecorder) UpdateTariff(partitionId, tariff any) *gomock.Call {
+func (mr *MockTariffWriterMockRecorder) UpdateTariff(partitionId, tariff, version any, records ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTariff", reflect.TypeOf((*MockTariffWriter)(nil).UpdateTariff), partitionId, tariff)
+	varargs := append([]any{partitionId, tariff, version}, records...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTariff", reflect.TypeOf((*MockTariffWriter)(nil).UpdateTariff), varargs...)
 }
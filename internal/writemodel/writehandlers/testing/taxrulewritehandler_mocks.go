@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: taxrulewritehandler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=taxrulewritehandler.go -destination=testing/taxrulewritehandler_mocks.go -package=testing TaxRuleWriter
+//
+
+// Package testing is a generated GoMock package.
+package testing
+
+import (
+	reflect "reflect"
+	models "tariff-calculation-service/internal/models"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTaxRuleWriter is a mock of TaxRuleWriter interface.
+type MockTaxRuleWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaxRuleWriterMockRecorder
+}
+
+// MockTaxRuleWriterMockRecorder is the mock recorder for MockTaxRuleWriter.
+type MockTaxRuleWriterMockRecorder struct {
+	mock *MockTaxRuleWriter
+}
+
+// NewMockTaxRuleWriter creates a new mock instance.
+func NewMockTaxRuleWriter(ctrl *gomock.Controller) *MockTaxRuleWriter {
+	mock := &MockTaxRuleWriter{ctrl: ctrl}
+	mock.recorder = &MockTaxRuleWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaxRuleWriter) EXPECT() *MockTaxRuleWriterMockRecorder {
+	return m.recorder
+}
+
+// CreateTaxRule mocks base method.
+func (m *MockTaxRuleWriter) CreateTaxRule(partitionId string, taxRule models.TaxRule) (*models.TaxRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaxRule", partitionId, taxRule)
+	ret0, _ := ret[0].(*models.TaxRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTaxRule indicates an expected call of CreateTaxRule.
+func (mr *MockTaxRuleWriterMockRecorder) CreateTaxRule(partitionId, taxRule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaxRule", reflect.TypeOf((*MockTaxRuleWriter)(nil).CreateTaxRule), partitionId, taxRule)
+}
+
+// DeleteTaxRule mocks base method.
+func (m *MockTaxRuleWriter) DeleteTaxRule(partitionId, taxRuleId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaxRule", partitionId, taxRuleId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaxRule indicates an expected call of DeleteTaxRule.
+func (mr *MockTaxRuleWriterMockRecorder) DeleteTaxRule(partitionId, taxRuleId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaxRule", reflect.TypeOf((*MockTaxRuleWriter)(nil).DeleteTaxRule), partitionId, taxRuleId)
+}
+
+// UpdateTaxRule mocks base method.
+func (m *MockTaxRuleWriter) UpdateTaxRule(partitionId string, taxRule models.TaxRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaxRule", partitionId, taxRule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTaxRule indicates an expected call of UpdateTaxRule.
+func (mr *MockTaxRuleWriterMockRecorder) UpdateTaxRule(partitionId, taxRule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaxRule", reflect.TypeOf((*MockTaxRuleWriter)(nil).UpdateTaxRule), partitionId, taxRule)
+}
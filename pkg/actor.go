@@ -0,0 +1,36 @@
+package pkg
+
+import (
+	"os"
+
+	"github.com/awslabs/aws-lambda-go-api-proxy/core"
+	"github.com/gin-gonic/gin"
+)
+
+// ActorHeader is the header that names the actor of a request that API Gateway has not authorized, e.g. when
+// the service runs as a plain HTTP server behind a trusted proxy. It is only read if TrustActorHeaderEnv is set.
+const ActorHeader = "X-Actor"
+
+// TrustActorHeaderEnv enables the X-Actor header when set to "true". Only a server that no client can reach but
+// through a proxy that sets the header may enable it, as any client could name any actor otherwise.
+const TrustActorHeaderEnv = "TRUST_ACTOR_HEADER"
+
+// AnonymousActor is the actor of a request without an authenticated principal.
+const AnonymousActor = "anonymous"
+
+// Actor returns who sent the request: the principal of the API Gateway authorizer, else the X-Actor header if
+// TrustActorHeaderEnv enables it, else AnonymousActor. The header is ignored when there is a principal, so it
+// cannot override it.
+func Actor(ctx *gin.Context) string {
+	if apiGatewayContext, ok := core.GetAPIGatewayContextFromContext(ctx.Request.Context()); ok {
+		if principalId, ok := apiGatewayContext.Authorizer["principalId"].(string); ok && principalId != "" {
+			return principalId
+		}
+	}
+	if os.Getenv(TrustActorHeaderEnv) == "true" {
+		if actor := ctx.GetHeader(ActorHeader); actor != "" {
+			return actor
+		}
+	}
+	return AnonymousActor
+}
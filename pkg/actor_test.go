@@ -0,0 +1,53 @@
+package pkg
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/awslabs/aws-lambda-go-api-proxy/core"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Actor(t *testing.T) {
+	// arrange
+	authorizedRequest, _ := (&core.RequestAccessor{}).EventToRequestWithContext(context.Background(), events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/",
+		RequestContext: events.APIGatewayProxyRequestContext{
+			Authorizer: map[string]interface{}{"principalId": "principal"},
+		},
+	})
+	authorizedHeaderRequest := authorizedRequest.Clone(authorizedRequest.Context())
+	authorizedHeaderRequest.Header.Set(ActorHeader, "header-actor")
+	headerRequest := httptest.NewRequest(http.MethodGet, "/", nil)
+	headerRequest.Header.Set(ActorHeader, "header-actor")
+
+	testcases := []struct {
+		name          string
+		request       *http.Request
+		trustHeader   string
+		expectedActor string
+	}{
+		{"Positive Test Principal", authorizedRequest, "", "principal"},
+		{"Positive Test Principal Before Header", authorizedHeaderRequest, "true", "principal"},
+		{"Positive Test Trusted Header", headerRequest, "true", "header-actor"},
+		{"Positive Test Untrusted Header", headerRequest, "", AnonymousActor},
+		{"Positive Test Anonymous", httptest.NewRequest(http.MethodGet, "/", nil), "true", AnonymousActor},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(TrustActorHeaderEnv, tc.trustHeader)
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+			ctx.Request = tc.request
+			actor := Actor(ctx)
+
+			// assert
+			assert.Equal(t, tc.expectedActor, actor)
+		})
+	}
+}
@@ -1,7 +1,16 @@
 package constants
 
 const (
-	ResourceNotFound    = "ResourceNotFound"
-	InternalServerError = "InternalServerError"
-	BadRequest          = "BadRequest"
+	ResourceNotFound     = "ResourceNotFound"
+	MethodNotAllowed     = "MethodNotAllowed"
+	InternalServerError  = "InternalServerError"
+	BadRequest           = "BadRequest"
+	PreconditionFailed   = "PreconditionFailed"
+	PreconditionRequired = "PreconditionRequired"
+	Conflict             = "Conflict"
+	Referenced           = "Referenced"
+	ValidationFailed     = "ValidationFailed"
+	Throttled            = "Throttled"
+	Unavailable          = "Unavailable"
+	TooLarge             = "TooLarge"
 )
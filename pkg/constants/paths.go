@@ -1,14 +1,28 @@
 package constants
 
 const (
-	BasePath           string = "/api/v1/partitions/:pid"
-	HealthPath         string = "/health"
-	VersionPath        string = "/version"
-	RestVersionPath    string = "/rest-version"
-	TariffsPath        string = "/tariffs"
-	SingleTariffPath   string = TariffsPath + "/:tid"
-	ContractsPath      string = "/contracts"
-	SingleContractPath string = ContractsPath + "/:cid"
-	ProvidersPath      string = "/providers"
-	SingleProviderPath string = ProvidersPath + "/:id"
+	OpenAPIPath             string = "/api/v1/openapi.yml"
+	BasePath                string = "/api/v1/partitions/:partitionId"
+	HealthPath              string = "/health"
+	VersionPath             string = "/version"
+	RestVersionPath         string = "/rest-version"
+	TariffsPath             string = "/tariffs"
+	SingleTariffPath        string = TariffsPath + "/:id"
+	CalculationPath         string = SingleTariffPath + "/calculate"
+	PricePath               string = SingleTariffPath + "/price"
+	TariffContractsPath     string = SingleTariffPath + "/contracts"
+	TariffVersionsPath      string = SingleTariffPath + "/versions"
+	ContractsPath           string = "/contracts"
+	SingleContractPath      string = ContractsPath + "/:id"
+	ContractCalculationPath string = SingleContractPath + "/calculate"
+	ContractBillPath        string = SingleContractPath + "/bill"
+	ContractComparisonPath  string = SingleContractPath + "/compare"
+	ProvidersPath           string = "/providers"
+	SingleProviderPath      string = ProvidersPath + "/:id"
+	ProviderContractsPath   string = SingleProviderPath + "/contracts"
+	TaxRulesPath            string = "/tax-rules"
+	SingleTaxRulePath       string = TaxRulesPath + "/:id"
+	SettingsPath            string = "/settings"
+	FxRatesPath             string = "/fx-rates"
+	AuditPath               string = "/audit"
 )
@@ -0,0 +1,21 @@
+package enums
+
+type ChargeFrequency uint8
+
+const (
+	Daily ChargeFrequency = iota
+	Monthly
+	Yearly
+)
+
+func (chargeFrequency ChargeFrequency) String() string {
+	switch chargeFrequency {
+	case Daily:
+		return "Daily"
+	case Monthly:
+		return "Monthly"
+	case Yearly:
+		return "Yearly"
+	}
+	return "unknown"
+}
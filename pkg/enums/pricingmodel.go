@@ -0,0 +1,21 @@
+package enums
+
+type PricingModel uint8
+
+const (
+	Fixed PricingModel = iota
+	Dynamic
+	Tiered
+)
+
+func (pricingModel PricingModel) String() string {
+	switch pricingModel {
+	case Fixed:
+		return "Fixed"
+	case Dynamic:
+		return "Dynamic"
+	case Tiered:
+		return "Tiered"
+	}
+	return "unknown"
+}
@@ -0,0 +1,18 @@
+package enums
+
+type RoundingMode uint8
+
+const (
+	HalfUp RoundingMode = iota
+	HalfEven
+)
+
+func (roundingMode RoundingMode) String() string {
+	switch roundingMode {
+	case HalfUp:
+		return "HalfUp"
+	case HalfEven:
+		return "HalfEven"
+	}
+	return "unknown"
+}
@@ -0,0 +1,21 @@
+package enums
+
+type TaxType uint8
+
+const (
+	Vat TaxType = iota
+	EnergyTax
+	Levy
+)
+
+func (taxType TaxType) String() string {
+	switch taxType {
+	case Vat:
+		return "Vat"
+	case EnergyTax:
+		return "EnergyTax"
+	case Levy:
+		return "Levy"
+	}
+	return "unknown"
+}
@@ -1,19 +1,69 @@
 package pkg
 
 import (
-	"net/http"
-	"strings"
+	"errors"
+	"log"
 
+	"tariff-calculation-service/internal/dberrors"
 	"tariff-calculation-service/internal/models"
-	"tariff-calculation-service/pkg/constants"
 
 	"github.com/gin-gonic/gin"
 )
 
-func HandleResourceNotFoundAndInternalServerError(ctx *gin.Context, err error) {
-	if strings.Contains(err.Error(), constants.ResourceNotFound) {
-		ctx.JSON(http.StatusNotFound, models.NewResourceNotFoundError())
+// ProblemContentType is the media type of the error responses, see RFC 7807.
+const ProblemContentType = "application/problem+json"
+
+// ErrorHandler is the middleware that responds to the last error a handler attached to the context with
+// context.Error, unless the handler has responded already.
+func ErrorHandler(ctx *gin.Context) {
+	ctx.Next()
+
+	if len(ctx.Errors) == 0 || ctx.Writer.Written() {
 		return
 	}
-	ctx.JSON(http.StatusInternalServerError, models.NewInternalServerError())
+	HandleError(ctx, ctx.Errors.Last().Err)
+}
+
+// HandleError responds with the status code of the kind of the error, see dberrors. Errors of no kind and data the
+// storage backend rejected are internal server errors.
+func HandleError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, dberrors.ErrNotFound):
+		RespondWithError(ctx, models.NewResourceNotFoundError())
+	case errors.Is(err, dberrors.ErrPreconditionFailed):
+		RespondWithError(ctx, models.NewPreconditionFailedError())
+	case errors.Is(err, dberrors.ErrConflict):
+		RespondWithError(ctx, models.NewConflictError())
+	case errors.Is(err, dberrors.ErrInvalidRequest):
+		RespondWithError(ctx, models.NewBadRequestError(cause(err)))
+	case errors.Is(err, dberrors.ErrValidation):
+		// the request was validated, so the data the backend rejected is a fault of the service
+		log.Printf("storage rejected the data of request %s: %v", RequestId(ctx), err)
+		RespondWithError(ctx, models.NewInternalServerError())
+	case errors.Is(err, dberrors.ErrTooLarge):
+		RespondWithError(ctx, models.NewTooLargeError())
+	case errors.Is(err, dberrors.ErrThrottled):
+		RespondWithError(ctx, models.NewThrottledError())
+	case errors.Is(err, dberrors.ErrUnavailable):
+		RespondWithError(ctx, models.NewUnavailableError())
+	default:
+		RespondWithError(ctx, models.NewInternalServerError())
+	}
+}
+
+// RespondWithError responds with the problem as application/problem+json and its status code. The problem
+// carries the id of the request, so that clients can refer to the failed request.
+func RespondWithError(ctx *gin.Context, problem models.Error) {
+	problem.TraceId = RequestId(ctx)
+	ctx.Header("Content-Type", ProblemContentType)
+	ctx.JSON(problem.Status, problem)
+}
+
+// cause returns the cause of a dberrors.Error without the context the repositories wrapped it in.
+func cause(err error) error {
+	var dbErr *dberrors.Error
+	if errors.As(err, &dbErr) {
+		return dbErr.Cause
+	}
+	return err
 }
@@ -3,8 +3,13 @@ package pkg
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"tariff-calculation-service/internal/dberrors"
 	"tariff-calculation-service/internal/models"
-	"tariff-calculation-service/pkg/constants"
+	"tariff-calculation-service/internal/pagination"
+	"tariff-calculation-service/internal/versioning"
 	"tariff-calculation-service/test"
 	"testing"
 
@@ -22,7 +27,7 @@ type testcase struct {
 	inputError         error
 }
 
-func Test_HandleResourceNotFoundAndInternalServerError(t *testing.T) {
+func Test_HandleError(t *testing.T) {
 	// arrange
 	testcases := []testcase{
 		{
@@ -30,14 +35,70 @@ func Test_HandleResourceNotFoundAndInternalServerError(t *testing.T) {
 			test.GetTestGinContext(),
 			404,
 			models.NewResourceNotFoundError(),
-			errors.New(constants.ResourceNotFound),
+			dberrors.ErrNotFound,
+		},
+		{
+			"Positive Test Wrapped ResourceNotFound Error",
+			test.GetTestGinContext(),
+			404,
+			models.NewResourceNotFoundError(),
+			fmt.Errorf("failed to query tariffs: %w", dberrors.ErrNotFound),
+		},
+		{
+			"Positive Test Precondition Failed Error",
+			test.GetTestGinContext(),
+			412,
+			models.NewPreconditionFailedError(),
+			versioning.ErrVersionMismatch,
+		},
+		{
+			"Positive Test Conflict Error",
+			test.GetTestGinContext(),
+			409,
+			models.NewConflictError(),
+			dberrors.Wrap(dberrors.ErrConflict, errors.New("TransactionConflictException")),
+		},
+		{
+			"Positive Test Invalid Request Error",
+			test.GetTestGinContext(),
+			400,
+			models.NewBadRequestError(errors.New("invalid cursor")),
+			fmt.Errorf("failed to query tariffs: %w", pagination.ErrInvalidCursor),
+		},
+		{
+			"Positive Test Validation Error",
+			test.GetTestGinContext(),
+			500,
+			models.NewInternalServerError(),
+			dberrors.Wrap(dberrors.ErrValidation, errors.New("null value in column \"data\" violates not-null constraint")),
+		},
+		{
+			"Positive Test Too Large Error",
+			test.GetTestGinContext(),
+			422,
+			models.NewTooLargeError(),
+			dberrors.ErrTooLarge,
+		},
+		{
+			"Positive Test Throttled Error",
+			test.GetTestGinContext(),
+			429,
+			models.NewThrottledError(),
+			dberrors.Wrap(dberrors.ErrThrottled, errors.New("ProvisionedThroughputExceededException")),
+		},
+		{
+			"Positive Test Unavailable Error",
+			test.GetTestGinContext(),
+			503,
+			models.NewUnavailableError(),
+			dberrors.Wrap(dberrors.ErrUnavailable, errors.New("no database connection")),
 		},
 		{
 			"Positive Test Internal Server Error",
 			test.GetTestGinContext(),
 			500,
 			models.NewInternalServerError(),
-			errors.New(constants.InternalServerError),
+			errors.New("failed to unmarshal"),
 		},
 	}
 	// act
@@ -45,11 +106,12 @@ func Test_HandleResourceNotFoundAndInternalServerError(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			blw := &test.BodyLogWriter{Body: bytes.NewBufferString(""), ResponseWriter: tc.ctx.Writer}
 			tc.ctx.Writer = blw
-			HandleResourceNotFoundAndInternalServerError(tc.ctx, tc.inputError)
+			HandleError(tc.ctx, tc.inputError)
 			statusCode := tc.ctx.Writer.Status()
 
 			// assert
 			assert.Equal(t, tc.expectedStatusCode, statusCode)
+			assert.Equal(t, ProblemContentType, tc.ctx.Writer.Header().Get("Content-Type"))
 			var actualError models.Error
 			err := json.Unmarshal(blw.Body.Bytes(), &actualError)
 			if err != nil {
@@ -59,3 +121,60 @@ func Test_HandleResourceNotFoundAndInternalServerError(t *testing.T) {
 		})
 	}
 }
+
+func Test_RespondWithError(t *testing.T) {
+	// arrange
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestIdHandler)
+	router.GET("/", func(ctx *gin.Context) { RespondWithError(ctx, models.NewResourceNotFoundError()) })
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set(RequestIdHeader, "request-id")
+	recorder := httptest.NewRecorder()
+
+	// act
+	router.ServeHTTP(recorder, request)
+
+	// assert
+	expectedError := models.NewResourceNotFoundError()
+	expectedError.TraceId = "request-id"
+	var actualError models.Error
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &actualError))
+	assert.Equal(t, 404, recorder.Code)
+	assert.Equal(t, ProblemContentType, recorder.Header().Get("Content-Type"))
+	assert.Equal(t, expectedError, actualError)
+}
+
+func Test_ErrorHandler(t *testing.T) {
+	// arrange
+	testcases := []struct {
+		name               string
+		handler            gin.HandlerFunc
+		expectedStatusCode int
+	}{
+		{"Positive Test Error", func(ctx *gin.Context) { _ = ctx.Error(dberrors.ErrNotFound) }, 404},
+		{"Positive Test Last Error", func(ctx *gin.Context) {
+			_ = ctx.Error(dberrors.ErrNotFound)
+			_ = ctx.Error(dberrors.ErrThrottled)
+		}, 429},
+		{"Positive Test Handled Error", func(ctx *gin.Context) {
+			_ = ctx.Error(dberrors.ErrNotFound)
+			ctx.JSON(400, models.NewBadRequestError(errors.New("bad request")))
+		}, 400},
+		{"Positive Test No Error", func(ctx *gin.Context) { ctx.Status(204) }, 204},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.Use(ErrorHandler)
+			router.GET("/", tc.handler)
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			// assert
+			assert.Equal(t, tc.expectedStatusCode, recorder.Code)
+		})
+	}
+}
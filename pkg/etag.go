@@ -0,0 +1,99 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/versioning"
+
+	"github.com/gin-gonic/gin"
+)
+
+var errIfMatchList = errors.New("the If-Match header must hold a single entity tag or *")
+
+// ETag returns the entity tag of a version of an entity.
+func ETag(version int) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// SetETag sets the ETag header to the version of the entity of the response.
+func SetETag(ctx *gin.Context, version int) {
+	ctx.Header("ETag", ETag(version))
+}
+
+// IfMatchVersion returns the version of the If-Match header of a request that changes an entity. It responds
+// with 428 Precondition Required if the header is missing, and with 412 Precondition Failed if it holds no
+// entity tag of this service, since such a tag cannot match the current version. A write expects a single version,
+// so a list of several entity tags is rejected with 400 Bad Request.
+func IfMatchVersion(ctx *gin.Context) (int, bool) {
+	header := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if header == "" {
+		RespondWithError(ctx, models.NewPreconditionRequiredError())
+		return 0, false
+	}
+	if header == "*" {
+		return versioning.AnyVersion, true
+	}
+	if isETagList(header) {
+		RespondWithError(ctx, models.NewBadRequestError(errIfMatchList))
+		return 0, false
+	}
+	version, err := parseETag(header)
+	if err != nil {
+		RespondWithError(ctx, models.NewPreconditionFailedError())
+		return 0, false
+	}
+
+	return version, true
+}
+
+// NotModified responds with 304 Not Modified and returns true if the If-None-Match header of the request
+// matches the version of the entity. If-None-Match uses the weak comparison, so weak tags match as well.
+func NotModified(ctx *gin.Context, version int) bool {
+	header := ctx.GetHeader("If-None-Match")
+	if header == "" {
+		return false
+	}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == ETag(version) {
+			SetETag(ctx, version)
+			ctx.Status(http.StatusNotModified)
+			return true
+		}
+	}
+
+	return false
+}
+
+// isETagList returns whether the header is a list of more than one entity tag. Commas within the quotes of an
+// entity tag do not separate tags.
+func isETagList(header string) bool {
+	quoted := false
+	for _, char := range header {
+		switch {
+		case char == '"':
+			quoted = !quoted
+		case char == ',' && !quoted:
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseETag(tag string) (int, error) {
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, fmt.Errorf("invalid entity tag %s", tag)
+	}
+	version, err := strconv.Atoi(tag[1 : len(tag)-1])
+	if err != nil || version < 0 {
+		return 0, fmt.Errorf("invalid entity tag %s", tag)
+	}
+
+	return version, nil
+}
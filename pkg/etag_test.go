@@ -0,0 +1,71 @@
+package pkg
+
+import (
+	"testing"
+
+	"tariff-calculation-service/internal/versioning"
+	"tariff-calculation-service/test"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_IfMatchVersion(t *testing.T) {
+	// arrange
+	testcases := []struct {
+		name               string
+		ctx                *gin.Context
+		expectedVersion    int
+		expectedOk         bool
+		expectedStatusCode int
+	}{
+		{"Positive Test Version", test.WithHeader(test.GetTestGinContext(), "If-Match", `"3"`), 3, true, 200},
+		{"Positive Test Any Version", test.WithHeader(test.GetTestGinContext(), "If-Match", "*"), versioning.AnyVersion, true, 200},
+		{"Negative Test Missing", test.GetTestGinContext(), 0, false, 428},
+		{"Negative Test Weak Tag", test.WithHeader(test.GetTestGinContext(), "If-Match", `W/"3"`), 0, false, 412},
+		{"Negative Test Invalid Tag", test.WithHeader(test.GetTestGinContext(), "If-Match", `"abc"`), 0, false, 412},
+		{"Negative Test Invalid Tag With Comma", test.WithHeader(test.GetTestGinContext(), "If-Match", `"3,4"`), 0, false, 412},
+		{"Negative Test List", test.WithHeader(test.GetTestGinContext(), "If-Match", `"3", "4"`), 0, false, 400},
+		{"Negative Test List Of Same Tag", test.WithHeader(test.GetTestGinContext(), "If-Match", `"3","3"`), 0, false, 400},
+		{"Negative Test List With Any Version", test.WithHeader(test.GetTestGinContext(), "If-Match", `*, "3"`), 0, false, 400},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			version, ok := IfMatchVersion(tc.ctx)
+
+			// assert
+			assert.Equal(t, tc.expectedVersion, version)
+			assert.Equal(t, tc.expectedOk, ok)
+			assert.Equal(t, tc.expectedStatusCode, tc.ctx.Writer.Status())
+		})
+	}
+}
+
+func Test_NotModified(t *testing.T) {
+	// arrange
+	testcases := []struct {
+		name             string
+		ctx              *gin.Context
+		expectedModified bool
+	}{
+		{"Positive Test Match", test.WithHeader(test.GetTestGinContext(), "If-None-Match", `"2"`), false},
+		{"Positive Test Weak Match", test.WithHeader(test.GetTestGinContext(), "If-None-Match", `"1", W/"2"`), false},
+		{"Positive Test Any", test.WithHeader(test.GetTestGinContext(), "If-None-Match", "*"), false},
+		{"Negative Test Missing", test.GetTestGinContext(), true},
+		{"Negative Test Mismatch", test.WithHeader(test.GetTestGinContext(), "If-None-Match", `"1"`), true},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			notModified := NotModified(tc.ctx, 2)
+
+			// assert
+			assert.Equal(t, !tc.expectedModified, notModified)
+			if notModified {
+				assert.Equal(t, 304, tc.ctx.Writer.Status())
+				assert.Equal(t, `"2"`, tc.ctx.Writer.Header().Get("ETag"))
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package pkg
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetLocation sets the Location header of a created entity to its path, the path of the collection it was
+// posted to followed by its id.
+func SetLocation(ctx *gin.Context, id string) {
+	ctx.Header("Location", strings.TrimSuffix(ctx.Request.URL.Path, "/")+"/"+id)
+}
@@ -0,0 +1,34 @@
+package pkg
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SetLocation(t *testing.T) {
+	// arrange
+	testcases := []struct {
+		name             string
+		path             string
+		expectedLocation string
+	}{
+		{"Positive Test Collection", "/api/v1/partitions/partition/tariffs", "/api/v1/partitions/partition/tariffs/id"},
+		{"Positive Test Collection With Trailing Slash", "/api/v1/partitions/partition/tariffs/", "/api/v1/partitions/partition/tariffs/id"},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+			ctx.Request = httptest.NewRequest(http.MethodPost, tc.path, nil)
+			SetLocation(ctx, "id")
+
+			// assert
+			assert.Equal(t, tc.expectedLocation, ctx.Writer.Header().Get("Location"))
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package money
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"tariff-calculation-service/pkg/enums"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/shopspring/decimal"
+)
+
+// Decimal is an arbitrary precision decimal number for prices and amounts. It is marshalled as a JSON
+// number and a DynamoDB number. The zero value is 0.
+type Decimal struct {
+	value decimal.Decimal
+}
+
+var Zero = Decimal{}
+
+// New returns value * 10^exp.
+func New(value int64, exp int32) Decimal {
+	return normalize(decimal.New(value, exp))
+}
+
+func NewFromInt(value int64) Decimal {
+	return New(value, 0)
+}
+
+// NewFromFloat returns the shortest decimal representation of the float, e.g. 0.1 for 0.1.
+func NewFromFloat(value float64) Decimal {
+	return normalize(decimal.NewFromFloat(value))
+}
+
+func NewFromString(value string) (Decimal, error) {
+	d, err := decimal.NewFromString(value)
+	if err != nil {
+		return Zero, err
+	}
+	return normalize(d), nil
+}
+
+func RequireFromString(value string) Decimal {
+	d, err := NewFromString(value)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// normalize strips trailing zeros so that equal numbers have an equal representation.
+func normalize(d decimal.Decimal) Decimal {
+	if d.IsZero() {
+		return Zero
+	}
+	coefficient := d.Coefficient()
+	exp := d.Exponent()
+	ten := big.NewInt(10)
+	quotient, remainder := new(big.Int), new(big.Int)
+	for {
+		quotient.QuoRem(coefficient, ten, remainder)
+		if remainder.Sign() != 0 {
+			break
+		}
+		coefficient.Set(quotient)
+		exp++
+	}
+	return Decimal{value: decimal.NewFromBigInt(coefficient, exp)}
+}
+
+func (d Decimal) Add(other Decimal) Decimal {
+	return normalize(d.value.Add(other.value))
+}
+
+func (d Decimal) Sub(other Decimal) Decimal {
+	return normalize(d.value.Sub(other.value))
+}
+
+func (d Decimal) Mul(other Decimal) Decimal {
+	return normalize(d.value.Mul(other.value))
+}
+
+// Div divides with a precision of 16 decimal places. Like integer division, it panics if other is zero,
+// so callers check the divisor first.
+func (d Decimal) Div(other Decimal) Decimal {
+	if other.IsZero() {
+		panic("money: division by zero")
+	}
+	return normalize(d.value.Div(other.value))
+}
+
+func (d Decimal) MulFloat(other float64) Decimal {
+	return d.Mul(NewFromFloat(other))
+}
+
+func (d Decimal) Neg() Decimal {
+	return normalize(d.value.Neg())
+}
+
+func (d Decimal) Cmp(other Decimal) int {
+	return d.value.Cmp(other.value)
+}
+
+func (d Decimal) Equal(other Decimal) bool {
+	return d.value.Equal(other.value)
+}
+
+func (d Decimal) IsZero() bool {
+	return d.value.IsZero()
+}
+
+func (d Decimal) IsNegative() bool {
+	return d.value.IsNegative()
+}
+
+// Round rounds to the given number of decimal places.
+func (d Decimal) Round(places int32, mode enums.RoundingMode) Decimal {
+	if mode == enums.HalfEven {
+		return normalize(d.value.RoundBank(places))
+	}
+	return normalize(d.value.Round(places))
+}
+
+// RoundToCurrency rounds to the minor units of the ISO 4217 currency.
+func (d Decimal) RoundToCurrency(currency string, mode enums.RoundingMode) Decimal {
+	return d.Round(MinorUnits(currency), mode)
+}
+
+// Float64 returns the nearest float64, e.g. for comparisons during validation.
+func (d Decimal) Float64() float64 {
+	return d.value.InexactFloat64()
+}
+
+func (d Decimal) String() string {
+	return d.value.String()
+}
+
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON accepts JSON numbers and numeric strings.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*d = Zero
+		return nil
+	}
+	parsed, err := NewFromString(string(bytes.Trim(data, `"`)))
+	if err != nil {
+		return fmt.Errorf("invalid decimal %s: %w", data, err)
+	}
+	*d = parsed
+	return nil
+}
+
+func (d Decimal) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	return &types.AttributeValueMemberN{Value: d.String()}, nil
+}
+
+func (d *Decimal) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	var value string
+	switch typed := av.(type) {
+	case *types.AttributeValueMemberN:
+		value = typed.Value
+	case *types.AttributeValueMemberS:
+		value = typed.Value
+	case *types.AttributeValueMemberNULL:
+		*d = Zero
+		return nil
+	default:
+		return fmt.Errorf("unsupported attribute value %T for decimal", av)
+	}
+	parsed, err := NewFromString(value)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
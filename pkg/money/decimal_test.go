@@ -0,0 +1,119 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+
+	"tariff-calculation-service/pkg/enums"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type amount struct {
+	Cost Decimal `json:"cost"`
+}
+
+func Test_Arithmetic(t *testing.T) {
+	// arrange
+	price := RequireFromString("0.1")
+
+	// act
+	sum := price.Add(RequireFromString("0.2"))
+	product := price.MulFloat(3)
+	quotient := NewFromInt(31).Div(NewFromInt(4))
+
+	// assert
+	assert.Equal(t, RequireFromString("0.3"), sum)
+	assert.Equal(t, RequireFromString("0.3"), product)
+	assert.Equal(t, RequireFromString("7.75"), quotient)
+	assert.Panics(t, func() { NewFromInt(1).Div(Zero) })
+	assert.Equal(t, Zero, RequireFromString("0.50").Sub(RequireFromString("0.5")))
+	assert.Equal(t, RequireFromString("1.5"), RequireFromString("1.500"))
+}
+
+func Test_Round(t *testing.T) {
+	// arrange
+	testcases := []struct {
+		name     string
+		value    string
+		currency string
+		mode     enums.RoundingMode
+		expected string
+	}{
+		{"Positive Test Half Up", "2.345", "EUR", enums.HalfUp, "2.35"},
+		{"Positive Test Half Even", "2.345", "EUR", enums.HalfEven, "2.34"},
+		{"Positive Test Half Up Negative", "-2.345", "EUR", enums.HalfUp, "-2.35"},
+		{"Positive Test No Minor Units", "2.5", "JPY", enums.HalfUp, "3"},
+		{"Positive Test No Minor Units Half Even", "2.5", "JPY", enums.HalfEven, "2"},
+		{"Positive Test Three Minor Units", "2.3455", "BHD", enums.HalfEven, "2.346"},
+		{"Positive Test Unknown Currency", "2.345", "", enums.HalfUp, "2.35"},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			rounded := RequireFromString(tc.value).RoundToCurrency(tc.currency, tc.mode)
+
+			// assert
+			assert.Equal(t, RequireFromString(tc.expected), rounded)
+		})
+	}
+}
+
+func Test_JSON(t *testing.T) {
+	// arrange
+	testcases := []struct {
+		name     string
+		json     string
+		expected Decimal
+	}{
+		{"Positive Test Number", `{"cost":0.1}`, RequireFromString("0.1")},
+		{"Positive Test Full Precision", `{"cost":12345678901234567890.123456789}`, RequireFromString("12345678901234567890.123456789")},
+		{"Positive Test String", `{"cost":"64.50"}`, RequireFromString("64.5")},
+		{"Positive Test Null", `{"cost":null}`, Zero},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := amount{}
+			err := json.Unmarshal([]byte(tc.json), &actual)
+			assert.Nil(t, err)
+			marshalled, err := json.Marshal(actual)
+			assert.Nil(t, err)
+			roundTrip := amount{}
+			err = json.Unmarshal(marshalled, &roundTrip)
+
+			// assert
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, actual.Cost)
+			assert.Equal(t, actual, roundTrip)
+		})
+	}
+
+	t.Run("Negative Test Invalid", func(t *testing.T) {
+		err := json.Unmarshal([]byte(`{"cost":"abc"}`), &amount{})
+		assert.NotNil(t, err)
+	})
+}
+
+func Test_DynamoDB(t *testing.T) {
+	// arrange
+	expected := amount{Cost: RequireFromString("12345678901234567890.123456789")}
+
+	// act
+	item, err := attributevalue.MarshalMap(expected)
+	assert.Nil(t, err)
+	actual := amount{}
+	err = attributevalue.UnmarshalMap(item, &actual)
+
+	// assert
+	assert.Nil(t, err)
+	assert.Equal(t, &types.AttributeValueMemberN{Value: "12345678901234567890.123456789"}, item["Cost"])
+	assert.Equal(t, expected, actual)
+
+	t.Run("Negative Test Unsupported Type", func(t *testing.T) {
+		err := attributevalue.UnmarshalMap(map[string]types.AttributeValue{"Cost": &types.AttributeValueMemberBOOL{Value: true}}, &amount{})
+		assert.NotNil(t, err)
+	})
+}
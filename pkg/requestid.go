@@ -0,0 +1,45 @@
+package pkg
+
+import (
+	"regexp"
+
+	"github.com/awslabs/aws-lambda-go-api-proxy/core"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestIdHeader is the header that carries the id of a request, both in the request and in the response.
+const RequestIdHeader = "X-Request-Id"
+
+const requestIdKey = "requestId"
+
+// clientRequestId matches the ids of clients that are kept: UUIDs and other short tokens, which are safe to log
+// and to echo in a header.
+var clientRequestId = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestIdHandler is the middleware that assigns an id to each request and returns it in the X-Request-Id
+// header. It keeps the id the client sent if it is a short token, falls back to the request id of API Gateway
+// and generates one otherwise.
+func RequestIdHandler(ctx *gin.Context) {
+	requestId := ctx.GetHeader(RequestIdHeader)
+	if !clientRequestId.MatchString(requestId) {
+		requestId = ""
+	}
+	if requestId == "" {
+		if apiGatewayContext, ok := core.GetAPIGatewayContextFromContext(ctx.Request.Context()); ok {
+			requestId = apiGatewayContext.RequestID
+		}
+	}
+	if requestId == "" {
+		requestId = uuid.NewString()
+	}
+	ctx.Set(requestIdKey, requestId)
+	ctx.Header(RequestIdHeader, requestId)
+
+	ctx.Next()
+}
+
+// RequestId returns the id RequestIdHandler assigned to the request, or an empty string without the middleware.
+func RequestId(ctx *gin.Context) string {
+	return ctx.GetString(requestIdKey)
+}
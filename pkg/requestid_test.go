@@ -0,0 +1,71 @@
+package pkg
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/awslabs/aws-lambda-go-api-proxy/core"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RequestIdHandler(t *testing.T) {
+	// arrange
+	apiGatewayRequest, _ := (&core.RequestAccessor{}).EventToRequestWithContext(context.Background(), events.APIGatewayProxyRequest{
+		HTTPMethod:     http.MethodGet,
+		Path:           "/",
+		RequestContext: events.APIGatewayProxyRequestContext{RequestID: "api-gateway-id"},
+	})
+	apiGatewayClientRequest := apiGatewayRequest.Clone(apiGatewayRequest.Context())
+	apiGatewayClientRequest.Header.Set(RequestIdHeader, "client-id")
+	clientRequest := httptest.NewRequest(http.MethodGet, "/", nil)
+	clientRequest.Header.Set(RequestIdHeader, "client-id")
+	newRequest := func(requestId string) *http.Request {
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set(RequestIdHeader, requestId)
+		return request
+	}
+	apiGatewayInvalidRequest := apiGatewayRequest.Clone(apiGatewayRequest.Context())
+	apiGatewayInvalidRequest.Header.Set(RequestIdHeader, "client id")
+
+	testcases := []struct {
+		name              string
+		request           *http.Request
+		expectedRequestId string
+	}{
+		{"Positive Test Client Id", clientRequest, "client-id"},
+		{"Positive Test API Gateway Id", apiGatewayRequest, "api-gateway-id"},
+		{"Positive Test Client Id Before API Gateway Id", apiGatewayClientRequest, "client-id"},
+		{"Positive Test Generated Id", httptest.NewRequest(http.MethodGet, "/", nil), ""},
+		{"Positive Test Client UUID", newRequest("8eb474f4-3bf9-483c-8c4d-6193a7217fa3"), "8eb474f4-3bf9-483c-8c4d-6193a7217fa3"},
+		{"Negative Test Client Id Too Long", newRequest(strings.Repeat("a", 65)), ""},
+		{"Negative Test Client Id With Markup", newRequest("<script>alert(1)</script>"), ""},
+		{"Negative Test Client Id With Line Break", newRequest("id\nforged log line"), ""},
+		{"Negative Test Invalid Client Id Before API Gateway Id", apiGatewayInvalidRequest, "api-gateway-id"},
+	}
+	// act
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.Use(RequestIdHandler)
+			var requestId string
+			router.GET("/", func(ctx *gin.Context) { requestId = RequestId(ctx) })
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, tc.request)
+
+			// assert
+			if tc.expectedRequestId == "" {
+				assert.NoError(t, uuid.Validate(requestId))
+			} else {
+				assert.Equal(t, tc.expectedRequestId, requestId)
+			}
+			assert.Equal(t, requestId, recorder.Header().Get(RequestIdHeader))
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package validation
+
+import (
+	"reflect"
+
+	"tariff-calculation-service/pkg/money"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// init lets the binding tags of decimal fields, e.g. gte=0, compare the decimal value.
+func init() {
+	if engine, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		engine.RegisterCustomTypeFunc(decimalValue, money.Decimal{})
+	}
+}
+
+func decimalValue(field reflect.Value) any {
+	if decimal, ok := field.Interface().(money.Decimal); ok {
+		return decimal.Float64()
+	}
+	return nil
+}
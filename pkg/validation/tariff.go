@@ -0,0 +1,104 @@
+package validation
+
+import (
+	"fmt"
+	"time"
+
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/enums"
+	"tariff-calculation-service/pkg/money"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	daysPerWeek    = 7
+	secondsPerDay  = 24 * 60 * 60
+	secondsPerWeek = daysPerWeek * secondsPerDay
+)
+
+// init registers the semantic validation of tariffs. It runs after the binding tags of the fields, on every
+// tariff a request binds, and checks the fields against each other.
+func init() {
+	if engine, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		engine.RegisterStructValidation(validateTariff, models.Tariff{})
+	}
+}
+
+// validateTariff reports a validity that does not end after it starts, a dynamic tariff whose hourly tariffs
+// are missing, overlap or leave days of the week uncovered and a tiered tariff whose tiers are missing or do not
+// ascend. Fields of an invalid format are left to their tags.
+func validateTariff(structLevel validator.StructLevel) {
+	tariff := structLevel.Current().Interface().(models.Tariff)
+
+	validFrom, fromErr := time.Parse(time.RFC3339, tariff.ValidFrom)
+	validTo, toErr := time.Parse(time.RFC3339, tariff.ValidTo)
+	if fromErr == nil && toErr == nil && !validTo.After(validFrom) {
+		structLevel.ReportError(tariff.ValidTo, "validTo", "ValidTo", "gtfield", "validFrom")
+	}
+
+	if tariff.PricingModel == enums.Dynamic {
+		validateHourlyTariffs(structLevel, tariff.DynamicTariff.HourlyTariffs)
+	}
+	if tariff.PricingModel == enums.Tiered {
+		validateTiers(structLevel, tariff.TieredTariff.Tiers)
+	}
+}
+
+// validateTiers checks the tiers of a tiered tariff. The upper bound of a tier must exceed the one of the tier
+// before, or zero for the first tier. The last tier has no upper bound, so it may leave it zero.
+func validateTiers(structLevel validator.StructLevel, tiers []models.Tier) {
+	if len(tiers) == 0 {
+		structLevel.ReportError(tiers, "tieredTariff.tiers", "TieredTariff.Tiers", "required_if", "pricingModel 2")
+		return
+	}
+
+	lower := money.Zero
+	for idx, tier := range tiers {
+		unbounded := idx == len(tiers)-1 && tier.UpTo.IsZero()
+		if !unbounded && tier.UpTo.Cmp(lower) <= 0 {
+			structLevel.ReportError(tier.UpTo, fmt.Sprintf("tieredTariff.tiers[%d].upTo", idx),
+				fmt.Sprintf("TieredTariff.Tiers[%d].UpTo", idx), "gt", lower.String())
+		}
+		lower = tier.UpTo
+	}
+}
+
+// validateHourlyTariffs checks the hourly tariffs of a dynamic tariff. An hourly tariff lasts until the next one
+// of the week starts, as calculation.FindHourlyTariff prices it, so two hourly tariffs overlap if they start at the
+// same instant of the week. Hourly tariffs cover the whole week, since the last one of a day lasts into the following
+// days until the next one starts, so a day without an hourly tariff of its own is no gap.
+func validateHourlyTariffs(structLevel validator.StructLevel, hourlyTariffs []models.HourlyTariff) {
+	if len(hourlyTariffs) == 0 {
+		structLevel.ReportError(hourlyTariffs, "dynamicTariff.hourlyTariffs", "DynamicTariff.HourlyTariffs", "required_if", "pricingModel 1")
+		return
+	}
+
+	starts := map[int]int{}
+	for idx, hourlyTariff := range hourlyTariffs {
+		startTime, err := time.Parse(time.RFC3339, hourlyTariff.StartTime)
+		if err != nil {
+			continue
+		}
+		for _, day := range hourlyTariff.ValidDays {
+			if int(day) >= daysPerWeek {
+				continue
+			}
+			start := weekSecond(int(day), startTime)
+			if other, found := starts[start]; found && other != idx {
+				structLevel.ReportError(hourlyTariff.StartTime, fmt.Sprintf("dynamicTariff.hourlyTariffs[%d].startTime", idx),
+					fmt.Sprintf("DynamicTariff.HourlyTariffs[%d].StartTime", idx), "no_overlap", "")
+				break
+			}
+			starts[start] = idx
+		}
+	}
+}
+
+// weekSecond returns the second of the week, in UTC, at which an hourly tariff starts on a day of the week.
+func weekSecond(day int, startTime time.Time) int {
+	_, offset := startTime.Zone()
+	second := day*secondsPerDay + startTime.Hour()*3600 + startTime.Minute()*60 + startTime.Second() - offset
+	return (second + secondsPerWeek) % secondsPerWeek
+}
@@ -1,12 +1,34 @@
 package validation
 
 import (
-	"net/http"
+	"reflect"
+	"strings"
 	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
 )
 
+// init names the fields of validation errors as the request does, so that the problems of a bad request point
+// to the JSON field, query parameter or path parameter rather than to the Go field.
+func init() {
+	if engine, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		engine.RegisterTagNameFunc(requestName)
+	}
+}
+
+func requestName(field reflect.StructField) string {
+	for _, key := range []string{"json", "form", "uri"} {
+		name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
+
 type Validator struct {
 }
 
@@ -17,7 +39,7 @@ func NewValidator() Validator {
 func (Validator Validator) ValidateAndSetPathParams(ctx *gin.Context, objectPtr any) error {
 	err := ctx.ShouldBindUri(objectPtr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, models.NewBadRequestFieldValidationError(err))
+		pkg.RespondWithError(ctx, models.NewBadRequestFieldValidationError(err))
 		return err
 	}
 
@@ -1,12 +1,18 @@
 package validation
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/money"
 	"tariff-calculation-service/test"
 	"tariff-calculation-service/test/data"
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -116,3 +122,46 @@ func Test_ValidateAndSetPathParams_PartitionIdWithId(t *testing.T) {
 		})
 	}
 }
+
+func Test_NewBadRequestFieldValidationError(t *testing.T) {
+	tariffInvalidCharge := data.Tariff
+	tariffInvalidCharge.Name = ""
+	tariffInvalidCharge.FixedCharges = []models.FixedCharge{{Name: strings.Repeat("a", 65), Amount: money.RequireFromString("-1")}}
+
+	testCases := []struct {
+		name          string
+		err           error
+		expectedError models.Error
+	}{
+		{
+			"Positive Test Nested Fields",
+			binding.Validator.ValidateStruct(tariffInvalidCharge),
+			models.NewFieldValidationError([]models.FieldError{
+				{Pointer: "/name", Rule: "required"},
+				{Pointer: "/fixedCharges/0/name", Rule: "max", Allowed: "64"},
+				{Pointer: "/fixedCharges/0/amount", Rule: "gte", Allowed: "0"},
+			}),
+		},
+		{
+			"Positive Test Query Parameter",
+			binding.Validator.ValidateStruct(models.PageRequest{Limit: 1001}),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/limit", Rule: "lte", Allowed: "1000"}}),
+		},
+		{
+			"Positive Test Field Type",
+			json.Unmarshal([]byte(`{"fixedCharges":[{"frequency":"monthly"}]}`), &models.Tariff{}),
+			models.NewFieldValidationError([]models.FieldError{{Pointer: "/fixedCharges/0/frequency", Rule: "type", Allowed: "number"}}),
+		},
+		{
+			"Positive Test Invalid JSON",
+			errors.New("unexpected EOF"),
+			models.NewBadRequestError(errors.New("unexpected EOF")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedError, models.NewBadRequestFieldValidationError(tc.err))
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package test
+
+import (
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// TestRequestId is the API Gateway request id of the test requests.
+const TestRequestId = "c6af9ac6-7b61-11e6-9a41-93e8deadbeef"
+
+func GetTestAPIGatewayProxyRequest(method, path string, body []byte) events.APIGatewayProxyRequest {
+	return events.APIGatewayProxyRequest{
+		HTTPMethod:     method,
+		Path:           path,
+		Headers:        map[string]string{"Content-Type": "application/json"},
+		Body:           string(body),
+		RequestContext: events.APIGatewayProxyRequestContext{RequestID: TestRequestId},
+	}
+}
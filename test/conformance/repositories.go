@@ -0,0 +1,773 @@
+package conformance
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"tariff-calculation-service/internal/dberrors"
+	"tariff-calculation-service/internal/fxrate"
+	"tariff-calculation-service/internal/interfaces"
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/internal/pagination"
+	"tariff-calculation-service/internal/tariffquery"
+	"tariff-calculation-service/internal/versioning"
+	"tariff-calculation-service/pkg/constants"
+	"tariff-calculation-service/pkg/enums"
+	"tariff-calculation-service/test/data"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// Repositories are the repositories of one storage backend.
+type Repositories struct {
+	Tariffs   interfaces.TariffRepository
+	Contracts interfaces.ContractRepository
+	Providers interfaces.ProviderRepository
+	TaxRules  interfaces.TaxRuleRepository
+	Settings  interfaces.SettingsRepository
+	FxRates   interfaces.FxRateRepository
+	Audit     interfaces.AuditRepository
+}
+
+// RunRepositoryTests runs the tests every storage backend has to pass. Each run uses new partitions, so
+// it can run against a persistent database repeatedly.
+func RunRepositoryTests(t *testing.T, repos Repositories) {
+	t.Run("Tariffs", func(t *testing.T) { testTariffs(t, repos.Tariffs) })
+	t.Run("TariffVersions", func(t *testing.T) { testTariffVersions(t, repos.Tariffs) })
+	t.Run("Contracts", func(t *testing.T) { testContracts(t, repos) })
+	t.Run("Providers", func(t *testing.T) { testProviders(t, repos.Providers) })
+	t.Run("TaxRules", func(t *testing.T) { testTaxRules(t, repos.TaxRules) })
+	t.Run("Settings", func(t *testing.T) { testSettings(t, repos.Settings) })
+	t.Run("FxRates", func(t *testing.T) { testFxRates(t, repos.FxRates) })
+	t.Run("Audit", func(t *testing.T) { testAudit(t, repos.Audit) })
+	t.Run("AuditedWrites", func(t *testing.T) { testAuditedWrites(t, repos) })
+	t.Run("TariffPages", func(t *testing.T) {
+		tariffs := make([]models.Tariff, 5)
+		for i := range tariffs {
+			tariffs[i] = data.Tariff
+			tariffs[i].Id = uuid.NewString()
+		}
+		testPages(t, func(partitionId string, tariff models.Tariff) error {
+			_, err := repos.Tariffs.CreateTariff(partitionId, tariff)
+			return err
+		}, func(partitionId string, pageRequest models.PageRequest) (*models.Page[models.Tariff], error) {
+			return repos.Tariffs.GetTariffsPage(partitionId, models.TariffFilter{}, pageRequest)
+		}, tariffs)
+	})
+	t.Run("TariffFilters", func(t *testing.T) { testTariffFilters(t, repos.Tariffs) })
+	t.Run("ContractPages", func(t *testing.T) {
+		contracts := make([]models.Contract, 5)
+		for i := range contracts {
+			contracts[i] = data.Contract
+			contracts[i].Id = uuid.NewString()
+		}
+		testPages(t, func(partitionId string, contract models.Contract) error {
+			createReferences(t, repos, partitionId, contract)
+			_, err := repos.Contracts.CreateContract(partitionId, contract)
+			return err
+		}, repos.Contracts.GetContractsPage, contracts)
+	})
+	t.Run("ContractReferences", func(t *testing.T) { testContractReferences(t, repos) })
+	t.Run("ReferenceChecks", func(t *testing.T) { testReferenceChecks(t, repos) })
+	t.Run("DeleteCascades", func(t *testing.T) { testDeleteCascades(t, repos) })
+	t.Run("ContractsByTariffPages", func(t *testing.T) {
+		tariffId := uuid.NewString()
+		contracts := make([]models.Contract, 5)
+		for i := range contracts {
+			contracts[i] = data.Contract
+			contracts[i].Id = uuid.NewString()
+			contracts[i].Tariffs = []string{tariffId}
+		}
+		testPages(t, func(partitionId string, contract models.Contract) error {
+			createReferences(t, repos, partitionId, contract)
+			_, err := repos.Contracts.CreateContract(partitionId, contract)
+			return err
+		}, func(partitionId string, pageRequest models.PageRequest) (*models.Page[models.Contract], error) {
+			return repos.Contracts.GetContractsByTariff(partitionId, tariffId, pageRequest)
+		}, contracts)
+	})
+	t.Run("ProviderPages", func(t *testing.T) {
+		providers := make([]models.Provider, 5)
+		for i := range providers {
+			providers[i] = data.Provider
+			providers[i].Id = uuid.NewString()
+		}
+		testPages(t, func(partitionId string, provider models.Provider) error {
+			_, err := repos.Providers.CreateProvider(partitionId, provider)
+			return err
+		}, repos.Providers.GetProvidersPage, providers)
+	})
+	t.Run("AuditPages", func(t *testing.T) {
+		records := make([]models.AuditRecord, 5)
+		for i := range records {
+			records[i] = data.AuditRecord
+			records[i].Id = uuid.NewString()
+		}
+		testPages(t, repos.Audit.AppendAuditRecord, func(partitionId string, pageRequest models.PageRequest) (*models.Page[models.AuditRecord], error) {
+			return repos.Audit.GetAuditRecordsPage(partitionId, models.AuditFilter{}, pageRequest)
+		}, records)
+	})
+}
+
+func testTariffFilters(t *testing.T, repo interfaces.TariffRepository) {
+	// arrange
+	partitionId := uuid.NewString()
+	newTariff := func(name string, tariffType enums.TariffType, currency, validFrom, validTo string) models.Tariff {
+		tariff := data.Tariff
+		tariff.Id, tariff.Name, tariff.TariffType, tariff.Currency = uuid.NewString(), name, tariffType, currency
+		tariff.ValidFrom, tariff.ValidTo = validFrom, validTo
+		return tariff
+	}
+	gas := newTariff("Gas Basic", enums.Gas, "GBP", "2021-01-01T00:00:00Z", "2022-01-01T00:00:00Z")
+	gasPlus := newTariff("Gas Plus", enums.Gas, "EUR", "2022-01-01T00:00:00+01:00", "2023-01-01T00:00:00+01:00")
+	power := newTariff("Power", enums.Electricity, "GBP", "2020-06-01T00:00:00Z", "2021-06-01T00:00:00Z")
+	for _, tariff := range []models.Tariff{gasPlus, power, gas} {
+		_, err := repo.CreateTariff(partitionId, tariff)
+		assert.Nil(t, err)
+	}
+	tariffType := enums.Gas
+	electricity := enums.Electricity
+
+	testcases := []struct {
+		name     string
+		filter   models.TariffFilter
+		expected []models.Tariff
+	}{
+		{"Tariff Type", models.TariffFilter{TariffType: &tariffType}, []models.Tariff{gas, gasPlus}},
+		{"Tariff Type Electricity", models.TariffFilter{TariffType: &electricity}, []models.Tariff{power}},
+		{"Currency", models.TariffFilter{Currency: "GBP"}, []models.Tariff{gas, power}},
+		{"Name Prefix", models.TariffFilter{Name: "Gas"}, []models.Tariff{gas, gasPlus}},
+		{"Valid At", models.TariffFilter{ValidAt: "2021-03-01T00:00:00Z"}, []models.Tariff{gas, power}},
+		{"Valid At End Of Validity", models.TariffFilter{ValidAt: "2021-06-01T00:00:00Z"}, []models.Tariff{gas}},
+		{"Valid At With Offset", models.TariffFilter{ValidAt: "2021-12-31T23:30:00Z"}, []models.Tariff{gas, gasPlus}},
+		{"Valid From", models.TariffFilter{ValidFrom: "2021-07-01T00:00:00Z"}, []models.Tariff{gas, gasPlus}},
+		{"Valid To", models.TariffFilter{ValidTo: "2021-01-01T00:00:00Z"}, []models.Tariff{power}},
+		{"Valid Range", models.TariffFilter{ValidFrom: "2021-02-01T00:00:00Z", ValidTo: "2021-03-01T00:00:00Z"}, []models.Tariff{gas, power}},
+		{"Combined", models.TariffFilter{TariffType: &tariffType, Currency: "EUR", Name: "Gas"}, []models.Tariff{gasPlus}},
+		{"No Match", models.TariffFilter{Name: "Water"}, []models.Tariff{}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// act
+			page, err := repo.GetTariffsPage(partitionId, tc.filter, models.PageRequest{})
+
+			// assert
+			assert.Nil(t, err)
+			assert.ElementsMatch(t, tc.expected, page.Items)
+		})
+	}
+
+	t.Run("Sort By Name", func(t *testing.T) {
+		// act
+		firstPage, firstErr := repo.GetTariffsPage(partitionId, models.TariffFilter{Sort: tariffquery.SortByName}, models.PageRequest{Limit: 2})
+		lastPage, lastErr := repo.GetTariffsPage(partitionId, models.TariffFilter{Sort: tariffquery.SortByName}, models.PageRequest{Limit: 2, Cursor: firstPage.NextCursor})
+
+		// assert
+		assert.Nil(t, firstErr)
+		assert.Nil(t, lastErr)
+		assert.Equal(t, []models.Tariff{gas, gasPlus}, firstPage.Items)
+		assert.Equal(t, []models.Tariff{power}, lastPage.Items)
+	})
+
+	t.Run("Sort By Valid From", func(t *testing.T) {
+		// act
+		page, err := repo.GetTariffsPage(partitionId, models.TariffFilter{Sort: tariffquery.SortByValidFrom}, models.PageRequest{})
+
+		// assert
+		assert.Nil(t, err)
+		assert.Equal(t, []models.Tariff{power, gas, gasPlus}, page.Items)
+	})
+}
+
+// testPages creates the entities and reads them back two at a time. Backends may return a cursor for an
+// empty last page, so the test only requires that the pages together hold every entity exactly once.
+func testPages[T any](t *testing.T, create func(partitionId string, entity T) error,
+	getPage func(partitionId string, pageRequest models.PageRequest) (*models.Page[T], error), entities []T) {
+	// arrange
+	partitionId := uuid.NewString()
+	for _, entity := range entities {
+		assert.Nil(t, create(partitionId, entity))
+	}
+
+	// act
+	pageRequest := models.PageRequest{Limit: 2}
+	items, pageCount := []T{}, 0
+	for pageCount <= len(entities) {
+		page, err := getPage(partitionId, pageRequest)
+		assert.Nil(t, err)
+		assert.LessOrEqual(t, len(page.Items), pageRequest.Limit)
+		items = append(items, page.Items...)
+		pageCount++
+		if page.NextCursor == "" {
+			break
+		}
+		pageRequest.Cursor = page.NextCursor
+	}
+	_, invalidCursorErr := getPage(partitionId, models.PageRequest{Cursor: "invalid"})
+
+	// assert
+	assert.ElementsMatch(t, entities, items)
+	assert.GreaterOrEqual(t, pageCount, 3)
+	assert.ErrorIs(t, invalidCursorErr, pagination.ErrInvalidCursor)
+}
+
+func testTariffs(t *testing.T, repo interfaces.TariffRepository) {
+	// arrange
+	partitionId, otherPartitionId := uuid.NewString(), uuid.NewString()
+	updatedTariff := data.Tariff
+	updatedTariff.Name = "Updated"
+
+	// act
+	createdTariff, createErr := repo.CreateTariff(partitionId, data.Tariff)
+	_, duplicateErr := repo.CreateTariff(partitionId, updatedTariff)
+	_, _ = repo.CreateTariff(partitionId, data.TariffGas)
+	tariff, getErr := repo.GetTariff(partitionId, data.Tariff.Id)
+	_, version, getVersionErr := repo.GetTariffWithVersion(partitionId, data.Tariff.Id)
+	tariffs, getAllErr := repo.GetTariffs(partitionId)
+	otherTariffs, _ := repo.GetTariffs(otherPartitionId)
+	_, otherPartitionErr := repo.GetTariff(otherPartitionId, data.Tariff.Id)
+	otherPartitionDeleteErr := repo.DeleteTariff(otherPartitionId, data.Tariff.Id, versioning.AnyVersion, nil)
+	updatedVersion, updateErr := repo.UpdateTariff(partitionId, updatedTariff, version)
+	_, staleUpdateErr := repo.UpdateTariff(partitionId, data.Tariff, version)
+	updated, currentVersion, _ := repo.GetTariffWithVersion(partitionId, data.Tariff.Id)
+	staleDeleteErr := repo.DeleteTariff(partitionId, data.Tariff.Id, version, nil)
+	deleteErr := repo.DeleteTariff(partitionId, data.Tariff.Id, updatedVersion, nil)
+	_, notFoundErr := repo.GetTariff(partitionId, data.Tariff.Id)
+	_, deletedUpdateErr := repo.UpdateTariff(partitionId, updatedTariff, versioning.AnyVersion)
+
+	// assert
+	assert.Nil(t, createErr)
+	assert.Equal(t, &data.Tariff, createdTariff)
+	assert.ErrorIs(t, duplicateErr, dberrors.ErrConflict)
+	assert.Nil(t, getErr)
+	assert.Equal(t, &data.Tariff, tariff)
+	assert.Nil(t, getVersionErr)
+	assert.Equal(t, versioning.InitialVersion, version)
+	assert.Nil(t, getAllErr)
+	assert.ElementsMatch(t, []models.Tariff{data.Tariff, data.TariffGas}, *tariffs)
+	assert.Empty(t, *otherTariffs)
+	assert.EqualError(t, otherPartitionErr, constants.ResourceNotFound)
+	assert.ErrorIs(t, otherPartitionDeleteErr, dberrors.ErrNotFound)
+	assert.Nil(t, updateErr)
+	assert.Equal(t, versioning.InitialVersion+1, updatedVersion)
+	assert.ErrorIs(t, staleUpdateErr, versioning.ErrVersionMismatch)
+	assert.Equal(t, &updatedTariff, updated)
+	assert.Equal(t, updatedVersion, currentVersion)
+	assert.ErrorIs(t, staleDeleteErr, versioning.ErrVersionMismatch)
+	assert.Nil(t, deleteErr)
+	assert.EqualError(t, notFoundErr, constants.ResourceNotFound)
+	assert.ErrorIs(t, deletedUpdateErr, dberrors.ErrNotFound)
+}
+
+func testTariffVersions(t *testing.T, repo interfaces.TariffRepository) {
+	// arrange
+	partitionId := uuid.NewString()
+	tariff := data.Tariff
+	tariff.Id = uuid.NewString()
+	updatedTariff := tariff
+	updatedTariff.Name = "Updated"
+	recreatedTariff := tariff
+	recreatedTariff.Name = "Recreated"
+	before := time.Now().UTC().Truncate(time.Second)
+
+	// act
+	_, createErr := repo.CreateTariff(partitionId, tariff)
+	updatedVersion, updateErr := repo.UpdateTariff(partitionId, updatedTariff, versioning.InitialVersion)
+	versions, getErr := repo.GetTariffVersions(partitionId, tariff.Id)
+	tariffs, _ := repo.GetTariffs(partitionId)
+	page, _ := repo.GetTariffsPage(partitionId, models.TariffFilter{}, models.PageRequest{})
+	_, otherPartitionErr := repo.GetTariffVersions(uuid.NewString(), tariff.Id)
+	deleteErr := repo.DeleteTariff(partitionId, tariff.Id, updatedVersion, nil)
+	_, deletedErr := repo.GetTariffVersions(partitionId, tariff.Id)
+	_, recreateErr := repo.CreateTariff(partitionId, recreatedTariff)
+	recreatedVersions, _ := repo.GetTariffVersions(partitionId, tariff.Id)
+	_, reupdateErr := repo.UpdateTariff(partitionId, updatedTariff, versioning.InitialVersion)
+	reupdatedVersions, _ := repo.GetTariffVersions(partitionId, tariff.Id)
+	allVersions, allErr := repo.GetAllTariffVersions(partitionId)
+
+	// assert
+	assert.Nil(t, createErr)
+	assert.Nil(t, updateErr)
+	assert.Nil(t, getErr)
+	assert.Len(t, *versions, 2)
+	for idx, version := range *versions {
+		assert.Equal(t, versioning.InitialVersion+idx, version.Version)
+		effectiveFrom, err := time.Parse(time.RFC3339, version.EffectiveFrom)
+		assert.Nil(t, err)
+		assert.False(t, effectiveFrom.Before(before))
+	}
+	assert.Equal(t, tariff, (*versions)[0].Tariff)
+	assert.Equal(t, updatedTariff, (*versions)[1].Tariff)
+	assert.Equal(t, []models.Tariff{updatedTariff}, *tariffs)
+	assert.Equal(t, []models.Tariff{updatedTariff}, page.Items)
+	assert.ErrorIs(t, otherPartitionErr, dberrors.ErrNotFound)
+	assert.Nil(t, deleteErr)
+	assert.ErrorIs(t, deletedErr, dberrors.ErrNotFound)
+	assert.Nil(t, recreateErr)
+	// the versions of the deleted tariff are kept, but are not part of the history of the recreated one
+	assert.Len(t, *recreatedVersions, 1)
+	assert.Equal(t, recreatedTariff, (*recreatedVersions)[0].Tariff)
+	assert.Nil(t, reupdateErr)
+	assert.Len(t, *reupdatedVersions, 2)
+	assert.Equal(t, recreatedTariff, (*reupdatedVersions)[0].Tariff)
+	assert.Equal(t, updatedTariff, (*reupdatedVersions)[1].Tariff)
+	assert.Nil(t, allErr)
+	assert.Equal(t, &[][]models.TariffVersion{*reupdatedVersions}, allVersions)
+}
+
+// createReferences creates the provider and the tariffs that the contract refers to, unless they exist.
+func createReferences(t *testing.T, repos Repositories, partitionId string, contract models.Contract) {
+	provider := data.Provider
+	provider.Id = contract.Provider
+	if _, err := repos.Providers.CreateProvider(partitionId, provider); !errors.Is(err, dberrors.ErrConflict) {
+		assert.Nil(t, err)
+	}
+	for _, tariffId := range contract.Tariffs {
+		tariff := data.Tariff
+		tariff.Id = tariffId
+		if _, err := repos.Tariffs.CreateTariff(partitionId, tariff); !errors.Is(err, dberrors.ErrConflict) {
+			assert.Nil(t, err)
+		}
+	}
+}
+
+func testContracts(t *testing.T, repos Repositories) {
+	// arrange
+	repo := repos.Contracts
+	partitionId, otherPartitionId := uuid.NewString(), uuid.NewString()
+	updatedContract := data.ContractWithTariff
+	updatedContract.Name = "Updated"
+	createReferences(t, repos, partitionId, data.ContractWithTariff)
+
+	// act
+	createdContract, createErr := repo.CreateContract(partitionId, data.ContractWithTariff)
+	_, duplicateErr := repo.CreateContract(partitionId, updatedContract)
+	contract, getErr := repo.GetContract(partitionId, data.ContractWithTariff.Id)
+	_, version, getVersionErr := repo.GetContractWithVersion(partitionId, data.ContractWithTariff.Id)
+	contracts, getAllErr := repo.GetContracts(partitionId)
+	_, otherPartitionErr := repo.GetContract(otherPartitionId, data.ContractWithTariff.Id)
+	_, otherPartitionUpdateErr := repo.UpdateContract(otherPartitionId, updatedContract, versioning.AnyVersion)
+	updatedVersion, updateErr := repo.UpdateContract(partitionId, updatedContract, version)
+	_, staleUpdateErr := repo.UpdateContract(partitionId, data.ContractWithTariff, version)
+	updated, currentVersion, _ := repo.GetContractWithVersion(partitionId, data.ContractWithTariff.Id)
+	staleDeleteErr := repo.DeleteContract(partitionId, data.ContractWithTariff.Id, version)
+	deleteErr := repo.DeleteContract(partitionId, data.ContractWithTariff.Id, updatedVersion)
+	_, notFoundErr := repo.GetContract(partitionId, data.ContractWithTariff.Id)
+	_, deletedUpdateErr := repo.UpdateContract(partitionId, updatedContract, versioning.AnyVersion)
+	deletedDeleteErr := repo.DeleteContract(partitionId, data.ContractWithTariff.Id, versioning.AnyVersion)
+
+	// assert
+	assert.Nil(t, createErr)
+	assert.Equal(t, &data.ContractWithTariff, createdContract)
+	assert.ErrorIs(t, duplicateErr, dberrors.ErrConflict)
+	assert.Nil(t, getErr)
+	assert.Equal(t, &data.ContractWithTariff, contract)
+	assert.Nil(t, getVersionErr)
+	assert.Equal(t, versioning.InitialVersion, version)
+	assert.Nil(t, getAllErr)
+	assert.Equal(t, &[]models.Contract{data.ContractWithTariff}, contracts)
+	assert.EqualError(t, otherPartitionErr, constants.ResourceNotFound)
+	assert.ErrorIs(t, otherPartitionUpdateErr, dberrors.ErrNotFound)
+	assert.Nil(t, updateErr)
+	assert.Equal(t, versioning.InitialVersion+1, updatedVersion)
+	assert.ErrorIs(t, staleUpdateErr, versioning.ErrVersionMismatch)
+	assert.Equal(t, &updatedContract, updated)
+	assert.Equal(t, updatedVersion, currentVersion)
+	assert.ErrorIs(t, staleDeleteErr, versioning.ErrVersionMismatch)
+	assert.Nil(t, deleteErr)
+	assert.EqualError(t, notFoundErr, constants.ResourceNotFound)
+	assert.ErrorIs(t, deletedUpdateErr, dberrors.ErrNotFound)
+	assert.ErrorIs(t, deletedDeleteErr, dberrors.ErrNotFound)
+}
+
+func testContractReferences(t *testing.T, repos Repositories) {
+	// arrange
+	repo := repos.Contracts
+	partitionId := uuid.NewString()
+	providerId, otherProviderId := uuid.NewString(), uuid.NewString()
+	tariffId, sharedTariffId, otherTariffId := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	contract := models.Contract{Id: uuid.NewString(), Name: "Contract", Provider: providerId, Tariffs: []string{tariffId, sharedTariffId}}
+	sharingContract := models.Contract{Id: uuid.NewString(), Name: "Sharing", Provider: providerId, Tariffs: []string{sharedTariffId}}
+	otherContract := models.Contract{Id: uuid.NewString(), Name: "Other", Provider: otherProviderId, Tariffs: []string{}}
+	updatedContract := contract
+	updatedContract.Provider = otherProviderId
+	updatedContract.Tariffs = []string{otherTariffId}
+	contracts := func(page *models.Page[models.Contract], err error) []models.Contract {
+		assert.Nil(t, err)
+		return page.Items
+	}
+	for _, referring := range []models.Contract{contract, sharingContract, otherContract, updatedContract} {
+		createReferences(t, repos, partitionId, referring)
+	}
+
+	// act
+	for _, created := range []models.Contract{contract, sharingContract, otherContract} {
+		_, err := repo.CreateContract(partitionId, created)
+		assert.Nil(t, err)
+	}
+	providerContracts := contracts(repo.GetContractsByProvider(partitionId, providerId, models.PageRequest{}))
+	tariffContracts := contracts(repo.GetContractsByTariff(partitionId, tariffId, models.PageRequest{}))
+	sharedTariffContracts := contracts(repo.GetContractsByTariff(partitionId, sharedTariffId, models.PageRequest{}))
+	otherPartitionContracts := contracts(repo.GetContractsByProvider(uuid.NewString(), providerId, models.PageRequest{}))
+	_, updateErr := repo.UpdateContract(partitionId, updatedContract, versioning.AnyVersion)
+	updatedProviderContracts := contracts(repo.GetContractsByProvider(partitionId, providerId, models.PageRequest{}))
+	updatedOtherProviderContracts := contracts(repo.GetContractsByProvider(partitionId, otherProviderId, models.PageRequest{}))
+	updatedTariffContracts := contracts(repo.GetContractsByTariff(partitionId, tariffId, models.PageRequest{}))
+	updatedOtherTariffContracts := contracts(repo.GetContractsByTariff(partitionId, otherTariffId, models.PageRequest{}))
+	deleteErr := repo.DeleteContract(partitionId, sharingContract.Id, versioning.AnyVersion)
+	deletedProviderContracts := contracts(repo.GetContractsByProvider(partitionId, providerId, models.PageRequest{}))
+	deletedSharedTariffContracts := contracts(repo.GetContractsByTariff(partitionId, sharedTariffId, models.PageRequest{}))
+	allContracts, _ := repo.GetContracts(partitionId)
+
+	// assert
+	assert.ElementsMatch(t, []models.Contract{contract, sharingContract}, providerContracts)
+	assert.Equal(t, []models.Contract{contract}, tariffContracts)
+	assert.ElementsMatch(t, []models.Contract{contract, sharingContract}, sharedTariffContracts)
+	assert.Empty(t, otherPartitionContracts)
+	assert.Nil(t, updateErr)
+	assert.Equal(t, []models.Contract{sharingContract}, updatedProviderContracts)
+	assert.ElementsMatch(t, []models.Contract{updatedContract, otherContract}, updatedOtherProviderContracts)
+	assert.Empty(t, updatedTariffContracts)
+	assert.Equal(t, []models.Contract{updatedContract}, updatedOtherTariffContracts)
+	assert.Nil(t, deleteErr)
+	assert.Empty(t, deletedProviderContracts)
+	assert.Empty(t, deletedSharedTariffContracts)
+	assert.ElementsMatch(t, []models.Contract{updatedContract, otherContract}, *allContracts)
+}
+
+func testReferenceChecks(t *testing.T, repos Repositories) {
+	// arrange
+	partitionId := uuid.NewString()
+	contract := models.Contract{Id: uuid.NewString(), Name: "Contract", Provider: uuid.NewString(), Tariffs: []string{uuid.NewString()}}
+	withMissingProvider := contract
+	withMissingProvider.Id = uuid.NewString()
+	withMissingProvider.Provider = uuid.NewString()
+	withMissingTariff := contract
+	withMissingTariff.Tariffs = []string{contract.Tariffs[0], uuid.NewString()}
+	createReferences(t, repos, partitionId, contract)
+	_, _ = repos.Contracts.CreateContract(partitionId, contract)
+
+	// act
+	_, createErr := repos.Contracts.CreateContract(partitionId, withMissingProvider)
+	_, createdErr := repos.Contracts.GetContract(partitionId, withMissingProvider.Id)
+	_, updateErr := repos.Contracts.UpdateContract(partitionId, withMissingTariff, versioning.AnyVersion)
+	stored, storedVersion, _ := repos.Contracts.GetContractWithVersion(partitionId, contract.Id)
+	deleteProviderErr := repos.Providers.DeleteProvider(partitionId, contract.Provider, versioning.AnyVersion, nil)
+	_, providerErr := repos.Providers.GetProvider(partitionId, contract.Provider)
+	deleteTariffErr := repos.Tariffs.DeleteTariff(partitionId, contract.Tariffs[0], versioning.AnyVersion, nil)
+	_, tariffErr := repos.Tariffs.GetTariff(partitionId, contract.Tariffs[0])
+
+	// assert
+	assert.ErrorIs(t, createErr, dberrors.ErrConflict)
+	assert.EqualError(t, createdErr, constants.ResourceNotFound)
+	assert.ErrorIs(t, updateErr, dberrors.ErrConflict)
+	assert.Equal(t, &contract, stored)
+	assert.Equal(t, versioning.InitialVersion, storedVersion)
+	assert.ErrorIs(t, deleteProviderErr, dberrors.ErrConflict)
+	assert.Nil(t, providerErr)
+	assert.ErrorIs(t, deleteTariffErr, dberrors.ErrConflict)
+	assert.Nil(t, tariffErr)
+}
+
+func testDeleteCascades(t *testing.T, repos Repositories) {
+	// arrange
+	partitionId := uuid.NewString()
+	provider := data.Provider
+	provider.Id = uuid.NewString()
+	tariff := data.Tariff
+	tariff.Id = uuid.NewString()
+	otherTariffId := uuid.NewString()
+	contract := models.Contract{Id: uuid.NewString(), Name: "Contract", Provider: provider.Id, Tariffs: []string{tariff.Id}}
+	otherContract := models.Contract{Id: uuid.NewString(), Name: "Other", Provider: uuid.NewString(), Tariffs: []string{tariff.Id, otherTariffId}}
+	staleContract := contract
+	staleContract.Name = "Stale"
+	withoutTariff, otherWithoutTariff := contract, otherContract
+	withoutTariff.Tariffs = []string{}
+	otherWithoutTariff.Tariffs = []string{otherTariffId}
+	_, _ = repos.Providers.CreateProvider(partitionId, provider)
+	_, _ = repos.Tariffs.CreateTariff(partitionId, tariff)
+	createReferences(t, repos, partitionId, otherContract)
+	_, _ = repos.Contracts.CreateContract(partitionId, contract)
+	_, _ = repos.Contracts.CreateContract(partitionId, otherContract)
+
+	// act
+	staleDeleteErr := repos.Providers.DeleteProvider(partitionId, provider.Id, versioning.InitialVersion, []models.ContractChange{{Before: staleContract}})
+	_, staleProviderErr := repos.Providers.GetProvider(partitionId, provider.Id)
+	staleContractAfter, _ := repos.Contracts.GetContract(partitionId, contract.Id)
+	deleteTariffErr := repos.Tariffs.DeleteTariff(partitionId, tariff.Id, versioning.InitialVersion, []models.ContractChange{
+		{Before: contract, After: &withoutTariff},
+		{Before: otherContract, After: &otherWithoutTariff},
+	})
+	_, deletedTariffErr := repos.Tariffs.GetTariff(partitionId, tariff.Id)
+	tariffContracts, _ := repos.Contracts.GetContractsByTariff(partitionId, tariff.Id, models.PageRequest{})
+	updatedOther, updatedOtherVersion, _ := repos.Contracts.GetContractWithVersion(partitionId, otherContract.Id)
+	deleteProviderErr := repos.Providers.DeleteProvider(partitionId, provider.Id, versioning.InitialVersion, []models.ContractChange{{Before: withoutTariff}})
+	_, deletedProviderErr := repos.Providers.GetProvider(partitionId, provider.Id)
+	_, deletedContractErr := repos.Contracts.GetContract(partitionId, contract.Id)
+	providerContracts, _ := repos.Contracts.GetContractsByProvider(partitionId, provider.Id, models.PageRequest{})
+
+	// assert
+	assert.ErrorIs(t, staleDeleteErr, dberrors.ErrConflict)
+	assert.Nil(t, staleProviderErr)
+	assert.Equal(t, &contract, staleContractAfter)
+	assert.Nil(t, deleteTariffErr)
+	assert.ErrorIs(t, deletedTariffErr, dberrors.ErrNotFound)
+	assert.Empty(t, tariffContracts.Items)
+	assert.Equal(t, &otherWithoutTariff, updatedOther)
+	assert.Equal(t, versioning.InitialVersion+1, updatedOtherVersion)
+	assert.Nil(t, deleteProviderErr)
+	assert.ErrorIs(t, deletedProviderErr, dberrors.ErrNotFound)
+	assert.ErrorIs(t, deletedContractErr, dberrors.ErrNotFound)
+	assert.Empty(t, providerContracts.Items)
+}
+
+func testProviders(t *testing.T, repo interfaces.ProviderRepository) {
+	// arrange
+	partitionId, otherPartitionId := uuid.NewString(), uuid.NewString()
+	updatedProvider := data.Provider
+	updatedProvider.Name = "Updated"
+
+	// act
+	createdProvider, createErr := repo.CreateProvider(partitionId, data.Provider)
+	_, duplicateErr := repo.CreateProvider(partitionId, updatedProvider)
+	provider, getErr := repo.GetProvider(partitionId, data.Provider.Id)
+	_, version, getVersionErr := repo.GetProviderWithVersion(partitionId, data.Provider.Id)
+	providers, getAllErr := repo.GetProviders(partitionId)
+	_, otherPartitionErr := repo.GetProvider(otherPartitionId, data.Provider.Id)
+	_, otherPartitionUpdateErr := repo.UpdateProvider(otherPartitionId, updatedProvider, versioning.AnyVersion)
+	updatedVersion, updateErr := repo.UpdateProvider(partitionId, updatedProvider, version)
+	_, staleUpdateErr := repo.UpdateProvider(partitionId, data.Provider, version)
+	updated, currentVersion, _ := repo.GetProviderWithVersion(partitionId, data.Provider.Id)
+	staleDeleteErr := repo.DeleteProvider(partitionId, data.Provider.Id, version, nil)
+	deleteErr := repo.DeleteProvider(partitionId, data.Provider.Id, updatedVersion, nil)
+	_, notFoundErr := repo.GetProvider(partitionId, data.Provider.Id)
+	_, deletedUpdateErr := repo.UpdateProvider(partitionId, updatedProvider, versioning.AnyVersion)
+	deletedDeleteErr := repo.DeleteProvider(partitionId, data.Provider.Id, versioning.AnyVersion, nil)
+
+	// assert
+	assert.Nil(t, createErr)
+	assert.Equal(t, &data.Provider, createdProvider)
+	assert.ErrorIs(t, duplicateErr, dberrors.ErrConflict)
+	assert.Nil(t, getErr)
+	assert.Equal(t, &data.Provider, provider)
+	assert.Nil(t, getVersionErr)
+	assert.Equal(t, versioning.InitialVersion, version)
+	assert.Nil(t, getAllErr)
+	assert.Equal(t, &[]models.Provider{data.Provider}, providers)
+	assert.EqualError(t, otherPartitionErr, constants.ResourceNotFound)
+	assert.ErrorIs(t, otherPartitionUpdateErr, dberrors.ErrNotFound)
+	assert.Nil(t, updateErr)
+	assert.Equal(t, versioning.InitialVersion+1, updatedVersion)
+	assert.ErrorIs(t, staleUpdateErr, versioning.ErrVersionMismatch)
+	assert.Equal(t, &updatedProvider, updated)
+	assert.Equal(t, updatedVersion, currentVersion)
+	assert.ErrorIs(t, staleDeleteErr, versioning.ErrVersionMismatch)
+	assert.Nil(t, deleteErr)
+	assert.EqualError(t, notFoundErr, constants.ResourceNotFound)
+	assert.ErrorIs(t, deletedUpdateErr, dberrors.ErrNotFound)
+	assert.ErrorIs(t, deletedDeleteErr, dberrors.ErrNotFound)
+}
+
+func testTaxRules(t *testing.T, repo interfaces.TaxRuleRepository) {
+	// arrange
+	partitionId, otherPartitionId := uuid.NewString(), uuid.NewString()
+	updatedTaxRule := data.TaxRule
+	updatedTaxRule.Name = "Updated"
+
+	// act
+	createdTaxRule, createErr := repo.CreateTaxRule(partitionId, data.TaxRule)
+	_, duplicateErr := repo.CreateTaxRule(partitionId, updatedTaxRule)
+	taxRule, getErr := repo.GetTaxRule(partitionId, data.TaxRule.Id)
+	taxRules, getAllErr := repo.GetTaxRules(partitionId)
+	_, otherPartitionErr := repo.GetTaxRule(otherPartitionId, data.TaxRule.Id)
+	otherPartitionUpdateErr := repo.UpdateTaxRule(otherPartitionId, updatedTaxRule)
+	updateErr := repo.UpdateTaxRule(partitionId, updatedTaxRule)
+	updated, _ := repo.GetTaxRule(partitionId, data.TaxRule.Id)
+	deleteErr := repo.DeleteTaxRule(partitionId, data.TaxRule.Id)
+	_, notFoundErr := repo.GetTaxRule(partitionId, data.TaxRule.Id)
+	deletedUpdateErr := repo.UpdateTaxRule(partitionId, updatedTaxRule)
+	deletedDeleteErr := repo.DeleteTaxRule(partitionId, data.TaxRule.Id)
+
+	// assert
+	assert.Nil(t, createErr)
+	assert.Equal(t, &data.TaxRule, createdTaxRule)
+	assert.ErrorIs(t, duplicateErr, dberrors.ErrConflict)
+	assert.Nil(t, getErr)
+	assert.Equal(t, &data.TaxRule, taxRule)
+	assert.Nil(t, getAllErr)
+	assert.Equal(t, &[]models.TaxRule{data.TaxRule}, taxRules)
+	assert.EqualError(t, otherPartitionErr, constants.ResourceNotFound)
+	assert.ErrorIs(t, otherPartitionUpdateErr, dberrors.ErrNotFound)
+	assert.Nil(t, updateErr)
+	assert.Equal(t, &updatedTaxRule, updated)
+	assert.Nil(t, deleteErr)
+	assert.EqualError(t, notFoundErr, constants.ResourceNotFound)
+	assert.ErrorIs(t, deletedUpdateErr, dberrors.ErrNotFound)
+	assert.ErrorIs(t, deletedDeleteErr, dberrors.ErrNotFound)
+}
+
+func testSettings(t *testing.T, repo interfaces.SettingsRepository) {
+	// arrange
+	partitionId, otherPartitionId := uuid.NewString(), uuid.NewString()
+
+	// act
+	defaultSettings, defaultErr := repo.GetSettings(partitionId)
+	putErr := repo.PutSettings(partitionId, data.Settings)
+	settings, getErr := repo.GetSettings(partitionId)
+	otherSettings, _ := repo.GetSettings(otherPartitionId)
+
+	// assert
+	assert.Nil(t, defaultErr)
+	assert.Equal(t, &models.Settings{}, defaultSettings)
+	assert.Nil(t, putErr)
+	assert.Nil(t, getErr)
+	assert.Equal(t, &data.Settings, settings)
+	assert.Equal(t, &models.Settings{}, otherSettings)
+}
+
+func testFxRates(t *testing.T, repo interfaces.FxRateRepository) {
+	// arrange
+	partitionId, otherPartitionId := uuid.NewString(), uuid.NewString()
+	date := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+	nextDay := date.AddDate(0, 0, 1)
+
+	// act
+	_, putErr := repo.PutFxRate(partitionId, data.FxRateNextDay)
+	_, _ = repo.PutFxRate(partitionId, data.FxRate)
+	fxRates, getAllErr := repo.GetFxRates(partitionId)
+	fxRate, getErr := repo.GetFxRate(partitionId, data.FxRate.BaseCurrency, data.FxRate.QuoteCurrency, date)
+	nextDayRate, _ := repo.GetFxRate(partitionId, data.FxRate.BaseCurrency, data.FxRate.QuoteCurrency, nextDay)
+	inverseRate, inverseErr := repo.GetFxRate(partitionId, data.FxRate.QuoteCurrency, data.FxRate.BaseCurrency, date)
+	_, otherPartitionErr := repo.GetFxRate(otherPartitionId, data.FxRate.BaseCurrency, data.FxRate.QuoteCurrency, date)
+
+	// assert
+	assert.Nil(t, putErr)
+	assert.Nil(t, getAllErr)
+	assert.ElementsMatch(t, []models.FxRate{data.FxRate, data.FxRateNextDay}, *fxRates)
+	assert.Nil(t, getErr)
+	assert.Equal(t, &data.FxRate, fxRate)
+	assert.Equal(t, &data.FxRateNextDay, nextDayRate)
+	assert.Nil(t, inverseErr)
+	assert.Equal(t, data.FxRate.QuoteCurrency, inverseRate.BaseCurrency)
+	assert.ErrorIs(t, otherPartitionErr, fxrate.ErrNoFxRate)
+}
+
+func testAudit(t *testing.T, repo interfaces.AuditRepository) {
+	// arrange
+	partitionId, otherPartitionId := uuid.NewString(), uuid.NewString()
+	earlier := data.AuditRecord
+	earlier.Id = uuid.NewString()
+	earlier.Timestamp = "2021-03-23T08:00:00.000000000Z"
+	earlier.Actor = "other-actor"
+	contractRecord := data.AuditRecord
+	contractRecord.Id = uuid.NewString()
+	contractRecord.Timestamp = "2021-03-25T09:30:00.000000000Z"
+	contractRecord.EntityType = models.AuditEntityContract
+	contractRecord.EntityId = data.TestContractId
+	contractRecord.Operation = models.AuditOperationDelete
+	testcases := []struct {
+		name     string
+		filter   models.AuditFilter
+		expected []models.AuditRecord
+	}{
+		{"No Filter", models.AuditFilter{}, []models.AuditRecord{earlier, data.AuditRecord, contractRecord}},
+		{"Entity", models.AuditFilter{EntityType: models.AuditEntityTariff, EntityId: data.TestTariffId}, []models.AuditRecord{earlier, data.AuditRecord}},
+		{"Actor", models.AuditFilter{Actor: data.TestActor}, []models.AuditRecord{data.AuditRecord, contractRecord}},
+		{"From", models.AuditFilter{From: "2021-03-24T12:04:18Z"}, []models.AuditRecord{data.AuditRecord, contractRecord}},
+		{"To", models.AuditFilter{To: "2021-03-24T12:04:18Z"}, []models.AuditRecord{earlier}},
+		{"Range", data.AuditFilter, []models.AuditRecord{data.AuditRecord}},
+		{"Empty Range", models.AuditFilter{From: "2021-03-24T12:04:19Z", To: "2021-03-25T09:30:00Z"}, []models.AuditRecord{}},
+	}
+
+	// act
+	appendErrs := []error{
+		repo.AppendAuditRecord(partitionId, data.AuditRecord),
+		repo.AppendAuditRecord(partitionId, earlier),
+		repo.AppendAuditRecord(partitionId, contractRecord),
+	}
+	reappendErr := repo.AppendAuditRecord(partitionId, data.AuditRecord)
+	otherPartitionPage, otherPartitionErr := repo.GetAuditRecordsPage(otherPartitionId, models.AuditFilter{}, models.PageRequest{})
+
+	// assert
+	for _, err := range appendErrs {
+		assert.Nil(t, err)
+	}
+	assert.ErrorIs(t, reappendErr, dberrors.ErrConflict)
+	assert.Nil(t, otherPartitionErr)
+	assert.Empty(t, otherPartitionPage.Items)
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			page, err := repo.GetAuditRecordsPage(partitionId, tc.filter, models.PageRequest{})
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, page.Items)
+		})
+	}
+}
+
+func testAuditedWrites(t *testing.T, repos Repositories) {
+	// arrange
+	partitionId := uuid.NewString()
+	provider := data.Provider
+	provider.Id = uuid.NewString()
+	tariff := data.Tariff
+	tariff.Id = uuid.NewString()
+	updatedTariff := tariff
+	updatedTariff.Name = "Updated"
+	contract := models.Contract{Id: uuid.NewString(), Name: "Contract", Provider: provider.Id, Tariffs: []string{tariff.Id}}
+	withoutTariff := contract
+	withoutTariff.Tariffs = []string{}
+	staleContract := contract
+	staleContract.Name = "Stale"
+	seconds := 0
+	record := func(entityType, entityId, operation string) models.AuditRecord {
+		seconds++
+		return models.AuditRecord{
+			Id:          uuid.NewString(),
+			Actor:       data.TestActor,
+			Timestamp:   time.Date(2021, 3, 24, 12, 0, seconds, 0, time.UTC).Format("2006-01-02T15:04:05.000000000Z"),
+			PartitionId: partitionId,
+			EntityType:  entityType,
+			EntityId:    entityId,
+			Operation:   operation,
+			Changes:     data.AuditRecord.Changes,
+		}
+	}
+	createProvider := record(models.AuditEntityProvider, provider.Id, models.AuditOperationCreate)
+	createTariff := record(models.AuditEntityTariff, tariff.Id, models.AuditOperationCreate)
+	createContract := record(models.AuditEntityContract, contract.Id, models.AuditOperationCreate)
+	staleUpdate := record(models.AuditEntityTariff, tariff.Id, models.AuditOperationUpdate)
+	deleteTariff := record(models.AuditEntityTariff, tariff.Id, models.AuditOperationDelete)
+	updateContract := record(models.AuditEntityContract, contract.Id, models.AuditOperationUpdate)
+	staleDelete := record(models.AuditEntityProvider, provider.Id, models.AuditOperationDelete)
+
+	// act
+	_, createProviderErr := repos.Providers.CreateProvider(partitionId, provider, createProvider)
+	_, duplicateErr := repos.Providers.CreateProvider(partitionId, provider, record(models.AuditEntityProvider, provider.Id, models.AuditOperationCreate))
+	_, createTariffErr := repos.Tariffs.CreateTariff(partitionId, tariff, createTariff)
+	_, createContractErr := repos.Contracts.CreateContract(partitionId, contract, createContract)
+	_, staleUpdateErr := repos.Tariffs.UpdateTariff(partitionId, updatedTariff, versioning.InitialVersion+1, staleUpdate)
+	_, reusedRecordErr := repos.Tariffs.UpdateTariff(partitionId, updatedTariff, versioning.InitialVersion, createTariff)
+	unchangedTariff, unchangedVersion, _ := repos.Tariffs.GetTariffWithVersion(partitionId, tariff.Id)
+	deleteTariffErr := repos.Tariffs.DeleteTariff(partitionId, tariff.Id, versioning.InitialVersion,
+		[]models.ContractChange{{Before: contract, After: &withoutTariff}}, deleteTariff, updateContract)
+	staleDeleteErr := repos.Providers.DeleteProvider(partitionId, provider.Id, versioning.InitialVersion,
+		[]models.ContractChange{{Before: staleContract}}, staleDelete)
+	page, getErr := repos.Audit.GetAuditRecordsPage(partitionId, models.AuditFilter{}, models.PageRequest{})
+
+	// assert
+	assert.Nil(t, createProviderErr)
+	assert.ErrorIs(t, duplicateErr, dberrors.ErrConflict)
+	assert.Nil(t, createTariffErr)
+	assert.Nil(t, createContractErr)
+	assert.ErrorIs(t, staleUpdateErr, versioning.ErrVersionMismatch)
+	assert.ErrorIs(t, reusedRecordErr, dberrors.ErrConflict)
+	assert.Equal(t, &tariff, unchangedTariff)
+	assert.Equal(t, versioning.InitialVersion, unchangedVersion)
+	assert.Nil(t, deleteTariffErr)
+	assert.ErrorIs(t, staleDeleteErr, dberrors.ErrConflict)
+	assert.Nil(t, getErr)
+	assert.Equal(t, []models.AuditRecord{createProvider, createTariff, createContract, deleteTariff, updateContract}, page.Items)
+}
@@ -0,0 +1,35 @@
+package data
+
+import (
+	"encoding/json"
+
+	"tariff-calculation-service/internal/models"
+)
+
+const TestActor = "test-actor"
+
+var AuditRecord = models.AuditRecord{
+	Id:          TestAuditId,
+	Actor:       TestActor,
+	Timestamp:   "2021-03-24T12:04:18.000000000Z",
+	PartitionId: TestPartitionId,
+	EntityType:  models.AuditEntityTariff,
+	EntityId:    TestTariffId,
+	Operation:   models.AuditOperationUpdate,
+	Changes: []models.AuditChange{
+		{Pointer: "/fixedTariff/pricePerUnit", Before: json.RawMessage(`64.5`), After: json.RawMessage(`70.1`)},
+	},
+}
+
+var AuditRecordsPage = models.Page[models.AuditRecord]{
+	Items:      []models.AuditRecord{AuditRecord},
+	NextCursor: TestCursor,
+}
+
+var AuditFilter = models.AuditFilter{
+	EntityType: models.AuditEntityTariff,
+	EntityId:   TestTariffId,
+	Actor:      TestActor,
+	From:       "2021-03-24T00:00:00Z",
+	To:         "2021-03-25T00:00:00+01:00",
+}
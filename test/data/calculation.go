@@ -0,0 +1,108 @@
+package data
+
+import (
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/enums"
+	"tariff-calculation-service/pkg/money"
+)
+
+var CalculationRequest = models.CalculationRequest{
+	Quantity: money.RequireFromString("10"),
+	From:     "2021-01-01T00:00:00Z",
+	To:       "2021-02-01T00:00:00Z",
+}
+
+var CalculationRequestOutsideValidity = models.CalculationRequest{
+	Quantity: money.RequireFromString("10"),
+	From:     "2019-01-01T00:00:00Z",
+	To:       "2021-02-01T00:00:00Z",
+}
+
+var Calculation = models.Calculation{
+	TariffId:        TestTariffId,
+	Currency:        TestCurrency,
+	From:            "2021-01-01T00:00:00Z",
+	To:              "2021-02-01T00:00:00Z",
+	Quantity:        money.RequireFromString("10"),
+	PricePerUnit:    money.RequireFromString("64.5"),
+	ConsumptionCost: money.RequireFromString("645"),
+	Cost:            money.RequireFromString("645"),
+}
+
+var IntervalCalculationRequest = models.IntervalCalculationRequest{
+	Resolution: 15,
+	Readings: []models.Reading{
+		{Timestamp: "2021-01-01T00:00:00Z", Quantity: money.RequireFromString("0.5")},
+		{Timestamp: "2021-01-01T00:15:00Z", Quantity: money.RequireFromString("1.5")},
+	},
+}
+
+var IntervalCalculation = models.IntervalCalculation{
+	ContractId: TestContractId,
+	Resolution: 15,
+	Intervals: []models.IntervalCost{
+		{From: "2021-01-01T00:00:00Z", To: "2021-01-01T00:15:00Z", TariffId: TestTariffId, Currency: TestCurrency, Quantity: money.RequireFromString("0.5"), PricePerUnit: money.RequireFromString("64.5"), Cost: money.RequireFromString("32.25")},
+		{From: "2021-01-01T00:15:00Z", To: "2021-01-01T00:30:00Z", TariffId: TestTariffId, Currency: TestCurrency, Quantity: money.RequireFromString("1.5"), PricePerUnit: money.RequireFromString("64.5"), Cost: money.RequireFromString("96.75")},
+	},
+	Charges: []models.ChargeCost{},
+	TariffTotals: []models.IntervalTotal{
+		{TariffId: TestTariffId, Currency: TestCurrency, Quantity: money.RequireFromString("2"), Cost: money.RequireFromString("129")},
+	},
+	Totals: []models.IntervalTotal{
+		{Currency: TestCurrency, Quantity: money.RequireFromString("2"), Cost: money.RequireFromString("129")},
+	},
+}
+
+var TariffElectricityJanuary = models.Tariff{
+	Id:          "4f0f7c5e-3c1d-4b0e-9a7e-0c9f1f6e2a01",
+	Name:        "Electricity January",
+	Currency:    TestCurrency,
+	ValidFrom:   "2021-01-01T00:00:00Z",
+	ValidTo:     "2021-01-16T00:00:00Z",
+	TariffType:  enums.Electricity,
+	FixedTariff: models.FixedTariff{PricePerUnit: money.RequireFromString("0.3")},
+}
+
+var TariffElectricityFebruary = models.Tariff{
+	Id:          "4f0f7c5e-3c1d-4b0e-9a7e-0c9f1f6e2a02",
+	Name:        "Electricity February",
+	Currency:    TestCurrency,
+	ValidFrom:   "2021-01-16T00:00:00Z",
+	ValidTo:     "2021-03-01T00:00:00Z",
+	TariffType:  enums.Electricity,
+	FixedTariff: models.FixedTariff{PricePerUnit: money.RequireFromString("0.4")},
+}
+
+var TariffGas = models.Tariff{
+	Id:          "4f0f7c5e-3c1d-4b0e-9a7e-0c9f1f6e2a03",
+	Name:        "Gas",
+	Currency:    TestCurrency,
+	ValidFrom:   "2021-01-01T00:00:00Z",
+	ValidTo:     "2022-01-01T00:00:00Z",
+	TariffType:  enums.Gas,
+	FixedTariff: models.FixedTariff{PricePerUnit: money.RequireFromString("0.1")},
+}
+
+var BillRequest = models.BillRequest{
+	From: "2021-01-01T00:00:00Z",
+	To:   "2021-02-01T00:00:00Z",
+	Consumptions: []models.BillConsumption{
+		{TariffType: enums.Electricity, Quantity: money.RequireFromString("310")},
+		{TariffType: enums.Gas, Quantity: money.RequireFromString("100")},
+	},
+}
+
+var TariffWithCharges = models.Tariff{
+	Id:          "4f0f7c5e-3c1d-4b0e-9a7e-0c9f1f6e2a04",
+	Name:        "Electricity With Charges",
+	Currency:    TestCurrency,
+	ValidFrom:   "2021-01-01T00:00:00Z",
+	ValidTo:     "2022-01-01T00:00:00Z",
+	TariffType:  enums.Electricity,
+	FixedTariff: models.FixedTariff{PricePerUnit: money.RequireFromString("0.25")},
+	FixedCharges: []models.FixedCharge{
+		{Name: "Standing Charge", Amount: money.RequireFromString("0.5"), Frequency: enums.Daily},
+		{Name: "Base Fee", Amount: money.RequireFromString("31"), Frequency: enums.Monthly},
+		{Name: "Meter Rent", Amount: money.RequireFromString("365"), Frequency: enums.Yearly},
+	},
+}
@@ -7,8 +7,11 @@ const (
 	TestContractId  = "8b026b56-db5e-4b2a-8d7d-a8b69660977f"
 	TestTariffId    = "eb40ecd9-74c9-403c-9e11-33d3f1a26bfe"
 	TestProviderId  = "67aed530-e284-4f1a-9dde-833b8f4968d4"
+	TestTaxRuleId   = "0c4d9b7a-5e2f-4b8c-9a1d-3f6e7b2c8d90"
+	TestAuditId     = "5f3c2a1e-7b9d-4e6f-8a0b-1c2d3e4f5a6b"
 	TestSortKey     = "contract#"
 	TestIdInvalid   = "Invalid-8eb474f4"
+	TestCursor      = "eyJrZXkiOiJ0YXJpZmYjIn0"
 
 	TestContractName        = "Test Contract Name"
 	TestContractDescription = "Test Description"
@@ -20,4 +23,6 @@ const (
 	TestValidFrom  = "2020-03-24T12:04:18Z"
 	TestValidTo    = "2022-03-24T12:04:18Z"
 	TestTariffType = enums.Biogas
+
+	TestTariffIncarnation = "2020-03-24T12:04:18.000000000Z"
 )
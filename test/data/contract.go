@@ -17,3 +17,18 @@ var Contract = models.Contract{
 var Contracts = []models.Contract{
 	Contract,
 }
+
+var ContractsPage = models.Page[models.Contract]{
+	Items:      Contracts,
+	NextCursor: TestCursor,
+}
+
+var ContractWithTariff = models.Contract{
+	Id:          TestContractId,
+	Name:        TestContractName,
+	Description: TestContractDescription,
+	StartDate:   TestContractStartDate,
+	EndDate:     TestContractEndDate,
+	Provider:    TestProviderId,
+	Tariffs:     []string{TestTariffId},
+}
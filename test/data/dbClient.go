@@ -82,6 +82,28 @@ var TestGetItemOutputTariff = &dynamodb.GetItemOutput{
 	Item: TestAttributeValuesTariff,
 }
 
+// TestGetItemOutputTariffInitialVersion is a tariff at the initial version.
+var TestGetItemOutputTariffInitialVersion = &dynamodb.GetItemOutput{
+	Item: map[string]types.AttributeValue{
+		"Partition_Id": &types.AttributeValueMemberS{Value: TestPartitionId},
+		"Sort_Key":     &types.AttributeValueMemberS{Value: TestSortKey},
+		"Data":         TestAttributeValuesTariff["Data"],
+		"Version":      &types.AttributeValueMemberN{Value: "1"},
+		"Incarnation":  &types.AttributeValueMemberS{Value: TestTariffIncarnation},
+	},
+}
+
+// TestGetItemOutputTariffWithoutIncarnation is a tariff at the initial version that was created before the
+// incarnation was kept.
+var TestGetItemOutputTariffWithoutIncarnation = &dynamodb.GetItemOutput{
+	Item: map[string]types.AttributeValue{
+		"Partition_Id": &types.AttributeValueMemberS{Value: TestPartitionId},
+		"Sort_Key":     &types.AttributeValueMemberS{Value: TestSortKey},
+		"Data":         TestAttributeValuesTariff["Data"],
+		"Version":      &types.AttributeValueMemberN{Value: "1"},
+	},
+}
+
 var TestGetQueryOutputTariff = &dynamodb.QueryOutput{
 	Items: []map[string]types.AttributeValue{
 		TestAttributeValuesTariff,
@@ -100,6 +122,19 @@ var TestUpdateItemOutputTariff = &dynamodb.UpdateItemOutput{
 	Attributes: TestAttributeValuesTariff,
 }
 
+var TestUpdateItemOutputTariffVersion = &dynamodb.UpdateItemOutput{
+	Attributes: map[string]types.AttributeValue{
+		"Version": &types.AttributeValueMemberN{Value: "2"},
+	},
+}
+
+// TestConditionalCheckFailedTariffVersion is the error of a conditional write to a tariff with another version.
+var TestConditionalCheckFailedTariffVersion = &types.ConditionalCheckFailedException{
+	Item: map[string]types.AttributeValue{
+		"Version": &types.AttributeValueMemberN{Value: "2"},
+	},
+}
+
 var TestAttributeValuesProvider = map[string]types.AttributeValue{
 	"Partition_Id": &types.AttributeValueMemberS{Value: TestPartitionId},
 	"Sort_Key":     &types.AttributeValueMemberS{Value: TestSortKey},
@@ -108,10 +143,10 @@ var TestAttributeValuesProvider = map[string]types.AttributeValue{
 		"Name":  &types.AttributeValueMemberS{Value: "TestProvider"},
 		"Email": &types.AttributeValueMemberS{Value: "test@provider.com"},
 		"Address": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
-			"Street":     &types.AttributeValueMemberS{Value: "TestStreet"},
-			"PostalCode": &types.AttributeValueMemberS{Value: "107-6001"},
-			"City":       &types.AttributeValueMemberS{Value: "Tokyo"},
-			"Country":    &types.AttributeValueMemberS{Value: "Japan"},
+			"Street":      &types.AttributeValueMemberS{Value: "TestStreet"},
+			"PostalCode":  &types.AttributeValueMemberS{Value: "107-6001"},
+			"City":        &types.AttributeValueMemberS{Value: "Tokyo"},
+			"CountryCode": &types.AttributeValueMemberS{Value: "JPN"},
 		}},
 	}},
 }
@@ -120,6 +155,16 @@ var TestGetItemOutputProvider = &dynamodb.GetItemOutput{
 	Item: TestAttributeValuesProvider,
 }
 
+// TestGetItemOutputProviderInitialVersion is a provider at the initial version.
+var TestGetItemOutputProviderInitialVersion = &dynamodb.GetItemOutput{
+	Item: map[string]types.AttributeValue{
+		"Partition_Id": &types.AttributeValueMemberS{Value: TestPartitionId},
+		"Sort_Key":     &types.AttributeValueMemberS{Value: TestSortKey},
+		"Data":         TestAttributeValuesProvider["Data"],
+		"Version":      &types.AttributeValueMemberN{Value: "1"},
+	},
+}
+
 var TestContractQueryOutput = &dynamodb.QueryOutput{
 	Items: []map[string]types.AttributeValue{
 		TestAttributeValuesContract,
@@ -161,3 +206,107 @@ var TestPutItemOutputProvider = &dynamodb.PutItemOutput{
 var TestUpdateItemOutputProvider = &dynamodb.UpdateItemOutput{
 	Attributes: TestAttributeValuesProvider,
 }
+
+var TestAttributeValuesTariffTiered = map[string]types.AttributeValue{
+	"Partition_Id": &types.AttributeValueMemberS{Value: TestPartitionId},
+	"Sort_Key":     &types.AttributeValueMemberS{Value: TestSortKey},
+	"Data": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"Id":           &types.AttributeValueMemberS{Value: TestTariffId},
+		"Name":         &types.AttributeValueMemberS{Value: TestTariffName},
+		"Currency":     &types.AttributeValueMemberS{Value: TestCurrency},
+		"ValidFrom":    &types.AttributeValueMemberS{Value: TestValidFrom},
+		"ValidTo":      &types.AttributeValueMemberS{Value: TestValidTo},
+		"TariffType":   &types.AttributeValueMemberN{Value: "1"},
+		"PricingModel": &types.AttributeValueMemberN{Value: "2"},
+		"TieredTariff": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+			"Tiers": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+				&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+					"UpTo":         &types.AttributeValueMemberN{Value: "100"},
+					"PricePerUnit": &types.AttributeValueMemberN{Value: "2"},
+				}},
+				&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+					"UpTo":         &types.AttributeValueMemberN{Value: "0"},
+					"PricePerUnit": &types.AttributeValueMemberN{Value: "3"},
+				}},
+			}},
+		}},
+	}},
+}
+
+var TestGetItemOutputTariffTiered = &dynamodb.GetItemOutput{
+	Item: TestAttributeValuesTariffTiered,
+}
+
+var TestAttributeValuesTaxRule = map[string]types.AttributeValue{
+	"Partition_Id": &types.AttributeValueMemberS{Value: TestPartitionId},
+	"Sort_Key":     &types.AttributeValueMemberS{Value: "taxrule#" + TestTaxRuleId},
+	"Data": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"Id":               &types.AttributeValueMemberS{Value: TestTaxRuleId},
+		"Name":             &types.AttributeValueMemberS{Value: "Japan"},
+		"CountryCode":      &types.AttributeValueMemberS{Value: "JPN"},
+		"VatRate":          &types.AttributeValueMemberN{Value: "10"},
+		"EnergyTaxPerUnit": &types.AttributeValueMemberN{Value: "0.5"},
+		"Levies": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+			&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"Name":      &types.AttributeValueMemberS{Value: "Renewable Energy Levy"},
+				"Amount":    &types.AttributeValueMemberN{Value: "31"},
+				"Frequency": &types.AttributeValueMemberN{Value: "1"},
+			}},
+		}},
+	}},
+}
+
+var TestGetItemOutputTaxRule = &dynamodb.GetItemOutput{
+	Item: TestAttributeValuesTaxRule,
+}
+
+var TestTaxRuleQueryOutput = &dynamodb.QueryOutput{
+	Items: []map[string]types.AttributeValue{
+		TestAttributeValuesTaxRule,
+	},
+}
+
+var TestPutItemOutputTaxRule = &dynamodb.PutItemOutput{
+	Attributes: TestAttributeValuesTaxRule,
+}
+
+var TestUpdateItemOutputTaxRule = &dynamodb.UpdateItemOutput{
+	Attributes: TestAttributeValuesTaxRule,
+}
+
+var TestAttributeValuesSettings = map[string]types.AttributeValue{
+	"Partition_Id": &types.AttributeValueMemberS{Value: TestPartitionId},
+	"Sort_Key":     &types.AttributeValueMemberS{Value: "settings"},
+	"Data": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"RoundingMode": &types.AttributeValueMemberN{Value: "1"},
+	}},
+}
+
+var TestGetItemOutputSettings = &dynamodb.GetItemOutput{
+	Item: TestAttributeValuesSettings,
+}
+
+var TestPutItemOutputSettings = &dynamodb.PutItemOutput{
+	Attributes: TestAttributeValuesSettings,
+}
+
+var TestAttributeValuesFxRate = map[string]types.AttributeValue{
+	"Partition_Id": &types.AttributeValueMemberS{Value: TestPartitionId},
+	"Sort_Key":     &types.AttributeValueMemberS{Value: "fxrate#GBP#EUR#2021-01-01"},
+	"Data": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+		"BaseCurrency":  &types.AttributeValueMemberS{Value: TestCurrency},
+		"QuoteCurrency": &types.AttributeValueMemberS{Value: "EUR"},
+		"Date":          &types.AttributeValueMemberS{Value: "2021-01-01"},
+		"Rate":          &types.AttributeValueMemberN{Value: "1.125"},
+	}},
+}
+
+var TestFxRateQueryOutput = &dynamodb.QueryOutput{
+	Items: []map[string]types.AttributeValue{
+		TestAttributeValuesFxRate,
+	},
+}
+
+var TestPutItemOutputFxRate = &dynamodb.PutItemOutput{
+	Attributes: TestAttributeValuesFxRate,
+}
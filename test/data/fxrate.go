@@ -0,0 +1,22 @@
+package data
+
+import (
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/money"
+)
+
+var FxRate = models.FxRate{
+	BaseCurrency:  TestCurrency,
+	QuoteCurrency: "EUR",
+	Date:          "2021-01-01",
+	Rate:          money.RequireFromString("1.125"),
+}
+
+var FxRateNextDay = models.FxRate{
+	BaseCurrency:  TestCurrency,
+	QuoteCurrency: "EUR",
+	Date:          "2021-01-02",
+	Rate:          money.RequireFromString("1.1"),
+}
+
+var FxRates = []models.FxRate{FxRate, FxRateNextDay}
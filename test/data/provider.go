@@ -21,3 +21,8 @@ var address = models.Address{
 var Providers = []models.Provider{
 	Provider,
 }
+
+var ProvidersPage = models.Page[models.Provider]{
+	Items:      Providers,
+	NextCursor: TestCursor,
+}
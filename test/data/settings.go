@@ -0,0 +1,10 @@
+package data
+
+import (
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/enums"
+)
+
+var Settings = models.Settings{
+	RoundingMode: enums.HalfEven,
+}
@@ -2,6 +2,8 @@ package data
 
 import (
 	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/enums"
+	"tariff-calculation-service/pkg/money"
 )
 
 var Tariff = models.Tariff{
@@ -17,8 +19,25 @@ var Tariff = models.Tariff{
 
 var Tariffs = []models.Tariff{Tariff}
 
+var TariffsPage = models.Page[models.Tariff]{
+	Items:      Tariffs,
+	NextCursor: TestCursor,
+}
+
+var testTariffType = TestTariffType
+
+var TariffFilter = models.TariffFilter{
+	TariffType: &testTariffType,
+	Currency:   TestCurrency,
+	ValidAt:    "2021-03-24T12:04:18Z",
+	ValidFrom:  TestValidFrom,
+	ValidTo:    TestValidTo,
+	Name:       "Test",
+	Sort:       "validFrom",
+}
+
 var fixedTariff = models.FixedTariff{
-	PricePerUnit: 64.5,
+	PricePerUnit: money.RequireFromString("64.5"),
 }
 
 var dynamicTariff = models.DynamicTariff{
@@ -28,7 +47,7 @@ var dynamicTariff = models.DynamicTariff{
 var hourlyTariff = models.HourlyTariff{
 	StartTime:    TestValidFrom,
 	ValidDays:    []uint8{0, 1, 2},
-	PricePerUnit: 54.2,
+	PricePerUnit: money.RequireFromString("54.2"),
 }
 
 var TariffInvalidHourlyStartTime = models.Tariff{
@@ -49,7 +68,7 @@ var dynamicTariffInvalid = models.DynamicTariff{
 var hourlyTariffInvalidStartTime = models.HourlyTariff{
 	StartTime:    "",
 	ValidDays:    []uint8{0, 1, 2},
-	PricePerUnit: 54.2,
+	PricePerUnit: money.RequireFromString("54.2"),
 }
 
 var TariffInvalidHourlyValidDays = models.Tariff{
@@ -70,7 +89,7 @@ var dynamicTariffInvalidValidDays = models.DynamicTariff{
 var hourlyTariffInvalidValidDays = models.HourlyTariff{
 	StartTime:    TestValidFrom,
 	ValidDays:    []uint8{9},
-	PricePerUnit: 54.2,
+	PricePerUnit: money.RequireFromString("54.2"),
 }
 
 var TariffInvalidHourlyPricePerUnit = models.Tariff{
@@ -91,5 +110,107 @@ var dynamicTariffInvalidPricePerUnit = models.DynamicTariff{
 var hourlyTariffInvalidPricePerUnit = models.HourlyTariff{
 	StartTime:    TestValidFrom,
 	ValidDays:    []uint8{1},
-	PricePerUnit: -1,
+	PricePerUnit: money.RequireFromString("-1"),
+}
+
+var TariffDynamic = models.Tariff{
+	Id:           TestTariffId,
+	Name:         TestTariffName,
+	Currency:     TestCurrency,
+	ValidFrom:    TestValidFrom,
+	ValidTo:      TestValidTo,
+	TariffType:   TestTariffType,
+	PricingModel: enums.Dynamic,
+	DynamicTariff: models.DynamicTariff{
+		HourlyTariffs: []models.HourlyTariff{
+			{StartTime: "2021-01-04T00:00:00+01:00", ValidDays: []uint8{0, 1, 2, 3, 4, 5, 6}, PricePerUnit: money.RequireFromString("0.2")},
+			{StartTime: "2021-01-04T08:00:00+01:00", ValidDays: []uint8{0, 1, 2, 3, 4}, PricePerUnit: money.RequireFromString("0.4")},
+			{StartTime: "2021-01-04T20:00:00+01:00", ValidDays: []uint8{0, 1, 2, 3, 4}, PricePerUnit: money.RequireFromString("0.2")},
+		},
+	},
+}
+
+var TariffTiered = models.Tariff{
+	Id:           TestTariffId,
+	Name:         TestTariffName,
+	Currency:     TestCurrency,
+	ValidFrom:    TestValidFrom,
+	ValidTo:      TestValidTo,
+	TariffType:   enums.Water,
+	PricingModel: enums.Tiered,
+	TieredTariff: models.TieredTariff{
+		Tiers: []models.Tier{
+			{UpTo: money.RequireFromString("100"), PricePerUnit: money.RequireFromString("2")},
+			{PricePerUnit: money.RequireFromString("3")},
+		},
+	},
+}
+
+var TariffValidToBeforeValidFrom = models.Tariff{
+	Id:          TestTariffId,
+	Name:        TestTariffName,
+	Currency:    TestCurrency,
+	ValidFrom:   TestValidTo,
+	ValidTo:     TestValidFrom,
+	TariffType:  TestTariffType,
+	FixedTariff: fixedTariff,
+}
+
+var TariffDynamicWithoutHourlyTariffs = models.Tariff{
+	Id:           TestTariffId,
+	Name:         TestTariffName,
+	Currency:     TestCurrency,
+	ValidFrom:    TestValidFrom,
+	ValidTo:      TestValidTo,
+	TariffType:   TestTariffType,
+	PricingModel: enums.Dynamic,
+}
+
+var TariffDynamicOverlap = models.Tariff{
+	Id:           TestTariffId,
+	Name:         TestTariffName,
+	Currency:     TestCurrency,
+	ValidFrom:    TestValidFrom,
+	ValidTo:      TestValidTo,
+	TariffType:   TestTariffType,
+	PricingModel: enums.Dynamic,
+	DynamicTariff: models.DynamicTariff{
+		HourlyTariffs: []models.HourlyTariff{
+			{StartTime: "2021-01-04T00:00:00+01:00", ValidDays: []uint8{0, 1, 2, 3, 4, 5, 6}, PricePerUnit: money.RequireFromString("0.2")},
+			{StartTime: "2021-01-04T08:00:00+01:00", ValidDays: []uint8{0, 1, 2, 3, 4}, PricePerUnit: money.RequireFromString("0.4")},
+			{StartTime: "2021-01-04T07:00:00Z", ValidDays: []uint8{5, 2}, PricePerUnit: money.RequireFromString("0.3")},
+		},
+	},
+}
+
+var TariffDynamicWeekdays = models.Tariff{
+	Id:           TestTariffId,
+	Name:         TestTariffName,
+	Currency:     TestCurrency,
+	ValidFrom:    TestValidFrom,
+	ValidTo:      TestValidTo,
+	TariffType:   TestTariffType,
+	PricingModel: enums.Dynamic,
+	DynamicTariff: models.DynamicTariff{
+		HourlyTariffs: []models.HourlyTariff{
+			{StartTime: "2021-01-04T00:00:00+01:00", ValidDays: []uint8{0, 1, 2, 3, 4}, PricePerUnit: money.RequireFromString("0.2")},
+			{StartTime: "2021-01-04T08:00:00+01:00", ValidDays: []uint8{0, 1, 2, 3, 4}, PricePerUnit: money.RequireFromString("0.4")},
+		},
+	},
+}
+
+var TariffRevised = models.Tariff{
+	Id:            TestTariffId,
+	Name:          TestTariffName,
+	Currency:      TestCurrency,
+	ValidFrom:     TestValidFrom,
+	ValidTo:       TestValidTo,
+	TariffType:    TestTariffType,
+	FixedTariff:   models.FixedTariff{PricePerUnit: money.RequireFromString("70.1")},
+	DynamicTariff: dynamicTariff,
+}
+
+var TariffVersions = []models.TariffVersion{
+	{Version: 1, EffectiveFrom: "2021-01-01T00:00:00Z", Tariff: Tariff},
+	{Version: 2, EffectiveFrom: "2021-06-01T00:00:00Z", Tariff: TariffRevised},
 }
@@ -0,0 +1,26 @@
+package data
+
+import (
+	"tariff-calculation-service/internal/models"
+	"tariff-calculation-service/pkg/enums"
+	"tariff-calculation-service/pkg/money"
+)
+
+var TaxRule = models.TaxRule{
+	Id:               TestTaxRuleId,
+	Name:             "Japan",
+	CountryCode:      "JPN",
+	VatRate:          money.RequireFromString("10"),
+	EnergyTaxPerUnit: money.RequireFromString("0.5"),
+	Levies: []models.FixedCharge{
+		{Name: "Renewable Energy Levy", Amount: money.RequireFromString("31"), Frequency: enums.Monthly},
+	},
+}
+
+var TaxRuleDefault = models.TaxRule{
+	Id:      "0c4d9b7a-5e2f-4b8c-9a1d-3f6e7b2c8d91",
+	Name:    "Default",
+	VatRate: money.RequireFromString("20"),
+}
+
+var TaxRules = []models.TaxRule{TaxRuleDefault, TaxRule}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 
 	"github.com/gin-gonic/gin"
 )
@@ -15,6 +16,7 @@ func GetTestGinContext() *gin.Context {
 	ctx, _ := gin.CreateTestContext(writer)
 	ctx.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 
 	return ctx
@@ -27,6 +29,7 @@ func GetTestGinContextWithParameters(parameters map[string]string) *gin.Context
 	ctx, _ := gin.CreateTestContext(writer)
 	ctx.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 
 	for key, value := range parameters {
@@ -46,6 +49,7 @@ func GetTestGinContextWithParametersAndBody(parameters map[string]string, body [
 	ctx, _ := gin.CreateTestContext(writer)
 	ctx.Request = &http.Request{
 		Header: make(http.Header),
+		URL:    &url.URL{},
 	}
 
 	for key, value := range parameters {
@@ -62,3 +66,17 @@ func GetTestGinContextWithParametersAndBody(parameters map[string]string, body [
 
 	return ctx
 }
+
+func GetTestGinContextWithParametersAndQuery(parameters map[string]string, query string) *gin.Context {
+	ctx := GetTestGinContextWithParameters(parameters)
+	ctx.Request = httptest.NewRequest("GET", "https://test-url.com?"+query, nil)
+
+	return ctx
+}
+
+// WithHeader sets a header of the request of the test context.
+func WithHeader(ctx *gin.Context, key, value string) *gin.Context {
+	ctx.Request.Header.Set(key, value)
+
+	return ctx
+}
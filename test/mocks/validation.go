@@ -2,10 +2,10 @@ package mocks
 
 import (
 	"errors"
-	"net/http"
 	"reflect"
 	"tariff-calculation-service/internal/models"
 	"tariff-calculation-service/internal/readmodel/httphandler/testing"
+	"tariff-calculation-service/pkg"
 	"tariff-calculation-service/pkg/validation"
 
 	"github.com/gin-gonic/gin"
@@ -25,7 +25,7 @@ func NewValidatorPathNegative(mockController *gomock.Controller) *testing.MockVa
 	mockValidatorPathNegative := testing.NewMockValidator(mockController)
 	mockValidatorPathNegative.EXPECT().ValidateAndSetPathParams(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(context *gin.Context, objPtr any) error {
 		err := errors.New("ValidationError")
-		context.JSON(http.StatusBadRequest, models.NewBadRequestFieldValidationError(err))
+		pkg.RespondWithError(context, models.NewBadRequestFieldValidationError(err))
 		return err
 	})
 